@@ -9,3 +9,13 @@ data "identitynow_access_profiles_v1" "example" {
 output "engineering_access_profile_ids" {
   value = [for ap in data.identitynow_access_profiles_v1.example.access_profiles : ap.id]
 }
+
+# The same kind of query written with the typed `filter` block: requestable
+# access profiles on either of two sources, limited to one segment.
+data "identitynow_access_profiles_v1" "typed" {
+  filter = {
+    source_ids  = ["2c9180835d191a86015d28455b4a2329", "2c91808568c529c60168cca6f90c1313"]
+    requestable = true
+    segment_ids = ["0b5c9f25-83c6-4762-9073-e38f7bb2ae26"]
+  }
+}
@@ -17,6 +17,17 @@ output "entitlement_ids_by_value" {
   value = { for k, e in local.entitlements_by_value : k => e.id }
 }
 
+# The same source written with the typed `filter` block, narrowed to
+# requestable groups whose value starts with "CN=Engineering".
+data "identitynow_entitlements_v1" "typed" {
+  filter = {
+    source_id         = "01f28e7f21804bef8565673ed668f36e"
+    type              = "group"
+    requestable       = true
+    value_starts_with = "CN=Engineering"
+  }
+}
+
 # --- Eventual consistency note ---
 #
 # Entitlements only exist after a source aggregation has imported them - see
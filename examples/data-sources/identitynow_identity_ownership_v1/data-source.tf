@@ -0,0 +1,22 @@
+# Everything a leaver owns that must be reassigned before their identity is
+# disabled or deleted.
+data "identitynow_identity_ownership_v1" "leaver" {
+  identity_id = "2c91808576ddc7060176de5040574ab0"
+}
+
+# The identity taking over, as an owner reference.
+data "identitynow_identity_ownership_v1" "successor" {
+  identity_id = "2c91808576ddc7060176de5040574aa0"
+}
+
+output "leaver_owned_role_ids" {
+  value = [for r in data.identitynow_identity_ownership_v1.leaver.roles : r.id]
+}
+
+# owner_reference has the same {id, name, type} shape as the owner attribute
+# of identitynow_role_v1, identitynow_access_profile_v1 and
+# identitynow_governance_group_v1, so it can be assigned directly.
+resource "identitynow_role_v1" "example" {
+  name  = "Example Role"
+  owner = data.identitynow_identity_ownership_v1.successor.owner_reference
+}
@@ -0,0 +1,38 @@
+# Read the baseline mappings from an existing profile on the same source.
+data "identitynow_identity_profile_default_attribute_config_v1" "baseline" {
+  identity_profile_id = "2b838de9db9babcfe646d4f274ad4238"
+}
+
+locals {
+  identity_attribute_overrides = {
+    email = {
+      identityAttributeName = "email"
+      transformDefinition = {
+        type = "accountAttribute"
+        attributes = {
+          sourceName    = "Employees"
+          attributeName = "workEmail"
+        }
+      }
+    }
+  }
+
+  identity_attribute_transforms = merge(
+    { for name, transform in data.identitynow_identity_profile_default_attribute_config_v1.baseline.attribute_transforms : name => jsondecode(transform) },
+    local.identity_attribute_overrides,
+  )
+}
+
+resource "identitynow_identity_profile_v1" "employees" {
+  name = "Employees"
+
+  authoritative_source = {
+    id   = "2c9180857756ddc70177574aa0576ab1"
+    type = "SOURCE"
+  }
+
+  identity_attribute_config = jsonencode({
+    enabled             = data.identitynow_identity_profile_default_attribute_config_v1.baseline.enabled
+    attributeTransforms = values(local.identity_attribute_transforms)
+  })
+}
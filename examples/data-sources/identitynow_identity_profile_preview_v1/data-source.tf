@@ -0,0 +1,40 @@
+# Evaluate a proposed attribute mapping against a known sample identity
+# without saving it to any Identity Profile.
+data "identitynow_identity_profile_preview_v1" "email_mapping" {
+  identity_id = "2c91808576ddc7060176de5040574ab0"
+
+  identity_attribute_config = jsonencode({
+    enabled = true
+    attributeTransforms = [
+      {
+        identityAttributeName = "email"
+        transformDefinition = {
+          type = "lower"
+          attributes = {
+            input = {
+              type = "accountAttribute"
+              attributes = {
+                sourceName    = "Employees"
+                attributeName = "mail"
+              }
+            }
+          }
+        }
+      },
+    ]
+  })
+}
+
+# Fail the plan's checks (as warnings) if the new wiring doesn't produce the
+# expected value for the sample identity.
+check "email_mapping_preview" {
+  assert {
+    condition     = !data.identitynow_identity_profile_preview_v1.email_mapping.has_errors
+    error_message = "The proposed identity attribute config reported evaluation errors."
+  }
+
+  assert {
+    condition     = data.identitynow_identity_profile_preview_v1.email_mapping.attributes["email"] == "last.first@example.com"
+    error_message = "The proposed email mapping did not produce the expected value."
+  }
+}
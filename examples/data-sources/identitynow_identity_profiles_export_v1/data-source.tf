@@ -0,0 +1,13 @@
+# Export every identity profile whose name starts with "Employees".
+data "identitynow_identity_profiles_export_v1" "employees" {
+  filters = "name sw \"Employees\""
+}
+
+output "exported_identity_profiles" {
+  value = data.identitynow_identity_profiles_export_v1.employees.profiles[*].name
+}
+
+# The bundle can also be written to disk and committed for review.
+output "identity_profiles_bundle" {
+  value = data.identitynow_identity_profiles_export_v1.employees.export_json
+}
@@ -0,0 +1,15 @@
+# List every role assignment held by a break-glass identity, including who
+# assigned it, how and when.
+data "identitynow_identity_role_assignments_v1" "break_glass" {
+  identity_id = "2c91808576ddc7060176de5040574ab0"
+}
+
+output "break_glass_role_names" {
+  value = [for a in data.identitynow_identity_role_assignments_v1.break_glass.role_assignments : a.role_name]
+}
+
+# Only the assignments of a single role.
+data "identitynow_identity_role_assignments_v1" "admin_role" {
+  identity_id = "2c91808576ddc7060176de5040574ab0"
+  role_id     = "2c918086749d78830174a1a40e121518"
+}
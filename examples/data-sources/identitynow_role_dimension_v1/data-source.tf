@@ -0,0 +1,4 @@
+data "identitynow_role_dimension_v1" "austin" {
+  role_id      = "2c91808a7813090a017814121e121518"
+  dimension_id = "2c9180835d2e5168015d32f890ca1581"
+}
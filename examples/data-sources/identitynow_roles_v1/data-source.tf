@@ -8,3 +8,30 @@ data "identitynow_roles_v1" "example" {
 output "engineering_role_ids" {
   value = [for r in data.identitynow_roles_v1.example.roles : r.id]
 }
+
+# The same kind of query written with the typed `filter` block, which quotes
+# values and picks the operator for you. Only requestable, non-dimensional
+# roles owned by one identity and created since the start of 2024 are
+# returned.
+data "identitynow_roles_v1" "typed" {
+  filter = {
+    owner_id      = "2c9180a46faadee4016fb4e018c20639"
+    requestable   = true
+    dimensional   = false
+    created_after = "2024-01-01T00:00:00Z"
+    name_contains = "engineering"
+  }
+}
+
+# Setting `access_model_metadata` queries POST /roles/v1/filter instead.
+data "identitynow_roles_v1" "classified" {
+  filter = {
+    requestable = true
+    access_model_metadata = [
+      {
+        attribute = "iscFederalClassifications"
+        values    = ["secret"]
+      },
+    ]
+  }
+}
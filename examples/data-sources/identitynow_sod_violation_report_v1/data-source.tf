@@ -0,0 +1,22 @@
+data "identitynow_sod_violation_report_v1" "finance" {
+  report_result_id = identitynow_sod_violation_report_run_v1.finance.report_result_id
+}
+
+check "no_finance_sod_violations" {
+  assert {
+    condition = length(data.identitynow_sod_violation_report_v1.finance.violations) == 0
+    error_message = format("SOD violations found for: %s", join(", ", distinct([
+      for v in data.identitynow_sod_violation_report_v1.finance.violations : v.identity_name
+    ])))
+  }
+}
+
+output "finance_sod_violations" {
+  value = [
+    for v in data.identitynow_sod_violation_report_v1.finance.violations : {
+      identity = v.identity_name
+      left     = v.left_access
+      right    = v.right_access
+    }
+  ]
+}
@@ -0,0 +1,62 @@
+# Evaluate a transform definition offline against sample attributes. The
+# same type/attributes can be passed straight from an identitynow_transform_v1
+# resource, e.g. attributes = identitynow_transform_v1.email.attributes.
+data "identitynow_transform_preview_v1" "username" {
+  type = "lower"
+  attributes = jsonencode({
+    input = {
+      type = "concat"
+      attributes = {
+        values = [
+          {
+            type = "substring"
+            attributes = {
+              begin = 0
+              end   = 1
+              input = {
+                type       = "identityAttribute"
+                attributes = { name = "firstname" }
+              }
+            }
+          },
+          {
+            type = "replace"
+            attributes = {
+              regex       = "[^A-Za-z]"
+              replacement = ""
+              input = {
+                type       = "identityAttribute"
+                attributes = { name = "lastname" }
+              }
+            }
+          },
+        ]
+      }
+    }
+  })
+
+  identity_attributes = {
+    firstname = "Zoe"
+    lastname  = "O'Brien"
+  }
+}
+
+# Transforms that use an implicit input (the source attribute an identity
+# profile mapping feeds them) get it from "input".
+data "identitynow_transform_preview_v1" "phone" {
+  type       = "e164phone"
+  attributes = jsonencode({ defaultRegion = "US" })
+  input      = "(512) 555-0142"
+}
+
+check "transform_previews" {
+  assert {
+    condition     = data.identitynow_transform_preview_v1.username.output == "zobrien"
+    error_message = "The username transform did not produce the expected value."
+  }
+
+  assert {
+    condition     = data.identitynow_transform_preview_v1.phone.output == "+15125550142"
+    error_message = "The phone transform did not produce the expected value."
+  }
+}
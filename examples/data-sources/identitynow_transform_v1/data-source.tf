@@ -4,3 +4,8 @@
 data "identitynow_transform_v1" "example" {
   id = "2c91808576ddc7060176de5040574aa0"
 }
+
+# Look a transform up by the exact name identity profile mappings use.
+data "identitynow_transform_v1" "by_name" {
+  name = "Email Local Part"
+}
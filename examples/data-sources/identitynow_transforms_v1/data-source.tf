@@ -0,0 +1,19 @@
+# Every customer-managed lookup transform whose name starts with "Dept ".
+data "identitynow_transforms_v1" "department_lookups" {
+  filter = {
+    name_starts_with = "Dept "
+    type             = "lookup"
+  }
+}
+
+# All transforms, including SailPoint's built-ins, keyed by name - e.g. to
+# check that every transform an identity profile mapping references exists.
+data "identitynow_transforms_v1" "all" {
+  include_internal = true
+}
+
+locals {
+  transform_ids_by_name = {
+    for t in data.identitynow_transforms_v1.all.transforms : t.name => t.id
+  }
+}
@@ -0,0 +1,13 @@
+# Failed runs of a workflow over the last week.
+data "identitynow_workflow_executions_v1" "recent_failures" {
+  workflow_id = "c17bea3a-574d-453c-9e04-4365fbf5af0b"
+
+  filter = {
+    status              = "Failed"
+    started_on_or_after = "2026-10-12T00:00:00Z"
+  }
+}
+
+output "recent_failure_ids" {
+  value = [for e in data.identitynow_workflow_executions_v1.recent_failures.executions : e.id]
+}
@@ -0,0 +1,11 @@
+# Export a workflow in the UI's importable shape, e.g. to promote it to
+# another tenant (through the UI, or with identitynow_workflow_v1 - see that
+# resource's example).
+data "identitynow_workflow_export_v1" "send_email" {
+  id = "c17bea3a-574d-453c-9e04-4365fbf5af0b"
+}
+
+resource "local_file" "send_email_export" {
+  filename = "${path.module}/workflows/send-email.json"
+  content  = data.identitynow_workflow_export_v1.send_email.document
+}
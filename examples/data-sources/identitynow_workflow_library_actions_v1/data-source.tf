@@ -0,0 +1,10 @@
+# Look up the send-email action and the inputs it requires.
+data "identitynow_workflow_library_actions_v1" "all" {}
+
+locals {
+  send_email = one([for a in data.identitynow_workflow_library_actions_v1.all.items : a if a.id == "sp:send-email"])
+}
+
+output "send_email_required_inputs" {
+  value = [for f in local.send_email.form_fields : f.name if f.required]
+}
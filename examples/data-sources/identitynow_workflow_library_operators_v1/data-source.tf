@@ -0,0 +1,7 @@
+data "identitynow_workflow_library_operators_v1" "all" {
+  include_deprecated = true
+}
+
+output "operator_ids" {
+  value = [for o in data.identitynow_workflow_library_operators_v1.all.items : o.id]
+}
@@ -0,0 +1,5 @@
+data "identitynow_workflow_library_triggers_v1" "all" {}
+
+output "event_trigger_ids" {
+  value = [for t in data.identitynow_workflow_library_triggers_v1.all.items : t.id if t.type == "EVENT"]
+}
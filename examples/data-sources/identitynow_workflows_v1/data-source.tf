@@ -1,7 +1,12 @@
-# Lists enabled workflows, capped at 10 results. Each entry in "workflows"
-# has the same attributes as identitynow_workflow_v1.
+# Lists enabled, event-triggered workflows, most recently modified first and
+# capped at 10 results. Each entry in "workflows" has the same attributes as
+# identitynow_workflow_v1.
 data "identitynow_workflows_v1" "example" {
-  filters = "enabled eq true"
+  filter = {
+    enabled      = true
+    trigger_type = "EVENT"
+  }
+  sorters = "-modified"
   limit   = 10
 }
 
@@ -0,0 +1,2 @@
+terraform import identitynow_access_profile_entitlement_attachment_v1.example \
+  2c91808576ddc7060176de5040574ac0/2c9180835d191a86015d28455b4a2329
@@ -0,0 +1,33 @@
+# Owning workspace: manages the access profile and the entitlements it lists
+# itself, and leaves everything attached elsewhere alone.
+resource "identitynow_access_profile_v1" "shared" {
+  name        = "Shared Directory Access"
+  description = "Managed by Terraform."
+  enabled     = true
+  requestable = true
+
+  owner = {
+    id   = "2c91808576ddc7060176de5040574aa0"
+    type = "IDENTITY"
+  }
+
+  source = {
+    id   = "2c9180866166b5b0016167c32ef31f3c"
+    type = "SOURCE"
+  }
+
+  entitlements = [
+    {
+      id   = "2c9180835d191a86015d28455b4a2328"
+      type = "ENTITLEMENT"
+    },
+  ]
+
+  ignore_unmanaged = true
+}
+
+# Another team's workspace: adds one more entitlement from the same source.
+resource "identitynow_access_profile_entitlement_attachment_v1" "reporting" {
+  access_profile_id = "2c91808576ddc7060176de5040574ac0"
+  entitlement_id    = "2c9180835d191a86015d28455b4a2329"
+}
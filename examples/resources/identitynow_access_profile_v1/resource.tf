@@ -16,3 +16,36 @@ resource "identitynow_access_profile_v1" "example" {
     type = "SOURCE"
   }
 }
+
+# provisioning_criteria_json takes the API's own criteria JSON, up to the
+# API's limit of 3 levels (it conflicts with the provisioning_criteria block).
+resource "identitynow_access_profile_v1" "admin_account" {
+  name        = "example-admin-access-profile"
+  description = "Managed by Terraform."
+  enabled     = true
+  requestable = false
+
+  owner = {
+    id   = "2c91808576ddc7060176de5040574aa0"
+    type = "IDENTITY"
+  }
+
+  source = {
+    id   = "2c9180866166b5b0016167c32ef31f3c"
+    type = "SOURCE"
+  }
+
+  provisioning_criteria_json = jsonencode({
+    operation = "OR"
+    children = [
+      { operation = "EQUALS", attribute = "accountType", value = "admin" },
+      {
+        operation = "AND"
+        children = [
+          { operation = "HAS", attribute = "adminGroup" },
+          { operation = "CONTAINS", attribute = "distinguishedName", value = "OU=Admins" },
+        ]
+      },
+    ]
+  })
+}
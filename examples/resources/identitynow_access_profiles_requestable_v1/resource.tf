@@ -0,0 +1,19 @@
+# Freezes the access catalog of one segment for a quarterly review: every
+# access profile in the segment becomes non-requestable, and destroying the
+# resource puts each one back to the value it had before.
+resource "identitynow_access_profiles_requestable_v1" "freeze" {
+  filter = {
+    segment_ids = ["0b5c9f25-83c6-4762-9073-e38f7bb2ae26"]
+  }
+  requestable = false
+}
+
+# The same for an explicit list of access profiles. Profiles removed from
+# the list are restored; profiles added are recorded and then updated.
+resource "identitynow_access_profiles_requestable_v1" "hold" {
+  access_profile_ids = [
+    "2c9180835d191a86015d28455b4a2329",
+    "2c91808568c529c60168cca6f90c1313",
+  ]
+  requestable = false
+}
@@ -0,0 +1,12 @@
+# Synchronize attributes for a few service identities whenever the
+# attribute mapping they depend on changes.
+resource "identitynow_identity_attribute_sync_v1" "service_accounts" {
+  identity_ids = [
+    "2c91808576ddc7060176de5040574ab0",
+    "2c91808576ddc7060176de5040574aa0",
+  ]
+
+  triggers = {
+    mapping_version = "2"
+  }
+}
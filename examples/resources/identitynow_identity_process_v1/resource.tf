@@ -0,0 +1,14 @@
+# Reprocess every identity of an identity profile after changing one of its
+# transforms, and wait for the processing tasks to finish.
+resource "identitynow_identity_process_v1" "after_transform_change" {
+  filters = "identityProfile.id eq \"2c9180835d2e5168015d32f890ca1581\""
+
+  # Any change here forces replacement, re-running processing. Referencing
+  # the changed object (e.g. a transform's id or a hash of its attributes)
+  # reprocesses automatically whenever it changes.
+  triggers = {
+    transform_revision = "3"
+  }
+
+  create_timeout = "1h"
+}
@@ -29,6 +29,11 @@ resource "identitynow_identity_profile_v1" "example" {
 
   priority = 10
 
+  # Recalculate the profile's existing identities as soon as
+  # identity_attribute_config changes, rather than waiting for the next
+  # scheduled identity refresh.
+  process_identities_on_change = true
+
   # "identity_attribute_config" is a raw JSON object - see this resource's
   # "Known Limitations & Live Testing Notes" documentation section for why
   # it's modeled as a JSON string (each attributeTransforms entry's
@@ -0,0 +1,27 @@
+# Promote identity profiles from a sandbox tenant to production. The default
+# provider configuration points at production; the aliased one at the
+# sandbox the profiles are exported from.
+provider "identitynow" {
+  alias = "sandbox"
+
+  # Supply the sandbox tenant's client id/secret the same way as the
+  # default provider configuration's.
+  sail_base_url = "https://acme-sb.api.identitynow.com"
+}
+
+data "identitynow_identity_profiles_export_v1" "sandbox" {
+  provider = identitynow.sandbox
+  filters  = "name eq \"Employees\""
+}
+
+resource "identitynow_identity_profiles_import_v1" "production" {
+  bundle = data.identitynow_identity_profiles_export_v1.sandbox.export_json
+}
+
+# Shows, at plan time, which profiles the import would create or update.
+output "identity_profile_import_plan" {
+  value = {
+    for change in identitynow_identity_profiles_import_v1.production.planned_changes :
+    change.name => change.action
+  }
+}
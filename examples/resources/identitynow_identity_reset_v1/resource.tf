@@ -0,0 +1,10 @@
+# Reset an identity that has lost access to its authentication factors. This
+# de-registers the user and removes any elevated user levels they have.
+resource "identitynow_identity_reset_v1" "example" {
+  identity_ids = ["2c91808576ddc7060176de5040574ab0"]
+
+  # Bump to reset the identity again.
+  triggers = {
+    ticket = "INC0012345"
+  }
+}
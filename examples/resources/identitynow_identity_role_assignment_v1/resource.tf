@@ -0,0 +1,15 @@
+# Ensure a service identity holds a role. If the identity does not already
+# hold it, Create submits a GRANT_ACCESS access request and waits until the
+# assignment shows up; destroying this resource submits a REVOKE_ACCESS
+# request for the same assignment. An assignment the identity already held is
+# adopted instead, and destroying the resource leaves it in place.
+resource "identitynow_identity_role_assignment_v1" "svc_integration" {
+  identity_id = "2c91808576ddc7060176de5040574ab0"
+  role_id     = "2c918086749d78830174a1a40e121518"
+  comment     = "Managed by Terraform"
+
+  # Bounds both the wait for the granted assignment to appear and the wait
+  # for it to disappear on destroy. Roles whose access requests need
+  # approval stay pending until approved, so size this accordingly.
+  timeout = "15m"
+}
@@ -0,0 +1,28 @@
+# Platform team's workspace: owns the shared role and the access profiles it
+# lists itself, and leaves everything attached elsewhere alone.
+resource "identitynow_role_v1" "platform" {
+  name        = "Platform Baseline"
+  description = "Managed by Terraform."
+  enabled     = true
+  requestable = false
+
+  owner = {
+    id   = "2c91808576ddc7060176de5040574aa0"
+    type = "IDENTITY"
+  }
+
+  access_profiles = [
+    {
+      id   = "2c91808576ddc7060176de5040574ab0"
+      type = "ACCESS_PROFILE"
+    },
+  ]
+
+  ignore_unmanaged = true
+}
+
+# An app team's workspace: adds its own access profile to the shared role.
+resource "identitynow_role_access_profile_attachment_v1" "payments" {
+  role_id           = "2c91808a7813090a017814121e121518"
+  access_profile_id = "2c91808576ddc7060176de5040574ac0"
+}
@@ -0,0 +1,2 @@
+terraform import identitynow_role_dimension_v1.example \
+  2c91808a7813090a017814121e121518/2c9180835d2e5168015d32f890ca1581
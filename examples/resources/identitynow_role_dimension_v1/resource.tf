@@ -0,0 +1,35 @@
+# A dimensional role: each dimension below grants its own access profiles
+# to the identities matching its own criteria. dimension_refs is left unset
+# so the role reads back whatever refs its dimensions maintain.
+resource "identitynow_role_v1" "office_access" {
+  name        = "Office Access"
+  description = "Managed by Terraform."
+  enabled     = true
+  requestable = false
+  dimensional = true
+
+  owner = {
+    id   = "2c91808576ddc7060176de5040574aa0"
+    type = "IDENTITY"
+  }
+}
+
+resource "identitynow_role_dimension_v1" "austin" {
+  role_id     = identitynow_role_v1.office_access.id
+  name        = "Austin"
+  description = "Badge and printer access for the Austin office."
+  owner_id    = "2c91808576ddc7060176de5040574aa0"
+
+  access_profile_ids = [
+    "2c91808576ddc7060176de5040574ab0",
+  ]
+
+  membership_criteria_json = jsonencode({
+    operation = "EQUALS"
+    key = {
+      type     = "IDENTITY"
+      property = "attribute.location"
+    }
+    stringValue = "Austin"
+  })
+}
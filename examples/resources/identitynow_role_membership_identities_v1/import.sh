@@ -0,0 +1,2 @@
+terraform import identitynow_role_membership_identities_v1.example \
+  2c91808a7813090a017814121e121518,2c9180857182305e0171993735622948/2c9180857182305e0171993735622949
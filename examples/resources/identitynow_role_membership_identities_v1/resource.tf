@@ -0,0 +1,34 @@
+# The Role's identity list is shared by the helper resources below, so the
+# Role itself ignores membership drift instead of owning the whole list.
+resource "identitynow_role_v1" "contractors" {
+  name        = "Contractors"
+  description = "Managed by Terraform."
+  enabled     = true
+  requestable = false
+
+  owner = {
+    id   = "2c91808576ddc7060176de5040574aa0"
+    type = "IDENTITY"
+  }
+
+  lifecycle {
+    ignore_changes = [membership]
+  }
+}
+
+# Owned by the facilities team.
+resource "identitynow_role_membership_identities_v1" "facilities" {
+  role_id = identitynow_role_v1.contractors.id
+  identity_ids = [
+    "2c9180857182305e0171993735622948",
+    "2c9180857182305e0171993735622949",
+  ]
+}
+
+# Owned by the IT team; neither resource touches the other's identities.
+resource "identitynow_role_membership_identities_v1" "it" {
+  role_id = identitynow_role_v1.contractors.id
+  identity_ids = [
+    "2c9180857182305e017199373562294a",
+  ]
+}
@@ -10,3 +10,53 @@ resource "identitynow_role_v1" "example" {
     type = "IDENTITY"
   }
 }
+
+# Criteria trees deeper than the 3 levels the membership block can express
+# go in membership_criteria_json instead (the two conflict).
+resource "identitynow_role_v1" "birthright" {
+  name        = "birthright-engineering"
+  description = "Managed by Terraform."
+  enabled     = true
+  requestable = false
+
+  owner = {
+    id   = "2c91808576ddc7060176de5040574aa0"
+    type = "IDENTITY"
+  }
+
+  membership_criteria_json = jsonencode({
+    operation = "AND"
+    children = [
+      {
+        operation   = "EQUALS"
+        key         = { type = "IDENTITY", property = "attribute.department" }
+        stringValue = "Engineering"
+      },
+      {
+        operation = "OR"
+        children = [
+          {
+            operation   = "EQUALS"
+            key         = { type = "IDENTITY", property = "attribute.cloudLifecycleState" }
+            stringValue = "active"
+          },
+          {
+            operation = "AND"
+            children = [
+              {
+                operation   = "EQUALS"
+                key         = { type = "ACCOUNT", property = "attribute.employeeType", sourceId = "2c9180866166b5b0016167c32ef31f3c" }
+                stringValue = "contractor"
+              },
+              {
+                operation   = "STARTS_WITH"
+                key         = { type = "IDENTITY", property = "attribute.location" }
+                stringValue = "US-"
+              },
+            ]
+          },
+        ]
+      },
+    ]
+  })
+}
@@ -27,3 +27,37 @@ resource "identitynow_segment_v1" "example" {
     }
   }
 }
+
+# Criteria nested deeper than the two levels the visibility_criteria block can
+# express go in visibility_criteria_json instead (the two conflict).
+resource "identitynow_segment_v1" "austin_engineering" {
+  name        = "austin-engineering"
+  description = "Managed by Terraform."
+  active      = true
+
+  visibility_criteria_json = jsonencode({
+    operator = "AND"
+    children = [
+      {
+        operator  = "EQUALS"
+        attribute = "location"
+        value     = { type = "STRING", value = "Austin" }
+      },
+      {
+        operator = "OR"
+        children = [
+          {
+            operator  = "EQUALS"
+            attribute = "department"
+            value     = { type = "STRING", value = "Engineering" }
+          },
+          {
+            operator  = "EQUALS"
+            attribute = "costCenter"
+            value     = { type = "STRING", value = "42" }
+          }
+        ]
+      }
+    ]
+  })
+}
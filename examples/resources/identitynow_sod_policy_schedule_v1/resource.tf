@@ -0,0 +1,25 @@
+# Scan for violations of the policy every Monday at 06:00 New York time and
+# email the report to two compliance reviewers. Leave "scheduled" unset on the
+# policy itself - the schedule sets it.
+resource "identitynow_sod_policy_schedule_v1" "weekly" {
+  policy_id   = identitynow_sod_policy_v1.example.id
+  description = "Weekly SOD violation scan for SOX review."
+
+  schedule = {
+    type         = "WEEKLY"
+    time_zone_id = "America/New_York"
+    days = {
+      values = ["MON"]
+    }
+    hours = {
+      values = ["6"]
+    }
+  }
+
+  recipients = [
+    { id = "2c7180a46faadee4016fb4e018c20642" },
+    { id = "2c9180835d191305015d28d181fc1234" },
+  ]
+
+  email_empty_results = true
+}
@@ -0,0 +1,22 @@
+# Report on one policy, re-running whenever its criteria change.
+resource "identitynow_sod_violation_report_run_v1" "finance" {
+  policy_id = identitynow_sod_policy_v1.example.id
+
+  triggers = {
+    criteria = jsonencode(identitynow_sod_policy_v1.example.conflicting_access_criteria)
+  }
+}
+
+# Report on a subset of policies. Leave policy_ids unset to report on every
+# policy in the tenant.
+resource "identitynow_sod_violation_report_run_v1" "quarterly" {
+  policy_ids = [
+    "b868cd40-ffa4-4337-9c07-1a51846cfa94",
+    "63a07a7b-39a4-48aa-956d-50c827deba2a",
+  ]
+  create_timeout = "1h"
+
+  triggers = {
+    quarter = "2026-Q4"
+  }
+}
@@ -0,0 +1,23 @@
+# OAuth client for a workflow started by an external system, rotated every
+# 90 days. The credentials are handed to the caller through a secret store.
+resource "time_rotating" "external_client" {
+  rotation_days = 90
+}
+
+resource "identitynow_workflow_external_client_v1" "ticketing" {
+  workflow_id = identitynow_workflow_v1.external_ticket_intake.id
+
+  rotation_triggers = {
+    rotated = time_rotating.external_client.id
+  }
+}
+
+resource "vault_kv_secret_v2" "ticketing_workflow_client" {
+  mount = "secret"
+  name  = "identitynow/workflows/ticket-intake"
+  data_json = jsonencode({
+    client_id     = identitynow_workflow_external_client_v1.ticketing.client_id
+    client_secret = identitynow_workflow_external_client_v1.ticketing.client_secret
+    url           = identitynow_workflow_external_client_v1.ticketing.url
+  })
+}
@@ -0,0 +1,27 @@
+# Test-run a (disabled) workflow with the input its "Identity Attributes
+# Changed" trigger would send. The apply fails, showing the execution's step
+# history, unless the run completes. Bump "triggers" to run the test again.
+resource "identitynow_workflow_test_v1" "send_email_on_manager_change" {
+  workflow_id = identitynow_workflow_v1.send_email_on_manager_change.id
+
+  input = jsonencode({
+    identity = {
+      id   = "ee769173319b41d19ccec6cea52f237b"
+      name = "john.doe"
+      type = "IDENTITY"
+    }
+    changes = [
+      {
+        attribute = "manager"
+        oldValue  = { id = "ee769173319b41d19ccec6c235423237b", name = "nice.guy", type = "IDENTITY" }
+        newValue  = { id = "ee769173319b41d19ccec6c235423236c", name = "mean.guy", type = "IDENTITY" }
+      }
+    ]
+  })
+
+  triggers = {
+    definition = identitynow_workflow_v1.send_email_on_manager_change.definition
+  }
+
+  create_timeout = "5m"
+}
@@ -71,3 +71,29 @@ resource "identitynow_workflow_v1" "nightly_report" {
     }
   })
 }
+
+# A workflow exported from the UI (or by identitynow_workflow_export_v1) can
+# be used as-is: "definition" only takes the document's "definition", and
+# ignores server-managed fields, null values and key order when comparing.
+# The other attributes are read from the same document.
+locals {
+  exported_workflow = jsondecode(file("${path.module}/workflows/send-email.json"))
+}
+
+resource "identitynow_workflow_v1" "from_export" {
+  name        = local.exported_workflow.name
+  description = try(local.exported_workflow.description, null)
+  enabled     = false
+
+  owner = {
+    type = "IDENTITY"
+    id   = local.exported_workflow.owner.id
+  }
+
+  trigger = {
+    type       = local.exported_workflow.trigger.type
+    attributes = jsonencode(local.exported_workflow.trigger.attributes)
+  }
+
+  definition = file("${path.module}/workflows/send-email.json")
+}
@@ -18,15 +18,21 @@ package access_profile_v1
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/access_profiles"
 
 	"terraform-provider-identitynow/internal/provider/access_profile_v1/datasource_access_profile"
+	"terraform-provider-identitynow/internal/provider/util"
 )
 
 // accessProfilesListMaxLimit matches GET /access-profiles/v1's documented
@@ -37,8 +43,9 @@ import (
 const accessProfilesListMaxLimit = 250
 
 var (
-	_ datasource.DataSource              = (*accessProfilesDataSource)(nil)
-	_ datasource.DataSourceWithConfigure = (*accessProfilesDataSource)(nil)
+	_ datasource.DataSource                     = (*accessProfilesDataSource)(nil)
+	_ datasource.DataSourceWithConfigure        = (*accessProfilesDataSource)(nil)
+	_ datasource.DataSourceWithConfigValidators = (*accessProfilesDataSource)(nil)
 )
 
 func NewAccessProfilesDataSource() datasource.DataSource {
@@ -54,6 +61,7 @@ type accessProfilesDataSource struct {
 // nesting the generated datasource_access_profile.AccessProfileModel shape.
 type AccessProfilesDataSourceModel struct {
 	Filters            types.String `tfsdk:"filters"`
+	Filter             types.Object `tfsdk:"filter"`
 	Limit              types.Int64  `tfsdk:"limit"`
 	Offset             types.Int64  `tfsdk:"offset"`
 	IncludeCount       types.Bool   `tfsdk:"include_count"`
@@ -93,7 +101,8 @@ func (d *accessProfilesDataSource) Schema(ctx context.Context, req datasource.Sc
 	resp.Schema = schema.Schema{
 		Description: "Lists Access Profiles from IdentityNow/ISC, optionally filtered, sorted, and paginated.",
 		MarkdownDescription: "Lists [Access Profiles](https://documentation.sailpoint.com/saas/help/access/access-profiles.html) " +
-			"from IdentityNow/ISC via `GET /access-profiles/v1`, optionally filtered, sorted, and paginated. Returns " +
+			"from IdentityNow/ISC via `GET /access-profiles/v1`, optionally filtered (by a raw `filters` expression or a " +
+			"typed `filter` block), sorted, and paginated. Returns " +
 			"the same attributes per access profile as the singular `identitynow_access_profile_v1` data source.\n\n" +
 			"~> This is a `_v1` pilot data source - see `identitynow_access_profile_v1`'s \"Known Limitations & Live " +
 			"Testing Notes\" section before relying on it in production configurations; the same limitations apply " +
@@ -105,8 +114,9 @@ func (d *accessProfilesDataSource) Schema(ctx context.Context, req datasource.Sc
 				MarkdownDescription: "Filter expression used to query access profiles (e.g. `name sw \"Engineering\"`, " +
 					"`enabled eq true`). See [V3 API Standard Collection Parameters]" +
 					"(https://developer.sailpoint.com/idn/api/standard-collection-parameters#filtering-results) " +
-					"for the supported fields/operators for `GET /access-profiles/v1`.",
+					"for the supported fields/operators for `GET /access-profiles/v1`. Conflicts with `filter`.",
 			},
+			"filter": accessProfileFilterAttribute(),
 			"limit": schema.Int64Attribute{
 				Optional: true,
 				MarkdownDescription: "Maximum number of access profiles to return. The API's documented maximum for " +
@@ -152,6 +162,19 @@ func (d *accessProfilesDataSource) Schema(ctx context.Context, req datasource.Sc
 	}
 }
 
+func (d *accessProfilesDataSource) ConfigValidators(context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("filters"),
+			path.MatchRoot("filter"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("for_segment_ids"),
+			path.MatchRoot("filter").AtName("segment_ids"),
+		),
+	}
+}
+
 func (d *accessProfilesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -175,12 +198,28 @@ func (d *accessProfilesDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
-	tflog.Debug(ctx, "Reading Access Profiles data source", map[string]interface{}{"filters": config.Filters.ValueString()})
+	filters := config.Filters.ValueString()
+	if !config.Filter.IsNull() && !config.Filter.IsUnknown() {
+		expr, diags := util.BuildFilterExpression(config.Filter, path.Root("filter"), accessProfileFilterFields)
+		resp.Diagnostics.Append(diags...)
+		filters = expr
+	}
+	forSegmentIds := config.ForSegmentIds.ValueString()
+	segmentIds, diags := util.FilterBlockStrings(ctx, config.Filter, "segment_ids")
+	resp.Diagnostics.Append(diags...)
+	if len(segmentIds) > 0 {
+		forSegmentIds = strings.Join(segmentIds, ",")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading Access Profiles data source", map[string]interface{}{"filters": filters})
 
 	apiReq := d.client.AccessProfilesAPI.ListAccessProfilesV1(ctx)
 
-	if !config.Filters.IsNull() && !config.Filters.IsUnknown() {
-		apiReq = apiReq.Filters(config.Filters.ValueString())
+	if filters != "" {
+		apiReq = apiReq.Filters(filters)
 	}
 	if !config.Sorters.IsNull() && !config.Sorters.IsUnknown() {
 		apiReq = apiReq.Sorters(config.Sorters.ValueString())
@@ -188,8 +227,8 @@ func (d *accessProfilesDataSource) Read(ctx context.Context, req datasource.Read
 	if !config.ForSubadmin.IsNull() && !config.ForSubadmin.IsUnknown() {
 		apiReq = apiReq.ForSubadmin(config.ForSubadmin.ValueString())
 	}
-	if !config.ForSegmentIds.IsNull() && !config.ForSegmentIds.IsUnknown() {
-		apiReq = apiReq.ForSegmentIds(config.ForSegmentIds.ValueString())
+	if forSegmentIds != "" {
+		apiReq = apiReq.ForSegmentIds(forSegmentIds)
 	}
 	if !config.IncludeUnsegmented.IsNull() && !config.IncludeUnsegmented.IsUnknown() {
 		apiReq = apiReq.IncludeUnsegmented(config.IncludeUnsegmented.ValueBool())
@@ -197,10 +236,11 @@ func (d *accessProfilesDataSource) Read(ctx context.Context, req datasource.Read
 	if !config.IncludeCount.IsNull() && !config.IncludeCount.IsUnknown() {
 		apiReq = apiReq.Count(config.IncludeCount.ValueBool())
 	}
+	var offset, limit *int32
 	if !config.Offset.IsNull() && !config.Offset.IsUnknown() {
-		apiReq = apiReq.Offset(int32(config.Offset.ValueInt64()))
+		o := int32(config.Offset.ValueInt64())
+		offset = &o
 	}
-
 	if !config.Limit.IsNull() && !config.Limit.IsUnknown() {
 		requestedLimit := config.Limit.ValueInt64()
 		if requestedLimit > accessProfilesListMaxLimit {
@@ -209,19 +249,48 @@ func (d *accessProfilesDataSource) Read(ctx context.Context, req datasource.Read
 				fmt.Sprintf("The requested limit (%d) exceeds GET /access-profiles/v1's documented maximum of %d. Using %d instead.",
 					requestedLimit, accessProfilesListMaxLimit, accessProfilesListMaxLimit),
 			)
-			apiReq = apiReq.Limit(accessProfilesListMaxLimit)
-		} else {
-			apiReq = apiReq.Limit(int32(requestedLimit))
+			requestedLimit = accessProfilesListMaxLimit
 		}
+		l := int32(requestedLimit)
+		limit = &l
 	}
 
-	dtos, httpResp, err := apiReq.Execute()
+	// name_contains is matched here rather than by the API, so every page
+	// is read before offset/limit are applied to the matches.
+	nameContains, filterByName := util.FilterBlockString(config.Filter, "name_contains")
+
+	var dtos []access_profiles.AccessProfile
+	var httpResp *http.Response
+	var err error
+	if filterByName {
+		dtos, httpResp, err = util.ListAllPages(accessProfilesListMaxLimit, func(offset, limit int32) ([]access_profiles.AccessProfile, *http.Response, error) {
+			return apiReq.Offset(offset).Limit(limit).Execute()
+		})
+	} else {
+		if offset != nil {
+			apiReq = apiReq.Offset(*offset)
+		}
+		if limit != nil {
+			apiReq = apiReq.Limit(*limit)
+		}
+		dtos, httpResp, err = apiReq.Execute()
+	}
 	if err != nil {
 		tflog.Error(ctx, "Error reading Access Profiles data source", map[string]interface{}{"error": err.Error()})
 		resp.Diagnostics.AddError("Error listing Access Profiles", accessProfileErrDetail(err, httpResp))
 		return
 	}
 
+	if filterByName {
+		matched := dtos[:0]
+		for _, dto := range dtos {
+			if util.FilterNameContains(dto.Name, nameContains) {
+				matched = append(matched, dto)
+			}
+		}
+		dtos = util.PageSlice(matched, offset, limit)
+	}
+
 	elemType := datasource_access_profile.AccessProfileDataSourceSchema(ctx).Type()
 
 	models := make([]datasource_access_profile.AccessProfileModel, 0, len(dtos))
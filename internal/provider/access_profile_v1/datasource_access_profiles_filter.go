@@ -0,0 +1,40 @@
+// This file defines identitynow_access_profiles_v1's typed "filter" block,
+// built with the same util.BuildFilterExpression as identitynow_roles_v1's.
+// The fields are the ones GET /access-profiles/v1 documents; "segment_ids"
+// is sent as for-segment-ids and "name_contains" is matched against
+// every page of results, since the API has no "co" on name.
+package access_profile_v1
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+var accessProfileFilterFields = []util.FilterField{
+	{Attribute: "ids", Property: "id", Operator: "in", Kind: util.FilterKindStringSet, Description: "Only access profiles with one of these IDs."},
+	{Attribute: "name", Property: "name", Operator: "eq", Kind: util.FilterKindString, Description: "Only the access profile with exactly this name."},
+	{Attribute: "name_starts_with", Property: "name", Operator: "sw", Kind: util.FilterKindString, Description: "Only access profiles whose name starts with this prefix."},
+	{Attribute: "name_contains", Kind: util.FilterKindString, Description: "Only access profiles whose name contains this text, ignoring case. `GET /access-profiles/v1` can't filter on this, so the provider reads every page of results and matches them itself, then applies `offset` and `limit` to the matches."},
+	{Attribute: "owner_id", Property: "owner.id", Operator: "eq", Kind: util.FilterKindString, Description: "Only access profiles owned by this identity."},
+	{Attribute: "owner_ids", Property: "owner.id", Operator: "in", Kind: util.FilterKindStringSet, Description: "Only access profiles owned by one of these identities."},
+	{Attribute: "source_id", Property: "source.id", Operator: "eq", Kind: util.FilterKindString, Description: "Only access profiles on this Source."},
+	{Attribute: "source_ids", Property: "source.id", Operator: "in", Kind: util.FilterKindStringSet, Description: "Only access profiles on one of these Sources."},
+	{Attribute: "requestable", Property: "requestable", Operator: "eq", Kind: util.FilterKindBool, Description: "Only requestable (`true`) or non-requestable (`false`) access profiles."},
+	{Attribute: "created_after", Property: "created", Operator: "gt", Kind: util.FilterKindTimestamp, Description: "Only access profiles created after this RFC 3339 timestamp."},
+	{Attribute: "created_on_or_after", Property: "created", Operator: "ge", Kind: util.FilterKindTimestamp, Description: "Only access profiles created at or after this RFC 3339 timestamp."},
+	{Attribute: "created_on_or_before", Property: "created", Operator: "le", Kind: util.FilterKindTimestamp, Description: "Only access profiles created at or before this RFC 3339 timestamp."},
+	{Attribute: "modified_after", Property: "modified", Operator: "gt", Kind: util.FilterKindTimestamp, Description: "Only access profiles last modified after this RFC 3339 timestamp."},
+	{Attribute: "modified_before", Property: "modified", Operator: "lt", Kind: util.FilterKindTimestamp, Description: "Only access profiles last modified before this RFC 3339 timestamp."},
+	{Attribute: "modified_on_or_after", Property: "modified", Operator: "ge", Kind: util.FilterKindTimestamp, Description: "Only access profiles last modified at or after this RFC 3339 timestamp."},
+	{Attribute: "modified_on_or_before", Property: "modified", Operator: "le", Kind: util.FilterKindTimestamp, Description: "Only access profiles last modified at or before this RFC 3339 timestamp."},
+	{Attribute: "segment_ids", Kind: util.FilterKindStringSet, Description: "Only access profiles assigned to one of these Segments. Sent as the `for-segment-ids` query parameter; conflicts with the top-level `for_segment_ids`."},
+}
+
+func accessProfileFilterAttribute() schema.SingleNestedAttribute {
+	return util.FilterAttribute(
+		"Typed filter criteria, AND-ed together and compiled into the `filters` expression for you. Conflicts with `filters`.",
+		accessProfileFilterFields,
+		nil,
+	)
+}
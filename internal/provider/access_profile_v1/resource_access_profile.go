@@ -37,16 +37,25 @@
 //     resource_access_profile_readback.go) and, like role_v1's "membership"
 //     criteria tree, only resolves 3 levels deep (provisioning_criteria ->
 //     children -> grandchildren), matching the depth tfplugingen-framework
-//     flattened the recursive OpenAPI schema to.
+//     flattened the recursive OpenAPI schema to - also the API's own limit.
+//     "provisioning_criteria_json" is a hand-added, mutually exclusive raw
+//     JSON alternative (see resource_access_profile_provisioning_criteria_json.go).
+//   - "entitlements" is replaced wholesale on Update unless the hand-added
+//     "ignore_unmanaged" is set, in which case only the configured entries are
+//     added/removed and read back, so
+//     identitynow_access_profile_entitlement_attachment_v1 can attach others
+//     (see resource_access_profile_ignore_unmanaged.go).
 package access_profile_v1
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -64,12 +73,16 @@ import (
 // this package needing to import it (which would create an import cycle).
 type clientProvider interface {
 	GetClient() *sailpoint.APIClient
+	GetDeleteCoalescer() *util.DeleteCoalescer
 }
 
 var (
 	_ resource.Resource                = (*accessProfileResource)(nil)
 	_ resource.ResourceWithConfigure   = (*accessProfileResource)(nil)
 	_ resource.ResourceWithImportState = (*accessProfileResource)(nil)
+
+	_ resource.ResourceWithConfigValidators = (*accessProfileResource)(nil)
+	_ resource.ResourceWithValidateConfig   = (*accessProfileResource)(nil)
 )
 
 func NewAccessProfileResource() resource.Resource {
@@ -77,7 +90,37 @@ func NewAccessProfileResource() resource.Resource {
 }
 
 type accessProfileResource struct {
-	client *sailpoint.APIClient
+	client  *sailpoint.APIClient
+	deletes *util.DeleteCoalescer
+}
+
+// accessProfileResourceModel mirrors resource_access_profile.AccessProfileModel
+// plus the hand-added "provisioning_criteria_json" and "ignore_unmanaged"
+// fields (see resource_access_profile_provisioning_criteria_json.go and
+// resource_access_profile_ignore_unmanaged.go). Kept as a distinct,
+// hand-written struct (rather than embedding the generated model) since Go
+// doesn't allow adding a field to an imported struct type, and
+// req.Plan.Get/resp.State.Set match purely on `tfsdk` tags, not on which
+// struct type declares them.
+type accessProfileResourceModel struct {
+	AccessModelMetadata      resource_access_profile.AccessModelMetadataValue     `tfsdk:"access_model_metadata"`
+	AccessRequestConfig      resource_access_profile.AccessRequestConfigValue     `tfsdk:"access_request_config"`
+	AdditionalOwners         types.List                                           `tfsdk:"additional_owners"`
+	Created                  types.String                                         `tfsdk:"created"`
+	Description              types.String                                         `tfsdk:"description"`
+	Enabled                  types.Bool                                           `tfsdk:"enabled"`
+	Entitlements             types.List                                           `tfsdk:"entitlements"`
+	Id                       types.String                                         `tfsdk:"id"`
+	IgnoreUnmanaged          types.Bool                                           `tfsdk:"ignore_unmanaged"`
+	Modified                 types.String                                         `tfsdk:"modified"`
+	Name                     types.String                                         `tfsdk:"name"`
+	Owner                    resource_access_profile.OwnerValue                   `tfsdk:"owner"`
+	ProvisioningCriteria     resource_access_profile.ProvisioningCriteriaValue    `tfsdk:"provisioning_criteria"`
+	ProvisioningCriteriaJson jsontypes.Normalized                                 `tfsdk:"provisioning_criteria_json"`
+	Requestable              types.Bool                                           `tfsdk:"requestable"`
+	RevocationRequestConfig  resource_access_profile.RevocationRequestConfigValue `tfsdk:"revocation_request_config"`
+	Segments                 types.List                                           `tfsdk:"segments"`
+	Source                   resource_access_profile.SourceValue                  `tfsdk:"source"`
 }
 
 func (r *accessProfileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -93,6 +136,8 @@ func (r *accessProfileResource) Schema(ctx context.Context, req resource.SchemaR
 		"set of access that can be requested or assigned together.\n\n" +
 		"~> This is a `_v1` pilot resource - see the \"Known Limitations & Live Testing Notes\" section below before relying " +
 		"on it in production configurations."
+	applyAccessProfileProvisioningCriteriaJSONField(&resp.Schema.Attributes)
+	applyAccessProfileIgnoreUnmanagedField(&resp.Schema.Attributes)
 	applyAccessProfileUseStateForUnknown(&resp.Schema)
 }
 
@@ -110,6 +155,7 @@ func (r *accessProfileResource) Configure(ctx context.Context, req resource.Conf
 		return
 	}
 	r.client = cp.GetClient()
+	r.deletes = cp.GetDeleteCoalescer()
 }
 
 func (r *accessProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -117,7 +163,7 @@ func (r *accessProfileResource) ImportState(ctx context.Context, req resource.Im
 }
 
 func (r *accessProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan resource_access_profile.AccessProfileModel
+	var plan accessProfileResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -157,7 +203,7 @@ func (r *accessProfileResource) Create(ctx context.Context, req resource.CreateR
 }
 
 func (r *accessProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state resource_access_profile.AccessProfileModel
+	var state accessProfileResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -165,6 +211,11 @@ func (r *accessProfileResource) Read(ctx context.Context, req resource.ReadReque
 
 	tflog.Debug(ctx, "Reading Access Profile", map[string]interface{}{"id": state.Id.ValueString()})
 
+	// Only null right after `terraform import`.
+	if state.IgnoreUnmanaged.IsNull() {
+		state.IgnoreUnmanaged = types.BoolValue(defaultAccessProfileIgnoreUnmanaged)
+	}
+
 	apiResp, httpResp, err := r.client.AccessProfilesAPI.
 		GetAccessProfileV1(ctx, state.Id.ValueString()).
 		Execute()
@@ -191,13 +242,13 @@ func (r *accessProfileResource) Read(ctx context.Context, req resource.ReadReque
 }
 
 func (r *accessProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan resource_access_profile.AccessProfileModel
+	var plan accessProfileResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var state resource_access_profile.AccessProfileModel
+	var state accessProfileResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -241,7 +292,30 @@ func (r *accessProfileResource) Update(ctx context.Context, req resource.UpdateR
 	if dto.Requestable != nil {
 		patch = append(patch, accessProfileJSONPatchReplace("/requestable", access_profiles.BoolAsJsonPatchOperationValue(dto.Requestable)))
 	}
-	if dto.Entitlements != nil {
+	// With ignore_unmanaged, entitlements are added/removed individually by
+	// patchAccessProfileEntitlementLinks below instead of replaced wholesale -
+	// unless the source changed, which the API only accepts together with a
+	// full entitlements replace.
+	linkEntitlements := plan.IgnoreUnmanaged.ValueBool() && plan.Source.Id.Equal(state.Source.Id)
+	var entitlementsToAdd, entitlementsToRemove []string
+	if linkEntitlements {
+		if dto.Entitlements != nil {
+			oldIds, d := accessProfileEntitlementRefIds(ctx, state.Entitlements)
+			resp.Diagnostics.Append(d...)
+			newIds, d := accessProfileEntitlementRefIds(ctx, plan.Entitlements)
+			resp.Diagnostics.Append(d...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			entitlementsToAdd, entitlementsToRemove = util.DiffIds(oldIds, newIds)
+			// Prior state still holds every live entitlement when
+			// ignore_unmanaged is only now being turned on, so nothing can
+			// safely be treated as removed from configuration yet.
+			if !state.IgnoreUnmanaged.ValueBool() {
+				entitlementsToRemove = nil
+			}
+		}
+	} else if dto.Entitlements != nil {
 		if arr, err := accessProfileSliceToArrayInner(dto.Entitlements); err == nil {
 			patch = append(patch, accessProfileJSONPatchReplace("/entitlements", access_profiles.ArrayOfArrayInnerAsJsonPatchOperationValue(&arr)))
 		}
@@ -258,7 +332,16 @@ func (r *accessProfileResource) Update(ctx context.Context, req resource.UpdateR
 		}
 		patch = append(patch, accessProfileJSONPatchReplace("/segments", access_profiles.ArrayOfArrayInnerAsJsonPatchOperationValue(&arr)))
 	}
-	if dto.ProvisioningCriteria.IsSet() {
+	if !plan.ProvisioningCriteriaJson.IsNull() && !plan.ProvisioningCriteriaJson.IsUnknown() {
+		// Patched from the configured JSON rather than dto.ProvisioningCriteria
+		// so the criteria are sent exactly as written.
+		m, _, d := provisioningCriteriaFromJSON(plan.ProvisioningCriteriaJson)
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		patch = append(patch, accessProfileJSONPatchReplace("/provisioningCriteria", access_profiles.MapmapOfStringAnyAsJsonPatchOperationValue(&m)))
+	} else if dto.ProvisioningCriteria.IsSet() {
 		if m, err := accessProfileStructToMap(dto.ProvisioningCriteria.Get()); err == nil {
 			patch = append(patch, accessProfileJSONPatchReplace("/provisioningCriteria", access_profiles.MapmapOfStringAnyAsJsonPatchOperationValue(&m)))
 		}
@@ -266,10 +349,17 @@ func (r *accessProfileResource) Update(ctx context.Context, req resource.UpdateR
 
 	tflog.Debug(ctx, "Patching Access Profile", map[string]interface{}{"id": state.Id.ValueString(), "patch_ops": len(patch)})
 
-	apiResp, httpResp, err := r.client.AccessProfilesAPI.
-		PatchAccessProfileV1(ctx, state.Id.ValueString()).
-		JsonPatchOperation(patch).
-		Execute()
+	var apiResp *access_profiles.AccessProfile
+	var httpResp *http.Response
+	var err error
+	if linkEntitlements {
+		apiResp, httpResp, err = patchAccessProfileEntitlementLinks(ctx, r.client, state.Id.ValueString(), entitlementsToAdd, entitlementsToRemove, patch)
+	} else {
+		apiResp, httpResp, err = r.client.AccessProfilesAPI.
+			PatchAccessProfileV1(ctx, state.Id.ValueString()).
+			JsonPatchOperation(patch).
+			Execute()
+	}
 	if err != nil {
 		tflog.Error(ctx, "Error updating Access Profile", map[string]interface{}{"id": state.Id.ValueString(), "error": err.Error()})
 		resp.Diagnostics.AddError("Error updating Access Profile", accessProfileErrDetail(err, httpResp))
@@ -288,7 +378,7 @@ func (r *accessProfileResource) Update(ctx context.Context, req resource.UpdateR
 }
 
 func (r *accessProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state resource_access_profile.AccessProfileModel
+	var state accessProfileResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -296,6 +386,21 @@ func (r *accessProfileResource) Delete(ctx context.Context, req resource.DeleteR
 
 	tflog.Debug(ctx, "Deleting Access Profile", map[string]interface{}{"id": state.Id.ValueString()})
 
+	if r.deletes.Enabled() {
+		err := r.deletes.Delete(ctx, accessProfileBulkDeleteKind, accessProfileBulkDeleteMax, state.Id.ValueString(), accessProfileBulkDelete(r.client))
+		if errors.Is(err, util.ErrBulkDeleteNotFound) {
+			tflog.Warn(ctx, "Access Profile already absent on delete", map[string]interface{}{"id": state.Id.ValueString()})
+			return
+		}
+		if err != nil {
+			tflog.Error(ctx, "Error deleting Access Profile", map[string]interface{}{"id": state.Id.ValueString(), "error": err.Error()})
+			resp.Diagnostics.AddError("Error deleting Access Profile", err.Error())
+			return
+		}
+		tflog.Info(ctx, "Deleted Access Profile", map[string]interface{}{"id": state.Id.ValueString()})
+		return
+	}
+
 	httpResp, err := r.client.AccessProfilesAPI.
 		DeleteAccessProfileV1(ctx, state.Id.ValueString()).
 		Execute()
@@ -319,7 +424,7 @@ func (r *accessProfileResource) Delete(ctx context.Context, req resource.DeleteR
 // owner and source are both Required in the schema (matches the API's
 // required: [name, owner, source]), so they are never Unknown by the time
 // Create/Update run.
-func accessProfileModelToDto(ctx context.Context, m resource_access_profile.AccessProfileModel) (*access_profiles.AccessProfile, diag.Diagnostics) {
+func accessProfileModelToDto(ctx context.Context, m accessProfileResourceModel) (*access_profiles.AccessProfile, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	owner, d := m.Owner.ToApi_betaOwnerReference(ctx)
@@ -390,6 +495,13 @@ func accessProfileModelToDto(ctx context.Context, m resource_access_profile.Acce
 			dto.ProvisioningCriteria = *access_profiles.NewNullableProvisioningCriteriaLevel1(level1)
 		}
 	}
+	if !m.ProvisioningCriteriaJson.IsNull() && !m.ProvisioningCriteriaJson.IsUnknown() {
+		_, level1, d := provisioningCriteriaFromJSON(m.ProvisioningCriteriaJson)
+		diags.Append(d...)
+		if level1 != nil {
+			dto.ProvisioningCriteria = *access_profiles.NewNullableProvisioningCriteriaLevel1(level1)
+		}
+	}
 
 	return dto, diags
 }
@@ -397,7 +509,7 @@ func accessProfileModelToDto(ctx context.Context, m resource_access_profile.Acce
 // accessProfileDtoToModel converts an API response DTO into the Terraform
 // state model, preferring fields carried over from fallback (plan/prior
 // state) for the pass-through-only blocks documented in the package doc.
-func accessProfileDtoToModel(ctx context.Context, dto *access_profiles.AccessProfile, fallback resource_access_profile.AccessProfileModel) (resource_access_profile.AccessProfileModel, diag.Diagnostics) {
+func accessProfileDtoToModel(ctx context.Context, dto *access_profiles.AccessProfile, fallback accessProfileResourceModel) (accessProfileResourceModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	model := fallback
 
@@ -444,6 +556,11 @@ func accessProfileDtoToModel(ctx context.Context, dto *access_profiles.AccessPro
 				EntitlementsType: types.StringPointerValue(e.Type),
 			})
 		}
+		if fallback.IgnoreUnmanaged.ValueBool() {
+			managed, d := accessProfileManagedEntitlements(ctx, values, fallback.Entitlements)
+			diags.Append(d...)
+			values = managed
+		}
 		listVal, d := types.ListValueFrom(ctx, resource_access_profile.EntitlementsValue{}.Type(ctx), values)
 		diags.Append(d...)
 		model.Entitlements = listVal
@@ -496,9 +613,21 @@ func accessProfileDtoToModel(ctx context.Context, dto *access_profiles.AccessPro
 	// so - unlike the three pass-through-only blocks above - it is always read
 	// back from the API response rather than only when unconfigured, mirroring
 	// how entitlements/additional_owners/segments are always read back.
-	pc, d := accessProfileProvisioningCriteriaFromApi(ctx, dto.ProvisioningCriteria.Get())
-	diags.Append(d...)
-	model.ProvisioningCriteria = pc
+	//
+	// When provisioning_criteria_json is configured it owns the criteria
+	// instead (see resource_access_profile_provisioning_criteria_json.go), and
+	// the 3-level block is left null rather than showing a truncated copy of
+	// the same tree.
+	if !model.ProvisioningCriteriaJson.IsNull() {
+		v, d := provisioningCriteriaJSONFromApi(dto.ProvisioningCriteria.Get(), model.ProvisioningCriteriaJson)
+		diags.Append(d...)
+		model.ProvisioningCriteriaJson = v
+		model.ProvisioningCriteria = resource_access_profile.NewProvisioningCriteriaValueNull()
+	} else {
+		pc, d := accessProfileProvisioningCriteriaFromApi(ctx, dto.ProvisioningCriteria.Get())
+		diags.Append(d...)
+		model.ProvisioningCriteria = pc
+	}
 
 	return model, diags
 }
@@ -537,6 +666,29 @@ func accessProfileJSONPatchReplace(path string, value access_profiles.JsonPatchO
 	}
 }
 
+func accessProfileJSONPatchAdd(path string, value access_profiles.JsonPatchOperationValue) access_profiles.JsonPatchOperation {
+	return access_profiles.JsonPatchOperation{
+		Op:    "add",
+		Path:  path,
+		Value: &value,
+	}
+}
+
+func accessProfileJSONPatchTest(path string, value access_profiles.JsonPatchOperationValue) access_profiles.JsonPatchOperation {
+	return access_profiles.JsonPatchOperation{
+		Op:    "test",
+		Path:  path,
+		Value: &value,
+	}
+}
+
+func accessProfileJSONPatchRemove(path string) access_profiles.JsonPatchOperation {
+	return access_profiles.JsonPatchOperation{
+		Op:   "remove",
+		Path: path,
+	}
+}
+
 // accessProfileStructToMap round-trips an SDK model struct through JSON to get
 // a map[string]interface{} suitable for
 // access_profiles.MapmapOfStringAnyAsJsonPatchOperationValue, since the
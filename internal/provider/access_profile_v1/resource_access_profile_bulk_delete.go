@@ -0,0 +1,77 @@
+// This file routes identitynow_access_profile_v1's Delete through the
+// provider's util.DeleteCoalescer, so access profiles destroyed in the same
+// apply are removed with POST /access-profiles/v1/bulk-delete, up to 50 at a
+// time. The request is sent with bestEffortOnly, so one profile still in use
+// by a role doesn't block the rest of the batch: the response's inUse list
+// becomes that profile's error, and everything else is checked once the
+// returned task has finished.
+package access_profile_v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/access_profiles"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+const (
+	accessProfileBulkDeleteKind = "access_profiles"
+	// accessProfileBulkDeleteMax is POST /access-profiles/v1/bulk-delete's
+	// documented limit.
+	accessProfileBulkDeleteMax         = 50
+	accessProfileBulkDeleteTaskTimeout = 10 * time.Minute
+)
+
+func accessProfileBulkDelete(client *sailpoint.APIClient) util.BulkDeleteFunc {
+	return util.BulkDeleteByTask(client, "Access Profile", accessProfileBulkDeleteTaskTimeout,
+		func(ctx context.Context, ids []string) ([]string, error) {
+			live, httpResp, err := client.AccessProfilesAPI.ListAccessProfilesV1(ctx).
+				Filters(util.FilterIdsIn(ids)).
+				Limit(accessProfileBulkDeleteMax).
+				Execute()
+			if err != nil {
+				return nil, errors.New(accessProfileErrDetail(err, httpResp))
+			}
+			out := make([]string, 0, len(live))
+			for i := range live {
+				out = append(out, live[i].GetId())
+			}
+			return out, nil
+		},
+		func(ctx context.Context, ids []string) (string, map[string]error, error) {
+			body := access_profiles.NewAccessProfileBulkDeleteRequest()
+			body.SetAccessProfileIds(ids)
+			body.SetBestEffortOnly(true)
+			result, httpResp, err := client.AccessProfilesAPI.DeleteAccessProfilesInBulkV1(ctx).
+				AccessProfileBulkDeleteRequest(*body).
+				Execute()
+			if err != nil {
+				return "", nil, errors.New(accessProfileErrDetail(err, httpResp))
+			}
+			failed := map[string]error{}
+			for _, inUse := range result.GetInUse() {
+				failed[inUse.GetAccessProfileId()] = fmt.Errorf("Access Profile %q is in use by %s", inUse.GetAccessProfileId(), accessProfileUsedBy(inUse.GetUsedBy()))
+			}
+			return result.GetTaskId(), failed, nil
+		},
+	)
+}
+
+// accessProfileUsedBy describes the objects an in-use access profile is
+// referenced by, e.g. `ROLE "Engineering" (2c91...)`.
+func accessProfileUsedBy(usedBy []access_profiles.AccessProfileUsage) string {
+	if len(usedBy) == 0 {
+		return "another object"
+	}
+	parts := make([]string, 0, len(usedBy))
+	for _, u := range usedBy {
+		parts = append(parts, fmt.Sprintf("%s %q (%s)", u.GetType(), u.GetName(), u.GetId()))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,234 @@
+// This file implements identitynow_access_profile_entitlement_attachment_v1,
+// a fully hand-written resource for one (access profile, entitlement) link.
+//
+// identitynow_access_profile_v1 sends "entitlements" as a whole-list replace,
+// so an access profile shared between teams can't be extended from another
+// workspace without the two fighting over the list. This resource owns
+// exactly one entry instead: Create appends it and Delete removes it with the
+// targeted, retried JSON Patch operations in
+// resource_access_profile_links.go, leaving every other entitlement on the
+// access profile untouched. Pair it with ignore_unmanaged = true on the
+// identitynow_access_profile_v1 that owns the access profile.
+package access_profile_v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+var (
+	_ resource.Resource                = (*accessProfileEntitlementAttachmentResource)(nil)
+	_ resource.ResourceWithConfigure   = (*accessProfileEntitlementAttachmentResource)(nil)
+	_ resource.ResourceWithImportState = (*accessProfileEntitlementAttachmentResource)(nil)
+)
+
+func NewAccessProfileEntitlementAttachmentResource() resource.Resource {
+	return &accessProfileEntitlementAttachmentResource{}
+}
+
+type accessProfileEntitlementAttachmentResource struct {
+	client *sailpoint.APIClient
+}
+
+type accessProfileEntitlementAttachmentResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	AccessProfileId types.String `tfsdk:"access_profile_id"`
+	EntitlementId   types.String `tfsdk:"entitlement_id"`
+}
+
+func (r *accessProfileEntitlementAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_profile_entitlement_attachment_v1"
+}
+
+func (r *accessProfileEntitlementAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceschema.Schema{
+		Description: "Attaches one Entitlement to an Access Profile in IdentityNow/ISC without managing the Access Profile's other entitlements.",
+		MarkdownDescription: "Attaches one [Entitlement](https://documentation.sailpoint.com/) " +
+			"to an [Access Profile](https://documentation.sailpoint.com/saas/help/access/access-profiles.html) in IdentityNow/ISC " +
+			"without managing the Access Profile's other entitlements. This is a fully hand-written `_v1` resource: it adds and " +
+			"removes its one entry in the access profile's `entitlements` with targeted JSON Patch operations through " +
+			"`PATCH /access-profiles/v1/{id}`, so teams can extend a shared access profile from their own workspaces.",
+		Attributes: map[string]resourceschema.Attribute{
+			"id": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "Composite ID in the form access_profile_id/entitlement_id.",
+				MarkdownDescription: "Composite ID in the form `access_profile_id/entitlement_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"access_profile_id": resourceschema.StringAttribute{
+				Required:            true,
+				Description:         "ID of the Access Profile.",
+				MarkdownDescription: "ID of the Access Profile. Changing this forces replacement.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"entitlement_id": resourceschema.StringAttribute{
+				Required:            true,
+				Description:         "ID of the Entitlement to attach to the Access Profile.",
+				MarkdownDescription: "ID of the Entitlement to attach to the Access Profile. Changing this forces replacement.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *accessProfileEntitlementAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = cp.GetClient()
+}
+
+func (r *accessProfileEntitlementAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan accessProfileEntitlementAttachmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accessProfileID := plan.AccessProfileId.ValueString()
+	entitlementID := plan.EntitlementId.ValueString()
+
+	tflog.Debug(ctx, "Attaching Entitlement to Access Profile", map[string]interface{}{"access_profile_id": accessProfileID, "entitlement_id": entitlementID})
+
+	// An entitlement that is already attached is adopted: the link ops
+	// come out empty and nothing is sent.
+	_, httpResp, err := patchAccessProfileEntitlementLinks(ctx, r.client, accessProfileID, []string{entitlementID}, nil, nil)
+	if err != nil {
+		tflog.Error(ctx, "Error attaching Entitlement to Access Profile", map[string]interface{}{"access_profile_id": accessProfileID, "error": err.Error()})
+		resp.Diagnostics.AddError("Error attaching Entitlement to Access Profile", accessProfileErrDetail(err, httpResp))
+		return
+	}
+
+	plan.Id = types.StringValue(accessProfileEntitlementAttachmentID(accessProfileID, entitlementID))
+
+	tflog.Info(ctx, "Attached Entitlement to Access Profile", map[string]interface{}{"id": plan.Id.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *accessProfileEntitlementAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state accessProfileEntitlementAttachmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accessProfileID := state.AccessProfileId.ValueString()
+	entitlementID := state.EntitlementId.ValueString()
+
+	tflog.Debug(ctx, "Reading Access Profile entitlement attachment", map[string]interface{}{"id": state.Id.ValueString()})
+
+	accessProfile, httpResp, err := r.client.AccessProfilesAPI.GetAccessProfileV1(ctx, accessProfileID).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			tflog.Warn(ctx, "Access Profile not found, removing entitlement attachment from state", map[string]interface{}{"access_profile_id": accessProfileID})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		tflog.Error(ctx, "Error reading Access Profile entitlement attachment", map[string]interface{}{"access_profile_id": accessProfileID, "error": err.Error()})
+		resp.Diagnostics.AddError("Error reading Access Profile entitlement attachment", accessProfileErrDetail(err, httpResp))
+		return
+	}
+
+	if !util.ContainsString(accessProfileEntitlementIds(accessProfile), entitlementID) {
+		tflog.Warn(ctx, "Entitlement no longer attached to Access Profile, removing from state", map[string]interface{}{"id": state.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Id = types.StringValue(accessProfileEntitlementAttachmentID(accessProfileID, entitlementID))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *accessProfileEntitlementAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan accessProfileEntitlementAttachmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// access_profile_id and entitlement_id both force replacement, so there is
+	// nothing to send here.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *accessProfileEntitlementAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state accessProfileEntitlementAttachmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accessProfileID := state.AccessProfileId.ValueString()
+	entitlementID := state.EntitlementId.ValueString()
+
+	tflog.Debug(ctx, "Detaching Entitlement from Access Profile", map[string]interface{}{"access_profile_id": accessProfileID, "entitlement_id": entitlementID})
+
+	_, httpResp, err := patchAccessProfileEntitlementLinks(ctx, r.client, accessProfileID, nil, []string{entitlementID}, nil)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			tflog.Warn(ctx, "Access Profile already absent on entitlement detach", map[string]interface{}{"access_profile_id": accessProfileID})
+			return
+		}
+		tflog.Error(ctx, "Error detaching Entitlement from Access Profile", map[string]interface{}{"access_profile_id": accessProfileID, "error": err.Error()})
+		resp.Diagnostics.AddError("Error detaching Entitlement from Access Profile", accessProfileErrDetail(err, httpResp))
+		return
+	}
+
+	tflog.Info(ctx, "Detached Entitlement from Access Profile", map[string]interface{}{"id": state.Id.ValueString()})
+}
+
+func (r *accessProfileEntitlementAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	accessProfileID, entitlementID, err := accessProfileEntitlementAttachmentIDToParts(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("access_profile_id"), accessProfileID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("entitlement_id"), entitlementID)...)
+}
+
+// accessProfileEntitlementAttachmentID uses the same "/"-joined composite form as
+// source_provisioning_policy_v1's idFromParts.
+func accessProfileEntitlementAttachmentID(accessProfileID, entitlementID string) string {
+	return accessProfileID + "/" + entitlementID
+}
+
+func accessProfileEntitlementAttachmentIDToParts(id string) (accessProfileID, entitlementID string, err error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("expected import id in the form \"access_profile_id/entitlement_id\", got: %q", id)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,81 @@
+// This file implements "ignore_unmanaged", a hand-added opt-in that lets
+// identitynow_access_profile_v1 share its access profile's "entitlements"
+// with identitynow_access_profile_entitlement_attachment_v1 resources - the
+// same mode role_v1 offers for a role's "access_profiles".
+//
+// With it set, "entitlements" only describes the entries this resource
+// configured: Update sends the difference between prior state and plan as
+// targeted add/remove operations (see resource_access_profile_links.go)
+// instead of replacing the whole list, and the read-back keeps only the live
+// entries that were configured, so entitlements attached by anyone else are
+// neither removed nor reported as drift.
+package access_profile_v1
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-identitynow/internal/provider/access_profile_v1/resource_access_profile"
+)
+
+const defaultAccessProfileIgnoreUnmanaged = false
+
+func applyAccessProfileIgnoreUnmanagedField(attrs *map[string]schema.Attribute) {
+	if *attrs == nil {
+		*attrs = map[string]schema.Attribute{}
+	}
+	(*attrs)["ignore_unmanaged"] = schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(defaultAccessProfileIgnoreUnmanaged),
+		Description: "When true, entitlements only manages the entitlements listed in configuration; ones attached " +
+			"outside this resource are neither removed nor reported as drift.",
+		MarkdownDescription: "When `true`, `entitlements` only manages the entitlements listed in configuration: updates " +
+			"add and remove just those entries instead of replacing the whole list, and entitlements attached outside this " +
+			"resource (for example by `identitynow_access_profile_entitlement_attachment_v1`) are neither removed nor " +
+			"reported as drift. A change of `source` still replaces the whole list, as the API requires. Defaults to `false`.",
+	}
+}
+
+// accessProfileEntitlementRefIds returns the ids of a model's entitlements
+// list; a null or unknown list has none.
+func accessProfileEntitlementRefIds(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+
+	var items []resource_access_profile.EntitlementsValue
+	diags := list.ElementsAs(ctx, &items, false)
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		if !item.Id.IsNull() && !item.Id.IsUnknown() {
+			ids = append(ids, item.Id.ValueString())
+		}
+	}
+	return ids, diags
+}
+
+// accessProfileManagedEntitlements narrows live (the API's entitlements) to
+// the ids in managed, in managed's order so a configured list reads back in
+// the order it was written. Managed ids no longer on the access profile are
+// dropped, which surfaces as drift on the next plan.
+func accessProfileManagedEntitlements(ctx context.Context, live []resource_access_profile.EntitlementsValue, managed types.List) ([]resource_access_profile.EntitlementsValue, diag.Diagnostics) {
+	ids, diags := accessProfileEntitlementRefIds(ctx, managed)
+
+	byID := make(map[string]resource_access_profile.EntitlementsValue, len(live))
+	for _, v := range live {
+		byID[v.Id.ValueString()] = v
+	}
+
+	out := make([]resource_access_profile.EntitlementsValue, 0, len(ids))
+	for _, id := range ids {
+		if v, ok := byID[id]; ok {
+			out = append(out, v)
+		}
+	}
+	return out, diags
+}
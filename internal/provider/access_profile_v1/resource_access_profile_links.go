@@ -0,0 +1,192 @@
+// This file holds the helpers shared by the resources that edit part of an
+// access profile's "entitlements" without owning the whole list:
+// identitynow_access_profile_entitlement_attachment_v1 and
+// identitynow_access_profile_v1 itself when ignore_unmanaged is set.
+//
+// Like role_v1's accessProfiles helpers (role_v1's resource_role_links.go),
+// entitlements are edited with targeted JSON Patch operations: additions
+// append to "/entitlements/-", and each removal is guarded by a "test" of the
+// id at the index being removed, so a list that was reordered or shrunk by
+// someone else fails the patch instead of losing the wrong entry.
+// patchAccessProfileEntitlementLinks re-reads the access profile and
+// rebuilds the patch when that happens.
+package access_profile_v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/access_profiles"
+)
+
+const accessProfileEntitlementRefType = "ENTITLEMENT"
+
+// accessProfileLinkPatchAttempts/-RetryInterval bound how often
+// patchAccessProfileEntitlementLinks retries after a concurrent change to
+// the access profile's entitlements.
+const (
+	accessProfileLinkPatchAttempts      = 5
+	accessProfileLinkPatchRetryInterval = 2 * time.Second
+)
+
+// accessProfileLocks serializes read-modify-writes of an access profile's
+// entitlements per access profile ID across every resource instance in this
+// provider process.
+var accessProfileLocks sync.Map
+
+func lockAccessProfile(accessProfileID string) func() {
+	mu, _ := accessProfileLocks.LoadOrStore(accessProfileID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// patchAccessProfileEntitlementLinks adds toAdd to and removes toRemove from
+// accessProfileID's entitlements with targeted operations, sent in one PATCH
+// together with extra (e.g. identitynow_access_profile_v1's other field
+// replaces). When the PATCH fails and a fresh read shows the entitlement list
+// moved in the meantime, the patch is rebuilt from that read and retried.
+// Returns the patched access profile, or the access profile as read if there
+// was nothing to do.
+func patchAccessProfileEntitlementLinks(ctx context.Context, client *sailpoint.APIClient, accessProfileID string, toAdd, toRemove []string, extra []access_profiles.JsonPatchOperation) (*access_profiles.AccessProfile, *http.Response, error) {
+	unlock := lockAccessProfile(accessProfileID)
+	defer unlock()
+
+	return retryAccessProfileEntitlementLinks(ctx, accessProfileID, toAdd, toRemove, extra,
+		func(ctx context.Context) (*access_profiles.AccessProfile, *http.Response, error) {
+			return client.AccessProfilesAPI.GetAccessProfileV1(ctx, accessProfileID).Execute()
+		},
+		func(ctx context.Context, patch []access_profiles.JsonPatchOperation) (*access_profiles.AccessProfile, *http.Response, error) {
+			return client.AccessProfilesAPI.PatchAccessProfileV1(ctx, accessProfileID).JsonPatchOperation(patch).Execute()
+		},
+	)
+}
+
+// retryAccessProfileEntitlementLinks is patchAccessProfileEntitlementLinks'
+// read-patch-retry loop, with the GET and PATCH calls passed in so the
+// retry behaviour can be tested without an API.
+func retryAccessProfileEntitlementLinks(
+	ctx context.Context,
+	accessProfileID string,
+	toAdd, toRemove []string,
+	extra []access_profiles.JsonPatchOperation,
+	get func(ctx context.Context) (*access_profiles.AccessProfile, *http.Response, error),
+	patchFn func(ctx context.Context, patch []access_profiles.JsonPatchOperation) (*access_profiles.AccessProfile, *http.Response, error),
+) (*access_profiles.AccessProfile, *http.Response, error) {
+	accessProfile, httpResp, err := get(ctx)
+	if err != nil {
+		return nil, httpResp, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		current := accessProfileEntitlementIds(accessProfile)
+		patch := append(append([]access_profiles.JsonPatchOperation{}, extra...), accessProfileEntitlementLinkOps(current, toAdd, toRemove)...)
+		if len(patch) == 0 {
+			return accessProfile, httpResp, nil
+		}
+
+		tflog.Debug(ctx, "Patching Access Profile entitlements", map[string]interface{}{
+			"id":        accessProfileID,
+			"attempt":   attempt,
+			"patch_ops": len(patch),
+		})
+
+		patched, patchResp, patchErr := patchFn(ctx, patch)
+		if patchErr == nil {
+			return patched, patchResp, nil
+		}
+		if attempt >= accessProfileLinkPatchAttempts {
+			return nil, patchResp, patchErr
+		}
+
+		// Only a list that changed since it was read explains a failed
+		// "test" guard; any other failure is returned as-is.
+		latest, _, getErr := get(ctx)
+		if getErr != nil || accessProfileIdsEqual(accessProfileEntitlementIds(latest), current) {
+			return nil, patchResp, patchErr
+		}
+
+		tflog.Warn(ctx, "Access Profile entitlements changed concurrently, retrying patch", map[string]interface{}{
+			"id":      accessProfileID,
+			"attempt": attempt,
+		})
+		accessProfile = latest
+
+		timer := time.NewTimer(accessProfileLinkPatchRetryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, patchResp, patchErr
+		case <-timer.C:
+		}
+	}
+}
+
+// accessProfileEntitlementLinkOps returns the JSON Patch operations that take
+// current (an access profile's entitlement ids, in API order) to current
+// minus toRemove plus toAdd. Removals run from the highest index down so
+// earlier removals don't shift later ones; ids already in the right state
+// produce no operation.
+func accessProfileEntitlementLinkOps(current, toAdd, toRemove []string) []access_profiles.JsonPatchOperation {
+	removeSet := make(map[string]struct{}, len(toRemove))
+	for _, id := range toRemove {
+		removeSet[id] = struct{}{}
+	}
+
+	var ops []access_profiles.JsonPatchOperation
+	kept := make(map[string]struct{}, len(current))
+	for i := len(current) - 1; i >= 0; i-- {
+		id := current[i]
+		if _, remove := removeSet[id]; !remove {
+			kept[id] = struct{}{}
+			continue
+		}
+		entryPath := fmt.Sprintf("/entitlements/%d", i)
+		ops = append(ops,
+			accessProfileJSONPatchTest(entryPath+"/id", access_profiles.StringAsJsonPatchOperationValue(&id)),
+			accessProfileJSONPatchRemove(entryPath),
+		)
+	}
+
+	for _, id := range toAdd {
+		if _, present := kept[id]; id == "" || present {
+			continue
+		}
+		kept[id] = struct{}{}
+		ref := map[string]interface{}{"id": id, "type": accessProfileEntitlementRefType}
+		ops = append(ops, accessProfileJSONPatchAdd("/entitlements/-", access_profiles.MapmapOfStringAnyAsJsonPatchOperationValue(&ref)))
+	}
+	return ops
+}
+
+// accessProfileEntitlementIds returns accessProfile's entitlement ids, in API
+// order.
+func accessProfileEntitlementIds(accessProfile *access_profiles.AccessProfile) []string {
+	if accessProfile == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(accessProfile.Entitlements))
+	for _, ref := range accessProfile.Entitlements {
+		if ref.Id != nil {
+			ids = append(ids, *ref.Id)
+		}
+	}
+	return ids
+}
+
+func accessProfileIdsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,231 @@
+package access_profile_v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/sailpoint-oss/golang-sdk/v3/access_profiles"
+)
+
+// describeAccessProfileLinkOps renders ops as "op path value" strings so
+// tests can compare them without depending on the SDK's JSON Patch value
+// wrapper.
+func describeAccessProfileLinkOps(t *testing.T, ops []access_profiles.JsonPatchOperation) []string {
+	t.Helper()
+	out := make([]string, 0, len(ops))
+	for _, op := range ops {
+		s := op.Op + " " + op.Path
+		if op.Value != nil {
+			b, err := json.Marshal(op.Value)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			s += " " + string(b)
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// testAccessProfileWithEntitlements returns an access profile holding ids,
+// in order.
+func testAccessProfileWithEntitlements(ids ...string) *access_profiles.AccessProfile {
+	refs := make([]access_profiles.EntitlementRef, 0, len(ids))
+	for i := range ids {
+		refs = append(refs, access_profiles.EntitlementRef{Id: &ids[i]})
+	}
+	return &access_profiles.AccessProfile{Entitlements: refs}
+}
+
+func TestAccessProfileEntitlementLinkOps(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  []string
+		toAdd    []string
+		toRemove []string
+		want     []string
+	}{
+		{
+			name:     "removes from the highest index down, guarding each with a test",
+			current:  []string{"ent-1", "ent-2", "ent-3", "ent-4"},
+			toRemove: []string{"ent-2", "ent-4"},
+			want: []string{
+				`test /entitlements/3/id "ent-4"`,
+				`remove /entitlements/3`,
+				`test /entitlements/1/id "ent-2"`,
+				`remove /entitlements/1`,
+			},
+		},
+		{
+			name:    "appends only ids not already attached",
+			current: []string{"ent-1"},
+			toAdd:   []string{"ent-1", "ent-2", "ent-2", ""},
+			want: []string{
+				`add /entitlements/- {"id":"ent-2","type":"ENTITLEMENT"}`,
+			},
+		},
+		{
+			name:     "removals come before additions",
+			current:  []string{"ent-1", "ent-2"},
+			toAdd:    []string{"ent-3"},
+			toRemove: []string{"ent-1"},
+			want: []string{
+				`test /entitlements/0/id "ent-1"`,
+				`remove /entitlements/0`,
+				`add /entitlements/- {"id":"ent-3","type":"ENTITLEMENT"}`,
+			},
+		},
+		{
+			name:     "nothing to do",
+			current:  []string{"ent-1"},
+			toAdd:    []string{"ent-1"},
+			toRemove: []string{"ent-9"},
+			want:     []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := describeAccessProfileLinkOps(t, accessProfileEntitlementLinkOps(tt.current, tt.toAdd, tt.toRemove))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("accessProfileEntitlementLinkOps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeAccessProfileLinksAPI serves reads from a queue of access profiles
+// (repeating the last one) and answers each PATCH with the next error in
+// patchErrs, recording every patch it was sent.
+type fakeAccessProfileLinksAPI struct {
+	reads     []*access_profiles.AccessProfile
+	patchErrs []error
+	patches   [][]string
+}
+
+func (f *fakeAccessProfileLinksAPI) get(context.Context) (*access_profiles.AccessProfile, *http.Response, error) {
+	ap := f.reads[0]
+	if len(f.reads) > 1 {
+		f.reads = f.reads[1:]
+	}
+	return ap, &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func (f *fakeAccessProfileLinksAPI) patch(t *testing.T) func(context.Context, []access_profiles.JsonPatchOperation) (*access_profiles.AccessProfile, *http.Response, error) {
+	return func(_ context.Context, ops []access_profiles.JsonPatchOperation) (*access_profiles.AccessProfile, *http.Response, error) {
+		f.patches = append(f.patches, describeAccessProfileLinkOps(t, ops))
+		var err error
+		if len(f.patches) <= len(f.patchErrs) {
+			err = f.patchErrs[len(f.patches)-1]
+		}
+		if err != nil {
+			return nil, &http.Response{StatusCode: http.StatusBadRequest}, err
+		}
+		return testAccessProfileWithEntitlements("patched"), &http.Response{StatusCode: http.StatusOK}, nil
+	}
+}
+
+func TestRetryAccessProfileEntitlementLinks(t *testing.T) {
+	errTestFailed := errors.New("test operation failed")
+
+	t.Run("nothing to do returns the access profile as read", func(t *testing.T) {
+		f := &fakeAccessProfileLinksAPI{reads: []*access_profiles.AccessProfile{testAccessProfileWithEntitlements("ent-1")}}
+
+		got, _, err := retryAccessProfileEntitlementLinks(context.Background(), "ap", []string{"ent-1"}, nil, nil, f.get, f.patch(t))
+		if err != nil {
+			t.Fatalf("returned error: %v", err)
+		}
+		if ids := accessProfileEntitlementIds(got); !reflect.DeepEqual(ids, []string{"ent-1"}) {
+			t.Errorf("entitlement ids = %v, want [ent-1]", ids)
+		}
+		if len(f.patches) != 0 {
+			t.Errorf("patches = %v, want none", f.patches)
+		}
+	})
+
+	t.Run("sends extra operations with the link operations", func(t *testing.T) {
+		f := &fakeAccessProfileLinksAPI{reads: []*access_profiles.AccessProfile{testAccessProfileWithEntitlements("ent-1")}}
+		name := "renamed"
+		extra := []access_profiles.JsonPatchOperation{accessProfileJSONPatchReplace("/name", access_profiles.StringAsJsonPatchOperationValue(&name))}
+
+		got, _, err := retryAccessProfileEntitlementLinks(context.Background(), "ap", []string{"ent-2"}, []string{"ent-1"}, extra, f.get, f.patch(t))
+		if err != nil {
+			t.Fatalf("returned error: %v", err)
+		}
+		if ids := accessProfileEntitlementIds(got); !reflect.DeepEqual(ids, []string{"patched"}) {
+			t.Errorf("entitlement ids = %v, want the patched access profile", ids)
+		}
+		want := [][]string{{
+			`replace /name "renamed"`,
+			`test /entitlements/0/id "ent-1"`,
+			`remove /entitlements/0`,
+			`add /entitlements/- {"id":"ent-2","type":"ENTITLEMENT"}`,
+		}}
+		if !reflect.DeepEqual(f.patches, want) {
+			t.Errorf("patches = %v, want %v", f.patches, want)
+		}
+	})
+
+	t.Run("rebuilds the patch after a concurrent change", func(t *testing.T) {
+		f := &fakeAccessProfileLinksAPI{
+			reads: []*access_profiles.AccessProfile{
+				testAccessProfileWithEntitlements("ent-1", "ent-2"),
+				testAccessProfileWithEntitlements("other", "ent-1", "ent-2"),
+			},
+			patchErrs: []error{errTestFailed},
+		}
+
+		if _, _, err := retryAccessProfileEntitlementLinks(context.Background(), "ap", nil, []string{"ent-2"}, nil, f.get, f.patch(t)); err != nil {
+			t.Fatalf("returned error: %v", err)
+		}
+		want := [][]string{
+			{`test /entitlements/1/id "ent-2"`, `remove /entitlements/1`},
+			{`test /entitlements/2/id "ent-2"`, `remove /entitlements/2`},
+		}
+		if !reflect.DeepEqual(f.patches, want) {
+			t.Errorf("patches = %v, want %v", f.patches, want)
+		}
+	})
+
+	t.Run("returns other failures without retrying", func(t *testing.T) {
+		f := &fakeAccessProfileLinksAPI{
+			reads:     []*access_profiles.AccessProfile{testAccessProfileWithEntitlements("ent-1")},
+			patchErrs: []error{errTestFailed},
+		}
+
+		_, httpResp, err := retryAccessProfileEntitlementLinks(context.Background(), "ap", []string{"ent-2"}, nil, nil, f.get, f.patch(t))
+		if !errors.Is(err, errTestFailed) {
+			t.Errorf("error = %v, want %v", err, errTestFailed)
+		}
+		if httpResp == nil || httpResp.StatusCode != http.StatusBadRequest {
+			t.Errorf("response = %v, want the failed PATCH's", httpResp)
+		}
+		if len(f.patches) != 1 {
+			t.Errorf("patches = %v, want exactly one", f.patches)
+		}
+	})
+
+	t.Run("stops retrying when the context is cancelled", func(t *testing.T) {
+		f := &fakeAccessProfileLinksAPI{
+			reads: []*access_profiles.AccessProfile{
+				testAccessProfileWithEntitlements("ent-1"),
+				testAccessProfileWithEntitlements("other", "ent-1"),
+			},
+			patchErrs: []error{errTestFailed},
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, _, err := retryAccessProfileEntitlementLinks(ctx, "ap", nil, []string{"ent-1"}, nil, f.get, f.patch(t))
+		if !errors.Is(err, errTestFailed) {
+			t.Errorf("error = %v, want %v", err, errTestFailed)
+		}
+		if len(f.patches) != 1 {
+			t.Errorf("patches = %v, want exactly one", f.patches)
+		}
+	})
+}
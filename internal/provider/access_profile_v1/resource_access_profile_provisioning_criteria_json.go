@@ -0,0 +1,279 @@
+// This file implements "provisioning_criteria_json", a hand-added
+// alternative to the generated "provisioning_criteria" block, added
+// alongside role_v1's membership_criteria_json.
+//
+// The JSON form is the API's own provisioningCriteria object ({operation,
+// attribute, value, children}), written on Create/Update and read back from
+// the API response. Unlike role membership criteria, provisioning criteria
+// are limited by the API itself to 3 levels including leaf nodes, and the
+// v1 spec types the third level's "children" as a string, so the SDK's
+// ProvisioningCriteriaLevel1/2/3 models cannot carry anything deeper either;
+// the validator rejects deeper trees at plan time rather than letting
+// Create fail to encode them. The two are mutually exclusive (see
+// ConfigValidators).
+package access_profile_v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+
+	"github.com/sailpoint-oss/golang-sdk/v3/access_profiles"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+// provisioningCriteriaCompositeOperations combine their children; every other
+// operation in provisioningCriteriaOperations tests an account attribute.
+var provisioningCriteriaCompositeOperations = []string{"AND", "OR"}
+
+// provisioningCriteriaOperations is ProvisioningCriteriaOperation's enum from
+// the v1 spec.
+var provisioningCriteriaOperations = []string{"EQUALS", "NOT_EQUALS", "CONTAINS", "HAS", "AND", "OR"}
+
+// provisioningCriteriaMaxDepth is the v1 spec's documented maximum of three
+// levels of criteria, including leaf nodes.
+const provisioningCriteriaMaxDepth = 3
+
+func applyAccessProfileProvisioningCriteriaJSONField(attrs *map[string]schema.Attribute) {
+	if *attrs == nil {
+		*attrs = map[string]schema.Attribute{}
+	}
+	(*attrs)["provisioning_criteria_json"] = schema.StringAttribute{
+		CustomType: jsontypes.NormalizedType{},
+		Optional:   true,
+		Description: "Provisioning criteria as a raw JSON object ({operation, attribute, value, children}), at most " +
+			"3 levels deep. Conflicts with provisioning_criteria.",
+		MarkdownDescription: "Provisioning criteria as a raw JSON object (`{operation, attribute, value, children}`, the " +
+			"API's `provisioningCriteria` shape). Operators, required fields and the API's limit of 3 levels " +
+			"(including leaf nodes) are validated at plan time. Conflicts with `provisioning_criteria`.",
+	}
+}
+
+func (r *accessProfileResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("provisioning_criteria"),
+			path.MatchRoot("provisioning_criteria_json"),
+		),
+	}
+}
+
+func (r *accessProfileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var criteria jsontypes.Normalized
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("provisioning_criteria_json"), &criteria)...)
+	if resp.Diagnostics.HasError() || criteria.IsNull() || criteria.IsUnknown() {
+		return
+	}
+
+	var node interface{}
+	if err := json.Unmarshal([]byte(criteria.ValueString()), &node); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("provisioning_criteria_json"),
+			"Invalid provisioning criteria JSON",
+			fmt.Sprintf("Could not decode \"provisioning_criteria_json\" as JSON: %s", err.Error()),
+		)
+		return
+	}
+	for _, problem := range validateProvisioningCriteriaNode(node, "") {
+		resp.Diagnostics.AddAttributeError(path.Root("provisioning_criteria_json"), "Invalid provisioning criteria", problem)
+	}
+}
+
+// validateProvisioningCriteriaNode checks one criteria node and, recursively,
+// its children against the rules documented for the API's
+// provisioningCriteria, returning one message per problem prefixed with the
+// node's JSON path (e.g. "children[1].attribute"). at is "" for the root
+// node, and its number of "children[" segments is the node's depth below it.
+func validateProvisioningCriteriaNode(v interface{}, at string) []string {
+	node, ok := v.(map[string]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("%s: must be a JSON object", provisioningCriteriaPath(at))}
+	}
+
+	var problems []string
+	for _, k := range util.SortedKeys(node) {
+		switch k {
+		case "operation", "attribute", "value", "children":
+		default:
+			problems = append(problems, fmt.Sprintf("%s: unknown field (expected operation, attribute, value or children)", provisioningCriteriaPath(provisioningCriteriaJoin(at, k))))
+		}
+	}
+
+	op, _ := node["operation"].(string)
+	if !util.ContainsString(provisioningCriteriaOperations, op) {
+		return append(problems, fmt.Sprintf("%s: must be one of %s, got %s",
+			provisioningCriteriaPath(provisioningCriteriaJoin(at, "operation")), strings.Join(provisioningCriteriaOperations, ", "), util.DescribeJSONValue(node["operation"])))
+	}
+
+	children, hasChildren := provisioningCriteriaField(node, "children")
+	if util.ContainsString(provisioningCriteriaCompositeOperations, op) {
+		for _, k := range []string{"attribute", "value"} {
+			if _, ok := provisioningCriteriaField(node, k); ok {
+				problems = append(problems, fmt.Sprintf("%s: must not be set when operation is %s", provisioningCriteriaPath(provisioningCriteriaJoin(at, k)), op))
+			}
+		}
+		list, ok := children.([]interface{})
+		if !ok || len(list) == 0 {
+			return append(problems, fmt.Sprintf("%s: must be a non-empty list when operation is %s", provisioningCriteriaPath(provisioningCriteriaJoin(at, "children")), op))
+		}
+		if strings.Count(at, "children[")+1 >= provisioningCriteriaMaxDepth {
+			return append(problems, fmt.Sprintf("%s: criteria can be nested at most %d levels deep, including leaf nodes", provisioningCriteriaPath(provisioningCriteriaJoin(at, "children")), provisioningCriteriaMaxDepth))
+		}
+		for i, child := range list {
+			problems = append(problems, validateProvisioningCriteriaNode(child, fmt.Sprintf("%s[%d]", provisioningCriteriaJoin(at, "children"), i))...)
+		}
+		return problems
+	}
+
+	if list, ok := children.([]interface{}); hasChildren && (!ok || len(list) > 0) {
+		problems = append(problems, fmt.Sprintf("%s: must not be set when operation is %s", provisioningCriteriaPath(provisioningCriteriaJoin(at, "children")), op))
+	}
+	if s, ok := node["attribute"].(string); !ok || s == "" {
+		problems = append(problems, fmt.Sprintf("%s: is required when operation is %s", provisioningCriteriaPath(provisioningCriteriaJoin(at, "attribute")), op))
+	}
+	if op == "HAS" {
+		if _, ok := provisioningCriteriaField(node, "value"); ok {
+			problems = append(problems, fmt.Sprintf("%s: must not be set when operation is HAS", provisioningCriteriaPath(provisioningCriteriaJoin(at, "value"))))
+		}
+	} else if s, ok := node["value"].(string); !ok || s == "" {
+		problems = append(problems, fmt.Sprintf("%s: is required when operation is %s", provisioningCriteriaPath(provisioningCriteriaJoin(at, "value")), op))
+	}
+	return problems
+}
+
+// provisioningCriteriaFromJSON decodes the configured criteria, returned both
+// as a generic map (sent as-is in a JSON Patch value) and as the SDK's
+// ProvisioningCriteriaLevel1 for Create.
+func provisioningCriteriaFromJSON(v jsontypes.Normalized) (map[string]interface{}, *access_profiles.ProvisioningCriteriaLevel1, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(v.ValueString()), &m); err != nil {
+		diags.AddError(
+			"Invalid provisioning criteria JSON",
+			fmt.Sprintf("Could not decode \"provisioning_criteria_json\" as a JSON object: %s", err.Error()),
+		)
+		return nil, nil, diags
+	}
+	var level1 access_profiles.ProvisioningCriteriaLevel1
+	if err := json.Unmarshal([]byte(v.ValueString()), &level1); err != nil {
+		diags.AddError(
+			"Invalid provisioning criteria JSON",
+			fmt.Sprintf("Could not convert \"provisioning_criteria_json\" into provisioning criteria: %s", err.Error()),
+		)
+		return nil, nil, diags
+	}
+	return m, &level1, diags
+}
+
+// provisioningCriteriaJSONFromApi re-encodes the API's provisioningCriteria
+// for "provisioning_criteria_json". prior (the plan or prior state value) is
+// kept whenever it describes the same tree, since the API echoes criteria
+// back with explicit nulls and empty children lists that
+// jsontypes.Normalized alone would report as a diff. A null prior means the
+// practitioner isn't using this attribute, so it stays null rather than
+// shadowing "provisioning_criteria".
+func provisioningCriteriaJSONFromApi(dto *access_profiles.ProvisioningCriteriaLevel1, prior jsontypes.Normalized) (jsontypes.Normalized, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if prior.IsNull() {
+		return prior, diags
+	}
+	if dto == nil {
+		return jsontypes.NewNormalizedNull(), diags
+	}
+
+	b, err := json.Marshal(dto)
+	if err != nil {
+		diags.AddError(
+			"Error encoding provisioning criteria from API response",
+			fmt.Sprintf("Could not re-encode the API's provisioning criteria as JSON: %s", err.Error()),
+		)
+		return prior, diags
+	}
+	var remote interface{}
+	if err := json.Unmarshal(b, &remote); err != nil {
+		diags.AddError(
+			"Error encoding provisioning criteria from API response",
+			fmt.Sprintf("Could not re-encode the API's provisioning criteria as JSON: %s", err.Error()),
+		)
+		return prior, diags
+	}
+	remote = compactProvisioningCriteria(remote)
+
+	if !prior.IsUnknown() {
+		var local interface{}
+		if err := json.Unmarshal([]byte(prior.ValueString()), &local); err == nil && reflect.DeepEqual(compactProvisioningCriteria(local), remote) {
+			return prior, diags
+		}
+	}
+
+	out, err := json.Marshal(remote)
+	if err != nil {
+		diags.AddError(
+			"Error encoding provisioning criteria from API response",
+			fmt.Sprintf("Could not re-encode the API's provisioning criteria as JSON: %s", err.Error()),
+		)
+		return prior, diags
+	}
+	return jsontypes.NewNormalizedValue(string(out)), diags
+}
+
+// compactProvisioningCriteria drops null values and empty lists/objects at
+// every depth so a criteria tree compares equal however the API or the
+// practitioner spells "not set".
+func compactProvisioningCriteria(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, child := range t {
+			if c := compactProvisioningCriteria(child); c != nil {
+				out[k] = c
+			}
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, 0, len(t))
+		for _, child := range t {
+			if c := compactProvisioningCriteria(child); c != nil {
+				out = append(out, c)
+			}
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func provisioningCriteriaField(m map[string]interface{}, k string) (interface{}, bool) {
+	v, ok := m[k]
+	return v, ok && v != nil
+}
+
+func provisioningCriteriaJoin(at, field string) string {
+	if at == "" {
+		return field
+	}
+	return at + "." + field
+}
+
+func provisioningCriteriaPath(at string) string {
+	if at == "" {
+		return "provisioningCriteria"
+	}
+	return at
+}
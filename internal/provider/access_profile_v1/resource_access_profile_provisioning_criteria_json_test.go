@@ -0,0 +1,210 @@
+package access_profile_v1
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/sailpoint-oss/golang-sdk/v3/access_profiles"
+)
+
+// testDeepProvisioningCriteria uses all 3 levels the API allows, with every
+// leaf operation.
+const testDeepProvisioningCriteria = `{
+  "operation": "OR",
+  "children": [
+    {"operation": "AND", "children": [
+      {"operation": "EQUALS", "attribute": "accountType", "value": "admin"},
+      {"operation": "HAS", "attribute": "adminGroup"}
+    ]},
+    {"operation": "AND", "children": [
+      {"operation": "CONTAINS", "attribute": "distinguishedName", "value": "OU=Admins"},
+      {"operation": "NOT_EQUALS", "attribute": "status", "value": "disabled"}
+    ]}
+  ]
+}`
+
+func TestValidateProvisioningCriteriaNode_Valid(t *testing.T) {
+	for _, raw := range []string{
+		testDeepProvisioningCriteria,
+		`{"operation": "HAS", "attribute": "adminGroup"}`,
+		`{"operation": "EQUALS", "attribute": "accountType", "value": "admin", "children": []}`,
+	} {
+		var node interface{}
+		if err := json.Unmarshal([]byte(raw), &node); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if problems := validateProvisioningCriteriaNode(node, ""); len(problems) != 0 {
+			t.Errorf("validateProvisioningCriteriaNode returned problems for a valid tree %s: %v", raw, problems)
+		}
+	}
+}
+
+func TestValidateProvisioningCriteriaNode_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{
+			name: "unknown operation",
+			raw:  `{"operation": "STARTS_WITH", "attribute": "x", "value": "y"}`,
+			want: "operation: must be one of",
+		},
+		{
+			name: "missing value deep in the tree",
+			raw:  `{"operation": "OR", "children": [{"operation": "HAS", "attribute": "x"}, {"operation": "AND", "children": [{"operation": "EQUALS", "attribute": "x"}]}]}`,
+			want: "children[1].children[0].value: is required when operation is EQUALS",
+		},
+		{
+			name: "nested past 3 levels",
+			raw:  `{"operation": "OR", "children": [{"operation": "AND", "children": [{"operation": "OR", "children": [{"operation": "HAS", "attribute": "x"}]}]}]}`,
+			want: "children[0].children[0].children: criteria can be nested at most 3 levels deep",
+		},
+		{
+			name: "composite without children",
+			raw:  `{"operation": "AND", "children": []}`,
+			want: "children: must be a non-empty list",
+		},
+		{
+			name: "composite with an attribute",
+			raw:  `{"operation": "OR", "attribute": "x", "children": [{"operation": "HAS", "attribute": "x"}]}`,
+			want: "attribute: must not be set when operation is OR",
+		},
+		{
+			name: "leaf with children",
+			raw:  `{"operation": "EQUALS", "attribute": "x", "value": "y", "children": [{"operation": "HAS", "attribute": "x"}]}`,
+			want: "children: must not be set when operation is EQUALS",
+		},
+		{
+			name: "leaf without attribute",
+			raw:  `{"operation": "CONTAINS", "value": "y"}`,
+			want: "attribute: is required when operation is CONTAINS",
+		},
+		{
+			name: "HAS with a value",
+			raw:  `{"operation": "HAS", "attribute": "x", "value": "y"}`,
+			want: "value: must not be set when operation is HAS",
+		},
+		{
+			name: "unknown field",
+			raw:  `{"operation": "EQUALS", "attribute": "x", "value": "y", "key": "z"}`,
+			want: "key: unknown field",
+		},
+		{
+			name: "not an object",
+			raw:  `["OR"]`,
+			want: "provisioningCriteria: must be a JSON object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var node interface{}
+			if err := json.Unmarshal([]byte(tt.raw), &node); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			problems := validateProvisioningCriteriaNode(node, "")
+			for _, p := range problems {
+				if strings.HasPrefix(p, tt.want) {
+					return
+				}
+			}
+			t.Errorf("validateProvisioningCriteriaNode problems = %v, want one starting with %q", problems, tt.want)
+		})
+	}
+}
+
+// TestProvisioningCriteriaFromJSON_DeepCriteria checks that a tree using all
+// 3 levels comes back out of ProvisioningCriteriaLevel1 unchanged, whether
+// re-encoded directly or through provisioningCriteriaJSONFromApi.
+func TestProvisioningCriteriaFromJSON_DeepCriteria(t *testing.T) {
+	var want interface{}
+	if err := json.Unmarshal([]byte(testDeepProvisioningCriteria), &want); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	m, level1, diags := provisioningCriteriaFromJSON(jsontypes.NewNormalizedValue(testDeepProvisioningCriteria))
+	if diags.HasError() {
+		t.Fatalf("provisioningCriteriaFromJSON returned diagnostics: %v", diags)
+	}
+	if !reflect.DeepEqual(interface{}(m), want) {
+		t.Errorf("patch value = %v, want %v", m, want)
+	}
+
+	b, err := json.Marshal(level1)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var got interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(compactProvisioningCriteria(got), compactProvisioningCriteria(want)) {
+		t.Errorf("criteria changed in the SDK model:\n got %s\nwant %s", b, testDeepProvisioningCriteria)
+	}
+
+	remote, diags := provisioningCriteriaJSONFromApi(level1, jsontypes.NewNormalizedUnknown())
+	if diags.HasError() {
+		t.Fatalf("provisioningCriteriaJSONFromApi returned diagnostics: %v", diags)
+	}
+	var reencoded interface{}
+	if err := json.Unmarshal([]byte(remote.ValueString()), &reencoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(reencoded, compactProvisioningCriteria(want)) {
+		t.Errorf("provisioningCriteriaJSONFromApi = %s, want %s", remote.ValueString(), testDeepProvisioningCriteria)
+	}
+}
+
+func TestProvisioningCriteriaFromJSON_Invalid(t *testing.T) {
+	for _, raw := range []string{`["OR"]`, `{"operation": 1}`} {
+		if _, _, diags := provisioningCriteriaFromJSON(jsontypes.NewNormalizedValue(raw)); !diags.HasError() {
+			t.Errorf("provisioningCriteriaFromJSON(%s) returned no error", raw)
+		}
+	}
+}
+
+func TestProvisioningCriteriaJSONFromApi(t *testing.T) {
+	var dto access_profiles.ProvisioningCriteriaLevel1
+	// The API echoes explicit nulls and empty children lists back.
+	raw := `{"operation": "OR", "attribute": null, "value": null, "children": [
+  {"operation": "EQUALS", "attribute": "accountType", "value": "admin", "children": []},
+  {"operation": "AND", "attribute": null, "value": null, "children": [
+    {"operation": "HAS", "attribute": "adminGroup", "value": null, "children": null}
+  ]}
+]}`
+	if err := json.Unmarshal([]byte(raw), &dto); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	prior := jsontypes.NewNormalizedValue(`{"operation": "OR", "children": [{"operation": "EQUALS", "attribute": "accountType", "value": "admin"}, {"operation": "AND", "children": [{"operation": "HAS", "attribute": "adminGroup"}]}]}`)
+	got, diags := provisioningCriteriaJSONFromApi(&dto, prior)
+	if diags.HasError() {
+		t.Fatalf("provisioningCriteriaJSONFromApi returned diagnostics: %v", diags)
+	}
+	if got.ValueString() != prior.ValueString() {
+		t.Errorf("equivalent criteria were not kept as configured: %s", got.ValueString())
+	}
+
+	drifted := jsontypes.NewNormalizedValue(`{"operation": "OR", "children": [{"operation": "EQUALS", "attribute": "accountType", "value": "service"}, {"operation": "AND", "children": [{"operation": "HAS", "attribute": "adminGroup"}]}]}`)
+	got, diags = provisioningCriteriaJSONFromApi(&dto, drifted)
+	if diags.HasError() {
+		t.Fatalf("provisioningCriteriaJSONFromApi returned diagnostics: %v", diags)
+	}
+	if !strings.Contains(got.ValueString(), `"admin"`) {
+		t.Errorf("drift was not surfaced, got %s", got.ValueString())
+	}
+
+	got, _ = provisioningCriteriaJSONFromApi(&dto, jsontypes.NewNormalizedNull())
+	if !got.IsNull() {
+		t.Errorf("unconfigured attribute = %s, want null", got.ValueString())
+	}
+
+	got, _ = provisioningCriteriaJSONFromApi(nil, prior)
+	if !got.IsNull() {
+		t.Errorf("criteria removed in the API = %s, want null", got.ValueString())
+	}
+}
@@ -0,0 +1,659 @@
+// This file implements identitynow_access_profiles_requestable_v1, a fully
+// hand-written resource that holds the "requestable" flag of many access
+// profiles at one value, e.g. for an access-catalog freeze, and puts every
+// profile back the way it was on destroy.
+//
+// The profiles are either listed in access_profile_ids or selected with the
+// same typed filter block as identitynow_access_profiles_v1, resolved once at
+// Create time like identitynow_identity_process_v1's filters. Writes go
+// through POST /access-profiles/v1/bulk-update-requestable in batches of
+// accessProfileRequestableBatchSize, and each profile's value from before
+// this resource first touched it is kept in previous_requestable, whose keys
+// are also the set of profiles the resource manages:
+//   - Create reads every profile back, records its value and sets the flag.
+//   - Read reads every profile back. Profiles that were deleted drop out of
+//     state; a profile whose flag was changed elsewhere is listed in
+//     drifted_access_profile_ids, which ModifyPlan plans back to empty so
+//     the next apply sets the flag again. "requestable" keeps the
+//     configured value.
+//   - Update restores profiles removed from access_profile_ids, records the
+//     ones added, and sets the flag on all of them.
+//   - Delete restores every profile to its recorded value, whatever its live
+//     value is.
+package access_profile_v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/access_profiles"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+const (
+	// accessProfileRequestableBatchSize bounds each bulk-update-requestable
+	// request. The spec documents no maximum for the request array, so this
+	// matches the page size used to read the profiles back.
+	accessProfileRequestableBatchSize = 50
+	accessProfileListPageLimit        = 250
+	// accessProfileBulkUpdatedStatus is the per-item status the bulk
+	// endpoint reports for a profile it updated; 404 means not found.
+	accessProfileBulkUpdatedStatus = "201"
+)
+
+var (
+	_ resource.Resource                     = (*accessProfilesRequestableResource)(nil)
+	_ resource.ResourceWithConfigure        = (*accessProfilesRequestableResource)(nil)
+	_ resource.ResourceWithConfigValidators = (*accessProfilesRequestableResource)(nil)
+	_ resource.ResourceWithModifyPlan       = (*accessProfilesRequestableResource)(nil)
+)
+
+func NewAccessProfilesRequestableResource() resource.Resource {
+	return &accessProfilesRequestableResource{}
+}
+
+type accessProfilesRequestableResource struct {
+	client *sailpoint.APIClient
+}
+
+type accessProfilesRequestableResourceModel struct {
+	Id                  types.String `tfsdk:"id"`
+	AccessProfileIds    types.Set    `tfsdk:"access_profile_ids"`
+	Filter              types.Object `tfsdk:"filter"`
+	Requestable         types.Bool   `tfsdk:"requestable"`
+	PreviousRequestable types.Map    `tfsdk:"previous_requestable"`
+	DriftedIds          types.Set    `tfsdk:"drifted_access_profile_ids"`
+}
+
+// accessProfileRequestableFilterFields is accessProfileFilterFields without
+// name_contains, which is too loose a way to pick profiles to flip. There is
+// no top-level for_segment_ids here for segment_ids to conflict with.
+var accessProfileRequestableFilterFields = func() []util.FilterField {
+	var out []util.FilterField
+	for _, f := range accessProfileFilterFields {
+		switch f.Attribute {
+		case "name_contains":
+			continue
+		case "segment_ids":
+			f.Description = "Only access profiles assigned to one of these Segments. Sent as the `for-segment-ids` query parameter."
+		}
+		out = append(out, f)
+	}
+	return out
+}()
+
+func (r *accessProfilesRequestableResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_profiles_requestable_v1"
+}
+
+func (r *accessProfilesRequestableResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	filter := util.FilterResourceAttribute(
+		"Typed filter criteria selecting the Access Profiles, AND-ed together and resolved to IDs at Create time with "+
+			"`GET /access-profiles/v1`. Profiles created later are not picked up. Changing this forces replacement. "+
+			"Exactly one of `access_profile_ids` or `filter` must be set.",
+		accessProfileRequestableFilterFields,
+	)
+	filter.PlanModifiers = []planmodifier.Object{
+		objectplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = resourceschema.Schema{
+		Description: "Sets the requestable flag of many Access Profiles in IdentityNow/ISC and restores their previous values on destroy.",
+		MarkdownDescription: "Sets `requestable` on many [Access Profiles](https://documentation.sailpoint.com/saas/help/access/access-profiles.html) " +
+			"in IdentityNow/ISC through `POST /access-profiles/v1/bulk-update-requestable`, in batches, and restores each " +
+			"profile's previous value on destroy - for example to freeze a segment's access catalog for a quarterly review. " +
+			"This is a fully hand-written `_v1` resource. Don't also manage `requestable` for the same profiles with " +
+			"`identitynow_access_profile_v1`, or the two will keep reverting each other.",
+		Attributes: map[string]resourceschema.Attribute{
+			"id": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "Synthetic Terraform identifier: the first Access Profile ID acted on at Create time.",
+				MarkdownDescription: "Synthetic Terraform identifier: the first Access Profile ID acted on at Create time.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"access_profile_ids": resourceschema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Description:         "IDs of the Access Profiles to update. Exactly one of access_profile_ids or filter must be set.",
+				MarkdownDescription: "IDs of the Access Profiles to update. Profiles removed from this set are restored to their previous value. Exactly one of `access_profile_ids` or `filter` must be set.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"filter": filter,
+			"requestable": resourceschema.BoolAttribute{
+				Required:            true,
+				Description:         "Value to hold the Access Profiles' requestable flag at.",
+				MarkdownDescription: "Value to hold the Access Profiles' `requestable` flag at.",
+			},
+			"previous_requestable": resourceschema.MapAttribute{
+				ElementType: types.BoolType,
+				Computed:    true,
+				Description: "Requestable flag of each managed Access Profile before this resource first changed it, keyed by " +
+					"Access Profile ID. Destroy restores these values.",
+				MarkdownDescription: "`requestable` of each managed Access Profile before this resource first changed it, keyed by " +
+					"Access Profile ID. Destroy restores these values.",
+			},
+			"drifted_access_profile_ids": resourceschema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "IDs of the managed Access Profiles whose requestable flag was found changed outside Terraform. " +
+					"Empty after every apply; a refresh that finds drift makes the next plan set the flag again.",
+				MarkdownDescription: "IDs of the managed Access Profiles whose `requestable` flag was found changed outside Terraform. " +
+					"Empty after every apply; a refresh that finds drift makes the next plan set the flag again.",
+			},
+		},
+	}
+}
+
+func (r *accessProfilesRequestableResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("access_profile_ids"),
+			path.MatchRoot("filter"),
+		),
+	}
+}
+
+func (r *accessProfilesRequestableResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = cp.GetClient()
+}
+
+// ModifyPlan keeps previous_requestable from state unless access_profile_ids
+// changes, in which case Update records the added profiles and drops the
+// removed ones. drifted_access_profile_ids is always planned empty, so drift
+// Read found shows up as a change for Update to put right.
+func (r *accessProfilesRequestableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state accessProfilesRequestableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.AccessProfileIds.IsNull() || plan.AccessProfileIds.Equal(state.AccessProfileIds) {
+		plan.PreviousRequestable = state.PreviousRequestable
+	} else {
+		plan.PreviousRequestable = types.MapUnknown(types.BoolType)
+	}
+	plan.DriftedIds = accessProfileIdSet(nil)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *accessProfilesRequestableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan accessProfilesRequestableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	if !plan.AccessProfileIds.IsNull() {
+		resp.Diagnostics.Append(plan.AccessProfileIds.ElementsAs(ctx, &ids, false)...)
+	} else {
+		var diags diag.Diagnostics
+		ids, diags = r.accessProfileIdsForFilter(ctx, plan.Filter)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ids = accessProfileUniqueSortedIds(ids)
+	if len(ids) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("filter"), "Error resolving Access Profiles", "filter matched no Access Profiles; there is nothing to update.")
+		return
+	}
+
+	tflog.Info(ctx, "Setting requestable on Access Profiles", map[string]interface{}{"count": len(ids), "requestable": plan.Requestable.ValueBool()})
+
+	live, err := r.readRequestable(ctx, ids)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Access Profiles", err.Error())
+		return
+	}
+	if missing := accessProfileIdsMissing(ids, live); len(missing) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			accessProfileRequestableIdsPath(plan),
+			"Access Profiles not found",
+			fmt.Sprintf("These Access Profiles do not exist: %s", strings.Join(missing, ", ")),
+		)
+		return
+	}
+
+	previous, diags := types.MapValueFrom(ctx, types.BoolType, live)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// State is saved before the first write so that, if a batch fails, the
+	// tainted resource still knows what to restore on destroy.
+	state := plan
+	state.Id = types.StringValue(ids[0])
+	state.PreviousRequestable = previous
+	state.DriftedIds = accessProfileIdSet(nil)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	notFound, err := r.bulkUpdateRequestable(ctx, accessProfileRequestableUpdates(ids, plan.Requestable.ValueBool()))
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating Access Profiles", err.Error())
+		return
+	}
+	r.warnNotFound(ctx, &resp.Diagnostics, notFound)
+}
+
+func (r *accessProfilesRequestableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state accessProfilesRequestableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous := map[string]bool{}
+	resp.Diagnostics.Append(state.PreviousRequestable.ElementsAs(ctx, &previous, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	live, err := r.readRequestable(ctx, accessProfileMapKeys(previous))
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Access Profiles", err.Error())
+		return
+	}
+
+	kept, drifted := accessProfileRequestableDrift(previous, live, state.Requestable.ValueBool())
+	for id := range previous {
+		if _, ok := kept[id]; !ok {
+			tflog.Warn(ctx, "Access Profile not found, removing it from state", map[string]interface{}{"access_profile_id": id})
+		}
+	}
+	if len(kept) == 0 {
+		tflog.Warn(ctx, "None of the managed Access Profiles exist anymore, removing resource from state", map[string]interface{}{"id": state.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if len(drifted) > 0 {
+		tflog.Warn(ctx, "Access Profiles' requestable changed outside Terraform", map[string]interface{}{"access_profile_ids": drifted})
+	}
+	state.DriftedIds = accessProfileIdSet(drifted)
+
+	previousValue, diags := types.MapValueFrom(ctx, types.BoolType, kept)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.PreviousRequestable = previousValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *accessProfilesRequestableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state accessProfilesRequestableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous := map[string]bool{}
+	resp.Diagnostics.Append(state.PreviousRequestable.ElementsAs(ctx, &previous, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	desiredIds, toAdd, toRemove, diags := accessProfileRequestableUpdateIds(ctx, accessProfileMapKeys(previous), plan.AccessProfileIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(toRemove) > 0 {
+		restores := accessProfileRestoreUpdates(toRemove, previous)
+		for _, id := range toRemove {
+			delete(previous, id)
+		}
+		notFound, err := r.bulkUpdateRequestable(ctx, restores)
+		if err != nil {
+			resp.Diagnostics.AddError("Error restoring Access Profiles", err.Error())
+			return
+		}
+		r.warnNotFound(ctx, &resp.Diagnostics, notFound)
+	}
+
+	if len(toAdd) > 0 {
+		live, err := r.readRequestable(ctx, toAdd)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading Access Profiles", err.Error())
+			return
+		}
+		if missing := accessProfileIdsMissing(toAdd, live); len(missing) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				accessProfileRequestableIdsPath(plan),
+				"Access Profiles not found",
+				fmt.Sprintf("These Access Profiles do not exist: %s", strings.Join(missing, ", ")),
+			)
+			return
+		}
+		for id, value := range live {
+			previous[id] = value
+		}
+	}
+
+	tflog.Info(ctx, "Setting requestable on Access Profiles", map[string]interface{}{
+		"count":       len(desiredIds),
+		"added":       len(toAdd),
+		"removed":     len(toRemove),
+		"requestable": plan.Requestable.ValueBool(),
+	})
+
+	previousValue, diags := types.MapValueFrom(ctx, types.BoolType, previous)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The flag is set on every profile, not just the added ones, so a plan
+	// produced by Read's drift detection puts the drifted ones back.
+	notFound, err := r.bulkUpdateRequestable(ctx, accessProfileRequestableUpdates(desiredIds, plan.Requestable.ValueBool()))
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating Access Profiles", err.Error())
+		return
+	}
+	r.warnNotFound(ctx, &resp.Diagnostics, notFound)
+
+	plan.Id = state.Id
+	plan.PreviousRequestable = previousValue
+	plan.DriftedIds = accessProfileIdSet(nil)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *accessProfilesRequestableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state accessProfilesRequestableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous := map[string]bool{}
+	resp.Diagnostics.Append(state.PreviousRequestable.ElementsAs(ctx, &previous, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restores := accessProfileDeleteRestores(previous)
+
+	tflog.Info(ctx, "Restoring requestable on Access Profiles", map[string]interface{}{"count": len(restores)})
+
+	notFound, err := r.bulkUpdateRequestable(ctx, restores)
+	if err != nil {
+		resp.Diagnostics.AddError("Error restoring Access Profiles", err.Error())
+		return
+	}
+	r.warnNotFound(ctx, &resp.Diagnostics, notFound)
+}
+
+// accessProfileIdsForFilter lists every Access Profile matching the filter
+// block, following pages until one comes back short.
+func (r *accessProfilesRequestableResource) accessProfileIdsForFilter(ctx context.Context, filter types.Object) ([]string, diag.Diagnostics) {
+	filters, diags := util.BuildFilterExpression(filter, path.Root("filter"), accessProfileRequestableFilterFields)
+	segmentIds, d := util.FilterBlockStrings(ctx, filter, "segment_ids")
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var ids []string
+	var offset int32
+	for {
+		apiReq := r.client.AccessProfilesAPI.ListAccessProfilesV1(ctx).Offset(offset).Limit(accessProfileListPageLimit)
+		if filters != "" {
+			apiReq = apiReq.Filters(filters)
+		}
+		if len(segmentIds) > 0 {
+			apiReq = apiReq.ForSegmentIds(strings.Join(segmentIds, ","))
+		}
+		page, httpResp, err := apiReq.Execute()
+		if err != nil {
+			diags.AddError("Error resolving Access Profiles", fmt.Sprintf("listing access profiles matching %q: %s", filters, accessProfileErrDetail(err, httpResp)))
+			return nil, diags
+		}
+		for i := range page {
+			if id := page[i].GetId(); id != "" {
+				ids = append(ids, id)
+			}
+		}
+		if len(page) < accessProfileListPageLimit {
+			return ids, diags
+		}
+		offset += accessProfileListPageLimit
+	}
+}
+
+// readRequestable returns the live requestable flag of each of ids that
+// still exists, listing them accessProfileRequestableBatchSize at a time
+// with an "id in (...)" filter rather than one GET per profile.
+func (r *accessProfilesRequestableResource) readRequestable(ctx context.Context, ids []string) (map[string]bool, error) {
+	out := make(map[string]bool, len(ids))
+	for _, batch := range accessProfileChunkIds(ids, accessProfileRequestableBatchSize) {
+		page, httpResp, err := r.client.AccessProfilesAPI.ListAccessProfilesV1(ctx).
+			Filters(util.FilterIdsIn(batch)).
+			Limit(accessProfileRequestableBatchSize).
+			Execute()
+		if err != nil {
+			return nil, fmt.Errorf("listing access profiles: %s", accessProfileErrDetail(err, httpResp))
+		}
+		for i := range page {
+			out[page[i].GetId()] = page[i].GetRequestable()
+		}
+	}
+	return out, nil
+}
+
+// bulkUpdateRequestable sends updates in batches and returns the IDs the
+// endpoint reported as not found. Any other per-item failure is an error.
+func (r *accessProfilesRequestableResource) bulkUpdateRequestable(ctx context.Context, updates []access_profiles.AccessProfileBulkUpdateRequestInner) ([]string, error) {
+	var notFound []string
+	batches := (len(updates) + accessProfileRequestableBatchSize - 1) / accessProfileRequestableBatchSize
+	for i := 0; i < batches; i++ {
+		batch := updates[i*accessProfileRequestableBatchSize : min((i+1)*accessProfileRequestableBatchSize, len(updates))]
+
+		results, httpResp, err := r.client.AccessProfilesAPI.UpdateAccessProfilesInBulkV1(ctx).
+			AccessProfileBulkUpdateRequestInner(batch).
+			Execute()
+		if err != nil {
+			return notFound, fmt.Errorf("batch %d of %d: %s", i+1, batches, accessProfileErrDetail(err, httpResp))
+		}
+		for _, result := range results {
+			switch result.GetStatus() {
+			case accessProfileBulkUpdatedStatus:
+			case "404":
+				notFound = append(notFound, result.GetId())
+			default:
+				return notFound, fmt.Errorf("batch %d of %d: access profile %q: status %s: %s",
+					i+1, batches, result.GetId(), result.GetStatus(), strings.TrimSpace(result.GetDescription()))
+			}
+		}
+		tflog.Debug(ctx, "Sent bulk requestable update", map[string]interface{}{
+			"batch": fmt.Sprintf("%d/%d", i+1, batches),
+			"count": len(batch),
+		})
+	}
+	return notFound, nil
+}
+
+func (r *accessProfilesRequestableResource) warnNotFound(ctx context.Context, diags *diag.Diagnostics, notFound []string) {
+	if len(notFound) == 0 {
+		return
+	}
+	tflog.Warn(ctx, "Access Profiles not found during bulk requestable update", map[string]interface{}{"access_profile_ids": notFound})
+	diags.AddWarning(
+		"Access Profiles not found",
+		fmt.Sprintf("These Access Profiles no longer exist and were skipped: %s", strings.Join(notFound, ", ")),
+	)
+}
+
+func accessProfileRequestableUpdates(ids []string, requestable bool) []access_profiles.AccessProfileBulkUpdateRequestInner {
+	out := make([]access_profiles.AccessProfileBulkUpdateRequestInner, 0, len(ids))
+	for _, id := range ids {
+		var item access_profiles.AccessProfileBulkUpdateRequestInner
+		item.SetId(id)
+		item.SetRequestable(requestable)
+		out = append(out, item)
+	}
+	return out
+}
+
+// accessProfileRequestableIdsPath is the attribute m's profiles were
+// selected with, for diagnostics about them.
+func accessProfileRequestableIdsPath(m accessProfilesRequestableResourceModel) path.Path {
+	if m.AccessProfileIds.IsNull() {
+		return path.Root("filter")
+	}
+	return path.Root("access_profile_ids")
+}
+
+// accessProfileRequestableUpdateIds returns the profiles an Update manages,
+// given current, the ones in state, and the configured access_profile_ids,
+// plus the ones it adds and releases. With a filter, configured is null and
+// the set stays current: a filter is resolved only at Create, and changing
+// it forces replacement.
+func accessProfileRequestableUpdateIds(ctx context.Context, current []string, configured types.Set) (desired, toAdd, toRemove []string, diags diag.Diagnostics) {
+	desired = current
+	if !configured.IsNull() {
+		desired = nil
+		diags.Append(configured.ElementsAs(ctx, &desired, false)...)
+		desired = accessProfileUniqueSortedIds(desired)
+	}
+	toAdd, toRemove = util.DiffIds(current, desired)
+	return desired, toAdd, toRemove, diags
+}
+
+// accessProfileRestoreUpdates returns the updates putting each of ids back
+// to its value in previous.
+func accessProfileRestoreUpdates(ids []string, previous map[string]bool) []access_profiles.AccessProfileBulkUpdateRequestInner {
+	out := make([]access_profiles.AccessProfileBulkUpdateRequestInner, 0, len(ids))
+	for _, id := range ids {
+		if value, ok := previous[id]; ok {
+			out = append(out, accessProfileRequestableUpdates([]string{id}, value)...)
+		}
+	}
+	return out
+}
+
+// accessProfileDeleteRestores returns the updates Delete sends: every
+// profile back to its recorded value, not just those recorded at a value
+// other than the held one, since a profile changed by hand since the last
+// apply may be at neither.
+func accessProfileDeleteRestores(previous map[string]bool) []access_profiles.AccessProfileBulkUpdateRequestInner {
+	return accessProfileRestoreUpdates(accessProfileMapKeys(previous), previous)
+}
+
+// accessProfileRequestableDrift splits previous, the managed profiles, into
+// kept, those that still exist in live, and drifted, sorted, those whose
+// live value is no longer desired.
+func accessProfileRequestableDrift(previous, live map[string]bool, desired bool) (kept map[string]bool, drifted []string) {
+	kept = make(map[string]bool, len(previous))
+	for _, id := range accessProfileMapKeys(previous) {
+		value, ok := live[id]
+		if !ok {
+			continue
+		}
+		kept[id] = previous[id]
+		if value != desired {
+			drifted = append(drifted, id)
+		}
+	}
+	return kept, drifted
+}
+
+// accessProfileIdSet returns ids as a known, possibly empty, set.
+func accessProfileIdSet(ids []string) types.Set {
+	elems := make([]attr.Value, 0, len(ids))
+	for _, id := range ids {
+		elems = append(elems, types.StringValue(id))
+	}
+	return types.SetValueMust(types.StringType, elems)
+}
+
+func accessProfileIdsMissing(ids []string, live map[string]bool) []string {
+	var missing []string
+	for _, id := range ids {
+		if _, ok := live[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+func accessProfileMapKeys(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func accessProfileUniqueSortedIds(ids []string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func accessProfileChunkIds(ids []string, size int) [][]string {
+	var chunks [][]string
+	for len(ids) > size {
+		chunks = append(chunks, ids[:size])
+		ids = ids[size:]
+	}
+	if len(ids) > 0 {
+		chunks = append(chunks, ids)
+	}
+	return chunks
+}
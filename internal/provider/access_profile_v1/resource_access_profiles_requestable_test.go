@@ -0,0 +1,191 @@
+package access_profile_v1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sailpoint-oss/golang-sdk/v3/access_profiles"
+)
+
+// describeRequestableUpdates renders updates as "id=requestable" strings.
+func describeRequestableUpdates(updates []access_profiles.AccessProfileBulkUpdateRequestInner) []string {
+	out := make([]string, 0, len(updates))
+	for _, u := range updates {
+		out = append(out, fmt.Sprintf("%s=%t", u.GetId(), u.GetRequestable()))
+	}
+	return out
+}
+
+func stringSet(ids ...string) types.Set {
+	elems := make([]attr.Value, 0, len(ids))
+	for _, id := range ids {
+		elems = append(elems, types.StringValue(id))
+	}
+	return types.SetValueMust(types.StringType, elems)
+}
+
+func TestAccessProfileRequestableUpdateIds(t *testing.T) {
+	tests := []struct {
+		name         string
+		current      []string
+		configured   types.Set
+		wantDesired  []string
+		wantToAdd    []string
+		wantToRemove []string
+	}{
+		{
+			name:        "access_profile_ids unchanged",
+			current:     []string{"a", "b"},
+			configured:  stringSet("b", "a"),
+			wantDesired: []string{"a", "b"},
+		},
+		{
+			name:         "access_profile_ids changed",
+			current:      []string{"a", "b"},
+			configured:   stringSet("c", "b"),
+			wantDesired:  []string{"b", "c"},
+			wantToAdd:    []string{"c"},
+			wantToRemove: []string{"a"},
+		},
+		{
+			name:        "blank ids dropped",
+			current:     []string{"a"},
+			configured:  stringSet("a", ""),
+			wantDesired: []string{"a"},
+		},
+		{
+			name:        "filter keeps the set resolved at Create",
+			current:     []string{"a", "b"},
+			configured:  types.SetNull(types.StringType),
+			wantDesired: []string{"a", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired, toAdd, toRemove, diags := accessProfileRequestableUpdateIds(context.Background(), tt.current, tt.configured)
+			if diags.HasError() {
+				t.Fatalf("diagnostics: %v", diags)
+			}
+			if !reflect.DeepEqual(desired, tt.wantDesired) {
+				t.Errorf("desired = %v, want %v", desired, tt.wantDesired)
+			}
+			if !reflect.DeepEqual(toAdd, tt.wantToAdd) {
+				t.Errorf("toAdd = %v, want %v", toAdd, tt.wantToAdd)
+			}
+			if !reflect.DeepEqual(toRemove, tt.wantToRemove) {
+				t.Errorf("toRemove = %v, want %v", toRemove, tt.wantToRemove)
+			}
+		})
+	}
+}
+
+func TestAccessProfileRequestableDrift(t *testing.T) {
+	previous := map[string]bool{"a": true, "b": true, "c": false, "gone": true}
+	live := map[string]bool{"a": false, "b": true, "c": false}
+
+	kept, drifted := accessProfileRequestableDrift(previous, live, false)
+	if want := map[string]bool{"a": true, "b": true, "c": false}; !reflect.DeepEqual(kept, want) {
+		t.Errorf("kept = %v, want %v", kept, want)
+	}
+	if want := []string{"b"}; !reflect.DeepEqual(drifted, want) {
+		t.Errorf("drifted = %v, want %v", drifted, want)
+	}
+
+	if _, drifted := accessProfileRequestableDrift(previous, live, true); !reflect.DeepEqual(drifted, []string{"a", "c"}) {
+		t.Errorf("drifted holding true = %v, want [a c]", drifted)
+	}
+}
+
+// TestAccessProfileRequestableDeleteAfterDrift covers a freeze at false over
+// profiles that were all requestable, with one re-enabled by hand: Read
+// reports the drift without touching "requestable", and Delete still puts
+// every profile back.
+func TestAccessProfileRequestableDeleteAfterDrift(t *testing.T) {
+	previous := map[string]bool{"a": true, "b": true, "c": true}
+	live := map[string]bool{"a": false, "b": true, "c": false}
+
+	kept, drifted := accessProfileRequestableDrift(previous, live, false)
+	if want := []string{"b"}; !reflect.DeepEqual(drifted, want) {
+		t.Fatalf("drifted = %v, want %v", drifted, want)
+	}
+	if got := accessProfileIdSet(drifted); !got.Equal(stringSet("b")) {
+		t.Errorf("drifted_access_profile_ids = %s, want [b]", got)
+	}
+
+	got := describeRequestableUpdates(accessProfileDeleteRestores(kept))
+	if want := []string{"a=true", "b=true", "c=true"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Delete restores = %v, want %v", got, want)
+	}
+}
+
+func TestAccessProfileIdSet(t *testing.T) {
+	if got := accessProfileIdSet(nil); got.IsNull() || len(got.Elements()) != 0 {
+		t.Errorf("accessProfileIdSet(nil) = %s, want an empty set", got)
+	}
+}
+
+// TestAccessProfileRequestableUpdateRestores covers the set Update
+// restores: each profile removed from access_profile_ids, at its recorded
+// value, skipping ids it never recorded.
+func TestAccessProfileRequestableUpdateRestores(t *testing.T) {
+	previous := map[string]bool{"a": true, "b": false, "c": false}
+
+	got := describeRequestableUpdates(accessProfileRestoreUpdates([]string{"b", "a", "unknown"}, previous))
+	if want := []string{"b=false", "a=true"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("restores = %v, want %v", got, want)
+	}
+}
+
+func TestAccessProfileRequestableIdsPath(t *testing.T) {
+	byIds := accessProfilesRequestableResourceModel{AccessProfileIds: stringSet("a")}
+	if got := accessProfileRequestableIdsPath(byIds); !got.Equal(path.Root("access_profile_ids")) {
+		t.Errorf("path with access_profile_ids = %s, want access_profile_ids", got)
+	}
+
+	byFilter := accessProfilesRequestableResourceModel{AccessProfileIds: types.SetNull(types.StringType)}
+	if got := accessProfileRequestableIdsPath(byFilter); !got.Equal(path.Root("filter")) {
+		t.Errorf("path with filter = %s, want filter", got)
+	}
+}
+
+func TestAccessProfileIdsMissing(t *testing.T) {
+	got := accessProfileIdsMissing([]string{"a", "b", "c"}, map[string]bool{"b": false})
+	if want := []string{"a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("accessProfileIdsMissing = %v, want %v", got, want)
+	}
+}
+
+func TestAccessProfileUniqueSortedIds(t *testing.T) {
+	got := accessProfileUniqueSortedIds([]string{"c", "a", "", "c", "b"})
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("accessProfileUniqueSortedIds = %v, want %v", got, want)
+	}
+}
+
+func TestAccessProfileChunkIds(t *testing.T) {
+	got := accessProfileChunkIds([]string{"a", "b", "c", "d", "e"}, 2)
+	if want := [][]string{{"a", "b"}, {"c", "d"}, {"e"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("accessProfileChunkIds = %v, want %v", got, want)
+	}
+	if got := accessProfileChunkIds(nil, 2); len(got) != 0 {
+		t.Errorf("accessProfileChunkIds(nil) = %v, want none", got)
+	}
+}
+
+func TestAccessProfileRequestableFilterFields(t *testing.T) {
+	var attrs []string
+	for _, f := range accessProfileRequestableFilterFields {
+		attrs = append(attrs, f.Attribute)
+		if f.Attribute == "name_contains" {
+			t.Error("accessProfileRequestableFilterFields includes name_contains")
+		}
+	}
+	if len(attrs) != len(accessProfileFilterFields)-1 {
+		t.Errorf("accessProfileRequestableFilterFields = %v, want every accessProfileFilterFields entry but name_contains", attrs)
+	}
+}
@@ -6,21 +6,28 @@ package entitlement_v1
 import (
 	"context"
 	"fmt"
+	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/entitlements"
+
+	"terraform-provider-identitynow/internal/provider/util"
 )
 
 const entitlementsListMaxLimit = 250
 
 var (
-	_ datasource.DataSource              = (*entitlementsDataSource)(nil)
-	_ datasource.DataSourceWithConfigure = (*entitlementsDataSource)(nil)
+	_ datasource.DataSource                     = (*entitlementsDataSource)(nil)
+	_ datasource.DataSourceWithConfigure        = (*entitlementsDataSource)(nil)
+	_ datasource.DataSourceWithConfigValidators = (*entitlementsDataSource)(nil)
 )
 
 func NewEntitlementsDataSource() datasource.DataSource {
@@ -33,6 +40,7 @@ type entitlementsDataSource struct {
 
 type EntitlementsDataSourceModel struct {
 	Filters      types.String `tfsdk:"filters"`
+	Filter       types.Object `tfsdk:"filter"`
 	Sorters      types.String `tfsdk:"sorters"`
 	Limit        types.Int64  `tfsdk:"limit"`
 	Offset       types.Int64  `tfsdk:"offset"`
@@ -60,14 +68,17 @@ func (d *entitlementsDataSource) Metadata(ctx context.Context, req datasource.Me
 func (d *entitlementsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Lists Entitlements from IdentityNow/ISC, optionally filtered, sorted, and paginated.",
-		MarkdownDescription: "Lists Entitlements from IdentityNow/ISC via `GET /entitlements/v1`, optionally filtered, sorted, and paginated. " +
+		MarkdownDescription: "Lists Entitlements from IdentityNow/ISC via `GET /entitlements/v1`, optionally filtered (by a raw `filters` " +
+			"expression or a typed `filter` block), sorted, and paginated. " +
 			"Returns the same attributes per entitlement as the singular `identitynow_entitlement_v1` data source.",
 		Attributes: map[string]schema.Attribute{
 			"filters": schema.StringAttribute{
 				Optional: true,
 				MarkdownDescription: "Filter expression used to query entitlements. See [V3 API Standard Collection Parameters]" +
-					"(https://developer.sailpoint.com/idn/api/standard-collection-parameters#filtering-results).",
+					"(https://developer.sailpoint.com/idn/api/standard-collection-parameters#filtering-results). " +
+					"Conflicts with `filter`.",
 			},
+			"filter": entitlementFilterAttribute(),
 			"sorters": schema.StringAttribute{
 				Optional: true,
 				MarkdownDescription: "Sort expression for the results. See [V3 API Standard Collection Parameters]" +
@@ -93,6 +104,15 @@ func (d *entitlementsDataSource) Schema(ctx context.Context, req datasource.Sche
 	}
 }
 
+func (d *entitlementsDataSource) ConfigValidators(context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("filters"),
+			path.MatchRoot("filter"),
+		),
+	}
+}
+
 func (d *entitlementsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -116,15 +136,27 @@ func (d *entitlementsDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
+	filters := config.Filters.ValueString()
+	if !config.Filter.IsNull() && !config.Filter.IsUnknown() {
+		expr, diags := util.BuildFilterExpression(config.Filter, path.Root("filter"), entitlementFilterFields)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		filters = expr
+	}
+
 	apiReq := d.client.EntitlementsAPI.ListEntitlementsV1(ctx)
-	if !config.Filters.IsNull() && !config.Filters.IsUnknown() {
-		apiReq = apiReq.Filters(config.Filters.ValueString())
+	if filters != "" {
+		apiReq = apiReq.Filters(filters)
 	}
 	if !config.Sorters.IsNull() && !config.Sorters.IsUnknown() {
 		apiReq = apiReq.Sorters(config.Sorters.ValueString())
 	}
+	var offset, limit *int32
 	if !config.Offset.IsNull() && !config.Offset.IsUnknown() {
-		apiReq = apiReq.Offset(int32(config.Offset.ValueInt64()))
+		o := int32(config.Offset.ValueInt64())
+		offset = &o
 	}
 	if !config.Limit.IsNull() && !config.Limit.IsUnknown() {
 		requestedLimit := config.Limit.ValueInt64()
@@ -133,21 +165,50 @@ func (d *entitlementsDataSource) Read(ctx context.Context, req datasource.ReadRe
 				"Limit exceeds maximum",
 				fmt.Sprintf("The requested limit (%d) exceeds GET /entitlements/v1's documented maximum of %d. Using %d instead.", requestedLimit, entitlementsListMaxLimit, entitlementsListMaxLimit),
 			)
-			apiReq = apiReq.Limit(entitlementsListMaxLimit)
-		} else {
-			apiReq = apiReq.Limit(int32(requestedLimit))
+			requestedLimit = entitlementsListMaxLimit
 		}
+		l := int32(requestedLimit)
+		limit = &l
 	}
 
-	tflog.Debug(ctx, "Reading Entitlements data source", map[string]interface{}{"filters": config.Filters.ValueString()})
-
-	dtos, httpResp, err := apiReq.Execute()
+	tflog.Debug(ctx, "Reading Entitlements data source", map[string]interface{}{"filters": filters})
+
+	// name_contains is matched here rather than by the API, so every page
+	// is read before offset/limit are applied to the matches.
+	nameContains, filterByName := util.FilterBlockString(config.Filter, "name_contains")
+
+	var dtos []entitlements.EntitlementV2
+	var httpResp *http.Response
+	var err error
+	if filterByName {
+		dtos, httpResp, err = util.ListAllPages(entitlementsListMaxLimit, func(offset, limit int32) ([]entitlements.EntitlementV2, *http.Response, error) {
+			return apiReq.Offset(offset).Limit(limit).Execute()
+		})
+	} else {
+		if offset != nil {
+			apiReq = apiReq.Offset(*offset)
+		}
+		if limit != nil {
+			apiReq = apiReq.Limit(*limit)
+		}
+		dtos, httpResp, err = apiReq.Execute()
+	}
 	if err != nil {
 		tflog.Error(ctx, "Error reading Entitlements data source", map[string]interface{}{"error": err.Error()})
 		resp.Diagnostics.AddError("Error listing Entitlements", entitlementErrDetail(err, httpResp))
 		return
 	}
 
+	if filterByName {
+		matched := dtos[:0]
+		for _, dto := range dtos {
+			if util.FilterNameContains(dto.GetName(), nameContains) {
+				matched = append(matched, dto)
+			}
+		}
+		dtos = util.PageSlice(matched, offset, limit)
+	}
+
 	entitlementsAttrSchema := schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"entitlements": schema.ListNestedAttribute{
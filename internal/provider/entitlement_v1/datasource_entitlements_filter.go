@@ -0,0 +1,47 @@
+// This file defines identitynow_entitlements_v1's typed "filter" block,
+// built with the same util.BuildFilterExpression as identitynow_roles_v1's.
+// The fields are the ones GET /entitlements/v1 documents; "name_contains"
+// is matched against every page of results, since the API has no "co" on
+// name. Unlike roles and access profiles, entitlements have no segment
+// filter.
+package entitlement_v1
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+var entitlementFilterFields = []util.FilterField{
+	{Attribute: "ids", Property: "id", Operator: "in", Kind: util.FilterKindStringSet, Description: "Only entitlements with one of these IDs."},
+	{Attribute: "name", Property: "name", Operator: "eq", Kind: util.FilterKindString, Description: "Only entitlements with exactly this name."},
+	{Attribute: "names", Property: "name", Operator: "in", Kind: util.FilterKindStringSet, Description: "Only entitlements with one of these names."},
+	{Attribute: "name_starts_with", Property: "name", Operator: "sw", Kind: util.FilterKindString, Description: "Only entitlements whose name starts with this prefix."},
+	{Attribute: "name_contains", Kind: util.FilterKindString, Description: "Only entitlements whose name contains this text, ignoring case. `GET /entitlements/v1` can't filter on this, so the provider reads every page of results and matches them itself, then applies `offset` and `limit` to the matches."},
+	{Attribute: "type", Property: "type", Operator: "eq", Kind: util.FilterKindString, Description: "Only entitlements of this source schema object type, e.g. `group`."},
+	{Attribute: "attribute", Property: "attribute", Operator: "eq", Kind: util.FilterKindString, Description: "Only entitlements of this account attribute, e.g. `memberOf`."},
+	{Attribute: "value", Property: "value", Operator: "eq", Kind: util.FilterKindString, Description: "Only entitlements with exactly this value."},
+	{Attribute: "values", Property: "value", Operator: "in", Kind: util.FilterKindStringSet, Description: "Only entitlements with one of these values."},
+	{Attribute: "value_starts_with", Property: "value", Operator: "sw", Kind: util.FilterKindString, Description: "Only entitlements whose value starts with this prefix."},
+	{Attribute: "owner_id", Property: "owner.id", Operator: "eq", Kind: util.FilterKindString, Description: "Only entitlements owned by this identity."},
+	{Attribute: "owner_ids", Property: "owner.id", Operator: "in", Kind: util.FilterKindStringSet, Description: "Only entitlements owned by one of these identities."},
+	{Attribute: "source_id", Property: "source.id", Operator: "eq", Kind: util.FilterKindString, Description: "Only entitlements on this Source."},
+	{Attribute: "source_ids", Property: "source.id", Operator: "in", Kind: util.FilterKindStringSet, Description: "Only entitlements on one of these Sources."},
+	{Attribute: "requestable", Property: "requestable", Operator: "eq", Kind: util.FilterKindBool, Description: "Only requestable (`true`) or non-requestable (`false`) entitlements."},
+	{Attribute: "created_after", Property: "created", Operator: "gt", Kind: util.FilterKindTimestamp, Description: "Only entitlements created after this RFC 3339 timestamp."},
+	{Attribute: "created_before", Property: "created", Operator: "lt", Kind: util.FilterKindTimestamp, Description: "Only entitlements created before this RFC 3339 timestamp."},
+	{Attribute: "created_on_or_after", Property: "created", Operator: "ge", Kind: util.FilterKindTimestamp, Description: "Only entitlements created at or after this RFC 3339 timestamp."},
+	{Attribute: "created_on_or_before", Property: "created", Operator: "le", Kind: util.FilterKindTimestamp, Description: "Only entitlements created at or before this RFC 3339 timestamp."},
+	{Attribute: "modified_after", Property: "modified", Operator: "gt", Kind: util.FilterKindTimestamp, Description: "Only entitlements last modified after this RFC 3339 timestamp."},
+	{Attribute: "modified_before", Property: "modified", Operator: "lt", Kind: util.FilterKindTimestamp, Description: "Only entitlements last modified before this RFC 3339 timestamp."},
+	{Attribute: "modified_on_or_after", Property: "modified", Operator: "ge", Kind: util.FilterKindTimestamp, Description: "Only entitlements last modified at or after this RFC 3339 timestamp."},
+	{Attribute: "modified_on_or_before", Property: "modified", Operator: "le", Kind: util.FilterKindTimestamp, Description: "Only entitlements last modified at or before this RFC 3339 timestamp."},
+}
+
+func entitlementFilterAttribute() schema.SingleNestedAttribute {
+	return util.FilterAttribute(
+		"Typed filter criteria, AND-ed together and compiled into the `filters` expression for you. Conflicts with `filters`.",
+		entitlementFilterFields,
+		nil,
+	)
+}
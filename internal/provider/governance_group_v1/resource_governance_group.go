@@ -34,6 +34,7 @@ package governance_group_v1
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -55,6 +56,7 @@ import (
 // this package needing to import it (which would create an import cycle).
 type clientProvider interface {
 	GetClient() *sailpoint.APIClient
+	GetDeleteCoalescer() *util.DeleteCoalescer
 }
 
 var (
@@ -68,7 +70,8 @@ func NewGovernanceGroupResource() resource.Resource {
 }
 
 type governanceGroupResource struct {
-	client *sailpoint.APIClient
+	client  *sailpoint.APIClient
+	deletes *util.DeleteCoalescer
 }
 
 func (r *governanceGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -99,6 +102,7 @@ func (r *governanceGroupResource) Configure(ctx context.Context, req resource.Co
 		return
 	}
 	r.client = cp.GetClient()
+	r.deletes = cp.GetDeleteCoalescer()
 }
 
 func (r *governanceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -240,6 +244,21 @@ func (r *governanceGroupResource) Delete(ctx context.Context, req resource.Delet
 
 	tflog.Debug(ctx, "Deleting Governance Group", map[string]interface{}{"id": state.Id.ValueString()})
 
+	if r.deletes.Enabled() {
+		err := r.deletes.Delete(ctx, governanceGroupBulkDeleteKind, governanceGroupBulkDeleteMax, state.Id.ValueString(), governanceGroupBulkDelete(r.client))
+		if errors.Is(err, util.ErrBulkDeleteNotFound) {
+			tflog.Warn(ctx, "Governance Group already absent on delete", map[string]interface{}{"id": state.Id.ValueString()})
+			return
+		}
+		if err != nil {
+			tflog.Error(ctx, "Error deleting Governance Group", map[string]interface{}{"id": state.Id.ValueString(), "error": err.Error()})
+			resp.Diagnostics.AddError("Error deleting Governance Group", err.Error())
+			return
+		}
+		tflog.Info(ctx, "Deleted Governance Group", map[string]interface{}{"id": state.Id.ValueString()})
+		return
+	}
+
 	httpResp, err := r.client.GovernanceGroupsAPI.
 		DeleteWorkgroupV1(ctx, state.Id.ValueString()).
 		Execute()
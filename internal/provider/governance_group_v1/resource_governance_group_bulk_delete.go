@@ -0,0 +1,59 @@
+// This file routes identitynow_governance_group_v1's Delete through the
+// provider's util.DeleteCoalescer, so governance groups destroyed in the
+// same apply are removed with POST /workgroups/v1/bulk-delete, up to 100 at
+// a time. Unlike the role and access profile endpoints, this one is
+// synchronous and answers 207 with a status per workgroup, so no task needs
+// waiting on.
+package governance_group_v1
+
+import (
+	"context"
+	"fmt"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/governance_groups"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+const (
+	governanceGroupBulkDeleteKind = "governance_groups"
+	// governanceGroupBulkDeleteMax is POST /workgroups/v1/bulk-delete's
+	// documented limit.
+	governanceGroupBulkDeleteMax = 100
+)
+
+func governanceGroupBulkDelete(client *sailpoint.APIClient) util.BulkDeleteFunc {
+	return func(ctx context.Context, ids []string) (map[string]error, error) {
+		results, httpResp, err := client.GovernanceGroupsAPI.
+			DeleteWorkgroupsInBulkV1(ctx).
+			WorkgroupBulkDeleteRequest(*governance_groups.NewWorkgroupBulkDeleteRequest(ids)).
+			Execute()
+		if err != nil {
+			return nil, fmt.Errorf("%s", errDetail(err, httpResp))
+		}
+
+		out := map[string]error{}
+		reported := make(map[string]bool, len(results))
+		for _, item := range results {
+			reported[item.Id] = true
+			switch item.Status {
+			case 204:
+			case 404:
+				out[item.Id] = util.ErrBulkDeleteNotFound
+			default:
+				desc := ""
+				if item.Description != nil {
+					desc = *item.Description
+				}
+				out[item.Id] = fmt.Errorf("failed to delete Governance Group %q: HTTP %d %s", item.Id, item.Status, desc)
+			}
+		}
+		for _, id := range ids {
+			if !reported[id] {
+				out[id] = fmt.Errorf("bulk-delete response has no result for Governance Group %q", id)
+			}
+		}
+		return out, nil
+	}
+}
@@ -24,11 +24,9 @@
 // member currently tracked in state.
 //
 // The top-level POST /workgroups/v1/bulk-delete (bulk *governance group*
-// deletion, not member deletion) is intentionally not modeled anywhere in
-// this provider - it is redundant for Terraform's purposes since the
-// generated DELETE /workgroups/v1/{id} (already wired into
-// governanceGroupResource.Delete) covers the same single-object-delete
-// semantics Terraform actually needs.
+// deletion, not member deletion) is not a resource of its own: it backs
+// governanceGroupResource.Delete when the provider's delete coalescer is
+// enabled (see resource_governance_group_bulk_delete.go).
 //
 // The read-only GET /workgroups/v1/{workgroupId}/connections endpoint is
 // modeled separately as the identitynow_governance_group_connections_v1 data
@@ -54,6 +52,8 @@ import (
 
 	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
 	"github.com/sailpoint-oss/golang-sdk/v3/governance_groups"
+
+	"terraform-provider-identitynow/internal/provider/util"
 )
 
 var (
@@ -229,7 +229,7 @@ func (r *governanceGroupMembersResource) Update(ctx context.Context, req resourc
 		return
 	}
 
-	toAdd, toRemove := diffMemberIds(current, desired)
+	toAdd, toRemove := util.DiffIds(current, desired)
 
 	tflog.Debug(ctx, "Updating Governance Group members", map[string]interface{}{
 		"governance_group_id": workgroupID,
@@ -412,28 +412,3 @@ func setToStrings(ctx context.Context, s types.Set) ([]string, diag.Diagnostics)
 	diags := s.ElementsAs(ctx, &out, false)
 	return out, diags
 }
-
-// diffMemberIds returns the identity IDs present in desired but not current
-// (toAdd), and present in current but not desired (toRemove).
-func diffMemberIds(current, desired []string) (toAdd, toRemove []string) {
-	currentSet := make(map[string]struct{}, len(current))
-	for _, id := range current {
-		currentSet[id] = struct{}{}
-	}
-	desiredSet := make(map[string]struct{}, len(desired))
-	for _, id := range desired {
-		desiredSet[id] = struct{}{}
-	}
-
-	for _, id := range desired {
-		if _, ok := currentSet[id]; !ok {
-			toAdd = append(toAdd, id)
-		}
-	}
-	for _, id := range current {
-		if _, ok := desiredSet[id]; !ok {
-			toRemove = append(toRemove, id)
-		}
-	}
-	return toAdd, toRemove
-}
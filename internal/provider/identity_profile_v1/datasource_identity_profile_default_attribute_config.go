@@ -0,0 +1,177 @@
+// This file implements
+// identitynow_identity_profile_default_attribute_config_v1, the
+// GetDefaultIdentityAttributeConfig follow-up deferred in
+// resource_identity_profile.go's package doc. GET
+// /identity-profiles/v1/{identity-profile-id}/default-identity-attribute-config
+// returns SailPoint's baseline identity attribute mappings.
+//
+// identity_attribute_config is encoded with the same
+// identityAttributeConfigFromAPI helper identitynow_identity_profile_v1 uses
+// for its attribute of the same name, so the two are interchangeable.
+// attribute_transforms splits the same data per identity attribute so HCL
+// can merge overrides over the defaults with merge() - see the example.
+package identity_profile_v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/identity_profiles"
+)
+
+var (
+	_ datasource.DataSource              = (*identityProfileDefaultAttributeConfigDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*identityProfileDefaultAttributeConfigDataSource)(nil)
+)
+
+func NewIdentityProfileDefaultAttributeConfigDataSource() datasource.DataSource {
+	return &identityProfileDefaultAttributeConfigDataSource{}
+}
+
+type identityProfileDefaultAttributeConfigDataSource struct {
+	client *sailpoint.APIClient
+}
+
+type identityProfileDefaultAttributeConfigDataSourceModel struct {
+	IdentityProfileId       types.String         `tfsdk:"identity_profile_id"`
+	IdentityAttributeConfig jsontypes.Normalized `tfsdk:"identity_attribute_config"`
+	Enabled                 types.Bool           `tfsdk:"enabled"`
+	AttributeTransforms     types.Map            `tfsdk:"attribute_transforms"`
+}
+
+func (d *identityProfileDefaultAttributeConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity_profile_default_attribute_config_v1"
+}
+
+func (d *identityProfileDefaultAttributeConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads SailPoint's default identity attribute config for an Identity Profile, in the same shape as identitynow_identity_profile_v1's identity_attribute_config.",
+		MarkdownDescription: "Reads SailPoint's default identity attribute config for an Identity Profile via " +
+			"`GET /identity-profiles/v1/{identity-profile-id}/default-identity-attribute-config`. `identity_attribute_config` " +
+			"has exactly the same shape as `identitynow_identity_profile_v1`'s attribute of the same name, and " +
+			"`attribute_transforms` splits it per identity attribute so a few mappings can be overridden with `merge()`.\n\n" +
+			"~> This is a `_v1` pilot data source.",
+		Attributes: map[string]schema.Attribute{
+			"identity_profile_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "ID of the Identity Profile to read the default config for.",
+				MarkdownDescription: "ID of the Identity Profile to read the default config for.",
+			},
+			"identity_attribute_config": schema.StringAttribute{
+				CustomType:  jsontypes.NormalizedType{},
+				Computed:    true,
+				Description: "The default config as JSON, in the same {enabled, attributeTransforms} shape as identitynow_identity_profile_v1's identity_attribute_config.",
+				MarkdownDescription: "The default config as JSON, in the same `{enabled, attributeTransforms: [...]}` shape as " +
+					"`identitynow_identity_profile_v1`'s `identity_attribute_config`.",
+			},
+			"enabled": schema.BoolAttribute{
+				Computed:            true,
+				Description:         "The default config's enabled flag.",
+				MarkdownDescription: "The default config's `enabled` flag.",
+			},
+			"attribute_transforms": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Each default attributeTransforms entry as JSON, keyed by identity attribute name.",
+				MarkdownDescription: "Each default `attributeTransforms` entry as a JSON object " +
+					"(`{identityAttributeName, transformDefinition}`), keyed by `identityAttributeName`. Decode with " +
+					"`jsondecode()` and merge overrides over it to build a new `identity_attribute_config`.",
+			},
+		},
+	}
+}
+
+func (d *identityProfileDefaultAttributeConfigDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = cp.GetClient()
+}
+
+func (d *identityProfileDefaultAttributeConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config identityProfileDefaultAttributeConfigDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := config.IdentityProfileId.ValueString()
+	tflog.Debug(ctx, "Reading default identity attribute config", map[string]interface{}{"identity_profile_id": id})
+
+	cfg, httpResp, err := d.client.IdentityProfilesAPI.
+		GetDefaultIdentityAttributeConfigV1(ctx, id).
+		Execute()
+	if err != nil {
+		tflog.Error(ctx, "Error reading default identity attribute config", map[string]interface{}{"identity_profile_id": id, "error": err.Error()})
+		resp.Diagnostics.AddError("Error reading default identity attribute config", errDetail(err, httpResp))
+		return
+	}
+
+	state, diags := defaultAttributeConfigToModel(ctx, cfg, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Read default identity attribute config", map[string]interface{}{
+		"identity_profile_id":  id,
+		"attribute_transforms": len(state.AttributeTransforms.Elements()),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func defaultAttributeConfigToModel(ctx context.Context, cfg *identity_profiles.IdentityAttributeConfig, config identityProfileDefaultAttributeConfigDataSourceModel) (identityProfileDefaultAttributeConfigDataSourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	model := config
+
+	normalized, d := identityAttributeConfigFromAPI(cfg)
+	diags.Append(d...)
+	model.IdentityAttributeConfig = normalized
+
+	model.Enabled = types.BoolNull()
+	transforms := map[string]string{}
+	if cfg != nil {
+		model.Enabled = types.BoolPointerValue(cfg.Enabled)
+		for i := range cfg.AttributeTransforms {
+			t := cfg.AttributeTransforms[i]
+			name := t.GetIdentityAttributeName()
+			if name == "" {
+				continue
+			}
+			b, err := json.Marshal(t)
+			if err != nil {
+				diags.AddError(
+					"Error encoding default identity attribute transform",
+					fmt.Sprintf("Could not encode the default transform for identity attribute %q as JSON: %s", name, err.Error()),
+				)
+				continue
+			}
+			transforms[name] = string(b)
+		}
+	}
+
+	m, d := types.MapValueFrom(ctx, types.StringType, transforms)
+	diags.Append(d...)
+	model.AttributeTransforms = m
+
+	return model, diags
+}
@@ -0,0 +1,82 @@
+package identity_profile_v1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDefaultAttributeConfigToModel(t *testing.T) {
+	ctx := context.Background()
+
+	raw := `{
+  "enabled": true,
+  "attributeTransforms": [
+    {"identityAttributeName": "email", "transformDefinition": {"type": "accountAttribute", "attributes": {"sourceName": "HR", "attributeName": "mail"}}},
+    {"identityAttributeName": "uid", "transformDefinition": {"type": "accountAttribute", "attributes": {"sourceName": "HR", "attributeName": "id"}}},
+    {"transformDefinition": {"type": "static", "attributes": {"value": "unnamed"}}}
+  ]
+}`
+	cfg, diags := identityAttributeConfigToApi(jsontypes.NewNormalizedValue(raw))
+	if diags.HasError() {
+		t.Fatalf("identityAttributeConfigToApi returned diagnostics: %v", diags)
+	}
+
+	config := identityProfileDefaultAttributeConfigDataSourceModel{IdentityProfileId: types.StringValue("profile-id")}
+	model, diags := defaultAttributeConfigToModel(ctx, cfg, config)
+	if diags.HasError() {
+		t.Fatalf("defaultAttributeConfigToModel returned diagnostics: %v", diags)
+	}
+
+	if model.IdentityProfileId.ValueString() != "profile-id" {
+		t.Errorf("IdentityProfileId = %q, want config value preserved", model.IdentityProfileId.ValueString())
+	}
+	if !model.Enabled.ValueBool() {
+		t.Error("Enabled = false, want true")
+	}
+
+	// identity_attribute_config must round-trip through the resource's own
+	// decoder unchanged, so it can be passed straight to the resource.
+	roundTrip, diags := identityAttributeConfigToApi(model.IdentityAttributeConfig)
+	if diags.HasError() {
+		t.Fatalf("identity_attribute_config does not decode as a resource value: %v", diags)
+	}
+	if len(roundTrip.AttributeTransforms) != 3 {
+		t.Errorf("len(AttributeTransforms) = %d, want 3", len(roundTrip.AttributeTransforms))
+	}
+
+	var transforms map[string]string
+	diags = model.AttributeTransforms.ElementsAs(ctx, &transforms, false)
+	if diags.HasError() {
+		t.Fatalf("AttributeTransforms.ElementsAs returned diagnostics: %v", diags)
+	}
+	if len(transforms) != 2 {
+		t.Fatalf("len(attribute_transforms) = %d, want 2 (unnamed entry skipped)", len(transforms))
+	}
+	var email map[string]interface{}
+	if err := json.Unmarshal([]byte(transforms["email"]), &email); err != nil {
+		t.Fatalf("attribute_transforms[\"email\"] is not valid JSON: %v", err)
+	}
+	if email["identityAttributeName"] != "email" {
+		t.Errorf("attribute_transforms[\"email\"].identityAttributeName = %v, want email", email["identityAttributeName"])
+	}
+}
+
+func TestDefaultAttributeConfigToModel_Nil(t *testing.T) {
+	model, diags := defaultAttributeConfigToModel(context.Background(), nil, identityProfileDefaultAttributeConfigDataSourceModel{})
+	if diags.HasError() {
+		t.Fatalf("defaultAttributeConfigToModel returned diagnostics: %v", diags)
+	}
+	if !model.IdentityAttributeConfig.IsNull() {
+		t.Errorf("IdentityAttributeConfig = %v, want null", model.IdentityAttributeConfig)
+	}
+	if !model.Enabled.IsNull() {
+		t.Errorf("Enabled = %v, want null", model.Enabled)
+	}
+	if len(model.AttributeTransforms.Elements()) != 0 {
+		t.Errorf("AttributeTransforms = %v, want empty", model.AttributeTransforms)
+	}
+}
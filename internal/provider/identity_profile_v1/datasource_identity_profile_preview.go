@@ -0,0 +1,247 @@
+// This file implements identitynow_identity_profile_preview_v1, one of the
+// follow-ups deferred in resource_identity_profile.go's package doc
+// ("identity-preview"). It is hand-written (no codegen): POST
+// /identity-profiles/v1/identity-preview is a side-effect-free evaluation
+// endpoint, not a CRUD object, so a data source is the natural shape - it
+// lets a plan (typically with check blocks) assert on what a proposed
+// identity_attribute_config would produce for a sample identity without
+// saving anything.
+//
+// identity_attribute_config is the exact same jsontypes.Normalized JSON
+// string as identitynow_identity_profile_v1's attribute of the same name and
+// is decoded with the same identityAttributeConfigToApi helper, so a value
+// can be passed straight from the resource (or its jsonencode source) into
+// this data source.
+package identity_profile_v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/identity_profiles"
+)
+
+var (
+	_ datasource.DataSource              = (*identityProfilePreviewDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*identityProfilePreviewDataSource)(nil)
+)
+
+func NewIdentityProfilePreviewDataSource() datasource.DataSource {
+	return &identityProfilePreviewDataSource{}
+}
+
+type identityProfilePreviewDataSource struct {
+	client *sailpoint.APIClient
+}
+
+type identityProfilePreviewDataSourceModel struct {
+	IdentityId              types.String         `tfsdk:"identity_id"`
+	IdentityAttributeConfig jsontypes.Normalized `tfsdk:"identity_attribute_config"`
+	IdentityName            types.String         `tfsdk:"identity_name"`
+	Attributes              types.Map            `tfsdk:"attributes"`
+	PreviewAttributes       types.List           `tfsdk:"preview_attributes"`
+	HasErrors               types.Bool           `tfsdk:"has_errors"`
+}
+
+// identityAttributePreviewModel mirrors one IdentityAttributePreview, with
+// errorMessages flattened to their text.
+type identityAttributePreviewModel struct {
+	Name          types.String `tfsdk:"name"`
+	Value         types.String `tfsdk:"value"`
+	PreviousValue types.String `tfsdk:"previous_value"`
+	ErrorMessages types.List   `tfsdk:"error_messages"`
+}
+
+func identityAttributePreviewAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":           types.StringType,
+		"value":          types.StringType,
+		"previous_value": types.StringType,
+		"error_messages": types.ListType{ElemType: types.StringType},
+	}
+}
+
+func (d *identityProfilePreviewDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity_profile_preview_v1"
+}
+
+func (d *identityProfilePreviewDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Previews the identity attribute values a proposed identity attribute config would produce for one identity, without saving anything.",
+		MarkdownDescription: "Previews the identity attribute values a proposed `identity_attribute_config` would produce for one " +
+			"identity, via `POST /identity-profiles/v1/identity-preview`. Nothing is saved, which makes this data source " +
+			"suitable for `check` blocks that assert new transform wiring before it is applied to an Identity Profile.\n\n" +
+			"~> This is a `_v1` pilot data source.",
+		Attributes: map[string]schema.Attribute{
+			"identity_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "ID of the sample identity to evaluate the config against.",
+				MarkdownDescription: "ID of the sample identity to evaluate the config against.",
+			},
+			"identity_attribute_config": schema.StringAttribute{
+				CustomType:  jsontypes.NormalizedType{},
+				Required:    true,
+				Description: "Proposed identity attribute config as JSON, in the same {enabled, attributeTransforms} shape as identitynow_identity_profile_v1's identity_attribute_config.",
+				MarkdownDescription: "Proposed identity attribute config as JSON, in the same `{enabled, attributeTransforms: [...]}` shape " +
+					"as `identitynow_identity_profile_v1`'s `identity_attribute_config`.",
+			},
+			"identity_name": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Display name of the sample identity.",
+				MarkdownDescription: "Display name of the sample identity.",
+			},
+			"attributes": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				Description:         "Previewed attribute values keyed by identity attribute name.",
+				MarkdownDescription: "Previewed attribute values keyed by identity attribute name. Attributes that evaluated to no value are omitted.",
+			},
+			"preview_attributes": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Every previewed attribute, including its previous value and any evaluation errors, in API order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Identity attribute name.",
+						},
+						"value": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Value derived by the proposed config.",
+						},
+						"previous_value": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The identity's current value of the attribute.",
+						},
+						"error_messages": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							MarkdownDescription: "Text of any errors encountered while evaluating the attribute.",
+						},
+					},
+				},
+			},
+			"has_errors": schema.BoolAttribute{
+				Computed:            true,
+				Description:         "True when any previewed attribute reported an evaluation error.",
+				MarkdownDescription: "`true` when any previewed attribute reported an evaluation error.",
+			},
+		},
+	}
+}
+
+func (d *identityProfilePreviewDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = cp.GetClient()
+}
+
+func (d *identityProfilePreviewDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config identityProfilePreviewDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cfg, diags := identityAttributeConfigToApi(config.IdentityAttributeConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	identityID := config.IdentityId.ValueString()
+	body := identity_profiles.NewIdentityPreviewRequest()
+	body.SetIdentityId(identityID)
+	if cfg != nil {
+		body.SetIdentityAttributeConfig(*cfg)
+	}
+
+	tflog.Debug(ctx, "Generating Identity Profile preview", map[string]interface{}{"identity_id": identityID})
+
+	dto, httpResp, err := d.client.IdentityProfilesAPI.
+		GenerateIdentityPreviewV1(ctx).
+		IdentityPreviewRequest(*body).
+		Execute()
+	if err != nil {
+		tflog.Error(ctx, "Error generating Identity Profile preview", map[string]interface{}{"identity_id": identityID, "error": err.Error()})
+		resp.Diagnostics.AddError("Error generating Identity Profile preview", errDetail(err, httpResp))
+		return
+	}
+
+	state, diags := identityProfilePreviewDtoToModel(ctx, dto, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func identityProfilePreviewDtoToModel(ctx context.Context, dto *identity_profiles.IdentityPreviewResponse, config identityProfilePreviewDataSourceModel) (identityProfilePreviewDataSourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	model := config
+	model.IdentityName = types.StringNull()
+	model.HasErrors = types.BoolValue(false)
+
+	values := map[string]string{}
+	previews := []identityAttributePreviewModel{}
+	if dto != nil {
+		if identity, ok := dto.GetIdentityOk(); ok && identity != nil {
+			model.IdentityName = types.StringPointerValue(identity.Name)
+		}
+		for i := range dto.PreviewAttributes {
+			p := dto.PreviewAttributes[i]
+
+			messages := make([]string, 0, len(p.ErrorMessages))
+			for j := range p.ErrorMessages {
+				if text := p.ErrorMessages[j].GetText(); text != "" {
+					messages = append(messages, text)
+				}
+			}
+			if len(messages) > 0 {
+				model.HasErrors = types.BoolValue(true)
+			}
+			errorMessages, d := types.ListValueFrom(ctx, types.StringType, messages)
+			diags.Append(d...)
+
+			previews = append(previews, identityAttributePreviewModel{
+				Name:          types.StringPointerValue(p.Name),
+				Value:         types.StringPointerValue(p.Value),
+				PreviousValue: types.StringPointerValue(p.PreviousValue),
+				ErrorMessages: errorMessages,
+			})
+			if p.Name != nil && p.Value != nil {
+				values[*p.Name] = *p.Value
+			}
+		}
+	}
+
+	attributes, d := types.MapValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+	model.Attributes = attributes
+
+	previewList, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: identityAttributePreviewAttrTypes()}, previews)
+	diags.Append(d...)
+	model.PreviewAttributes = previewList
+
+	return model, diags
+}
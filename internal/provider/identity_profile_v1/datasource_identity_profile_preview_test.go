@@ -0,0 +1,51 @@
+package identity_profile_v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sailpoint-oss/golang-sdk/v3/identity_profiles"
+)
+
+func TestIdentityProfilePreviewDtoToModel(t *testing.T) {
+	ctx := context.Background()
+	strPtr := func(s string) *string { return &s }
+
+	dto := identity_profiles.NewIdentityPreviewResponse()
+	dto.PreviewAttributes = []identity_profiles.IdentityAttributePreview{
+		{Name: strPtr("email"), Value: strPtr("new@example.com"), PreviousValue: strPtr("old@example.com")},
+		{
+			Name:          strPtr("department"),
+			ErrorMessages: []identity_profiles.ErrorMessageDto{{Text: strPtr("attribute not found on source")}},
+		},
+	}
+
+	config := identityProfilePreviewDataSourceModel{
+		IdentityId:              types.StringValue("identity-id"),
+		IdentityAttributeConfig: jsontypes.NewNormalizedValue(`{"enabled":true,"attributeTransforms":[]}`),
+	}
+
+	model, diags := identityProfilePreviewDtoToModel(ctx, dto, config)
+	if diags.HasError() {
+		t.Fatalf("identityProfilePreviewDtoToModel returned diagnostics: %v", diags)
+	}
+
+	if model.IdentityId.ValueString() != "identity-id" {
+		t.Errorf("IdentityId = %q, want config value preserved", model.IdentityId.ValueString())
+	}
+	if !model.HasErrors.ValueBool() {
+		t.Error("HasErrors = false, want true")
+	}
+	attrs := model.Attributes.Elements()
+	if len(attrs) != 1 {
+		t.Fatalf("Attributes has %d entries, want 1 (department has no value)", len(attrs))
+	}
+	if v, ok := attrs["email"].(types.String); !ok || v.ValueString() != "new@example.com" {
+		t.Errorf("Attributes[email] = %v, want %q", attrs["email"], "new@example.com")
+	}
+	if len(model.PreviewAttributes.Elements()) != 2 {
+		t.Errorf("PreviewAttributes has %d entries, want 2", len(model.PreviewAttributes.Elements()))
+	}
+}
@@ -0,0 +1,314 @@
+// This file implements identitynow_identity_profiles_export_v1, one half of
+// the export/import pair deferred in resource_identity_profile.go's package
+// doc. GET /identity-profiles/v1/export returns identity profiles as
+// sp-config "exported objects" ({version, self, object}), the same document
+// POST /identity-profiles/v1/import (identitynow_identity_profiles_import_v1)
+// accepts, so the two are designed to be chained for sandbox-to-production
+// promotion.
+//
+// The SDK's exported-object models are only ever marshalled straight back
+// to JSON here: the bundle is handled as generic JSON (see
+// normalizeIdentityProfilesBundle) so both halves agree on exactly one
+// canonical form regardless of how the SDK types its nested "object".
+package identity_profile_v1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+)
+
+// identityProfilesExportVolatileFields are server-maintained object fields
+// stripped from the normalized bundle. They change on every refresh or
+// aggregation without any configuration change, and the import endpoint
+// ignores them anyway.
+var identityProfilesExportVolatileFields = []string{"created", "modified", "identityCount"}
+
+var (
+	_ datasource.DataSource              = (*identityProfilesExportDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*identityProfilesExportDataSource)(nil)
+)
+
+func NewIdentityProfilesExportDataSource() datasource.DataSource {
+	return &identityProfilesExportDataSource{}
+}
+
+type identityProfilesExportDataSource struct {
+	client *sailpoint.APIClient
+}
+
+type identityProfilesExportDataSourceModel struct {
+	Filters    types.String         `tfsdk:"filters"`
+	Sorters    types.String         `tfsdk:"sorters"`
+	Id         types.String         `tfsdk:"id"`
+	ExportJson jsontypes.Normalized `tfsdk:"export_json"`
+	Profiles   types.List           `tfsdk:"profiles"`
+}
+
+// identityProfileBundleEntryModel summarizes one exported object of a
+// bundle by its "self" reference.
+type identityProfileBundleEntryModel struct {
+	Id      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Version types.Int64  `tfsdk:"version"`
+}
+
+func identityProfileBundleEntryAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":      types.StringType,
+		"name":    types.StringType,
+		"version": types.Int64Type,
+	}
+}
+
+func (d *identityProfilesExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity_profiles_export_v1"
+}
+
+func (d *identityProfilesExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exports Identity Profiles from IdentityNow/ISC as a normalized sp-config JSON bundle.",
+		MarkdownDescription: "Exports Identity Profiles from IdentityNow/ISC via `GET /identity-profiles/v1/export` as a " +
+			"normalized sp-config JSON bundle, ready to be passed to `identitynow_identity_profiles_import_v1` (typically " +
+			"through a second, aliased provider configured for the target tenant).\n\n" +
+			"~> This is a `_v1` pilot data source.",
+		Attributes: map[string]schema.Attribute{
+			"filters": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Filter expression limiting which identity profiles are exported (e.g. `name eq \"Employees\"`). " +
+					"Filtering is supported for `id`, `name`, and `priority` (eq, ne).",
+			},
+			"sorters": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Sort expression for the API query (`id`, `name`, `priority`). The normalized bundle is " +
+					"always re-sorted by profile name, so this only matters in combination with `filters`.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 of `export_json`; changes only when the exported content changes.",
+			},
+			"export_json": schema.StringAttribute{
+				CustomType: jsontypes.NormalizedType{},
+				Computed:   true,
+				MarkdownDescription: "The exported bundle as a JSON array of `{version, self, object}` entries, sorted by " +
+					"profile name, with the volatile `created`, `modified`, and `identityCount` object fields removed.",
+			},
+			"profiles": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "One summary per exported identity profile, in `export_json` order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Identity profile ID in the exporting tenant.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Identity profile name.",
+						},
+						"version": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "sp-config object version.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *identityProfilesExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = cp.GetClient()
+}
+
+func (d *identityProfilesExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config identityProfilesExportDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Exporting Identity Profiles", map[string]interface{}{"filters": config.Filters.ValueString()})
+
+	var entries []interface{}
+	for offset := int32(0); ; offset += identityProfilesListMaxLimit {
+		apiReq := d.client.IdentityProfilesAPI.ExportIdentityProfilesV1(ctx).
+			Limit(identityProfilesListMaxLimit).
+			Offset(offset)
+		if !config.Filters.IsNull() && !config.Filters.IsUnknown() {
+			apiReq = apiReq.Filters(config.Filters.ValueString())
+		}
+		if !config.Sorters.IsNull() && !config.Sorters.IsUnknown() {
+			apiReq = apiReq.Sorters(config.Sorters.ValueString())
+		}
+
+		page, httpResp, err := apiReq.Execute()
+		if err != nil {
+			tflog.Error(ctx, "Error exporting Identity Profiles", map[string]interface{}{"offset": offset, "error": err.Error()})
+			resp.Diagnostics.AddError("Error exporting Identity Profiles", errDetail(err, httpResp))
+			return
+		}
+
+		raw, err := json.Marshal(page)
+		if err != nil {
+			resp.Diagnostics.AddError("Error exporting Identity Profiles", fmt.Sprintf("Could not encode export page: %s", err))
+			return
+		}
+		var decoded []interface{}
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			resp.Diagnostics.AddError("Error exporting Identity Profiles", fmt.Sprintf("Could not decode export page: %s", err))
+			return
+		}
+		entries = append(entries, decoded...)
+
+		if len(page) < identityProfilesListMaxLimit {
+			break
+		}
+	}
+
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		resp.Diagnostics.AddError("Error exporting Identity Profiles", fmt.Sprintf("Could not encode export bundle: %s", err))
+		return
+	}
+	bundle, summaries, err := normalizeIdentityProfilesBundle(raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Error exporting Identity Profiles", err.Error())
+		return
+	}
+
+	config.Id = types.StringValue(identityProfilesBundleHash(bundle))
+	config.ExportJson = jsontypes.NewNormalizedValue(bundle)
+
+	profiles, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: identityProfileBundleEntryAttrTypes()}, summaries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.Profiles = profiles
+
+	tflog.Debug(ctx, "Exported Identity Profiles", map[string]interface{}{"count": len(summaries)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// identityProfileBundleRef is the identifying part of one bundle entry,
+// taken from "self" and falling back to "object" for hand-written bundles.
+type identityProfileBundleRef struct {
+	Id      string
+	Name    string
+	Version *int64
+}
+
+// normalizeIdentityProfilesBundle parses an export/import bundle, strips
+// identityProfilesExportVolatileFields from each entry's "object", sorts
+// entries by profile name (then id) and re-encodes the result with sorted
+// keys, so two exports of the same configuration are byte-identical.
+func normalizeIdentityProfilesBundle(raw []byte) (string, []identityProfileBundleEntryModel, error) {
+	var entries []map[string]interface{}
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return "", nil, fmt.Errorf("identity profile bundle must be a JSON array of exported objects: %w", err)
+	}
+	if entries == nil {
+		entries = []map[string]interface{}{}
+	}
+
+	refs := make([]identityProfileBundleRef, len(entries))
+	for i, entry := range entries {
+		if entry == nil {
+			return "", nil, fmt.Errorf("identity profile bundle entry %d is null", i)
+		}
+		if object, ok := entry["object"].(map[string]interface{}); ok {
+			for _, field := range identityProfilesExportVolatileFields {
+				delete(object, field)
+			}
+		}
+		refs[i] = identityProfileBundleEntryRef(entry)
+		if refs[i].Name == "" && refs[i].Id == "" {
+			return "", nil, fmt.Errorf("identity profile bundle entry %d has neither a self nor an object id/name", i)
+		}
+	}
+
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ra, rb := refs[order[a]], refs[order[b]]
+		if ra.Name != rb.Name {
+			return ra.Name < rb.Name
+		}
+		return ra.Id < rb.Id
+	})
+
+	sorted := make([]map[string]interface{}, 0, len(entries))
+	summaries := make([]identityProfileBundleEntryModel, 0, len(entries))
+	for _, i := range order {
+		sorted = append(sorted, entries[i])
+		summary := identityProfileBundleEntryModel{
+			Id:      types.StringValue(refs[i].Id),
+			Name:    types.StringValue(refs[i].Name),
+			Version: types.Int64PointerValue(refs[i].Version),
+		}
+		if refs[i].Id == "" {
+			summary.Id = types.StringNull()
+		}
+		summaries = append(summaries, summary)
+	}
+
+	out, err := json.Marshal(sorted)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not encode identity profile bundle: %w", err)
+	}
+	return string(out), summaries, nil
+}
+
+func identityProfileBundleEntryRef(entry map[string]interface{}) identityProfileBundleRef {
+	var ref identityProfileBundleRef
+	for _, key := range []string{"self", "object"} {
+		block, ok := entry[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v, ok := block["id"].(string); ok && ref.Id == "" {
+			ref.Id = v
+		}
+		if v, ok := block["name"].(string); ok && ref.Name == "" {
+			ref.Name = v
+		}
+	}
+	if v, ok := entry["version"].(float64); ok {
+		version := int64(v)
+		ref.Version = &version
+	}
+	return ref
+}
+
+func identityProfilesBundleHash(bundle string) string {
+	sum := sha256.Sum256([]byte(bundle))
+	return hex.EncodeToString(sum[:])
+}
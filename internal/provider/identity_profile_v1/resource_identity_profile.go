@@ -90,20 +90,36 @@
 // gone. Delete() polls this task status with bounded retries/backoff before
 // returning.
 //
+// Opt-in identity processing: identity_attribute_config changes otherwise
+// only reach existing identities on the next scheduled refresh. When
+// process_identities_on_change is true, Update() follows a PATCH that
+// actually changed identity_attribute_config with SyncIdentityProfile
+// (POST /identity-profiles/v1/{id}/process-identities) and polls the
+// returned task the same way Delete() does, bounded by
+// process_identities_timeout (see resource_identity_profile_process.go).
+// Both knobs are provider-local and never sent to the API.
+//
+// Export/import (ExportIdentityProfiles/ImportIdentityProfiles) live in
+// their own data source/resource pair - see
+// datasource_identity_profiles_export.go and
+// resource_identity_profiles_import.go.
+//
+// GetDefaultIdentityAttributeConfig (a template-fetch helper, not part of
+// this resource's own lifecycle) is exposed as its own data source - see
+// datasource_identity_profile_default_attribute_config.go.
+//
+// Delete goes through the bulk DeleteIdentityProfiles endpoint when the
+// provider batches deletes - see resource_identity_profile_bulk_delete.go.
+//
 // Deliberately deferred (out of scope for this pilot): lifecycle-states
 // (own sub-resource service, mirrors governance_group_v1's
-// members/connections precedent), identity-preview
-// (ShowGenerateIdentityPreview), export/import (ExportIdentityProfiles/
-// ImportIdentityProfiles), process-identities/sync
-// (SyncIdentityProfile - triggers an on-demand refresh, not a CRUD
-// operation), the bulk DeleteIdentityProfiles endpoint, and
-// GetDefaultIdentityAttributeConfig (a template-fetch helper, not part of
-// this resource's own lifecycle).
+// members/connections precedent).
 package identity_profile_v1
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -126,6 +142,7 @@ import (
 // this package needing to import it (which would create an import cycle).
 type clientProvider interface {
 	GetClient() *sailpoint.APIClient
+	GetDeleteCoalescer() *util.DeleteCoalescer
 }
 
 var (
@@ -139,7 +156,8 @@ func NewIdentityProfileResource() resource.Resource {
 }
 
 type identityProfileResource struct {
-	client *sailpoint.APIClient
+	client  *sailpoint.APIClient
+	deletes *util.DeleteCoalescer
 }
 
 // identityProfileResourceModel mirrors
@@ -163,6 +181,8 @@ type identityProfileResourceModel struct {
 	Name                             types.String                                                    `tfsdk:"name"`
 	Owner                            resource_identity_profile.OwnerValue                            `tfsdk:"owner"`
 	Priority                         types.Int64                                                     `tfsdk:"priority"`
+	ProcessIdentitiesOnChange        types.Bool                                                      `tfsdk:"process_identities_on_change"`
+	ProcessIdentitiesTimeout         types.String                                                    `tfsdk:"process_identities_timeout"`
 }
 
 func (r *identityProfileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -176,9 +196,10 @@ func (r *identityProfileResource) Schema(ctx context.Context, req resource.Schem
 		"in IdentityNow/ISC - the governance object that binds an authoritative source, defines the identity attribute " +
 		"mapping, and assigns lifecycle states for the identities it aggregates.\n\n" +
 		"~> This is a `_v1` pilot resource - see the \"Known Limitations & Live Testing Notes\" section below before " +
-		"relying on it in production configurations. Lifecycle states, identity preview, export/import, on-demand " +
-		"sync, and several other sub-resource endpoints are deliberately deferred (see the package doc)."
+		"relying on it in production configurations. Lifecycle states and several other sub-resource endpoints " +
+		"are deliberately deferred (see the package doc)."
 	applyIdentityAttributeConfigField(&resp.Schema.Attributes, false)
+	applyProcessIdentitiesFields(&resp.Schema.Attributes)
 	applyIdentityProfileUseStateForUnknown(&resp.Schema)
 }
 
@@ -196,6 +217,7 @@ func (r *identityProfileResource) Configure(ctx context.Context, req resource.Co
 		return
 	}
 	r.client = cp.GetClient()
+	r.deletes = cp.GetDeleteCoalescer()
 }
 
 func (r *identityProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -266,6 +288,7 @@ func (r *identityProfileResource) Read(ctx context.Context, req resource.ReadReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	defaultProcessIdentitiesFields(&newState)
 
 	tflog.Debug(ctx, "Read Identity Profile", map[string]interface{}{"id": newState.Id.ValueString()})
 
@@ -364,7 +387,28 @@ func (r *identityProfileResource) Update(ctx context.Context, req resource.Updat
 
 	tflog.Info(ctx, "Updated Identity Profile", map[string]interface{}{"id": newState.Id.ValueString()})
 
+	// Persist the patched profile before (optionally) processing identities,
+	// so a processing failure or timeout still leaves state matching what
+	// was actually saved server-side.
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if identityAttributeConfigChanged && plan.ProcessIdentitiesOnChange.ValueBool() {
+		timeout, err := util.ParseWaitTimeout("process_identities_timeout", plan.ProcessIdentitiesTimeout, defaultProcessIdentitiesTimeout)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("process_identities_timeout"), "Invalid process_identities_timeout", err.Error())
+			return
+		}
+		if err := r.processIdentities(ctx, newState.Id.ValueString(), timeout); err != nil {
+			tflog.Error(ctx, "Error processing identities for Identity Profile", map[string]interface{}{"id": newState.Id.ValueString(), "error": err.Error()})
+			resp.Diagnostics.AddError(
+				"Error processing identities for Identity Profile",
+				fmt.Sprintf("The Identity Profile was updated, but processing its identities after the identity_attribute_config change failed: %s", err),
+			)
+		}
+	}
 }
 
 // identityProfileDeletePollAttempts/-Interval bound how long Delete() waits
@@ -388,6 +432,21 @@ func (r *identityProfileResource) Delete(ctx context.Context, req resource.Delet
 
 	tflog.Debug(ctx, "Deleting Identity Profile", map[string]interface{}{"id": state.Id.ValueString()})
 
+	if r.deletes.Enabled() {
+		err := r.deletes.Delete(ctx, identityProfileBulkDeleteKind, identityProfileBulkDeleteMax, state.Id.ValueString(), identityProfileBulkDelete(r.client))
+		if errors.Is(err, util.ErrBulkDeleteNotFound) {
+			tflog.Warn(ctx, "Identity Profile already absent on delete", map[string]interface{}{"id": state.Id.ValueString()})
+			return
+		}
+		if err != nil {
+			tflog.Error(ctx, "Error deleting Identity Profile", map[string]interface{}{"id": state.Id.ValueString(), "error": err.Error()})
+			resp.Diagnostics.AddError("Error deleting Identity Profile", err.Error())
+			return
+		}
+		tflog.Info(ctx, "Deleted Identity Profile", map[string]interface{}{"id": state.Id.ValueString()})
+		return
+	}
+
 	taskResult, httpResp, err := r.client.IdentityProfilesAPI.
 		DeleteIdentityProfileV1(ctx, state.Id.ValueString()).
 		Execute()
@@ -0,0 +1,54 @@
+// This file routes identitynow_identity_profile_v1's Delete through the
+// provider's util.DeleteCoalescer, so identity profiles destroyed in the
+// same apply are removed with one POST /identity-profiles/v1/bulk-delete.
+// The endpoint documents no batch limit; batches are capped at 50 so the
+// "id in (...)" check of which profiles still exist fits in one list page.
+// Like the single DELETE, it starts a background task, which is waited on
+// before each profile's result is decided by whether it still exists.
+package identity_profile_v1
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+const (
+	identityProfileBulkDeleteKind        = "identity_profiles"
+	identityProfileBulkDeleteMax         = 50
+	identityProfileBulkDeleteTaskTimeout = 10 * time.Minute
+)
+
+func identityProfileBulkDelete(client *sailpoint.APIClient) util.BulkDeleteFunc {
+	return util.BulkDeleteByTask(client, "Identity Profile", identityProfileBulkDeleteTaskTimeout,
+		func(ctx context.Context, ids []string) ([]string, error) {
+			live, httpResp, err := client.IdentityProfilesAPI.
+				ListIdentityProfilesV1(ctx).
+				Filters(util.FilterIdsIn(ids)).
+				Limit(identityProfileBulkDeleteMax).
+				Execute()
+			if err != nil {
+				return nil, errors.New(errDetail(err, httpResp))
+			}
+			out := make([]string, 0, len(live))
+			for i := range live {
+				out = append(out, live[i].GetId())
+			}
+			return out, nil
+		},
+		func(ctx context.Context, ids []string) (string, map[string]error, error) {
+			task, httpResp, err := client.IdentityProfilesAPI.
+				DeleteIdentityProfilesV1(ctx).
+				RequestBody(ids).
+				Execute()
+			if err != nil {
+				return "", nil, errors.New(errDetail(err, httpResp))
+			}
+			return task.GetId(), nil, nil
+		},
+	)
+}
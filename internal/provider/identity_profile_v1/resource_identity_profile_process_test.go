@@ -0,0 +1,79 @@
+package identity_profile_v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sailpoint-oss/golang-sdk/v3/identity_profiles"
+)
+
+func TestProcessIdentitiesTaskID(t *testing.T) {
+	tests := []struct {
+		name string
+		body map[string]interface{}
+		want string
+	}{
+		{name: "nil body", body: nil, want: ""},
+		{name: "empty body", body: map[string]interface{}{}, want: ""},
+		{name: "task result id", body: map[string]interface{}{"id": "task-1", "type": "QUARTZ"}, want: "task-1"},
+		{name: "taskId fallback", body: map[string]interface{}{"taskId": " task-2 "}, want: "task-2"},
+		{name: "blank id falls through", body: map[string]interface{}{"id": "  ", "taskId": "task-3"}, want: "task-3"},
+		{name: "non-string id", body: map[string]interface{}{"id": 42}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := processIdentitiesTaskID(tt.body); got != tt.want {
+				t.Errorf("processIdentitiesTaskID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDtoToModel_PreservesProcessIdentitiesFields guards that the
+// provider-local knobs survive a round trip through dtoToModel, since the
+// API response never carries them.
+func TestDtoToModel_PreservesProcessIdentitiesFields(t *testing.T) {
+	fallback := minimalModel()
+	fallback.ProcessIdentitiesOnChange = types.BoolValue(true)
+	fallback.ProcessIdentitiesTimeout = types.StringValue("1h")
+
+	id := "idprofile-id"
+	dto := &identity_profiles.IdentityProfile{
+		Id:   &id,
+		Name: *identity_profiles.NewNullableString(strPtr("test-identity-profile")),
+	}
+
+	model, diags := dtoToModel(context.Background(), dto, fallback)
+	if diags.HasError() {
+		t.Fatalf("dtoToModel returned diagnostics: %v", diags)
+	}
+	if !model.ProcessIdentitiesOnChange.ValueBool() {
+		t.Errorf("ProcessIdentitiesOnChange = %v, want true", model.ProcessIdentitiesOnChange)
+	}
+	if model.ProcessIdentitiesTimeout.ValueString() != "1h" {
+		t.Errorf("ProcessIdentitiesTimeout = %q, want %q", model.ProcessIdentitiesTimeout.ValueString(), "1h")
+	}
+}
+
+func TestDefaultProcessIdentitiesFields(t *testing.T) {
+	m := identityProfileResourceModel{
+		ProcessIdentitiesOnChange: types.BoolNull(),
+		ProcessIdentitiesTimeout:  types.StringNull(),
+	}
+	defaultProcessIdentitiesFields(&m)
+	if m.ProcessIdentitiesOnChange.IsNull() || m.ProcessIdentitiesOnChange.ValueBool() {
+		t.Errorf("ProcessIdentitiesOnChange = %v, want false", m.ProcessIdentitiesOnChange)
+	}
+	if m.ProcessIdentitiesTimeout.ValueString() != "30m" {
+		t.Errorf("ProcessIdentitiesTimeout = %q, want %q", m.ProcessIdentitiesTimeout.ValueString(), "30m")
+	}
+
+	m.ProcessIdentitiesOnChange = types.BoolValue(true)
+	m.ProcessIdentitiesTimeout = types.StringValue("5m")
+	defaultProcessIdentitiesFields(&m)
+	if !m.ProcessIdentitiesOnChange.ValueBool() || m.ProcessIdentitiesTimeout.ValueString() != "5m" {
+		t.Errorf("configured values were overwritten: %v / %q", m.ProcessIdentitiesOnChange, m.ProcessIdentitiesTimeout.ValueString())
+	}
+}
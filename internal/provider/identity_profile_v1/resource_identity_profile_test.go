@@ -32,6 +32,8 @@ func minimalModel() identityProfileResourceModel {
 		Name:                             types.StringValue("test-identity-profile"),
 		Owner:                            resource_identity_profile.NewOwnerValueNull(),
 		Priority:                         types.Int64Null(),
+		ProcessIdentitiesOnChange:        types.BoolValue(false),
+		ProcessIdentitiesTimeout:         types.StringValue("30m"),
 	}
 }
 
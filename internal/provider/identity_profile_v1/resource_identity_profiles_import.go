@@ -0,0 +1,510 @@
+// This file implements identitynow_identity_profiles_import_v1, the other
+// half of the export/import pair (see datasource_identity_profiles_export.go).
+// POST /identity-profiles/v1/import applies a whole sp-config bundle in one
+// request, creating or updating profiles on the target tenant; it is not a
+// CRUD object with its own id or read endpoint, so this resource follows
+// the same trigger-style shape as source_load_entitlement_wait_v1:
+//   - Create/Update (whenever "bundle" changes) run the import.
+//   - Read is a no-op; the imported profiles can be read back with
+//     identitynow_identity_profile_v1/identitynow_identity_profiles_v1.
+//   - Delete only forgets the import - the imported profiles are left in
+//     place, since the API has no way to undo an import.
+//
+// "planned_changes" is filled in by ModifyPlan from the target tenant's
+// current profile list whenever the bundle changes, so `terraform plan`
+// shows which profiles the import would create and which it would update
+// before anything is sent.
+package identity_profile_v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/identity_profiles"
+)
+
+const (
+	identityProfileImportActionCreate = "CREATE"
+	identityProfileImportActionUpdate = "UPDATE"
+)
+
+var (
+	_ resource.Resource               = (*identityProfilesImportResource)(nil)
+	_ resource.ResourceWithConfigure  = (*identityProfilesImportResource)(nil)
+	_ resource.ResourceWithModifyPlan = (*identityProfilesImportResource)(nil)
+)
+
+func NewIdentityProfilesImportResource() resource.Resource {
+	return &identityProfilesImportResource{}
+}
+
+type identityProfilesImportResource struct {
+	client *sailpoint.APIClient
+}
+
+type identityProfilesImportResourceModel struct {
+	Id              types.String         `tfsdk:"id"`
+	Bundle          jsontypes.Normalized `tfsdk:"bundle"`
+	PlannedChanges  types.List           `tfsdk:"planned_changes"`
+	ImportedObjects types.List           `tfsdk:"imported_objects"`
+	Infos           types.List           `tfsdk:"infos"`
+	Warnings        types.List           `tfsdk:"warnings"`
+}
+
+// identityProfilePlannedChangeModel is one row of the plan-time diff.
+type identityProfilePlannedChangeModel struct {
+	Name     types.String `tfsdk:"name"`
+	SourceId types.String `tfsdk:"source_id"`
+	TargetId types.String `tfsdk:"target_id"`
+	Action   types.String `tfsdk:"action"`
+}
+
+func identityProfilePlannedChangeAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":      types.StringType,
+		"source_id": types.StringType,
+		"target_id": types.StringType,
+		"action":    types.StringType,
+	}
+}
+
+// importedObjectModel mirrors one ImportObject of the import response.
+type importedObjectModel struct {
+	Type types.String `tfsdk:"type"`
+	Id   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func importedObjectAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"type": types.StringType,
+		"id":   types.StringType,
+		"name": types.StringType,
+	}
+}
+
+// identityProfilesImportResult is the subset of the import response body
+// this resource surfaces. The response is decoded from its JSON form rather
+// than through the SDK model so the message "details" maps don't need
+// converting.
+type identityProfilesImportResult struct {
+	Infos           []identityProfilesImportMessage `json:"infos"`
+	Warnings        []identityProfilesImportMessage `json:"warnings"`
+	Errors          []identityProfilesImportMessage `json:"errors"`
+	ImportedObjects []struct {
+		Type string `json:"type"`
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"importedObjects"`
+}
+
+type identityProfilesImportMessage struct {
+	Key  string `json:"key"`
+	Text string `json:"text"`
+}
+
+func (m identityProfilesImportMessage) String() string {
+	if m.Key == "" {
+		return m.Text
+	}
+	return fmt.Sprintf("%s: %s", m.Key, m.Text)
+}
+
+func (r *identityProfilesImportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity_profiles_import_v1"
+}
+
+func (r *identityProfilesImportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	messages := func(desc string) schema.ListAttribute {
+		return schema.ListAttribute{
+			ElementType:         types.StringType,
+			Computed:            true,
+			MarkdownDescription: desc,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Imports an Identity Profile export bundle into IdentityNow/ISC, showing which profiles would be created or updated at plan time.",
+		MarkdownDescription: "Imports an Identity Profile sp-config bundle (as produced by `identitynow_identity_profiles_export_v1`) " +
+			"via `POST /identity-profiles/v1/import`, creating or updating the bundled profiles in the target tenant. " +
+			"`planned_changes` is computed at plan time from the target tenant's current profiles, so the plan shows " +
+			"which profiles would be created and which updated.\n\n" +
+			"Destroying this resource only removes it from state; imported profiles are left in place.\n\n" +
+			"~> This is a `_v1` pilot resource - see the \"Known Limitations & Live Testing Notes\" section below.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 of the normalized `bundle`.",
+			},
+			"bundle": schema.StringAttribute{
+				CustomType: jsontypes.NormalizedType{},
+				Required:   true,
+				MarkdownDescription: "The bundle to import: a JSON array of `{version, self, object}` exported objects, " +
+					"normally `data.identitynow_identity_profiles_export_v1.<name>.export_json`. Any change re-runs the import.",
+			},
+			"planned_changes": schema.ListNestedAttribute{
+				Computed: true,
+				MarkdownDescription: "Per-profile preview of what the import does, computed at plan time whenever `bundle` " +
+					"changes. Profiles are matched to the target tenant by name, then by ID.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Identity profile name from the bundle.",
+						},
+						"source_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Identity profile ID from the bundle (the exporting tenant's ID).",
+						},
+						"target_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the matching profile in the target tenant; null when the profile will be created.",
+						},
+						"action": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "`CREATE` or `UPDATE`.",
+						},
+					},
+				},
+			},
+			"imported_objects": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Objects the last import created or updated, as reported by the API.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "DTO type of the object, e.g. `IDENTITY_PROFILE`.",
+						},
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the object in the target tenant.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Display name of the object.",
+						},
+					},
+				},
+			},
+			"infos":    messages("Informational messages returned by the last import."),
+			"warnings": messages("Warning messages returned by the last import. They are also reported as Terraform warnings."),
+		},
+	}
+}
+
+func (r *identityProfilesImportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = cp.GetClient()
+}
+
+func (r *identityProfilesImportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Destroy plans have nothing to preview; an unconfigured provider (e.g.
+	// during validate) can't look up the target tenant.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan identityProfilesImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Bundle.IsUnknown() || plan.Bundle.IsNull() {
+		return
+	}
+
+	bundle, refs, err := parseIdentityProfilesBundle(plan.Bundle)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("bundle"), "Invalid identity profile bundle", err.Error())
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state identityProfilesImportResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.Id.ValueString() == identityProfilesBundleHash(bundle) {
+			// Unchanged bundle: the framework keeps the prior state as the
+			// plan, and re-previewing now would only flip every row to
+			// UPDATE and produce a perpetual diff.
+			return
+		}
+	}
+
+	changes, diags := r.plannedChanges(ctx, refs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = types.StringValue(identityProfilesBundleHash(bundle))
+	plan.PlannedChanges = changes
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *identityProfilesImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan identityProfilesImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags := r.importBundle(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *identityProfilesImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state identityProfilesImportResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *identityProfilesImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan identityProfilesImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags := r.importBundle(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *identityProfilesImportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state identityProfilesImportResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Removing Identity Profile import from state; imported profiles are left in place", map[string]interface{}{"id": state.Id.ValueString()})
+}
+
+// importBundle sends the planned bundle and returns the resulting state.
+// Any error message in the import response fails the apply.
+func (r *identityProfilesImportResource) importBundle(ctx context.Context, plan identityProfilesImportResourceModel) (identityProfilesImportResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	state := plan
+
+	bundle, refs, err := parseIdentityProfilesBundle(plan.Bundle)
+	if err != nil {
+		diags.AddAttributeError(path.Root("bundle"), "Invalid identity profile bundle", err.Error())
+		return state, diags
+	}
+	state.Id = types.StringValue(identityProfilesBundleHash(bundle))
+
+	// The bundle was unknown at plan time (e.g. it comes from a data
+	// source read during apply), so preview it now, before importing.
+	if plan.PlannedChanges.IsUnknown() || plan.PlannedChanges.IsNull() {
+		changes, d := r.plannedChanges(ctx, refs)
+		diags.Append(d...)
+		if diags.HasError() {
+			return state, diags
+		}
+		state.PlannedChanges = changes
+	}
+
+	var objects []identity_profiles.IdentityProfileExportedObject
+	if err := json.Unmarshal([]byte(bundle), &objects); err != nil {
+		diags.AddAttributeError(path.Root("bundle"), "Invalid identity profile bundle", fmt.Sprintf("Could not decode bundle into exported objects: %s", err))
+		return state, diags
+	}
+
+	tflog.Info(ctx, "Importing Identity Profiles", map[string]interface{}{"id": state.Id.ValueString(), "count": len(objects)})
+
+	apiResp, httpResp, err := r.client.IdentityProfilesAPI.
+		ImportIdentityProfilesV1(ctx).
+		IdentityProfileExportedObject(objects).
+		Execute()
+	if err != nil {
+		tflog.Error(ctx, "Error importing Identity Profiles", map[string]interface{}{"error": err.Error()})
+		diags.AddError("Error importing Identity Profiles", errDetail(err, httpResp))
+		return state, diags
+	}
+
+	var result identityProfilesImportResult
+	if apiResp != nil {
+		raw, err := json.Marshal(apiResp)
+		if err == nil {
+			err = json.Unmarshal(raw, &result)
+		}
+		if err != nil {
+			diags.AddError("Error importing Identity Profiles", fmt.Sprintf("Could not decode import response: %s", err))
+			return state, diags
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		texts := make([]string, 0, len(result.Errors))
+		for _, m := range result.Errors {
+			texts = append(texts, "- "+m.String())
+		}
+		diags.AddError(
+			"Error importing Identity Profiles",
+			"The import endpoint reported errors; objects it could import may still have been changed:\n"+strings.Join(texts, "\n"),
+		)
+		return state, diags
+	}
+	for _, m := range result.Warnings {
+		diags.AddWarning("Identity Profile import warning", m.String())
+	}
+
+	state, d := identityProfilesImportResultToModel(ctx, result, state)
+	diags.Append(d...)
+
+	tflog.Info(ctx, "Imported Identity Profiles", map[string]interface{}{"id": state.Id.ValueString(), "imported_objects": len(result.ImportedObjects)})
+	return state, diags
+}
+
+// plannedChanges matches each bundle entry against the target tenant's
+// current profiles, by name first (IDs differ between tenants) and then by
+// ID (re-importing into the exporting tenant).
+func (r *identityProfilesImportResource) plannedChanges(ctx context.Context, refs []identityProfileBundleRef) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	elemType := types.ObjectType{AttrTypes: identityProfilePlannedChangeAttrTypes()}
+
+	byName := map[string]string{}
+	byId := map[string]bool{}
+	for offset := int32(0); ; offset += identityProfilesListMaxLimit {
+		page, httpResp, err := r.client.IdentityProfilesAPI.
+			ListIdentityProfilesV1(ctx).
+			Limit(identityProfilesListMaxLimit).
+			Offset(offset).
+			Execute()
+		if err != nil {
+			diags.AddError("Error listing target Identity Profiles", errDetail(err, httpResp))
+			return types.ListNull(elemType), diags
+		}
+		for i := range page {
+			id := page[i].GetId()
+			byId[id] = true
+			if name := page[i].Name.Get(); name != nil {
+				byName[*name] = id
+			}
+		}
+		if len(page) < identityProfilesListMaxLimit {
+			break
+		}
+	}
+
+	changes := identityProfilePlannedChanges(refs, byName, byId)
+	for _, c := range changes {
+		tflog.Debug(ctx, "Planned Identity Profile import change", map[string]interface{}{"name": c.Name.ValueString(), "action": c.Action.ValueString()})
+	}
+
+	list, d := types.ListValueFrom(ctx, elemType, changes)
+	diags.Append(d...)
+	return list, diags
+}
+
+func identityProfilePlannedChanges(refs []identityProfileBundleRef, byName map[string]string, byId map[string]bool) []identityProfilePlannedChangeModel {
+	changes := make([]identityProfilePlannedChangeModel, 0, len(refs))
+	for _, ref := range refs {
+		change := identityProfilePlannedChangeModel{
+			Name:     types.StringValue(ref.Name),
+			SourceId: types.StringValue(ref.Id),
+			TargetId: types.StringNull(),
+			Action:   types.StringValue(identityProfileImportActionCreate),
+		}
+		if ref.Id == "" {
+			change.SourceId = types.StringNull()
+		}
+		if id, ok := byName[ref.Name]; ok && ref.Name != "" {
+			change.TargetId = types.StringValue(id)
+			change.Action = types.StringValue(identityProfileImportActionUpdate)
+		} else if ref.Id != "" && byId[ref.Id] {
+			change.TargetId = types.StringValue(ref.Id)
+			change.Action = types.StringValue(identityProfileImportActionUpdate)
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func parseIdentityProfilesBundle(v jsontypes.Normalized) (string, []identityProfileBundleRef, error) {
+	bundle, _, err := normalizeIdentityProfilesBundle([]byte(v.ValueString()))
+	if err != nil {
+		return "", nil, err
+	}
+
+	var entries []map[string]interface{}
+	if err := json.Unmarshal([]byte(bundle), &entries); err != nil {
+		return "", nil, err
+	}
+	refs := make([]identityProfileBundleRef, 0, len(entries))
+	for _, entry := range entries {
+		refs = append(refs, identityProfileBundleEntryRef(entry))
+	}
+	return bundle, refs, nil
+}
+
+func identityProfilesImportResultToModel(ctx context.Context, result identityProfilesImportResult, fallback identityProfilesImportResourceModel) (identityProfilesImportResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	model := fallback
+
+	objects := make([]importedObjectModel, 0, len(result.ImportedObjects))
+	for _, o := range result.ImportedObjects {
+		objects = append(objects, importedObjectModel{
+			Type: types.StringValue(o.Type),
+			Id:   types.StringValue(o.Id),
+			Name: types.StringValue(o.Name),
+		})
+	}
+	list, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: importedObjectAttrTypes()}, objects)
+	diags.Append(d...)
+	model.ImportedObjects = list
+
+	messageList := func(messages []identityProfilesImportMessage) types.List {
+		texts := make([]string, 0, len(messages))
+		for _, m := range messages {
+			texts = append(texts, m.String())
+		}
+		l, d := types.ListValueFrom(ctx, types.StringType, texts)
+		diags.Append(d...)
+		return l
+	}
+	model.Infos = messageList(result.Infos)
+	model.Warnings = messageList(result.Warnings)
+
+	return model, diags
+}
@@ -0,0 +1,161 @@
+package identity_profile_v1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testIdentityProfilesBundle = `[
+  {
+    "version": 1,
+    "self": {"type": "IDENTITY_PROFILE", "id": "ip-2", "name": "Contractors"},
+    "object": {"id": "ip-2", "name": "Contractors", "priority": 20, "created": "2024-01-01T00:00:00Z", "modified": "2024-02-01T00:00:00Z", "identityCount": 12}
+  },
+  {
+    "version": 3,
+    "self": {"type": "IDENTITY_PROFILE", "id": "ip-1", "name": "Employees"},
+    "object": {"id": "ip-1", "name": "Employees", "priority": 10}
+  }
+]`
+
+func TestNormalizeIdentityProfilesBundle(t *testing.T) {
+	bundle, summaries, err := normalizeIdentityProfilesBundle([]byte(testIdentityProfilesBundle))
+	if err != nil {
+		t.Fatalf("normalizeIdentityProfilesBundle returned error: %v", err)
+	}
+
+	var entries []map[string]interface{}
+	if err := json.Unmarshal([]byte(bundle), &entries); err != nil {
+		t.Fatalf("normalized bundle is not valid JSON: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("len(entries) = %d, want 2", len(entries))
+	}
+	object := entries[0]["object"].(map[string]interface{})
+	for _, field := range identityProfilesExportVolatileFields {
+		if _, ok := object[field]; ok {
+			t.Errorf("volatile field %q was not stripped", field)
+		}
+	}
+	if object["priority"] != float64(20) {
+		t.Errorf("priority = %v, want 20", object["priority"])
+	}
+
+	if len(summaries) != 2 {
+		t.Fatalf("len(summaries) = %d, want 2", len(summaries))
+	}
+	if summaries[0].Name.ValueString() != "Contractors" || summaries[1].Name.ValueString() != "Employees" {
+		t.Errorf("summaries not sorted by name: %q, %q", summaries[0].Name.ValueString(), summaries[1].Name.ValueString())
+	}
+	if summaries[1].Id.ValueString() != "ip-1" || summaries[1].Version.ValueInt64() != 3 {
+		t.Errorf("summaries[1] = %+v, want id ip-1 version 3", summaries[1])
+	}
+}
+
+func TestNormalizeIdentityProfilesBundle_Stable(t *testing.T) {
+	reordered := `[
+  {"object": {"priority": 10, "name": "Employees", "id": "ip-1"}, "self": {"name": "Employees", "id": "ip-1", "type": "IDENTITY_PROFILE"}, "version": 3},
+  {"object": {"name": "Contractors", "id": "ip-2", "priority": 20, "modified": "2025-05-05T00:00:00Z"}, "self": {"name": "Contractors", "id": "ip-2", "type": "IDENTITY_PROFILE"}, "version": 1}
+]`
+	a, _, err := normalizeIdentityProfilesBundle([]byte(testIdentityProfilesBundle))
+	if err != nil {
+		t.Fatalf("normalizeIdentityProfilesBundle returned error: %v", err)
+	}
+	b, _, err := normalizeIdentityProfilesBundle([]byte(reordered))
+	if err != nil {
+		t.Fatalf("normalizeIdentityProfilesBundle returned error: %v", err)
+	}
+	if a != b {
+		t.Errorf("equivalent bundles normalized differently:\n%s\n%s", a, b)
+	}
+	if identityProfilesBundleHash(a) != identityProfilesBundleHash(b) {
+		t.Error("equivalent bundles hashed differently")
+	}
+}
+
+func TestNormalizeIdentityProfilesBundle_Invalid(t *testing.T) {
+	for _, raw := range []string{`{"self": {}}`, `not json`, `[null]`, `[{"version": 1}]`} {
+		if _, _, err := normalizeIdentityProfilesBundle([]byte(raw)); err == nil {
+			t.Errorf("normalizeIdentityProfilesBundle(%s) returned no error", raw)
+		}
+	}
+}
+
+func TestParseIdentityProfilesBundle(t *testing.T) {
+	_, refs, err := parseIdentityProfilesBundle(jsontypes.NewNormalizedValue(testIdentityProfilesBundle))
+	if err != nil {
+		t.Fatalf("parseIdentityProfilesBundle returned error: %v", err)
+	}
+	if len(refs) != 2 || refs[0].Name != "Contractors" || refs[0].Id != "ip-2" {
+		t.Errorf("refs = %+v, want Contractors/ip-2 first", refs)
+	}
+}
+
+func TestIdentityProfilePlannedChanges(t *testing.T) {
+	refs := []identityProfileBundleRef{
+		{Id: "sb-1", Name: "Employees"},
+		{Id: "shared-id", Name: "Renamed"},
+		{Id: "sb-3", Name: "Contractors"},
+	}
+	byName := map[string]string{"Employees": "prod-1"}
+	byId := map[string]bool{"prod-1": true, "shared-id": true}
+
+	changes := identityProfilePlannedChanges(refs, byName, byId)
+	want := []struct {
+		action, target string
+	}{
+		{identityProfileImportActionUpdate, "prod-1"},
+		{identityProfileImportActionUpdate, "shared-id"},
+		{identityProfileImportActionCreate, ""},
+	}
+	for i, w := range want {
+		if got := changes[i].Action.ValueString(); got != w.action {
+			t.Errorf("changes[%d].Action = %q, want %q", i, got, w.action)
+		}
+		if got := changes[i].TargetId.ValueString(); got != w.target {
+			t.Errorf("changes[%d].TargetId = %q, want %q", i, got, w.target)
+		}
+	}
+	if !changes[2].TargetId.IsNull() {
+		t.Errorf("changes[2].TargetId = %v, want null for a CREATE", changes[2].TargetId)
+	}
+}
+
+func TestIdentityProfilesImportResultToModel(t *testing.T) {
+	var result identityProfilesImportResult
+	raw := `{
+  "infos": [{"key": "INFO_KEY", "text": "imported", "details": {}}],
+  "warnings": [{"key": "", "text": "owner not found", "details": {}}],
+  "errors": [],
+  "importedObjects": [{"type": "IDENTITY_PROFILE", "id": "prod-1", "name": "Employees"}]
+}`
+	if err := json.Unmarshal([]byte(raw), &result); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	fallback := identityProfilesImportResourceModel{Id: types.StringValue("hash")}
+	model, diags := identityProfilesImportResultToModel(context.Background(), result, fallback)
+	if diags.HasError() {
+		t.Fatalf("identityProfilesImportResultToModel returned diagnostics: %v", diags)
+	}
+	if model.Id.ValueString() != "hash" {
+		t.Errorf("Id = %q, want fallback %q", model.Id.ValueString(), "hash")
+	}
+	if len(model.ImportedObjects.Elements()) != 1 {
+		t.Errorf("len(ImportedObjects) = %d, want 1", len(model.ImportedObjects.Elements()))
+	}
+
+	var infos, warnings []string
+	model.Infos.ElementsAs(context.Background(), &infos, false)
+	model.Warnings.ElementsAs(context.Background(), &warnings, false)
+	if len(infos) != 1 || infos[0] != "INFO_KEY: imported" {
+		t.Errorf("Infos = %v, want [INFO_KEY: imported]", infos)
+	}
+	if len(warnings) != 1 || warnings[0] != "owner not found" {
+		t.Errorf("Warnings = %v, want [owner not found]", warnings)
+	}
+}
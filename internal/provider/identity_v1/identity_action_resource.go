@@ -0,0 +1,577 @@
+// This file implements three hand-written, trigger-style resources for the
+// identities API's action endpoints, following the same null_resource-like
+// shape as source_load_entitlement_wait_v1:
+//
+//   - identitynow_identity_attribute_sync_v1:
+//     POST /identities/v1/{identityId}/synchronize-attributes, once per
+//     identity.
+//   - identitynow_identity_reset_v1: POST /identities/v1/{id}/reset, once per
+//     identity.
+//   - identitynow_identity_process_v1: POST /identities/v1/process, in batches
+//     of up to 250 identity ids.
+//
+// All three share one implementation (identityActionResource), parametrized
+// by identityAction. The targeted identities are either listed explicitly in
+// `identity_ids` or resolved at Create time from a GET /identities/v1
+// `filters` expression; the ids actually acted on are recorded in
+// `resolved_identity_ids`. Create runs the action and waits for what the
+// endpoint hands back, bounded by `create_timeout`:
+//   - process returns a task id per batch, which is polled through
+//     GET /task-status/v1/{id} exactly like source_load_entitlement_wait_v1.
+//   - synchronize-attributes returns a sync job with an initial status but
+//     there is no endpoint to poll that job afterwards, so Create only fails
+//     if the job is reported as ERROR up front.
+//   - reset returns a bare 202 with nothing to wait on.
+//
+// Read is a no-op, Update only persists create_timeout, and Delete only
+// removes state - there is nothing to undo for any of these actions.
+// `triggers` forces replacement, which is how practitioners re-run them.
+package identity_v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/identities"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+const (
+	defaultIdentityActionTimeoutString = "30m"
+	// identityProcessBatchSize is POST /identities/v1/process's documented
+	// maxItems for identityIds.
+	identityProcessBatchSize = 250
+	identityListPageLimit    = 250
+)
+
+type identityAction int
+
+const (
+	identityActionSyncAttributes identityAction = iota
+	identityActionReset
+	identityActionProcess
+)
+
+var (
+	_ resource.Resource                     = (*identityActionResource)(nil)
+	_ resource.ResourceWithConfigure        = (*identityActionResource)(nil)
+	_ resource.ResourceWithConfigValidators = (*identityActionResource)(nil)
+	_ resource.ResourceWithImportState      = (*identityActionResource)(nil)
+)
+
+func NewIdentityAttributeSyncResource() resource.Resource {
+	return &identityActionResource{action: identityActionSyncAttributes}
+}
+
+func NewIdentityResetResource() resource.Resource {
+	return &identityActionResource{action: identityActionReset}
+}
+
+func NewIdentityProcessResource() resource.Resource {
+	return &identityActionResource{action: identityActionProcess}
+}
+
+type identityActionResource struct {
+	client *sailpoint.APIClient
+	action identityAction
+}
+
+type identityActionResourceModel struct {
+	Id                  types.String `tfsdk:"id"`
+	IdentityIDs         types.Set    `tfsdk:"identity_ids"`
+	Filters             types.String `tfsdk:"filters"`
+	ResolvedIdentityIDs types.Set    `tfsdk:"resolved_identity_ids"`
+	TaskIDs             types.List   `tfsdk:"task_ids"`
+	Triggers            types.Map    `tfsdk:"triggers"`
+	CreateTimeout       types.String `tfsdk:"create_timeout"`
+}
+
+func (a identityAction) typeNameSuffix() string {
+	switch a {
+	case identityActionReset:
+		return "_identity_reset_v1"
+	case identityActionProcess:
+		return "_identity_process_v1"
+	default:
+		return "_identity_attribute_sync_v1"
+	}
+}
+
+func (a identityAction) label() string {
+	switch a {
+	case identityActionReset:
+		return "identity reset"
+	case identityActionProcess:
+		return "identity processing"
+	default:
+		return "identity attribute synchronization"
+	}
+}
+
+func (a identityAction) markdownDescription() string {
+	switch a {
+	case identityActionReset:
+		return "Resets one or more Identities in IdentityNow/ISC via `POST /identities/v1/{id}/reset`, which de-registers " +
+			"each user and removes any elevated user levels they have. The endpoint returns no task, so there is nothing " +
+			"to wait on once every reset has been accepted."
+	case identityActionProcess:
+		return "Processes one or more Identities in IdentityNow/ISC via `POST /identities/v1/process` (recalculating " +
+			"attributes, role assignments, provisioning and manager relationships) in batches of up to 250, and waits for " +
+			"each returned task to finish via `GET /task-status/v1/{id}`."
+	default:
+		return "Synchronizes attributes for one or more Identities in IdentityNow/ISC via " +
+			"`POST /identities/v1/{identityId}/synchronize-attributes`, once per identity. The returned sync jobs cannot be " +
+			"polled afterwards, so Create only fails if a job is reported as `ERROR` when it is submitted."
+	}
+}
+
+func (r *identityActionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.action.typeNameSuffix()
+}
+
+func (r *identityActionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Triggers %s for a set of identities in IdentityNow/ISC.", r.action.label()),
+		MarkdownDescription: r.action.markdownDescription() + "\n\n" +
+			"This is a hand-written action resource with `null_resource`-style replacement behavior, in the same style as " +
+			"`identitynow_source_load_entitlement_wait_v1`: change `triggers` to run the action again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Synthetic Terraform identifier: the first task or job id returned by the action, or the first resolved identity id when the action returns none.",
+				MarkdownDescription: "Synthetic Terraform identifier: the first task or job id returned by the action, or the first resolved identity id when the action returns none.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"identity_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Description:         "IDs of the identities to act on. Exactly one of identity_ids or filters must be set.",
+				MarkdownDescription: "IDs of the identities to act on. Exactly one of `identity_ids` or `filters` must be set.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"filters": schema.StringAttribute{
+				Optional: true,
+				Description: "Filter expression for GET /identities/v1, resolved to identity ids at Create time. " +
+					"Exactly one of identity_ids or filters must be set.",
+				MarkdownDescription: "[Filter expression](https://developer.sailpoint.com/docs/standard-collection-parameters#filtering-results) " +
+					"for `GET /identities/v1` (for example `alias sw \"svc-\"`), resolved to identity ids at Create time. " +
+					"Exactly one of `identity_ids` or `filters` must be set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"resolved_identity_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				Description:         "IDs of the identities the action was actually run for.",
+				MarkdownDescription: "IDs of the identities the action was actually run for.",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"task_ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				Description:         "IDs of the tasks (process) or sync jobs (attribute sync) returned by the action. Always empty for reset.",
+				MarkdownDescription: "IDs of the tasks (process) or sync jobs (attribute sync) returned by the action. Always empty for reset.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Description:         "Arbitrary key/value pairs that force replacement when changed, re-running the action.",
+				MarkdownDescription: "Arbitrary key/value pairs that force replacement when changed, re-running the action.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"create_timeout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultIdentityActionTimeoutString),
+				Description:         "Overall timeout for Create, covering identity resolution, the action requests and waiting for any returned tasks.",
+				MarkdownDescription: "Overall timeout for Create, covering identity resolution, the action requests and waiting for any returned tasks.",
+			},
+		},
+	}
+}
+
+func (r *identityActionResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("identity_ids"),
+			path.MatchRoot("filters"),
+		),
+	}
+}
+
+func (r *identityActionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = cp.GetClient()
+}
+
+func (r *identityActionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan identityActionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, err := util.ParseWaitTimeout("create_timeout", plan.CreateTimeout, defaultIdentityActionTimeoutString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid create_timeout", err.Error())
+		return
+	}
+	createCtx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var identityIDs []string
+	if !plan.IdentityIDs.IsNull() && !plan.IdentityIDs.IsUnknown() {
+		resp.Diagnostics.Append(plan.IdentityIDs.ElementsAs(ctx, &identityIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		identityIDs, err = r.identityIDsForFilter(createCtx, plan.Filters.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error resolving identities", err.Error())
+			return
+		}
+	}
+	identityIDs = uniqueSortedStrings(identityIDs)
+	if len(identityIDs) == 0 {
+		resp.Diagnostics.AddError(
+			"Error resolving identities",
+			fmt.Sprintf("filters %q matched no identities; there is nothing to run %s for.", plan.Filters.ValueString(), r.action.label()),
+		)
+		return
+	}
+
+	tflog.Info(createCtx, "Triggering identity action", map[string]interface{}{
+		"action":         r.action.label(),
+		"identity_count": len(identityIDs),
+	})
+
+	var taskIDs []string
+	switch r.action {
+	case identityActionReset:
+		err = r.resetIdentities(createCtx, identityIDs)
+	case identityActionProcess:
+		taskIDs, err = r.processIdentities(createCtx, identityIDs, createTimeout)
+	default:
+		taskIDs, err = r.syncIdentityAttributes(createCtx, identityIDs)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Error running %s", r.action.label()), err.Error())
+		return
+	}
+
+	state := plan
+	if len(taskIDs) > 0 {
+		state.Id = types.StringValue(taskIDs[0])
+	} else {
+		state.Id = types.StringValue(identityIDs[0])
+	}
+
+	resolved, diags := types.SetValueFrom(ctx, types.StringType, identityIDs)
+	resp.Diagnostics.Append(diags...)
+	if taskIDs == nil {
+		taskIDs = []string{}
+	}
+	tasks, diags := types.ListValueFrom(ctx, types.StringType, taskIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.ResolvedIdentityIDs = resolved
+	state.TaskIDs = tasks
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *identityActionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// No-op by design: these resources represent "the action was run" plus
+	// Terraform-only settings, not a persistent upstream object.
+	var state identityActionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *identityActionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan identityActionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state identityActionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := util.ParseWaitTimeout("create_timeout", plan.CreateTimeout, defaultIdentityActionTimeoutString); err != nil {
+		resp.Diagnostics.AddError("Invalid create_timeout", err.Error())
+		return
+	}
+
+	// identity_ids, filters and triggers all force replacement, so only the
+	// Terraform-local create_timeout can reach Update.
+	state.CreateTimeout = plan.CreateTimeout
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *identityActionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// No API call: none of these actions can be undone.
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts "<identity_id>/<identity_id>...,<trigger_key1>:<trigger_value1>/<trigger_key2>:<trigger_value2>",
+// mirroring source_load_entitlement_wait_v1's composite id. There is no
+// historical task to recover, so id is the first identity id and task_ids
+// is empty.
+func (r *identityActionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	identityIDs, triggers, err := parseIdentityActionImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import identifier", err.Error())
+		return
+	}
+
+	ids, diags := types.SetValueFrom(ctx, types.StringType, identityIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := identityActionResourceModel{
+		Id:                  types.StringValue(identityIDs[0]),
+		IdentityIDs:         ids,
+		Filters:             types.StringNull(),
+		ResolvedIdentityIDs: ids,
+		TaskIDs:             types.ListValueMust(types.StringType, []attr.Value{}),
+		Triggers:            triggers,
+		CreateTimeout:       types.StringValue(defaultIdentityActionTimeoutString),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *identityActionResource) identityIDsForFilter(ctx context.Context, filters string) ([]string, error) {
+	var ids []string
+	var offset int32
+	for {
+		page, httpResp, err := r.client.IdentitiesAPI.ListIdentitiesV1(ctx).
+			Filters(filters).
+			Offset(offset).
+			Limit(identityListPageLimit).
+			Execute()
+		if err != nil {
+			return nil, fmt.Errorf("listing identities matching %q: %s", filters, errDetail(err, httpResp))
+		}
+		for i := range page {
+			if id := page[i].GetId(); id != "" {
+				ids = append(ids, id)
+			}
+		}
+		if len(page) < identityListPageLimit {
+			return ids, nil
+		}
+		offset += identityListPageLimit
+	}
+}
+
+func (r *identityActionResource) syncIdentityAttributes(ctx context.Context, identityIDs []string) ([]string, error) {
+	jobIDs := make([]string, 0, len(identityIDs))
+	for i, identityID := range identityIDs {
+		job, httpResp, err := r.client.IdentitiesAPI.SynchronizeAttributesForIdentityV1(ctx, identityID).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("identity %q: %s", identityID, errDetail(err, httpResp))
+		}
+		if job == nil {
+			continue
+		}
+		if strings.EqualFold(job.GetStatus(), "ERROR") {
+			return nil, fmt.Errorf("identity %q: sync job %q was reported as ERROR", identityID, job.GetId())
+		}
+		jobIDs = append(jobIDs, job.GetId())
+		tflog.Debug(ctx, "Submitted identity attribute sync", map[string]interface{}{
+			"identity_id": identityID,
+			"job_id":      job.GetId(),
+			"job_status":  job.GetStatus(),
+			"progress":    fmt.Sprintf("%d/%d", i+1, len(identityIDs)),
+		})
+	}
+	return jobIDs, nil
+}
+
+func (r *identityActionResource) resetIdentities(ctx context.Context, identityIDs []string) error {
+	for i, identityID := range identityIDs {
+		httpResp, err := r.client.IdentitiesAPI.ResetIdentityV1(ctx, identityID).Execute()
+		if err != nil {
+			return fmt.Errorf("identity %q: %s", identityID, errDetail(err, httpResp))
+		}
+		tflog.Debug(ctx, "Submitted identity reset", map[string]interface{}{
+			"identity_id": identityID,
+			"progress":    fmt.Sprintf("%d/%d", i+1, len(identityIDs)),
+		})
+	}
+	return nil
+}
+
+// processIdentities starts one processing task per batch, then waits up to
+// timeout for each with util.WaitForTask.
+func (r *identityActionResource) processIdentities(ctx context.Context, identityIDs []string, timeout time.Duration) ([]string, error) {
+	batches := chunkStrings(identityIDs, identityProcessBatchSize)
+	taskIDs := make([]string, 0, len(batches))
+	for i, batch := range batches {
+		body := identities.NewProcessIdentitiesRequest()
+		body.SetIdentityIds(batch)
+
+		result, httpResp, err := r.client.IdentitiesAPI.StartIdentityProcessingV1(ctx).ProcessIdentitiesRequest(*body).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("batch %d of %d: %s", i+1, len(batches), errDetail(err, httpResp))
+		}
+		if result == nil || result.GetId() == "" {
+			return nil, fmt.Errorf("batch %d of %d did not return a task id to poll", i+1, len(batches))
+		}
+		taskIDs = append(taskIDs, result.GetId())
+		tflog.Info(ctx, "Started identity processing", map[string]interface{}{
+			"task_id":        result.GetId(),
+			"batch":          fmt.Sprintf("%d/%d", i+1, len(batches)),
+			"identity_count": len(batch),
+		})
+	}
+
+	for i, taskID := range taskIDs {
+		completionStatus, err := util.WaitForTask(ctx, r.client, taskID, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("task %q did not complete successfully: %w", taskID, err)
+		}
+		if !util.IsSuccessfulCompletionStatus(completionStatus) {
+			return nil, fmt.Errorf("task %q completed with status %q", taskID, completionStatus)
+		}
+		tflog.Info(ctx, "Identity processing task completed", map[string]interface{}{
+			"task_id":  taskID,
+			"progress": fmt.Sprintf("%d/%d", i+1, len(taskIDs)),
+		})
+	}
+	return taskIDs, nil
+}
+
+func parseIdentityActionImportID(id string) ([]string, types.Map, error) {
+	parts := strings.SplitN(id, ",", 2)
+	if len(parts) != 2 {
+		return nil, types.Map{}, fmt.Errorf(
+			"expected import id in the format <identity_id1>/<identity_id2>,<trigger_key1>:<trigger_value1>/<trigger_key2>:<trigger_value2>; got %q",
+			id,
+		)
+	}
+
+	var identityIDs []string
+	for _, identityID := range strings.Split(parts[0], "/") {
+		if identityID = strings.TrimSpace(identityID); identityID != "" {
+			identityIDs = append(identityIDs, identityID)
+		}
+	}
+	identityIDs = uniqueSortedStrings(identityIDs)
+	if len(identityIDs) == 0 {
+		return nil, types.Map{}, errors.New("identity id component must not be empty")
+	}
+
+	raw := strings.TrimSpace(parts[1])
+	if raw == "" {
+		return identityIDs, types.MapNull(types.StringType), nil
+	}
+
+	values := map[string]attr.Value{}
+	for _, pair := range strings.Split(raw, "/") {
+		kv := strings.SplitN(pair, ":", 2)
+		if len(kv) != 2 {
+			return nil, types.Map{}, fmt.Errorf("trigger %q must be in key:value format", pair)
+		}
+		key := strings.TrimSpace(kv[0])
+		if key == "" {
+			return nil, types.Map{}, fmt.Errorf("trigger %q has an empty key", pair)
+		}
+		values[key] = types.StringValue(kv[1])
+	}
+
+	triggers, diags := types.MapValue(types.StringType, values)
+	if diags.HasError() {
+		return nil, types.Map{}, fmt.Errorf("building triggers map value: %v", diags)
+	}
+	return identityIDs, triggers, nil
+}
+
+func uniqueSortedStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if _, ok := seen[v]; ok || v == "" {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func chunkStrings(values []string, size int) [][]string {
+	var chunks [][]string
+	for len(values) > size {
+		chunks = append(chunks, values[:size])
+		values = values[size:]
+	}
+	if len(values) > 0 {
+		chunks = append(chunks, values)
+	}
+	return chunks
+}
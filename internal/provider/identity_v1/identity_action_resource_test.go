@@ -0,0 +1,54 @@
+package identity_v1
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseIdentityActionImportID(t *testing.T) {
+	ids, triggers, err := parseIdentityActionImportID("id-b/id-a/id-b,reason:transform change/ticket:CHG-1")
+	if err != nil {
+		t.Fatalf("parseIdentityActionImportID returned error: %v", err)
+	}
+	if !reflect.DeepEqual(ids, []string{"id-a", "id-b"}) {
+		t.Errorf("identity ids = %v, want [id-a id-b]", ids)
+	}
+	if len(triggers.Elements()) != 2 {
+		t.Errorf("triggers has %d elements, want 2", len(triggers.Elements()))
+	}
+
+	ids, triggers, err = parseIdentityActionImportID("id-a,")
+	if err != nil {
+		t.Fatalf("parseIdentityActionImportID returned error: %v", err)
+	}
+	if len(ids) != 1 || !triggers.IsNull() {
+		t.Errorf("got (%v, %v), want one id and null triggers", ids, triggers)
+	}
+
+	for _, id := range []string{"id-a", ",reason:x", "id-a,reason"} {
+		if _, _, err := parseIdentityActionImportID(id); err == nil {
+			t.Errorf("parseIdentityActionImportID(%q) returned nil error, want error", id)
+		}
+	}
+}
+
+func TestChunkStrings(t *testing.T) {
+	values := make([]string, 0, 501)
+	for i := 0; i < 501; i++ {
+		values = append(values, "id")
+	}
+	chunks := chunkStrings(values, identityProcessBatchSize)
+	if len(chunks) != 3 || len(chunks[0]) != 250 || len(chunks[1]) != 250 || len(chunks[2]) != 1 {
+		t.Errorf("chunk sizes = %d/%d/%d over %d chunks, want 250/250/1 over 3", len(chunks[0]), len(chunks[1]), len(chunks[len(chunks)-1]), len(chunks))
+	}
+	if chunkStrings(nil, identityProcessBatchSize) != nil {
+		t.Error("chunkStrings(nil) should return nil")
+	}
+}
+
+func TestUniqueSortedStrings(t *testing.T) {
+	got := uniqueSortedStrings([]string{"b", "", "a", "b"})
+	if !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("uniqueSortedStrings = %v, want [a b]", got)
+	}
+}
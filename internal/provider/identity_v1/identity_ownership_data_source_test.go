@@ -0,0 +1,66 @@
+package identity_v1
+
+import (
+	"testing"
+
+	"github.com/sailpoint-oss/golang-sdk/v3/identities"
+)
+
+func TestOwnershipKind(t *testing.T) {
+	cases := []struct {
+		entityType, associationType, want string
+	}{
+		{"ROLE", "ROLE_OWNER", ownershipKindRoles},
+		{"", "ACCESS_PROFILE_OWNER", ownershipKindAccessProfiles},
+		{"workgroup", "", ownershipKindGovernanceGroups},
+		{"CAMPAIGN_CAMPAIGNER", "CAMPAIGN_OWNER", ownershipKindOther},
+		{"", "", ownershipKindOther},
+	}
+	for _, c := range cases {
+		if got := ownershipKind(c.entityType, c.associationType); got != c.want {
+			t.Errorf("ownershipKind(%q, %q) = %q, want %q", c.entityType, c.associationType, got, c.want)
+		}
+	}
+}
+
+func TestBucketOwnedEntities(t *testing.T) {
+	typed := identities.NewIdentityEntitiesIdentityEntity()
+	typed.SetId("role-b")
+	typed.SetName("Role B")
+	typed.SetType("ROLE")
+
+	roleOwner := identities.NewIdentityOwnershipAssociationDetailsAssociationDetailsInner()
+	roleOwner.SetAssociationType("ROLE_OWNER")
+	roleOwner.Entities = []identities.IdentityEntities{
+		{IdentityEntity: typed},
+		// Flat id/name/type as returned by the live API.
+		{AdditionalProperties: map[string]interface{}{"id": "role-a", "name": "Role A", "type": "ROLE"}},
+	}
+
+	campaignOwner := identities.NewIdentityOwnershipAssociationDetailsAssociationDetailsInner()
+	campaignOwner.SetAssociationType("CAMPAIGN_OWNER")
+	campaignOwner.Entities = []identities.IdentityEntities{
+		{AdditionalProperties: map[string]interface{}{"id": "campaign-id", "name": "Q1", "type": "CAMPAIGN_CAMPAIGNER"}},
+	}
+
+	buckets := bucketOwnedEntities([]identities.IdentityOwnershipAssociationDetailsAssociationDetailsInner{*roleOwner, *campaignOwner})
+
+	roles := buckets[ownershipKindRoles]
+	if len(roles) != 2 {
+		t.Fatalf("roles has %d entries, want 2", len(roles))
+	}
+	if roles[0].Id.ValueString() != "role-a" || roles[1].Id.ValueString() != "role-b" {
+		t.Errorf("roles ids = [%s %s], want [role-a role-b]", roles[0].Id.ValueString(), roles[1].Id.ValueString())
+	}
+	if roles[0].AssociationType.ValueString() != "ROLE_OWNER" {
+		t.Errorf("roles[0].AssociationType = %q, want %q", roles[0].AssociationType.ValueString(), "ROLE_OWNER")
+	}
+
+	other := buckets[ownershipKindOther]
+	if len(other) != 1 || other[0].Id.ValueString() != "campaign-id" {
+		t.Errorf("other = %v, want one campaign entry", other)
+	}
+	if len(buckets[ownershipKindAccessProfiles]) != 0 {
+		t.Errorf("access_profiles = %v, want empty", buckets[ownershipKindAccessProfiles])
+	}
+}
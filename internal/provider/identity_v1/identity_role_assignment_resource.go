@@ -0,0 +1,533 @@
+// This file implements identitynow_identity_role_assignment_v1, a
+// hand-written (no codegen) resource that ensures one Identity holds one
+// Role.
+//
+// There is no direct "assign role" write endpoint on the identities API -
+// GET /identities/v1/{identityId}/role-assignments is read-only. The only
+// supported way to grant or revoke a role for a specific identity is an
+// access request, so:
+//   - Create first checks whether the identity already holds the role (in
+//     which case that assignment is adopted as-is); otherwise it submits a
+//     GRANT_ACCESS request via POST /access-requests/v1 and polls the
+//     identity's role assignments, filtered by roleId, until the assignment
+//     shows up or `timeout` elapses.
+//   - Read re-reads the single assignment; a 404 means it was removed
+//     out-of-band, so the resource is dropped from state and re-requested on
+//     the next apply.
+//   - Update only persists Terraform-local knobs (`timeout`); everything that
+//     shapes the request forces replacement.
+//   - Delete re-reads the assignment first and does nothing if it is already
+//     gone. Adopted assignments (`adopted = true`) are left in place, since
+//     Terraform never granted them; otherwise it submits a REVOKE_ACCESS
+//     request for this specific assignment and waits for it to disappear.
+//
+// Access requests that need approval stay pending until an approver acts,
+// so Create will time out for roles whose access request config requires
+// approval unless the approval happens within `timeout`.
+package identity_v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/access_requests"
+	"github.com/sailpoint-oss/golang-sdk/v3/identities"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+const defaultRoleAssignmentTimeoutString = "15m"
+
+var (
+	_ resource.Resource                = (*identityRoleAssignmentResource)(nil)
+	_ resource.ResourceWithConfigure   = (*identityRoleAssignmentResource)(nil)
+	_ resource.ResourceWithImportState = (*identityRoleAssignmentResource)(nil)
+)
+
+func NewIdentityRoleAssignmentResource() resource.Resource {
+	return &identityRoleAssignmentResource{}
+}
+
+type identityRoleAssignmentResource struct {
+	client *sailpoint.APIClient
+}
+
+type identityRoleAssignmentResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	IdentityID       types.String `tfsdk:"identity_id"`
+	RoleID           types.String `tfsdk:"role_id"`
+	Comment          types.String `tfsdk:"comment"`
+	AccessRequestID  types.String `tfsdk:"access_request_id"`
+	AssignmentSource types.String `tfsdk:"assignment_source"`
+	AddedDate        types.String `tfsdk:"added_date"`
+	Adopted          types.Bool   `tfsdk:"adopted"`
+	Timeout          types.String `tfsdk:"timeout"`
+}
+
+func (r *identityRoleAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity_role_assignment_v1"
+}
+
+func (r *identityRoleAssignmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Ensures an Identity holds a specific Role in IdentityNow/ISC, granting it through an access request and waiting until the assignment exists.",
+		MarkdownDescription: "Ensures an Identity holds a specific Role in IdentityNow/ISC. Create submits a `GRANT_ACCESS` access request " +
+			"(`POST /access-requests/v1`) and polls `GET /identities/v1/{identityId}/role-assignments` until the assignment exists; " +
+			"Delete submits a `REVOKE_ACCESS` request for that assignment and waits for it to be removed. If the identity already " +
+			"holds the role when Create runs, the existing assignment is adopted instead of requesting it again.\n\n" +
+			"~> This is a `_v1` pilot resource. Destroying it leaves an adopted assignment (`adopted = true`) in place, since Terraform did not grant it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Role assignment ID.",
+				MarkdownDescription: "Role assignment ID.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"identity_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "ID of the Identity that should hold the role.",
+				MarkdownDescription: "ID of the Identity that should hold the role. Changing this forces replacement.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "ID of the Role to assign. The role must be requestable.",
+				MarkdownDescription: "ID of the Role to assign. The role must be requestable. Changing this forces replacement.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Comment attached to the access request. Only sent when a new request is submitted.",
+				MarkdownDescription: "Comment attached to the access request. Only sent when a new request is submitted. Changing this forces replacement.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"access_request_id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "ID of the access request submitted by Create. Null when an existing assignment was adopted or the resource was imported.",
+				MarkdownDescription: "ID of the access request submitted by Create. Null when an existing assignment was adopted or the resource was imported.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"assignment_source": schema.StringAttribute{
+				Computed:            true,
+				Description:         "How the assignment was made, as reported by the API.",
+				MarkdownDescription: "How the assignment was made, as reported by the API.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"added_date": schema.StringAttribute{
+				Computed:            true,
+				Description:         "RFC3339 timestamp of when the assignment was added.",
+				MarkdownDescription: "RFC3339 timestamp of when the assignment was added.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"adopted": schema.BoolAttribute{
+				Computed:            true,
+				Description:         "Whether Create adopted an assignment the identity already held instead of requesting it. Destroy does not revoke adopted assignments.",
+				MarkdownDescription: "Whether Create adopted an assignment the identity already held instead of requesting it. Destroy does not revoke adopted assignments.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultRoleAssignmentTimeoutString),
+				Description:         "How long Create waits for the granted assignment to appear, and Delete waits for the revoked assignment to disappear.",
+				MarkdownDescription: "How long Create waits for the granted assignment to appear, and Delete waits for the revoked assignment to disappear. A Go duration string such as `\"15m\"`.",
+			},
+		},
+	}
+}
+
+func (r *identityRoleAssignmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = cp.GetClient()
+}
+
+func (r *identityRoleAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan identityRoleAssignmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, err := util.ParseWaitTimeout("timeout", plan.Timeout, defaultRoleAssignmentTimeoutString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout", err.Error())
+		return
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	identityID := plan.IdentityID.ValueString()
+	roleID := plan.RoleID.ValueString()
+	plan.AccessRequestID = types.StringNull()
+	plan.Adopted = types.BoolValue(false)
+
+	existingID, httpResp, err := r.findRoleAssignmentID(waitCtx, identityID, roleID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing Identity role assignments", errDetail(err, httpResp))
+		return
+	}
+
+	assignmentID := existingID
+	if assignmentID != "" {
+		plan.Adopted = types.BoolValue(true)
+		tflog.Info(ctx, "Identity already holds role, adopting existing assignment", map[string]interface{}{
+			"identity_id":   identityID,
+			"role_id":       roleID,
+			"assignment_id": assignmentID,
+		})
+	} else {
+		requestID, err := r.submitAccessRequest(waitCtx, identityID, roleID, plan.Comment.ValueString(), "")
+		if err != nil {
+			resp.Diagnostics.AddError("Error requesting role assignment", err.Error())
+			return
+		}
+		if requestID != "" {
+			plan.AccessRequestID = types.StringValue(requestID)
+		}
+		tflog.Info(ctx, "Submitted role access request", map[string]interface{}{
+			"identity_id":       identityID,
+			"role_id":           roleID,
+			"access_request_id": requestID,
+		})
+
+		assignmentID, err = r.waitForRoleAssignment(waitCtx, identityID, roleID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error waiting for role assignment",
+				fmt.Sprintf("Role %q was requested for identity %q but no assignment appeared: %s", roleID, identityID, err.Error()),
+			)
+			return
+		}
+	}
+
+	dto, httpResp, err := r.client.IdentitiesAPI.GetRoleAssignmentV1(ctx, identityID, assignmentID).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Identity role assignment", errDetail(err, httpResp))
+		return
+	}
+
+	plan.Id = types.StringValue(assignmentID)
+	applyRoleAssignmentDTO(&plan, dto)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *identityRoleAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state identityRoleAssignmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	identityID := state.IdentityID.ValueString()
+	assignmentID := state.Id.ValueString()
+
+	dto, httpResp, err := r.client.IdentitiesAPI.GetRoleAssignmentV1(ctx, identityID, assignmentID).Execute()
+	if roleAssignmentMissing(dto, httpResp, err) {
+		tflog.Warn(ctx, "Identity role assignment not found, removing from state", map[string]interface{}{
+			"identity_id":   identityID,
+			"assignment_id": assignmentID,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Identity role assignment", errDetail(err, httpResp))
+		return
+	}
+
+	applyRoleAssignmentDTO(&state, dto)
+	if state.Timeout.IsNull() {
+		state.Timeout = types.StringValue(defaultRoleAssignmentTimeoutString)
+	}
+	if state.Adopted.IsNull() {
+		state.Adopted = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *identityRoleAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan identityRoleAssignmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state identityRoleAssignmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := util.ParseWaitTimeout("timeout", plan.Timeout, defaultRoleAssignmentTimeoutString); err != nil {
+		resp.Diagnostics.AddError("Invalid timeout", err.Error())
+		return
+	}
+
+	// identity_id, role_id and comment all force replacement, so only the
+	// Terraform-local timeout can reach Update.
+	state.Timeout = plan.Timeout
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *identityRoleAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state identityRoleAssignmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, err := util.ParseWaitTimeout("timeout", state.Timeout, defaultRoleAssignmentTimeoutString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout", err.Error())
+		return
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	identityID := state.IdentityID.ValueString()
+	roleID := state.RoleID.ValueString()
+	assignmentID := state.Id.ValueString()
+
+	dto, httpResp, err := r.client.IdentitiesAPI.GetRoleAssignmentV1(waitCtx, identityID, assignmentID).Execute()
+	if roleAssignmentMissing(dto, httpResp, err) {
+		tflog.Info(ctx, "Identity role assignment already removed, nothing to revoke", map[string]interface{}{
+			"identity_id":   identityID,
+			"assignment_id": assignmentID,
+		})
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Identity role assignment", errDetail(err, httpResp))
+		return
+	}
+	if state.Adopted.ValueBool() {
+		tflog.Warn(ctx, "Identity role assignment was adopted rather than granted by Terraform, leaving it in place", map[string]interface{}{
+			"identity_id":   identityID,
+			"assignment_id": assignmentID,
+		})
+		return
+	}
+
+	requestID, err := r.submitAccessRequest(waitCtx, identityID, roleID, "", assignmentID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error revoking role assignment", err.Error())
+		return
+	}
+	tflog.Info(ctx, "Submitted role revoke request", map[string]interface{}{
+		"identity_id":       identityID,
+		"assignment_id":     assignmentID,
+		"access_request_id": requestID,
+	})
+
+	if err := r.waitForRoleAssignmentRemoval(waitCtx, identityID, assignmentID); err != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for role assignment removal",
+			fmt.Sprintf("Assignment %q of identity %q was revoked but is still present: %s", assignmentID, identityID, err.Error()),
+		)
+		return
+	}
+}
+
+func (r *identityRoleAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	identityID, assignmentID, err := roleAssignmentImportIDToParts(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import identifier", err.Error())
+		return
+	}
+
+	dto, httpResp, err := r.client.IdentitiesAPI.GetRoleAssignmentV1(ctx, identityID, assignmentID).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Identity role assignment", errDetail(err, httpResp))
+		return
+	}
+
+	state := identityRoleAssignmentResourceModel{
+		Id:              types.StringValue(assignmentID),
+		IdentityID:      types.StringValue(identityID),
+		RoleID:          types.StringNull(),
+		Comment:         types.StringNull(),
+		AccessRequestID: types.StringNull(),
+		Adopted:         types.BoolValue(false),
+		Timeout:         types.StringValue(defaultRoleAssignmentTimeoutString),
+	}
+	applyRoleAssignmentDTO(&state, dto)
+	if role, ok := dto.GetRoleOk(); ok && role != nil {
+		state.RoleID = types.StringPointerValue(role.Id)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// submitAccessRequest submits a single-item ROLE access request for
+// identityID. An empty assignmentID requests GRANT_ACCESS; a non-empty one
+// requests REVOKE_ACCESS of that specific assignment. The first new access
+// request id is returned when the API reports one.
+func (r *identityRoleAssignmentResource) submitAccessRequest(ctx context.Context, identityID, roleID, comment, assignmentID string) (string, error) {
+	item := access_requests.NewAccessRequestItem("ROLE", roleID)
+	if comment != "" {
+		item.SetComment(comment)
+	}
+
+	body := access_requests.NewAccessRequest([]string{identityID}, []access_requests.AccessRequestItem{*item})
+	if assignmentID == "" {
+		body.SetRequestType(access_requests.ACCESSREQUESTTYPE_GRANT_ACCESS)
+	} else {
+		item.SetAssignmentId(assignmentID)
+		body.RequestedItems = []access_requests.AccessRequestItem{*item}
+		body.SetRequestType(access_requests.ACCESSREQUESTTYPE_REVOKE_ACCESS)
+	}
+
+	result, httpResp, err := r.client.AccessRequestsAPI.CreateAccessRequestV1(ctx).AccessRequest(*body).Execute()
+	if err != nil {
+		return "", errors.New(errDetail(err, httpResp))
+	}
+	if result == nil {
+		return "", nil
+	}
+	for _, tracking := range result.NewRequests {
+		if len(tracking.AccessRequestIds) > 0 {
+			return tracking.AccessRequestIds[0], nil
+		}
+	}
+	return "", nil
+}
+
+// findRoleAssignmentID returns identityID's assignment id for roleID, or ""
+// when the identity does not hold the role.
+func (r *identityRoleAssignmentResource) findRoleAssignmentID(ctx context.Context, identityID, roleID string) (string, *http.Response, error) {
+	items, httpResp, err := r.client.IdentitiesAPI.GetRoleAssignmentsV1(ctx, identityID).RoleId(roleID).Execute()
+	if err != nil {
+		return "", httpResp, err
+	}
+	ids := roleAssignmentIDsFromList(items)
+	if len(ids) == 0 {
+		return "", httpResp, nil
+	}
+	return ids[0], httpResp, nil
+}
+
+func (r *identityRoleAssignmentResource) waitForRoleAssignment(ctx context.Context, identityID, roleID string) (string, error) {
+	for attempt := 0; ; attempt++ {
+		assignmentID, httpResp, err := r.findRoleAssignmentID(ctx, identityID, roleID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return "", fmt.Errorf("timed out while polling role assignments: %w", ctx.Err())
+			}
+			return "", fmt.Errorf("listing role assignments: %s", errDetail(err, httpResp))
+		}
+		if assignmentID != "" {
+			return assignmentID, nil
+		}
+
+		interval := util.PollInterval(attempt)
+		tflog.Debug(ctx, "Waiting for role assignment", map[string]interface{}{
+			"identity_id":   identityID,
+			"role_id":       roleID,
+			"poll_interval": interval.String(),
+		})
+		if err := util.SleepWithContext(ctx, interval); err != nil {
+			return "", fmt.Errorf("timed out while waiting for the access request to be fulfilled: %w", err)
+		}
+	}
+}
+
+func (r *identityRoleAssignmentResource) waitForRoleAssignmentRemoval(ctx context.Context, identityID, assignmentID string) error {
+	for attempt := 0; ; attempt++ {
+		_, httpResp, err := r.client.IdentitiesAPI.GetRoleAssignmentV1(ctx, identityID, assignmentID).Execute()
+		if err != nil {
+			if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+				return nil
+			}
+			if ctx.Err() != nil {
+				return fmt.Errorf("timed out while polling role assignment: %w", ctx.Err())
+			}
+			return fmt.Errorf("reading role assignment: %s", errDetail(err, httpResp))
+		}
+
+		interval := util.PollInterval(attempt)
+		tflog.Debug(ctx, "Waiting for role assignment removal", map[string]interface{}{
+			"identity_id":   identityID,
+			"assignment_id": assignmentID,
+			"poll_interval": interval.String(),
+		})
+		if err := util.SleepWithContext(ctx, interval); err != nil {
+			return fmt.Errorf("timed out while waiting for the revoke request to be fulfilled: %w", err)
+		}
+	}
+}
+
+// roleAssignmentMissing reports whether a GetRoleAssignmentV1 result means the
+// assignment no longer exists: a 404, or a successful call with no body.
+func roleAssignmentMissing(dto *identities.RoleAssignmentDto, httpResp *http.Response, err error) bool {
+	if err != nil {
+		return httpResp != nil && httpResp.StatusCode == http.StatusNotFound
+	}
+	return dto == nil
+}
+
+// applyRoleAssignmentDTO copies the server-reported, read-only fields of dto
+// into m. identity_id/role_id/comment are practitioner inputs and are left
+// untouched.
+func applyRoleAssignmentDTO(m *identityRoleAssignmentResourceModel, dto *identities.RoleAssignmentDto) {
+	m.AssignmentSource = types.StringNull()
+	m.AddedDate = types.StringNull()
+	if dto == nil {
+		return
+	}
+	m.AssignmentSource = types.StringPointerValue(dto.AssignmentSource)
+	m.AddedDate = timeToStringValue(dto.AddedDate)
+}
+
+// roleAssignmentImportIDToParts parses this resource's
+// "identity_id/assignment_id" composite import id, using the same "/"
+// delimiter as source_provisioning_policy_v1's composite ids.
+func roleAssignmentImportIDToParts(id string) (identityID, assignmentID string, err error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+		return "", "", fmt.Errorf("expected import id in the form \"identity_id/assignment_id\", got: %q", id)
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), nil
+}
@@ -0,0 +1,109 @@
+package identity_v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sailpoint-oss/golang-sdk/v3/identities"
+)
+
+func TestRoleAssignmentImportIDToParts(t *testing.T) {
+	identityID, assignmentID, err := roleAssignmentImportIDToParts("identity-id/assignment-id")
+	if err != nil {
+		t.Fatalf("roleAssignmentImportIDToParts returned error: %v", err)
+	}
+	if identityID != "identity-id" || assignmentID != "assignment-id" {
+		t.Errorf("got (%q, %q), want (%q, %q)", identityID, assignmentID, "identity-id", "assignment-id")
+	}
+
+	for _, id := range []string{"", "identity-id", "identity-id/", "/assignment-id"} {
+		if _, _, err := roleAssignmentImportIDToParts(id); err == nil {
+			t.Errorf("roleAssignmentImportIDToParts(%q) returned nil error, want error", id)
+		}
+	}
+}
+
+func TestRoleAssignmentMissing(t *testing.T) {
+	dto := identities.NewRoleAssignmentDto()
+	apiErr := errors.New("api error")
+
+	tests := []struct {
+		name     string
+		dto      *identities.RoleAssignmentDto
+		httpResp *http.Response
+		err      error
+		want     bool
+	}{
+		{"found", dto, &http.Response{StatusCode: http.StatusOK}, nil, false},
+		{"empty body", nil, &http.Response{StatusCode: http.StatusOK}, nil, true},
+		{"not found", nil, &http.Response{StatusCode: http.StatusNotFound}, apiErr, true},
+		{"server error", nil, &http.Response{StatusCode: http.StatusInternalServerError}, apiErr, false},
+		{"transport error", nil, nil, apiErr, false},
+	}
+	for _, tt := range tests {
+		if got := roleAssignmentMissing(tt.dto, tt.httpResp, tt.err); got != tt.want {
+			t.Errorf("%s: roleAssignmentMissing = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRoleAssignmentIDsFromList(t *testing.T) {
+	dto := identities.NewRoleAssignmentDto()
+	dto.SetId("assignment-1")
+	ref := identities.NewRoleAssignmentRef()
+	ref.SetId("assignment-2")
+	duplicate := identities.NewRoleAssignmentRef()
+	duplicate.SetId("assignment-1")
+
+	items := []identities.GetRoleAssignmentsV1200ResponseInner{
+		{RoleAssignmentDto: dto},
+		{RoleAssignmentRef: ref},
+		{RoleAssignmentRef: duplicate},
+		{},
+	}
+
+	got := roleAssignmentIDsFromList(items)
+	if len(got) != 2 || got[0] != "assignment-1" || got[1] != "assignment-2" {
+		t.Errorf("roleAssignmentIDsFromList = %v, want [assignment-1 assignment-2]", got)
+	}
+}
+
+func TestIdentityRoleAssignmentModelFromDTO(t *testing.T) {
+	added := identities.SailPointTime{Time: time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)}
+
+	dto := identities.NewRoleAssignmentDto()
+	dto.SetId("assignment-id")
+	dto.SetComments("break-glass")
+	dto.SetAssignmentSource("AccessRequest")
+	dto.SetAddedDate(added)
+
+	model, diags := identityRoleAssignmentModelFromDTO(context.Background(), dto)
+	if diags.HasError() {
+		t.Fatalf("identityRoleAssignmentModelFromDTO returned diagnostics: %v", diags)
+	}
+
+	if model.Id.ValueString() != "assignment-id" {
+		t.Errorf("Id = %q, want %q", model.Id.ValueString(), "assignment-id")
+	}
+	if model.Comments.ValueString() != "break-glass" {
+		t.Errorf("Comments = %q, want %q", model.Comments.ValueString(), "break-glass")
+	}
+	if model.AssignmentSource.ValueString() != "AccessRequest" {
+		t.Errorf("AssignmentSource = %q, want %q", model.AssignmentSource.ValueString(), "AccessRequest")
+	}
+	if model.AddedDate.ValueString() != "2026-01-02T03:04:05Z" {
+		t.Errorf("AddedDate = %q, want %q", model.AddedDate.ValueString(), "2026-01-02T03:04:05Z")
+	}
+	if !model.RemoveDate.IsNull() {
+		t.Errorf("RemoveDate = %v, want null", model.RemoveDate)
+	}
+	if model.AssignedDimensions.IsNull() || len(model.AssignedDimensions.Elements()) != 0 {
+		t.Errorf("AssignedDimensions = %v, want empty list", model.AssignedDimensions)
+	}
+	if !model.AssignmentContext.IsNull() {
+		t.Errorf("AssignmentContext = %v, want null", model.AssignmentContext)
+	}
+}
@@ -0,0 +1,390 @@
+// This file implements identitynow_identity_role_assignments_v1, a
+// hand-written (no codegen) read-only view over
+// GET /identities/v1/{identityId}/role-assignments and
+// GET /identities/v1/{identityId}/role-assignments/{assignmentId}.
+//
+// The list endpoint's response is an `anyOf` of two shapes: a slim "Role
+// Assignment Ref" (id, role, dates) when called with only the identity id,
+// and the full "Role Assignment Dto" (assigner, assignmentSource, dimensions,
+// assignment context) when filtered by roleId/roleName. golang-sdk models
+// that as a wrapper with one pointer per variant and decodes into whichever
+// variant unmarshals first, so which pointer is populated is not something
+// this data source can rely on. Instead it only takes the assignment id from
+// the list call and then reads each assignment through the single-item
+// endpoint, which always returns the full Dto - one extra GET per
+// assignment, but the output shape is the same regardless of filters.
+package identity_v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/identities"
+)
+
+var (
+	_ datasource.DataSource                     = (*identityRoleAssignmentsDataSource)(nil)
+	_ datasource.DataSourceWithConfigure        = (*identityRoleAssignmentsDataSource)(nil)
+	_ datasource.DataSourceWithConfigValidators = (*identityRoleAssignmentsDataSource)(nil)
+)
+
+func NewIdentityRoleAssignmentsDataSource() datasource.DataSource {
+	return &identityRoleAssignmentsDataSource{}
+}
+
+type identityRoleAssignmentsDataSource struct {
+	client *sailpoint.APIClient
+}
+
+type identityRoleAssignmentsDataSourceModel struct {
+	IdentityID      types.String `tfsdk:"identity_id"`
+	RoleID          types.String `tfsdk:"role_id"`
+	RoleName        types.String `tfsdk:"role_name"`
+	RoleAssignments types.List   `tfsdk:"role_assignments"`
+}
+
+// identityRoleAssignmentModel mirrors one RoleAssignmentDto, flattened to
+// the fields practitioners actually assert on - hand-written since this data
+// source has no generated schema/model.
+type identityRoleAssignmentModel struct {
+	Id                 types.String         `tfsdk:"id"`
+	RoleID             types.String         `tfsdk:"role_id"`
+	RoleName           types.String         `tfsdk:"role_name"`
+	Comments           types.String         `tfsdk:"comments"`
+	AssignmentSource   types.String         `tfsdk:"assignment_source"`
+	AssignerID         types.String         `tfsdk:"assigner_id"`
+	AssignerType       types.String         `tfsdk:"assigner_type"`
+	AssignerName       types.String         `tfsdk:"assigner_name"`
+	AddedDate          types.String         `tfsdk:"added_date"`
+	StartDate          types.String         `tfsdk:"start_date"`
+	RemoveDate         types.String         `tfsdk:"remove_date"`
+	AssignedDimensions types.List           `tfsdk:"assigned_dimensions"`
+	AssignmentContext  jsontypes.Normalized `tfsdk:"assignment_context"`
+}
+
+// identityRoleAssignmentDimensionAttrTypes / identityRoleAssignmentAttrTypes
+// are shared by the schema and Read's types.ListValueFrom call so the two
+// can't drift out of sync.
+func identityRoleAssignmentDimensionAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":   types.StringType,
+		"name": types.StringType,
+	}
+}
+
+func identityRoleAssignmentAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":                  types.StringType,
+		"role_id":             types.StringType,
+		"role_name":           types.StringType,
+		"comments":            types.StringType,
+		"assignment_source":   types.StringType,
+		"assigner_id":         types.StringType,
+		"assigner_type":       types.StringType,
+		"assigner_name":       types.StringType,
+		"added_date":          types.StringType,
+		"start_date":          types.StringType,
+		"remove_date":         types.StringType,
+		"assigned_dimensions": types.ListType{ElemType: types.ObjectType{AttrTypes: identityRoleAssignmentDimensionAttrTypes()}},
+		"assignment_context":  jsontypes.NormalizedType{},
+	}
+}
+
+func (d *identityRoleAssignmentsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity_role_assignments_v1"
+}
+
+func (d *identityRoleAssignmentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the role assignments held by an Identity in IdentityNow/ISC, including assigner, assignment source, dates and dimensions.",
+		MarkdownDescription: "Lists the role assignments held by an Identity in IdentityNow/ISC via " +
+			"`GET /identities/v1/{identityId}/role-assignments`, then reads each assignment's full detail " +
+			"(assigner, assignment source, dates, assigned dimensions and assignment context) via " +
+			"`GET /identities/v1/{identityId}/role-assignments/{assignmentId}`.\n\n" +
+			"~> This is a `_v1` pilot data source.",
+		Attributes: map[string]schema.Attribute{
+			"identity_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "ID of the Identity whose role assignments are listed.",
+				MarkdownDescription: "ID of the Identity whose role assignments are listed.",
+			},
+			"role_id": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Only return assignments of the role with this ID. Conflicts with role_name.",
+				MarkdownDescription: "Only return assignments of the role with this ID. Conflicts with `role_name`.",
+			},
+			"role_name": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Only return assignments of the role with this exact name. Conflicts with role_id.",
+				MarkdownDescription: "Only return assignments of the role with this exact name. Conflicts with `role_id`.",
+			},
+			"role_assignments": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Role assignments held by the Identity.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Assignment ID.",
+						},
+						"role_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the assigned role.",
+						},
+						"role_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the assigned role.",
+						},
+						"comments": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Comments added by the requester when the assignment was made.",
+						},
+						"assignment_source": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "How the assignment was made (for example `UI` or `ACCESS_REQUEST`).",
+						},
+						"assigner_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the identity that performed the assignment. May be empty for system assignments.",
+						},
+						"assigner_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Type of the assigner (`IDENTITY` or `UNKNOWN`).",
+						},
+						"assigner_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Display name of the assigner.",
+						},
+						"added_date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "RFC3339 timestamp of when the assignment was added.",
+						},
+						"start_date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "RFC3339 timestamp of when a future-dated assignment becomes active. Null when the assignment is active immediately.",
+						},
+						"remove_date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "RFC3339 timestamp of when the assignment will be removed, if one is scheduled.",
+						},
+						"assigned_dimensions": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "Role dimensions assigned together with this role.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "Dimension ID.",
+									},
+									"name": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "Dimension name.",
+									},
+								},
+							},
+						},
+						"assignment_context": schema.StringAttribute{
+							CustomType:          jsontypes.NormalizedType{},
+							Computed:            true,
+							MarkdownDescription: "The assignment's context (requested context attributes and matched dimension criteria), as normalized JSON. Null when the API returns no context.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *identityRoleAssignmentsDataSource) ConfigValidators(context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("role_id"),
+			path.MatchRoot("role_name"),
+		),
+	}
+}
+
+func (d *identityRoleAssignmentsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = cp.GetClient()
+}
+
+func (d *identityRoleAssignmentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config identityRoleAssignmentsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	identityID := config.IdentityID.ValueString()
+
+	tflog.Debug(ctx, "Reading Identity role assignments", map[string]interface{}{"identity_id": identityID})
+
+	apiReq := d.client.IdentitiesAPI.GetRoleAssignmentsV1(ctx, identityID)
+	if !config.RoleID.IsNull() && !config.RoleID.IsUnknown() {
+		apiReq = apiReq.RoleId(config.RoleID.ValueString())
+	}
+	if !config.RoleName.IsNull() && !config.RoleName.IsUnknown() {
+		apiReq = apiReq.RoleName(config.RoleName.ValueString())
+	}
+
+	items, httpResp, err := apiReq.Execute()
+	if err != nil {
+		tflog.Error(ctx, "Error listing Identity role assignments", map[string]interface{}{"identity_id": identityID, "error": err.Error()})
+		resp.Diagnostics.AddError("Error listing Identity role assignments", errDetail(err, httpResp))
+		return
+	}
+
+	models := make([]identityRoleAssignmentModel, 0, len(items))
+	for _, assignmentID := range roleAssignmentIDsFromList(items) {
+		dto, httpResp, err := d.client.IdentitiesAPI.GetRoleAssignmentV1(ctx, identityID, assignmentID).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading Identity role assignment",
+				fmt.Sprintf("Assignment %q of identity %q: %s", assignmentID, identityID, errDetail(err, httpResp)),
+			)
+			return
+		}
+
+		model, diags := identityRoleAssignmentModelFromDTO(ctx, dto)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		models = append(models, model)
+	}
+
+	assignments, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: identityRoleAssignmentAttrTypes()}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.RoleAssignments = assignments
+
+	tflog.Debug(ctx, "Read Identity role assignments", map[string]interface{}{"identity_id": identityID, "count": len(models)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// roleAssignmentIDsFromList extracts the assignment id from each entry of
+// the list endpoint's anyOf wrapper, whichever variant the SDK decoded into
+// (see the file doc). Duplicates and empty ids are dropped.
+func roleAssignmentIDsFromList(items []identities.GetRoleAssignmentsV1200ResponseInner) []string {
+	ids := make([]string, 0, len(items))
+	seen := make(map[string]struct{}, len(items))
+	for i := range items {
+		var id string
+		switch {
+		case items[i].RoleAssignmentDto != nil:
+			id = items[i].RoleAssignmentDto.GetId()
+		case items[i].RoleAssignmentRef != nil:
+			id = items[i].RoleAssignmentRef.GetId()
+		}
+		if id == "" {
+			continue
+		}
+		if _, duplicate := seen[id]; duplicate {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func identityRoleAssignmentModelFromDTO(ctx context.Context, dto *identities.RoleAssignmentDto) (identityRoleAssignmentModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	model := identityRoleAssignmentModel{
+		Id:                types.StringNull(),
+		RoleID:            types.StringNull(),
+		RoleName:          types.StringNull(),
+		Comments:          types.StringNull(),
+		AssignmentSource:  types.StringNull(),
+		AssignerID:        types.StringNull(),
+		AssignerType:      types.StringNull(),
+		AssignerName:      types.StringNull(),
+		AddedDate:         types.StringNull(),
+		StartDate:         types.StringNull(),
+		RemoveDate:        types.StringNull(),
+		AssignmentContext: jsontypes.NewNormalizedNull(),
+	}
+	dimensionType := types.ObjectType{AttrTypes: identityRoleAssignmentDimensionAttrTypes()}
+	model.AssignedDimensions = types.ListNull(dimensionType)
+
+	if dto == nil {
+		return model, diags
+	}
+
+	model.Id = types.StringPointerValue(dto.Id)
+	if role, ok := dto.GetRoleOk(); ok && role != nil {
+		model.RoleID = types.StringPointerValue(role.Id)
+		model.RoleName = types.StringPointerValue(role.Name)
+	}
+	model.Comments = nullableStringValue(dto.GetCommentsOk())
+	model.AssignmentSource = types.StringPointerValue(dto.AssignmentSource)
+	if assigner, ok := dto.GetAssignerOk(); ok && assigner != nil {
+		model.AssignerID = types.StringPointerValue(assigner.Id)
+		if assigner.Type != nil {
+			model.AssignerType = types.StringValue(string(*assigner.Type))
+		}
+		model.AssignerName = nullableStringValue(assigner.GetNameOk())
+	}
+	model.AddedDate = timeToStringValue(dto.AddedDate)
+	model.StartDate = timeToStringValue(nullableSailPointTime(dto.GetStartDateOk()))
+	model.RemoveDate = timeToStringValue(nullableSailPointTime(dto.GetRemoveDateOk()))
+
+	dimensions := make([]attr.Value, 0, len(dto.AssignedDimensions))
+	for i := range dto.AssignedDimensions {
+		dimension, d := types.ObjectValue(identityRoleAssignmentDimensionAttrTypes(), map[string]attr.Value{
+			"id":   types.StringPointerValue(dto.AssignedDimensions[i].Id),
+			"name": types.StringPointerValue(dto.AssignedDimensions[i].Name),
+		})
+		diags.Append(d...)
+		dimensions = append(dimensions, dimension)
+	}
+	dimensionList, d := types.ListValue(dimensionType, dimensions)
+	diags.Append(d...)
+	model.AssignedDimensions = dimensionList
+
+	if assignmentContext, ok := dto.GetAssignmentContextOk(); ok && assignmentContext != nil {
+		b, err := json.Marshal(assignmentContext)
+		if err != nil {
+			diags.AddError("Error encoding role assignment context", err.Error())
+			return model, diags
+		}
+		model.AssignmentContext = jsontypes.NewNormalizedValue(string(b))
+	}
+
+	return model, diags
+}
+
+func nullableSailPointTime(v *identities.SailPointTime, ok bool) *identities.SailPointTime {
+	if !ok || v == nil {
+		return nil
+	}
+	return v
+}
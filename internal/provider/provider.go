@@ -2,12 +2,15 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
 
 	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -33,6 +36,7 @@ import (
 	"terraform-provider-identitynow/internal/provider/source_schema_v1"
 	"terraform-provider-identitynow/internal/provider/sources_v1"
 	"terraform-provider-identitynow/internal/provider/transform_v1"
+	"terraform-provider-identitynow/internal/provider/util"
 	"terraform-provider-identitynow/internal/provider/workflow_v1"
 )
 
@@ -45,8 +49,10 @@ func New() func() provider.Provider {
 }
 
 type identitynowProvider struct {
-	client *sailpoint.APIClient
-	config *sailpoint.Configuration
+	client  *sailpoint.APIClient
+	config  *sailpoint.Configuration
+	deletes *util.DeleteCoalescer
+	cache   *util.ProviderCache
 }
 
 // GetClient exposes the configured SDK client to resource/data source
@@ -60,20 +66,35 @@ func (p identitynowProvider) GetClient() *sailpoint.APIClient {
 
 // GetClientConfig exposes the root SDK Configuration (base URL, client
 // credentials, token URL and HTTP client) to subpackages that need to make a
-// raw HTTP call the generated per-service client does not expose - currently
-// only access_model_metadata_attribute_v1's hand-rolled DELETE (the published
-// spec omits a working DELETE endpoint, so golang-sdk generates no delete
-// method for it). golang-sdk v3's root *sailpoint.APIClient does not expose its
+// raw HTTP call the generated per-service client does not expose -
+// access_model_metadata_attribute_v1's hand-rolled DELETE and role_v1's role
+// dimension calls (the published specs omit those working endpoints, so
+// golang-sdk generates no methods for them). golang-sdk v3's root *sailpoint.APIClient does not expose its
 // Configuration, so the provider stashes and hands it out here.
 func (p identitynowProvider) GetClientConfig() *sailpoint.Configuration {
 	return p.config
 }
 
+// GetDeleteCoalescer exposes the provider instance's shared bulk-delete
+// batcher to the resources whose Delete can go through a bulk-delete
+// endpoint (roles, access profiles, governance groups, identity profiles).
+func (p identitynowProvider) GetDeleteCoalescer() *util.DeleteCoalescer {
+	return p.deletes
+}
+
+// GetProviderCache exposes the provider instance's shared cache of
+// read-only lookups (currently the workflow library catalogs used by
+// workflow_v1's plan-time definition checks and library data sources).
+func (p identitynowProvider) GetProviderCache() *util.ProviderCache {
+	return p.cache
+}
+
 type ProviderModel struct {
 	SailBaseUrl      types.String `tfsdk:"sail_base_url"`
 	SailClientId     types.String `tfsdk:"sail_client_id"`
 	SailClientSecret types.String `tfsdk:"sail_client_secret"`
 	HttpRetryMax     types.Int64  `tfsdk:"http_retry_max"`
+	BulkDeleteWindow types.String `tfsdk:"bulk_delete_window"`
 }
 
 func (p *identitynowProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
@@ -107,6 +128,15 @@ func (p *identitynowProvider) Schema(ctx context.Context, req provider.SchemaReq
 				Description:         "Override number of retries for the retryablehttp client - default is 20",
 				MarkdownDescription: "Override number of retries for the retryablehttp client - default is 20.",
 			},
+			"bulk_delete_window": schema.StringAttribute{
+				Optional: true,
+				Description: "How long the first delete of a role, access profile, governance group or identity profile waits " +
+					"for others of the same type to batch into one bulk-delete request, as a Go duration such as 2s. Default is 0s, " +
+					"which disables batching.",
+				MarkdownDescription: "How long the first delete of a role, access profile, governance group or identity profile waits " +
+					"for others of the same type to batch into one bulk-delete request, as a Go duration such as `2s`. Default is `0s`, " +
+					"which disables batching and deletes each object with its own `DELETE` call.",
+			},
 		},
 	}
 }
@@ -170,15 +200,33 @@ func (p *identitynowProvider) Configure(ctx context.Context, req provider.Config
 		httpClient.RetryMax = int(provider.HttpRetryMax.ValueInt64())
 	}
 
+	var bulkDeleteWindow time.Duration
+	if !provider.BulkDeleteWindow.IsNull() {
+		d, err := time.ParseDuration(provider.BulkDeleteWindow.ValueString())
+		if err != nil || d < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("bulk_delete_window"),
+				"Invalid bulk_delete_window",
+				fmt.Sprintf("bulk_delete_window must be a non-negative Go duration such as \"2s\", got %q.", provider.BulkDeleteWindow.ValueString()),
+			)
+			return
+		}
+		bulkDeleteWindow = d
+	}
+
 	configuration.HTTPClient = httpClient
 	apiClient := sailpoint.NewAPIClient(configuration)
 	p.client = apiClient
 	p.config = configuration
+	p.deletes = util.NewDeleteCoalescer(bulkDeleteWindow)
+	p.cache = util.NewProviderCache()
 
 	providerConfig := identitynowProvider{}
 
 	providerConfig.client = apiClient
 	providerConfig.config = configuration
+	providerConfig.deletes = p.deletes
+	providerConfig.cache = p.cache
 
 	resp.DataSourceData = providerConfig
 	resp.ResourceData = providerConfig
@@ -204,15 +252,22 @@ func (p *identitynowProvider) DataSources(ctx context.Context) []func() datasour
 		governance_group_v1.NewGovernanceGroupsDataSource,
 		identity_v1.NewIdentityDataSource,
 		identity_v1.NewIdentitiesDataSource,
+		identity_v1.NewIdentityOwnershipDataSource,
+		identity_v1.NewIdentityRoleAssignmentsDataSource,
 		identity_profile_v1.NewIdentityProfileDataSource,
+		identity_profile_v1.NewIdentityProfileDefaultAttributeConfigDataSource,
+		identity_profile_v1.NewIdentityProfilePreviewDataSource,
 		identity_profile_v1.NewIdentityProfilesDataSource,
+		identity_profile_v1.NewIdentityProfilesExportDataSource,
 		role_v1.NewRoleDataSource,
+		role_v1.NewRoleDimensionDataSource,
 		segment_v1.NewSegmentDataSource,
 		segment_v1.NewSegmentsDataSource,
 		role_v1.NewRolesDataSource,
 		service_desk_integration_v1.NewServiceDeskIntegrationDataSource,
 		sod_policy_v1.NewSodPolicyDataSource,
 		sod_policy_v1.NewSodPoliciesDataSource,
+		sod_policy_v1.NewSodViolationReportDataSource,
 		source_provisioning_policy_v1.NewSourceProvisioningPolicyDataSource,
 		source_provisioning_policy_v1.NewSourceProvisioningPoliciesDataSource,
 		source_schema_v1.NewSourceSchemaDataSource,
@@ -220,7 +275,14 @@ func (p *identitynowProvider) DataSources(ctx context.Context) []func() datasour
 		sources_v1.NewSourceDataSource,
 		sources_v1.NewSourcesDataSource,
 		transform_v1.NewTransformDataSource,
+		transform_v1.NewTransformPreviewDataSource,
+		transform_v1.NewTransformsDataSource,
 		workflow_v1.NewWorkflowDataSource,
+		workflow_v1.NewWorkflowExecutionsDataSource,
+		workflow_v1.NewWorkflowExportDataSource,
+		workflow_v1.NewWorkflowLibraryActionsDataSource,
+		workflow_v1.NewWorkflowLibraryOperatorsDataSource,
+		workflow_v1.NewWorkflowLibraryTriggersDataSource,
 		workflow_v1.NewWorkflowsDataSource,
 	}
 }
@@ -229,6 +291,8 @@ func (p *identitynowProvider) Resources(ctx context.Context) []func() resource.R
 	return []func() resource.Resource{
 		access_model_metadata_attribute_v1.NewAccessModelMetadataAttributeResource,
 		access_profile_v1.NewAccessProfileResource,
+		access_profile_v1.NewAccessProfileEntitlementAttachmentResource,
+		access_profile_v1.NewAccessProfilesRequestableResource,
 		application_access_association_v1.NewApplicationAccessAssociationResource,
 		application_v1.NewApplicationResource,
 		connector_rule_v1.NewConnectorRuleResource,
@@ -237,16 +301,28 @@ func (p *identitynowProvider) Resources(ctx context.Context) []func() resource.R
 		governance_group_v1.NewGovernanceGroupResource,
 		governance_group_v1.NewGovernanceGroupMembersResource,
 		identity_profile_v1.NewIdentityProfileResource,
+		identity_profile_v1.NewIdentityProfilesImportResource,
+		identity_v1.NewIdentityAttributeSyncResource,
+		identity_v1.NewIdentityProcessResource,
+		identity_v1.NewIdentityResetResource,
+		identity_v1.NewIdentityRoleAssignmentResource,
 		role_v1.NewRoleResource,
+		role_v1.NewRoleAccessProfileAttachmentResource,
+		role_v1.NewRoleDimensionResource,
+		role_v1.NewRoleMembershipIdentitiesResource,
 		segment_access_v1.NewSegmentAccessResource,
 		segment_v1.NewSegmentResource,
 		service_desk_integration_v1.NewServiceDeskIntegrationResource,
 		sod_policy_v1.NewSodPolicyResource,
+		sod_policy_v1.NewSodPolicyScheduleResource,
+		sod_policy_v1.NewSodViolationReportRunResource,
 		source_load_entitlement_wait_v1.NewSourceLoadEntitlementWaitResource,
 		source_provisioning_policy_v1.NewSourceProvisioningPolicyResource,
 		source_schema_v1.NewSourceSchemaResource,
 		sources_v1.NewSourceResource,
 		transform_v1.NewTransformResource,
 		workflow_v1.NewWorkflowResource,
+		workflow_v1.NewWorkflowExternalClientResource,
+		workflow_v1.NewWorkflowTestResource,
 	}
 }
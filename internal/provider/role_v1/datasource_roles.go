@@ -15,15 +15,21 @@ package role_v1
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/roles"
 
 	"terraform-provider-identitynow/internal/provider/role_v1/datasource_role"
+	"terraform-provider-identitynow/internal/provider/util"
 )
 
 // rolesListMaxLimit matches GET /roles/v1's documented maximum "limit" value
@@ -31,9 +37,14 @@ import (
 // see the spec's parameter description for /roles/v1).
 const rolesListMaxLimit = 50
 
+// rolesFilterMaxLimit is POST /roles/v1/filter's documented maximum, used
+// instead when filter.access_model_metadata is set.
+const rolesFilterMaxLimit = 250
+
 var (
-	_ datasource.DataSource              = (*rolesDataSource)(nil)
-	_ datasource.DataSourceWithConfigure = (*rolesDataSource)(nil)
+	_ datasource.DataSource                     = (*rolesDataSource)(nil)
+	_ datasource.DataSourceWithConfigure        = (*rolesDataSource)(nil)
+	_ datasource.DataSourceWithConfigValidators = (*rolesDataSource)(nil)
 )
 
 func NewRolesDataSource() datasource.DataSource {
@@ -49,6 +60,7 @@ type rolesDataSource struct {
 // datasource_role.RoleModel shape.
 type RolesDataSourceModel struct {
 	Filters            types.String `tfsdk:"filters"`
+	Filter             types.Object `tfsdk:"filter"`
 	Limit              types.Int64  `tfsdk:"limit"`
 	Offset             types.Int64  `tfsdk:"offset"`
 	Sorters            types.String `tfsdk:"sorters"`
@@ -98,8 +110,9 @@ func (d *rolesDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				MarkdownDescription: "Filter expression used to query roles (e.g. `name sw \"Engineering\"`, " +
 					"`enabled eq true`). See [V3 API Standard Collection Parameters]" +
 					"(https://developer.sailpoint.com/idn/api/standard-collection-parameters#filtering-results) " +
-					"for the supported fields/operators for `GET /roles/v1`.",
+					"for the supported fields/operators for `GET /roles/v1`. Conflicts with `filter`.",
 			},
+			"filter": roleFilterAttribute(),
 			"limit": schema.Int64Attribute{
 				Optional: true,
 				MarkdownDescription: "Maximum number of roles to return. The API's documented maximum for this " +
@@ -138,6 +151,19 @@ func (d *rolesDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 	}
 }
 
+func (d *rolesDataSource) ConfigValidators(context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("filters"),
+			path.MatchRoot("filter"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("for_segment_ids"),
+			path.MatchRoot("filter").AtName("segment_ids"),
+		),
+	}
+}
+
 func (d *rolesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -161,50 +187,142 @@ func (d *rolesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	tflog.Debug(ctx, "Reading Roles data source", map[string]interface{}{"filters": config.Filters.ValueString()})
-
-	apiReq := d.client.RolesAPI.ListRolesV1(ctx)
-
-	if !config.Filters.IsNull() && !config.Filters.IsUnknown() {
-		apiReq = apiReq.Filters(config.Filters.ValueString())
-	}
-	if !config.Sorters.IsNull() && !config.Sorters.IsUnknown() {
-		apiReq = apiReq.Sorters(config.Sorters.ValueString())
+	filters := config.Filters.ValueString()
+	if !config.Filter.IsNull() && !config.Filter.IsUnknown() {
+		expr, diags := util.BuildFilterExpression(config.Filter, path.Root("filter"), roleFilterFields)
+		resp.Diagnostics.Append(diags...)
+		filters = expr
 	}
-	if !config.ForSubadmin.IsNull() && !config.ForSubadmin.IsUnknown() {
-		apiReq = apiReq.ForSubadmin(config.ForSubadmin.ValueString())
+	forSegmentIds := config.ForSegmentIds.ValueString()
+	segmentIds, diags := util.FilterBlockStrings(ctx, config.Filter, "segment_ids")
+	resp.Diagnostics.Append(diags...)
+	if len(segmentIds) > 0 {
+		forSegmentIds = strings.Join(segmentIds, ",")
 	}
-	if !config.ForSegmentIds.IsNull() && !config.ForSegmentIds.IsUnknown() {
-		apiReq = apiReq.ForSegmentIds(config.ForSegmentIds.ValueString())
+	metadata, diags := roleFilterMetadata(ctx, config.Filter)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if !config.IncludeUnsegmented.IsNull() && !config.IncludeUnsegmented.IsUnknown() {
-		apiReq = apiReq.IncludeUnsegmented(config.IncludeUnsegmented.ValueBool())
+
+	tflog.Debug(ctx, "Reading Roles data source", map[string]interface{}{"filters": filters, "metadata_filters": len(metadata)})
+
+	maxLimit := int64(rolesListMaxLimit)
+	endpoint := "GET /roles/v1"
+	if metadata != nil {
+		maxLimit = rolesFilterMaxLimit
+		endpoint = "POST /roles/v1/filter"
 	}
+	var offset, limit *int32
 	if !config.Offset.IsNull() && !config.Offset.IsUnknown() {
-		apiReq = apiReq.Offset(int32(config.Offset.ValueInt64()))
+		o := int32(config.Offset.ValueInt64())
+		offset = &o
 	}
-
 	if !config.Limit.IsNull() && !config.Limit.IsUnknown() {
 		requestedLimit := config.Limit.ValueInt64()
-		if requestedLimit > rolesListMaxLimit {
+		if requestedLimit > maxLimit {
 			resp.Diagnostics.AddWarning(
 				"Limit exceeds maximum",
-				fmt.Sprintf("The requested limit (%d) exceeds GET /roles/v1's documented maximum of %d. Using %d instead.",
-					requestedLimit, rolesListMaxLimit, rolesListMaxLimit),
+				fmt.Sprintf("The requested limit (%d) exceeds %s's documented maximum of %d. Using %d instead.",
+					requestedLimit, endpoint, maxLimit, maxLimit),
 			)
-			apiReq = apiReq.Limit(rolesListMaxLimit)
-		} else {
-			apiReq = apiReq.Limit(int32(requestedLimit))
+			requestedLimit = maxLimit
 		}
+		l := int32(requestedLimit)
+		limit = &l
 	}
 
-	dtos, httpResp, err := apiReq.Execute()
+	// listRoles reads one page from whichever endpoint the query needs; a
+	// nil offset or limit is left to the API's default.
+	var listRoles func(offset, limit *int32) ([]roles.Role, *http.Response, error)
+	if metadata != nil {
+		body, diags := roleFilterBody(filters, metadata)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		apiReq := d.client.RolesAPI.SearchRolesByFilterV1(ctx).SearchRolesByFilterV1Request(*body)
+		if !config.Sorters.IsNull() && !config.Sorters.IsUnknown() {
+			apiReq = apiReq.Sorters(config.Sorters.ValueString())
+		}
+		if !config.ForSubadmin.IsNull() && !config.ForSubadmin.IsUnknown() {
+			apiReq = apiReq.ForSubadmin(config.ForSubadmin.ValueString())
+		}
+		if forSegmentIds != "" {
+			apiReq = apiReq.ForSegmentIds(forSegmentIds)
+		}
+		if !config.IncludeUnsegmented.IsNull() && !config.IncludeUnsegmented.IsUnknown() {
+			apiReq = apiReq.IncludeUnsegmented(config.IncludeUnsegmented.ValueBool())
+		}
+		listRoles = func(offset, limit *int32) ([]roles.Role, *http.Response, error) {
+			pageReq := apiReq
+			if offset != nil {
+				pageReq = pageReq.Offset(*offset)
+			}
+			if limit != nil {
+				pageReq = pageReq.Limit(*limit)
+			}
+			return pageReq.Execute()
+		}
+	} else {
+		apiReq := d.client.RolesAPI.ListRolesV1(ctx)
+		if filters != "" {
+			apiReq = apiReq.Filters(filters)
+		}
+		if !config.Sorters.IsNull() && !config.Sorters.IsUnknown() {
+			apiReq = apiReq.Sorters(config.Sorters.ValueString())
+		}
+		if !config.ForSubadmin.IsNull() && !config.ForSubadmin.IsUnknown() {
+			apiReq = apiReq.ForSubadmin(config.ForSubadmin.ValueString())
+		}
+		if forSegmentIds != "" {
+			apiReq = apiReq.ForSegmentIds(forSegmentIds)
+		}
+		if !config.IncludeUnsegmented.IsNull() && !config.IncludeUnsegmented.IsUnknown() {
+			apiReq = apiReq.IncludeUnsegmented(config.IncludeUnsegmented.ValueBool())
+		}
+		listRoles = func(offset, limit *int32) ([]roles.Role, *http.Response, error) {
+			pageReq := apiReq
+			if offset != nil {
+				pageReq = pageReq.Offset(*offset)
+			}
+			if limit != nil {
+				pageReq = pageReq.Limit(*limit)
+			}
+			return pageReq.Execute()
+		}
+	}
+
+	// name_contains is matched here rather than by the API, so every page
+	// is read before offset/limit are applied to the matches.
+	nameContains, filterByName := util.FilterBlockString(config.Filter, "name_contains")
+
+	var dtos []roles.Role
+	var httpResp *http.Response
+	var err error
+	if filterByName {
+		dtos, httpResp, err = util.ListAllPages(int32(maxLimit), func(offset, limit int32) ([]roles.Role, *http.Response, error) {
+			return listRoles(&offset, &limit)
+		})
+	} else {
+		dtos, httpResp, err = listRoles(offset, limit)
+	}
 	if err != nil {
 		tflog.Error(ctx, "Error reading Roles data source", map[string]interface{}{"error": err.Error()})
 		resp.Diagnostics.AddError("Error listing Roles", roleErrDetail(err, httpResp))
 		return
 	}
 
+	if filterByName {
+		matched := dtos[:0]
+		for _, dto := range dtos {
+			if util.FilterNameContains(dto.Name, nameContains) {
+				matched = append(matched, dto)
+			}
+		}
+		dtos = util.PageSlice(matched, offset, limit)
+	}
+
 	elemType := datasource_role.RoleDataSourceSchema(ctx).Type()
 
 	models := make([]datasource_role.RoleModel, 0, len(dtos))
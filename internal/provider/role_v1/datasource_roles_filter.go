@@ -0,0 +1,125 @@
+// This file defines identitynow_roles_v1's typed "filter" block, an
+// alternative to hand-writing the "filters" expression (quoting, which
+// operators each field supports, nested properties such as owner.id).
+// util.BuildFilterExpression compiles it using only the fields/operators
+// GET /roles/v1 documents; "segment_ids" is sent as for-segment-ids,
+// "name_contains" is matched against every page of results (the API has no
+// "co" on name), and "access_model_metadata" switches the query to
+// POST /roles/v1/filter, the only roles endpoint that filters on metadata.
+package role_v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sailpoint-oss/golang-sdk/v3/roles"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+var roleFilterFields = []util.FilterField{
+	{Attribute: "ids", Property: "id", Operator: "in", Kind: util.FilterKindStringSet, Description: "Only roles with one of these IDs."},
+	{Attribute: "name", Property: "name", Operator: "eq", Kind: util.FilterKindString, Description: "Only the role with exactly this name."},
+	{Attribute: "name_starts_with", Property: "name", Operator: "sw", Kind: util.FilterKindString, Description: "Only roles whose name starts with this prefix."},
+	{Attribute: "name_contains", Kind: util.FilterKindString, Description: "Only roles whose name contains this text, ignoring case. `GET /roles/v1` can't filter on this, so the provider reads every page of results and matches them itself, then applies `offset` and `limit` to the matches."},
+	{Attribute: "owner_id", Property: "owner.id", Operator: "eq", Kind: util.FilterKindString, Description: "Only roles owned by this identity."},
+	{Attribute: "owner_ids", Property: "owner.id", Operator: "in", Kind: util.FilterKindStringSet, Description: "Only roles owned by one of these identities."},
+	{Attribute: "requestable", Property: "requestable", Operator: "eq", Kind: util.FilterKindBool, Description: "Only requestable (`true`) or non-requestable (`false`) roles."},
+	{Attribute: "dimensional", Property: "dimensional", Operator: "eq", Kind: util.FilterKindBool, Description: "Only dimensional (`true`) or non-dimensional (`false`) roles."},
+	{Attribute: "created_after", Property: "created", Operator: "gt", Kind: util.FilterKindTimestamp, Description: "Only roles created after this RFC 3339 timestamp."},
+	{Attribute: "created_on_or_after", Property: "created", Operator: "ge", Kind: util.FilterKindTimestamp, Description: "Only roles created at or after this RFC 3339 timestamp."},
+	{Attribute: "created_on_or_before", Property: "created", Operator: "le", Kind: util.FilterKindTimestamp, Description: "Only roles created at or before this RFC 3339 timestamp."},
+	{Attribute: "modified_before", Property: "modified", Operator: "lt", Kind: util.FilterKindTimestamp, Description: "Only roles last modified before this RFC 3339 timestamp."},
+	{Attribute: "modified_on_or_after", Property: "modified", Operator: "ge", Kind: util.FilterKindTimestamp, Description: "Only roles last modified at or after this RFC 3339 timestamp."},
+	{Attribute: "modified_on_or_before", Property: "modified", Operator: "le", Kind: util.FilterKindTimestamp, Description: "Only roles last modified at or before this RFC 3339 timestamp."},
+	{Attribute: "segment_ids", Kind: util.FilterKindStringSet, Description: "Only roles assigned to one of these Segments. Sent as the `for-segment-ids` query parameter; conflicts with the top-level `for_segment_ids`."},
+}
+
+func roleFilterAttribute() schema.SingleNestedAttribute {
+	return util.FilterAttribute(
+		"Typed filter criteria, AND-ed together and compiled into the `filters` expression for you. Conflicts with `filters`.",
+		roleFilterFields,
+		map[string]schema.Attribute{
+			"access_model_metadata": schema.ListNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Only roles with these access model metadata values. Setting this queries " +
+					"`POST /roles/v1/filter` instead of `GET /roles/v1`, with the other criteria sent as that request's `filters`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"attribute": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Key of the access model metadata attribute, e.g. `iscFederalClassifications`.",
+						},
+						"values": schema.SetAttribute{
+							ElementType:         types.StringType,
+							Optional:            true,
+							MarkdownDescription: "Values of the attribute to match. Left unset, roles with any value for the attribute match.",
+						},
+					},
+				},
+			},
+		},
+	)
+}
+
+type roleFilterMetadataModel struct {
+	Attribute types.String `tfsdk:"attribute"`
+	Values    types.Set    `tfsdk:"values"`
+}
+
+// roleFilterMetadata returns the block's access_model_metadata entries as
+// the API's ammKeyValues, or nil when unset.
+func roleFilterMetadata(ctx context.Context, block types.Object) ([]map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if block.IsNull() || block.IsUnknown() {
+		return nil, diags
+	}
+	list, ok := block.Attributes()["access_model_metadata"].(types.List)
+	if !ok || list.IsNull() || list.IsUnknown() {
+		return nil, diags
+	}
+
+	var items []roleFilterMetadataModel
+	diags.Append(list.ElementsAs(ctx, &items, false)...)
+	out := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		values := []string{}
+		if !item.Values.IsNull() && !item.Values.IsUnknown() {
+			diags.Append(item.Values.ElementsAs(ctx, &values, false)...)
+		}
+		out = append(out, map[string]interface{}{
+			"attribute": item.Attribute.ValueString(),
+			"values":    values,
+		})
+	}
+	return out, diags
+}
+
+// roleFilterBody builds POST /roles/v1/filter's body. It goes through JSON
+// into the SDK type, as roleMembershipSelectorToApi does, so the nested
+// ammKeyValues entries need no hand-built SDK structs.
+func roleFilterBody(filters string, metadata []map[string]interface{}) (*roles.SearchRolesByFilterV1Request, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	body := map[string]interface{}{"ammKeyValues": metadata}
+	if filters != "" {
+		body["filters"] = filters
+	}
+
+	b, err := json.Marshal(body)
+	if err == nil {
+		var dto roles.SearchRolesByFilterV1Request
+		if err = json.Unmarshal(b, &dto); err == nil {
+			return &dto, diags
+		}
+	}
+	diags.AddError(
+		"Invalid role filter",
+		fmt.Sprintf("Could not build the POST /roles/v1/filter request body: %s", err.Error()),
+	)
+	return nil, diags
+}
@@ -0,0 +1,92 @@
+package role_v1
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+// roleFilterBlock builds a "filter" object with set as the only non-null
+// attributes.
+func roleFilterBlock(t *testing.T, set map[string]attr.Value) types.Object {
+	t.Helper()
+	attrTypes := roleFilterAttribute().GetType().(types.ObjectType).AttrTypes
+	values := make(map[string]attr.Value, len(attrTypes))
+	for name, typ := range attrTypes {
+		values[name] = nullValue(typ)
+	}
+	for name, v := range set {
+		values[name] = v
+	}
+	obj, diags := types.ObjectValue(attrTypes, values)
+	if diags.HasError() {
+		t.Fatalf("ObjectValue: %v", diags)
+	}
+	return obj
+}
+
+func nullValue(typ attr.Type) attr.Value {
+	switch typ := typ.(type) {
+	case types.ListType:
+		return types.ListNull(typ.ElemType)
+	case types.SetType:
+		return types.SetNull(typ.ElemType)
+	}
+	switch typ {
+	case types.BoolType:
+		return types.BoolNull()
+	default:
+		return types.StringNull()
+	}
+}
+
+func TestRoleFilterExpression(t *testing.T) {
+	ctx := context.Background()
+	owners, _ := types.SetValueFrom(ctx, types.StringType, []string{"id-2", "id-1"})
+	block := roleFilterBlock(t, map[string]attr.Value{
+		"name_starts_with": types.StringValue(`Eng "Core"`),
+		"owner_ids":        owners,
+		"requestable":      types.BoolValue(true),
+		"created_after":    types.StringValue("2024-01-31T00:00:00Z"),
+		"name_contains":    types.StringValue("ops"),
+	})
+
+	got, diags := util.BuildFilterExpression(block, path.Root("filter"), roleFilterFields)
+	if diags.HasError() {
+		t.Fatalf("BuildFilterExpression returned diagnostics: %v", diags)
+	}
+	want := `created gt 2024-01-31T00:00:00Z and name sw "Eng \"Core\"" and owner.id in ("id-1", "id-2") and requestable eq true`
+	if got != want {
+		t.Errorf("expression = %s\nwant %s", got, want)
+	}
+
+	block = roleFilterBlock(t, map[string]attr.Value{"modified_before": types.StringValue("2024-01-31")})
+	if _, diags := util.BuildFilterExpression(block, path.Root("filter"), roleFilterFields); !diags.HasError() {
+		t.Error("a non-RFC 3339 timestamp should be rejected")
+	}
+}
+
+func TestRoleFilterBody(t *testing.T) {
+	body, diags := roleFilterBody(`requestable eq true`, []map[string]interface{}{
+		{"attribute": "iscFederalClassifications", "values": []string{"secret"}},
+	})
+	if diags.HasError() {
+		t.Fatalf("roleFilterBody returned diagnostics: %v", diags)
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	for _, want := range []string{`"filters":"requestable eq true"`, `"attribute":"iscFederalClassifications"`, `"values":["secret"]`} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("body %s does not contain %s", b, want)
+		}
+	}
+}
@@ -19,6 +19,21 @@
 //     them to the API on Create/Update - see roleModelToDto/rolePassThroughWarning) -
 //     a configured value is preserved as-is (with an AddWarning) rather than
 //     overwritten by the API's response, to avoid a permanent non-convergent diff.
+//   - "membership"'s criteria tree is only 3 levels deep (criteria -> children
+//     -> grandchildren). "membership_criteria_json" is a hand-added,
+//     mutually exclusive alternative for STANDARD membership with no depth
+//     limit and real write support (see resource_role_membership_criteria_json.go).
+//   - "access_profiles" is replaced wholesale on Update unless the hand-added
+//     "ignore_unmanaged" is set, in which case only the configured entries are
+//     added/removed and read back, so identitynow_role_access_profile_attachment_v1
+//     can attach others (see resource_role_ignore_unmanaged.go).
+//   - "dimension_refs" is never replaced wholesale on Update: a configured
+//     change is sent as targeted adds/removes, since
+//     identitynow_role_dimension_v1 (resource_role_dimension.go) adds and
+//     removes its own entry (see resource_role_dimension_refs.go).
+//   - "membership" is owned wholesale; identitynow_role_membership_identities_v1
+//     (resource_role_membership_identities.go) manages an additive subset of an
+//     IDENTITY_LIST role's identities for roles that several teams share.
 //   - "legacy_membership_info" remains fully pass-through (state mirrors
 //     plan/prior-state): its generated schema has zero attributes (the API's
 //     arbitrary map[string]interface{} shape never got any concrete attribute
@@ -34,10 +49,12 @@ package role_v1
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -55,12 +72,17 @@ import (
 // this package needing to import it (which would create an import cycle).
 type clientProvider interface {
 	GetClient() *sailpoint.APIClient
+	GetClientConfig() *sailpoint.Configuration
+	GetDeleteCoalescer() *util.DeleteCoalescer
 }
 
 var (
 	_ resource.Resource                = (*roleResource)(nil)
 	_ resource.ResourceWithConfigure   = (*roleResource)(nil)
 	_ resource.ResourceWithImportState = (*roleResource)(nil)
+
+	_ resource.ResourceWithConfigValidators = (*roleResource)(nil)
+	_ resource.ResourceWithValidateConfig   = (*roleResource)(nil)
 )
 
 func NewRoleResource() resource.Resource {
@@ -68,7 +90,41 @@ func NewRoleResource() resource.Resource {
 }
 
 type roleResource struct {
-	client *sailpoint.APIClient
+	client  *sailpoint.APIClient
+	deletes *util.DeleteCoalescer
+}
+
+// roleResourceModel mirrors resource_role.RoleModel plus the hand-added
+// "membership_criteria_json" and "ignore_unmanaged" fields (see
+// resource_role_membership_criteria_json.go and
+// resource_role_ignore_unmanaged.go). Kept as a distinct,
+// hand-written struct (rather than embedding the generated model) since Go
+// doesn't allow adding a field to an imported struct type, and
+// req.Plan.Get/resp.State.Set match purely on `tfsdk` tags, not on which
+// struct type declares them.
+type roleResourceModel struct {
+	AccessModelMetadata     resource_role.AccessModelMetadataValue     `tfsdk:"access_model_metadata"`
+	AccessProfiles          types.List                                 `tfsdk:"access_profiles"`
+	AccessRequestConfig     resource_role.AccessRequestConfigValue     `tfsdk:"access_request_config"`
+	AdditionalOwners        types.List                                 `tfsdk:"additional_owners"`
+	Created                 types.String                               `tfsdk:"created"`
+	Description             types.String                               `tfsdk:"description"`
+	DimensionRefs           types.List                                 `tfsdk:"dimension_refs"`
+	Dimensional             types.Bool                                 `tfsdk:"dimensional"`
+	Enabled                 types.Bool                                 `tfsdk:"enabled"`
+	Entitlements            types.List                                 `tfsdk:"entitlements"`
+	Id                      types.String                               `tfsdk:"id"`
+	IgnoreUnmanaged         types.Bool                                 `tfsdk:"ignore_unmanaged"`
+	LegacyMembershipInfo    resource_role.LegacyMembershipInfoValue    `tfsdk:"legacy_membership_info"`
+	Membership              resource_role.MembershipValue              `tfsdk:"membership"`
+	MembershipCriteriaJson  jsontypes.Normalized                       `tfsdk:"membership_criteria_json"`
+	Modified                types.String                               `tfsdk:"modified"`
+	Name                    types.String                               `tfsdk:"name"`
+	Owner                   resource_role.OwnerValue                   `tfsdk:"owner"`
+	PrivilegeLevel          types.String                               `tfsdk:"privilege_level"`
+	Requestable             types.Bool                                 `tfsdk:"requestable"`
+	RevocationRequestConfig resource_role.RevocationRequestConfigValue `tfsdk:"revocation_request_config"`
+	Segments                types.List                                 `tfsdk:"segments"`
 }
 
 func (r *roleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -84,6 +140,8 @@ func (r *roleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 		"meet a set of membership criteria.\n\n" +
 		"~> This is a `_v1` pilot resource - see the \"Known Limitations & Live Testing Notes\" section below before relying on " +
 		"it in production configurations."
+	applyRoleMembershipCriteriaJSONField(&resp.Schema.Attributes)
+	applyRoleIgnoreUnmanagedField(&resp.Schema.Attributes)
 	applyRoleUseStateForUnknown(&resp.Schema)
 }
 
@@ -101,6 +159,7 @@ func (r *roleResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 	r.client = cp.GetClient()
+	r.deletes = cp.GetDeleteCoalescer()
 }
 
 func (r *roleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -108,7 +167,7 @@ func (r *roleResource) ImportState(ctx context.Context, req resource.ImportState
 }
 
 func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan resource_role.RoleModel
+	var plan roleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -125,7 +184,7 @@ func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, r
 	rolePassThroughWarning(ctx, &resp.Diagnostics, "access_model_metadata", plan.AccessModelMetadata.IsNull())
 	rolePassThroughWarning(ctx, &resp.Diagnostics, "access_request_config", plan.AccessRequestConfig.IsNull())
 	rolePassThroughWarning(ctx, &resp.Diagnostics, "revocation_request_config", plan.RevocationRequestConfig.IsNull())
-	rolePassThroughWarning(ctx, &resp.Diagnostics, "membership", plan.Membership.IsNull())
+	rolePassThroughWarning(ctx, &resp.Diagnostics, "membership", plan.Membership.IsNull() || !plan.MembershipCriteriaJson.IsNull())
 	rolePassThroughWarning(ctx, &resp.Diagnostics, "legacy_membership_info", plan.LegacyMembershipInfo.IsNull())
 
 	apiResp, httpResp, err := r.client.RolesAPI.
@@ -150,7 +209,7 @@ func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, r
 }
 
 func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state resource_role.RoleModel
+	var state roleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -158,6 +217,11 @@ func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	tflog.Debug(ctx, "Reading Role", map[string]interface{}{"id": state.Id.ValueString()})
 
+	// Only null right after `terraform import`.
+	if state.IgnoreUnmanaged.IsNull() {
+		state.IgnoreUnmanaged = types.BoolValue(defaultRoleIgnoreUnmanaged)
+	}
+
 	apiResp, httpResp, err := r.client.RolesAPI.
 		GetRoleV1(ctx, state.Id.ValueString()).
 		Execute()
@@ -184,13 +248,13 @@ func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan resource_role.RoleModel
+	var plan roleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var state resource_role.RoleModel
+	var state roleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -207,7 +271,7 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	rolePassThroughWarning(ctx, &resp.Diagnostics, "access_model_metadata", plan.AccessModelMetadata.IsNull())
 	rolePassThroughWarning(ctx, &resp.Diagnostics, "access_request_config", plan.AccessRequestConfig.IsNull())
 	rolePassThroughWarning(ctx, &resp.Diagnostics, "revocation_request_config", plan.RevocationRequestConfig.IsNull())
-	rolePassThroughWarning(ctx, &resp.Diagnostics, "membership", plan.Membership.IsNull())
+	rolePassThroughWarning(ctx, &resp.Diagnostics, "membership", plan.Membership.IsNull() || !plan.MembershipCriteriaJson.IsNull())
 	rolePassThroughWarning(ctx, &resp.Diagnostics, "legacy_membership_info", plan.LegacyMembershipInfo.IsNull())
 
 	// The v1 API updates via RFC 6902 JSON Patch. A "replace whole document"
@@ -229,7 +293,29 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	if dto.Requestable != nil {
 		patch = append(patch, roleJSONPatchReplace("/requestable", roles.BoolAsJsonPatchOperationValue(dto.Requestable)))
 	}
-	if dto.AccessProfiles != nil {
+	// With ignore_unmanaged, access profiles are added/removed individually
+	// by patchRoleRefLinks below instead of replaced wholesale.
+	var refEdits []roleRefEdit
+	if plan.IgnoreUnmanaged.ValueBool() {
+		var accessProfilesToAdd, accessProfilesToRemove []string
+		if dto.AccessProfiles != nil {
+			oldIds, d := roleAccessProfileRefIds(ctx, state.AccessProfiles)
+			resp.Diagnostics.Append(d...)
+			newIds, d := roleAccessProfileRefIds(ctx, plan.AccessProfiles)
+			resp.Diagnostics.Append(d...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			accessProfilesToAdd, accessProfilesToRemove = util.DiffIds(oldIds, newIds)
+			// Prior state still holds every live access profile when
+			// ignore_unmanaged is only now being turned on, so nothing can
+			// safely be treated as removed from configuration yet.
+			if !state.IgnoreUnmanaged.ValueBool() {
+				accessProfilesToRemove = nil
+			}
+		}
+		refEdits = append(refEdits, roleRefEdit{list: roleAccessProfileRefList, toAdd: accessProfilesToAdd, toRemove: accessProfilesToRemove})
+	} else if dto.AccessProfiles != nil {
 		if arr, err := roleSliceToArrayInner(dto.AccessProfiles); err == nil {
 			patch = append(patch, roleJSONPatchReplace("/accessProfiles", roles.ArrayOfArrayInnerAsJsonPatchOperationValue(&arr)))
 		}
@@ -244,10 +330,19 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 			patch = append(patch, roleJSONPatchReplace("/additionalOwners", roles.ArrayOfArrayInnerAsJsonPatchOperationValue(&arr)))
 		}
 	}
+	// dimension_refs is also edited by identitynow_role_dimension_v1, so a
+	// configured change is sent as targeted adds/removes (see
+	// resource_role_dimension_refs.go) instead of a replace.
 	if dto.DimensionRefs != nil {
-		if arr, err := roleSliceToArrayInner(dto.DimensionRefs); err == nil {
-			patch = append(patch, roleJSONPatchReplace("/dimensionRefs", roles.ArrayOfArrayInnerAsJsonPatchOperationValue(&arr)))
+		oldIds, d := roleDimensionRefModelIds(ctx, state.DimensionRefs)
+		resp.Diagnostics.Append(d...)
+		newIds, d := roleDimensionRefModelIds(ctx, plan.DimensionRefs)
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
+		toAdd, toRemove := util.DiffIds(oldIds, newIds)
+		refEdits = append(refEdits, roleRefEdit{list: roleDimensionRefList, toAdd: toAdd, toRemove: toRemove})
 	}
 	if dto.Segments != nil {
 		arr := make([]roles.ArrayInner, 0, len(dto.Segments))
@@ -259,13 +354,36 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	if dto.PrivilegeLevel.IsSet() {
 		patch = append(patch, roleJSONPatchReplace("/privilegeLevel", roles.StringAsJsonPatchOperationValue(dto.PrivilegeLevel.Get())))
 	}
+	// Only sent when it changes, so an unrelated update never touches
+	// whether a role that already has dimensions is dimensional.
+	if dto.Dimensional.IsSet() && !plan.Dimensional.Equal(state.Dimensional) {
+		patch = append(patch, roleJSONPatchReplace("/dimensional", roles.BoolAsJsonPatchOperationValue(dto.Dimensional.Get())))
+	}
+	if !plan.MembershipCriteriaJson.IsNull() && !plan.MembershipCriteriaJson.IsUnknown() {
+		// Patched from the configured JSON rather than dto.Membership so a
+		// criteria tree deeper than the SDK's RoleCriteriaLevel3 is sent
+		// exactly as written.
+		selector, d := roleMembershipSelectorFromCriteriaJSON(plan.MembershipCriteriaJson)
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		patch = append(patch, roleJSONPatchReplace("/membership", roles.MapmapOfStringAnyAsJsonPatchOperationValue(&selector)))
+	}
 
 	tflog.Debug(ctx, "Patching Role", map[string]interface{}{"id": state.Id.ValueString(), "patch_ops": len(patch)})
 
-	apiResp, httpResp, err := r.client.RolesAPI.
-		PatchRoleV1(ctx, state.Id.ValueString()).
-		JsonPatchOperation(patch).
-		Execute()
+	var apiResp *roles.Role
+	var httpResp *http.Response
+	var err error
+	if len(refEdits) > 0 {
+		apiResp, httpResp, err = patchRoleRefLinks(ctx, r.client, state.Id.ValueString(), refEdits, patch)
+	} else {
+		apiResp, httpResp, err = r.client.RolesAPI.
+			PatchRoleV1(ctx, state.Id.ValueString()).
+			JsonPatchOperation(patch).
+			Execute()
+	}
 	if err != nil {
 		tflog.Error(ctx, "Error updating Role", map[string]interface{}{"id": state.Id.ValueString(), "error": err.Error()})
 		resp.Diagnostics.AddError("Error updating Role", roleErrDetail(err, httpResp))
@@ -284,7 +402,7 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state resource_role.RoleModel
+	var state roleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -292,6 +410,21 @@ func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 	tflog.Debug(ctx, "Deleting Role", map[string]interface{}{"id": state.Id.ValueString()})
 
+	if r.deletes.Enabled() {
+		err := r.deletes.Delete(ctx, roleBulkDeleteKind, roleBulkDeleteMax, state.Id.ValueString(), roleBulkDelete(r.client))
+		if errors.Is(err, util.ErrBulkDeleteNotFound) {
+			tflog.Warn(ctx, "Role already absent on delete", map[string]interface{}{"id": state.Id.ValueString()})
+			return
+		}
+		if err != nil {
+			tflog.Error(ctx, "Error deleting Role", map[string]interface{}{"id": state.Id.ValueString(), "error": err.Error()})
+			resp.Diagnostics.AddError("Error deleting Role", err.Error())
+			return
+		}
+		tflog.Info(ctx, "Deleted Role", map[string]interface{}{"id": state.Id.ValueString()})
+		return
+	}
+
 	httpResp, err := r.client.RolesAPI.
 		DeleteRoleV1(ctx, state.Id.ValueString()).
 		Execute()
@@ -318,7 +451,7 @@ func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 // guarded the same way service_desk_integration_v1's modelToDto guards
 // before_provisioning_rule/cluster_ref/owner_ref, per that package's documented
 // Unknown-vs-Null pattern.
-func roleModelToDto(ctx context.Context, m resource_role.RoleModel) (*roles.Role, diag.Diagnostics) {
+func roleModelToDto(ctx context.Context, m roleResourceModel) (*roles.Role, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	owner, d := m.Owner.ToApi_betaOwnerReference(ctx)
@@ -343,6 +476,9 @@ func roleModelToDto(ctx context.Context, m resource_role.RoleModel) (*roles.Role
 	if !m.PrivilegeLevel.IsNull() && !m.PrivilegeLevel.IsUnknown() {
 		dto.PrivilegeLevel = *roles.NewNullableString(m.PrivilegeLevel.ValueStringPointer())
 	}
+	if !m.Dimensional.IsNull() && !m.Dimensional.IsUnknown() {
+		dto.Dimensional = *roles.NewNullableBool(m.Dimensional.ValueBoolPointer())
+	}
 
 	if !m.Segments.IsNull() && !m.Segments.IsUnknown() {
 		var segments []string
@@ -406,13 +542,23 @@ func roleModelToDto(ctx context.Context, m resource_role.RoleModel) (*roles.Role
 		dto.AdditionalOwners = refs
 	}
 
+	if !m.MembershipCriteriaJson.IsNull() && !m.MembershipCriteriaJson.IsUnknown() {
+		selector, d := roleMembershipSelectorFromCriteriaJSON(m.MembershipCriteriaJson)
+		diags.Append(d...)
+		if selector != nil {
+			membership, d := roleMembershipSelectorToApi(selector)
+			diags.Append(d...)
+			dto.Membership = *roles.NewNullableRoleMembershipSelector(membership)
+		}
+	}
+
 	return dto, diags
 }
 
 // roleDtoToModel converts an API response DTO into the Terraform state model,
 // preferring fields carried over from fallback (plan/prior state) for the
 // pass-through-only blocks documented in the package doc.
-func roleDtoToModel(ctx context.Context, dto *roles.Role, fallback resource_role.RoleModel) (resource_role.RoleModel, diag.Diagnostics) {
+func roleDtoToModel(ctx context.Context, dto *roles.Role, fallback roleResourceModel) (roleResourceModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	model := fallback
 
@@ -459,6 +605,11 @@ func roleDtoToModel(ctx context.Context, dto *roles.Role, fallback resource_role
 			diags.Append(d...)
 			values = append(values, v)
 		}
+		if fallback.IgnoreUnmanaged.ValueBool() {
+			managed, d := roleManagedAccessProfiles(ctx, values, fallback.AccessProfiles)
+			diags.Append(d...)
+			values = managed
+		}
 		listVal, d := types.ListValueFrom(ctx, resource_role.AccessProfilesValue{}.Type(ctx), values)
 		diags.Append(d...)
 		model.AccessProfiles = listVal
@@ -471,7 +622,9 @@ func roleDtoToModel(ctx context.Context, dto *roles.Role, fallback resource_role
 			diags.Append(d...)
 			values = append(values, v)
 		}
-		listVal, d := types.ListValueFrom(ctx, resource_role.DimensionRefsValue{}.Type(ctx), values)
+		ordered, d := roleOrderDimensionRefs(ctx, values, fallback.DimensionRefs)
+		diags.Append(d...)
+		listVal, d := types.ListValueFrom(ctx, resource_role.DimensionRefsValue{}.Type(ctx), ordered)
 		diags.Append(d...)
 		model.DimensionRefs = listVal
 	}
@@ -531,7 +684,16 @@ func roleDtoToModel(ctx context.Context, dto *roles.Role, fallback resource_role
 		diags.Append(d...)
 		model.RevocationRequestConfig = v
 	}
-	if model.Membership.IsNull() || model.Membership.IsUnknown() {
+	// membership_criteria_json, when configured, owns membership on both the
+	// write and read path (see resource_role_membership_criteria_json.go), so
+	// the 3-level "membership" read-back is left null rather than showing a
+	// truncated copy of the same tree.
+	if !model.MembershipCriteriaJson.IsNull() {
+		v, d := roleMembershipCriteriaJSONFromApi(dto.Membership.Get(), model.MembershipCriteriaJson)
+		diags.Append(d...)
+		model.MembershipCriteriaJson = v
+		model.Membership = resource_role.NewMembershipValueNull()
+	} else if model.Membership.IsNull() || model.Membership.IsUnknown() {
 		v, d := roleMembershipFromApi(ctx, dto.Membership.Get())
 		diags.Append(d...)
 		model.Membership = v
@@ -585,6 +747,29 @@ func roleJSONPatchReplace(path string, value roles.JsonPatchOperationValue) role
 	}
 }
 
+func roleJSONPatchAdd(path string, value roles.JsonPatchOperationValue) roles.JsonPatchOperation {
+	return roles.JsonPatchOperation{
+		Op:    "add",
+		Path:  path,
+		Value: &value,
+	}
+}
+
+func roleJSONPatchTest(path string, value roles.JsonPatchOperationValue) roles.JsonPatchOperation {
+	return roles.JsonPatchOperation{
+		Op:    "test",
+		Path:  path,
+		Value: &value,
+	}
+}
+
+func roleJSONPatchRemove(path string) roles.JsonPatchOperation {
+	return roles.JsonPatchOperation{
+		Op:   "remove",
+		Path: path,
+	}
+}
+
 // roleStructToMap round-trips an SDK model struct through JSON to get a
 // map[string]interface{} suitable for
 // roles.MapmapOfStringAnyAsJsonPatchOperationValue, since the
@@ -0,0 +1,231 @@
+// This file implements identitynow_role_access_profile_attachment_v1, a fully
+// hand-written resource for one (role, access profile) link.
+//
+// identitynow_role_v1 sends "access_profiles" as a whole-list replace, so a
+// role shared between teams can't be extended from another workspace without
+// the two fighting over the list. This resource owns exactly one entry
+// instead: Create appends it and Delete removes it with the targeted,
+// retried JSON Patch operations in resource_role_links.go, leaving every
+// other access profile on the role untouched. Pair it with
+// ignore_unmanaged = true on the identitynow_role_v1 that owns the role.
+package role_v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+)
+
+var (
+	_ resource.Resource                = (*roleAccessProfileAttachmentResource)(nil)
+	_ resource.ResourceWithConfigure   = (*roleAccessProfileAttachmentResource)(nil)
+	_ resource.ResourceWithImportState = (*roleAccessProfileAttachmentResource)(nil)
+)
+
+func NewRoleAccessProfileAttachmentResource() resource.Resource {
+	return &roleAccessProfileAttachmentResource{}
+}
+
+type roleAccessProfileAttachmentResource struct {
+	client *sailpoint.APIClient
+}
+
+type roleAccessProfileAttachmentResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	RoleId          types.String `tfsdk:"role_id"`
+	AccessProfileId types.String `tfsdk:"access_profile_id"`
+}
+
+func (r *roleAccessProfileAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_access_profile_attachment_v1"
+}
+
+func (r *roleAccessProfileAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceschema.Schema{
+		Description: "Attaches one Access Profile to a Role in IdentityNow/ISC without managing the Role's other access profiles.",
+		MarkdownDescription: "Attaches one [Access Profile](https://documentation.sailpoint.com/saas/help/access/access-profiles.html) " +
+			"to a [Role](https://documentation.sailpoint.com/saas/help/access/roles.html) in IdentityNow/ISC without managing the " +
+			"Role's other access profiles. This is a fully hand-written `_v1` resource: it adds and removes its one entry in the " +
+			"role's `accessProfiles` with targeted JSON Patch operations through `PATCH /roles/v1/{id}`, so teams can extend a " +
+			"shared role from their own workspaces.",
+		Attributes: map[string]resourceschema.Attribute{
+			"id": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "Composite ID in the form role_id/access_profile_id.",
+				MarkdownDescription: "Composite ID in the form `role_id/access_profile_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role_id": resourceschema.StringAttribute{
+				Required:            true,
+				Description:         "ID of the Role.",
+				MarkdownDescription: "ID of the Role. Changing this forces replacement.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"access_profile_id": resourceschema.StringAttribute{
+				Required:            true,
+				Description:         "ID of the Access Profile to attach to the Role.",
+				MarkdownDescription: "ID of the Access Profile to attach to the Role. Changing this forces replacement.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *roleAccessProfileAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = cp.GetClient()
+}
+
+func (r *roleAccessProfileAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan roleAccessProfileAttachmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleID := plan.RoleId.ValueString()
+	accessProfileID := plan.AccessProfileId.ValueString()
+
+	tflog.Debug(ctx, "Attaching Access Profile to Role", map[string]interface{}{"role_id": roleID, "access_profile_id": accessProfileID})
+
+	// An access profile that is already attached is adopted: the link ops
+	// come out empty and nothing is sent.
+	_, httpResp, err := patchRoleAccessProfileLinks(ctx, r.client, roleID, []string{accessProfileID}, nil, nil)
+	if err != nil {
+		tflog.Error(ctx, "Error attaching Access Profile to Role", map[string]interface{}{"role_id": roleID, "error": err.Error()})
+		resp.Diagnostics.AddError("Error attaching Access Profile to Role", roleErrDetail(err, httpResp))
+		return
+	}
+
+	plan.Id = types.StringValue(roleAccessProfileAttachmentID(roleID, accessProfileID))
+
+	tflog.Info(ctx, "Attached Access Profile to Role", map[string]interface{}{"id": plan.Id.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *roleAccessProfileAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state roleAccessProfileAttachmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleID := state.RoleId.ValueString()
+	accessProfileID := state.AccessProfileId.ValueString()
+
+	tflog.Debug(ctx, "Reading Role access profile attachment", map[string]interface{}{"id": state.Id.ValueString()})
+
+	role, httpResp, err := r.client.RolesAPI.GetRoleV1(ctx, roleID).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			tflog.Warn(ctx, "Role not found, removing access profile attachment from state", map[string]interface{}{"role_id": roleID})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		tflog.Error(ctx, "Error reading Role access profile attachment", map[string]interface{}{"role_id": roleID, "error": err.Error()})
+		resp.Diagnostics.AddError("Error reading Role access profile attachment", roleErrDetail(err, httpResp))
+		return
+	}
+
+	if len(retainRoleIds(roleAccessProfileIds(role), []string{accessProfileID})) == 0 {
+		tflog.Warn(ctx, "Access Profile no longer attached to Role, removing from state", map[string]interface{}{"id": state.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Id = types.StringValue(roleAccessProfileAttachmentID(roleID, accessProfileID))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *roleAccessProfileAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan roleAccessProfileAttachmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// role_id and access_profile_id both force replacement, so there is
+	// nothing to send here.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *roleAccessProfileAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state roleAccessProfileAttachmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleID := state.RoleId.ValueString()
+	accessProfileID := state.AccessProfileId.ValueString()
+
+	tflog.Debug(ctx, "Detaching Access Profile from Role", map[string]interface{}{"role_id": roleID, "access_profile_id": accessProfileID})
+
+	_, httpResp, err := patchRoleAccessProfileLinks(ctx, r.client, roleID, nil, []string{accessProfileID}, nil)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			tflog.Warn(ctx, "Role already absent on access profile detach", map[string]interface{}{"role_id": roleID})
+			return
+		}
+		tflog.Error(ctx, "Error detaching Access Profile from Role", map[string]interface{}{"role_id": roleID, "error": err.Error()})
+		resp.Diagnostics.AddError("Error detaching Access Profile from Role", roleErrDetail(err, httpResp))
+		return
+	}
+
+	tflog.Info(ctx, "Detached Access Profile from Role", map[string]interface{}{"id": state.Id.ValueString()})
+}
+
+func (r *roleAccessProfileAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	roleID, accessProfileID, err := roleAccessProfileAttachmentIDToParts(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_id"), roleID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("access_profile_id"), accessProfileID)...)
+}
+
+// roleAccessProfileAttachmentID uses the same "/"-joined composite form as
+// source_provisioning_policy_v1's idFromParts.
+func roleAccessProfileAttachmentID(roleID, accessProfileID string) string {
+	return roleID + "/" + accessProfileID
+}
+
+func roleAccessProfileAttachmentIDToParts(id string) (roleID, accessProfileID string, err error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("expected import id in the form \"role_id/access_profile_id\", got: %q", id)
+	}
+	return parts[0], parts[1], nil
+}
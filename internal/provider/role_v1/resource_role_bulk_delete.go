@@ -0,0 +1,52 @@
+// This file routes identitynow_role_v1's Delete through the provider's
+// util.DeleteCoalescer, so roles destroyed in the same apply are removed
+// with POST /roles/v1/bulk-delete, up to 50 at a time, instead of one
+// DELETE each. The endpoint only hands back a task, so per-role results come
+// from listing which roles still exist once the task has finished.
+package role_v1
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/roles"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+const (
+	roleBulkDeleteKind = "roles"
+	// roleBulkDeleteMax is POST /roles/v1/bulk-delete's documented limit.
+	roleBulkDeleteMax         = 50
+	roleBulkDeleteTaskTimeout = 10 * time.Minute
+)
+
+func roleBulkDelete(client *sailpoint.APIClient) util.BulkDeleteFunc {
+	return util.BulkDeleteByTask(client, "Role", roleBulkDeleteTaskTimeout,
+		func(ctx context.Context, ids []string) ([]string, error) {
+			live, httpResp, err := client.RolesAPI.ListRolesV1(ctx).
+				Filters(util.FilterIdsIn(ids)).
+				Limit(roleBulkDeleteMax).
+				Execute()
+			if err != nil {
+				return nil, errors.New(roleErrDetail(err, httpResp))
+			}
+			out := make([]string, 0, len(live))
+			for i := range live {
+				out = append(out, live[i].GetId())
+			}
+			return out, nil
+		},
+		func(ctx context.Context, ids []string) (string, map[string]error, error) {
+			task, httpResp, err := client.RolesAPI.DeleteBulkRolesV1(ctx).
+				RoleBulkDeleteRequest(*roles.NewRoleBulkDeleteRequest(ids)).
+				Execute()
+			if err != nil {
+				return "", nil, errors.New(roleErrDetail(err, httpResp))
+			}
+			return task.GetId(), nil, nil
+		},
+	)
+}
@@ -0,0 +1,160 @@
+package role_v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+// Role dimensions are managed through /roles/v1/{roleId}/dimensions, which
+// SailPoint's published per-service roles spec (and therefore golang-sdk's
+// generated roles.RolesAPI) does not include yet: the spec only describes
+// the parent role's "dimensional"/"dimensionRefs" fields, not the
+// dimensions themselves. The helpers below hand-roll those calls the same
+// way access_model_metadata_attribute_v1's DELETE helper does - building on
+// the exported sailpoint.Configuration (BaseURL, cached Token and
+// HTTPClient, with the token from util.BearerToken) rather than forking
+// generated code - and should be swapped for generated calls once a
+// golang-sdk release ships a dimensions API for this service.
+//
+// The dimensions endpoints are still flagged experimental by SailPoint, so
+// every request carries X-SailPoint-Experimental like the SDK's own
+// experimental operations do.
+
+// roleDimension is the API's Dimension object. Membership is kept as a
+// generic map so a criteria tree of any depth round-trips unchanged.
+type roleDimension struct {
+	Id             string                 `json:"id,omitempty"`
+	Name           string                 `json:"name"`
+	Description    *string                `json:"description,omitempty"`
+	Owner          *roleDimensionRef      `json:"owner,omitempty"`
+	AccessProfiles []roleDimensionRef     `json:"accessProfiles,omitempty"`
+	Membership     map[string]interface{} `json:"membership,omitempty"`
+	ParentId       string                 `json:"parentId,omitempty"`
+	Created        string                 `json:"created,omitempty"`
+	Modified       string                 `json:"modified,omitempty"`
+}
+
+type roleDimensionRef struct {
+	Type string `json:"type,omitempty"`
+	Id   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// roleDimensionPatchOp is one RFC 6902 operation in a dimension PATCH body.
+type roleDimensionPatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+func createRoleDimension(ctx context.Context, cfg *sailpoint.Configuration, roleID string, dim roleDimension) (*roleDimension, *http.Response, error) {
+	var out roleDimension
+	httpResp, err := roleDimensionRequest(ctx, cfg, http.MethodPost, roleID, "", dim, &out)
+	if err != nil {
+		return nil, httpResp, err
+	}
+	return &out, httpResp, nil
+}
+
+func getRoleDimension(ctx context.Context, cfg *sailpoint.Configuration, roleID, dimensionID string) (*roleDimension, *http.Response, error) {
+	var out roleDimension
+	httpResp, err := roleDimensionRequest(ctx, cfg, http.MethodGet, roleID, dimensionID, nil, &out)
+	if err != nil {
+		return nil, httpResp, err
+	}
+	return &out, httpResp, nil
+}
+
+func patchRoleDimension(ctx context.Context, cfg *sailpoint.Configuration, roleID, dimensionID string, ops []roleDimensionPatchOp) (*roleDimension, *http.Response, error) {
+	var out roleDimension
+	httpResp, err := roleDimensionRequest(ctx, cfg, http.MethodPatch, roleID, dimensionID, ops, &out)
+	if err != nil {
+		return nil, httpResp, err
+	}
+	return &out, httpResp, nil
+}
+
+func deleteRoleDimension(ctx context.Context, cfg *sailpoint.Configuration, roleID, dimensionID string) (*http.Response, error) {
+	return roleDimensionRequest(ctx, cfg, http.MethodDelete, roleID, dimensionID, nil, nil)
+}
+
+// roleDimensionRequest sends one request to roleID's dimensions collection
+// (dimensionID == "") or to one dimension, decoding a successful response
+// into out when out is non-nil. A non-2xx status is returned as an error
+// alongside the response, whose body is buffered so roleErrDetail can still
+// read SailPoint's error payload from it.
+func roleDimensionRequest(ctx context.Context, cfg *sailpoint.Configuration, method, roleID, dimensionID string, body, out interface{}) (*http.Response, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("no SDK configuration available to build %s request", method)
+	}
+
+	token, err := util.BearerToken(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("could not obtain bearer token: %w", err)
+	}
+
+	reqURL := roleDimensionURL(cfg.ClientConfiguration.BaseURL, roleID, dimensionID)
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("could not encode %s request body: %w", method, err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("could not build %s request: %w", method, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-SailPoint-Experimental", "true")
+	if body != nil {
+		contentType := "application/json"
+		if method == http.MethodPatch {
+			contentType = "application/json-patch+json"
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	httpResp, err := cfg.HTTPClient.StandardClient().Do(req)
+	if err != nil {
+		return httpResp, err
+	}
+	respBody, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	httpResp.Body = io.NopCloser(bytes.NewReader(respBody))
+	if err != nil {
+		return httpResp, fmt.Errorf("could not read %s response: %w", method, err)
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+		return httpResp, fmt.Errorf("%s %s returned %s: %s", method, req.URL.Path, httpResp.Status, string(respBody))
+	}
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return httpResp, fmt.Errorf("could not decode %s response: %w", method, err)
+		}
+	}
+	return httpResp, nil
+}
+
+func roleDimensionURL(baseURL, roleID, dimensionID string) string {
+	u := strings.TrimSuffix(baseURL, "/") + "/roles/v1/" + url.PathEscape(roleID) + "/dimensions"
+	if dimensionID != "" {
+		u += "/" + url.PathEscape(dimensionID)
+	}
+	return u
+}
@@ -0,0 +1,70 @@
+// This file holds identitynow_role_v1's handling of "dimension_refs", the
+// role's list of references to its dimensions.
+//
+// identitynow_role_dimension_v1 adds and removes its own entry in this list,
+// so identitynow_role_v1 never replaces it wholesale on Update: a configured
+// change is sent as the difference between prior state and plan through the
+// targeted operations in resource_role_links.go, which leaves refs added by
+// dimension resources since the last refresh alone. Left unconfigured,
+// dimension_refs is simply read back from the API.
+package role_v1
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-identitynow/internal/provider/role_v1/resource_role"
+)
+
+// roleDimensionRefModelIds returns the ids of a model's dimension_refs
+// list; a null or unknown list has none.
+func roleDimensionRefModelIds(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+
+	var items []resource_role.DimensionRefsValue
+	diags := list.ElementsAs(ctx, &items, false)
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		if !item.Id.IsNull() && !item.Id.IsUnknown() {
+			ids = append(ids, item.Id.ValueString())
+		}
+	}
+	return ids, diags
+}
+
+// roleOrderDimensionRefs puts live (the API's dimension refs) in configured's
+// order, followed by any refs configured doesn't list in API order. Targeted
+// adds always append, so without this a ref configured mid-list would read
+// back at the end and fail Terraform's post-apply consistency check.
+func roleOrderDimensionRefs(ctx context.Context, live []resource_role.DimensionRefsValue, configured types.List) ([]resource_role.DimensionRefsValue, diag.Diagnostics) {
+	ids, diags := roleDimensionRefModelIds(ctx, configured)
+	if len(ids) == 0 {
+		return live, diags
+	}
+
+	byID := make(map[string]resource_role.DimensionRefsValue, len(live))
+	for _, v := range live {
+		byID[v.Id.ValueString()] = v
+	}
+
+	out := make([]resource_role.DimensionRefsValue, 0, len(live))
+	placed := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if v, ok := byID[id]; ok {
+			if _, dup := placed[id]; !dup {
+				out = append(out, v)
+				placed[id] = struct{}{}
+			}
+		}
+	}
+	for _, v := range live {
+		if _, ok := placed[v.Id.ValueString()]; !ok {
+			out = append(out, v)
+		}
+	}
+	return out, diags
+}
@@ -0,0 +1,160 @@
+package role_v1
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-identitynow/internal/provider/role_v1/resource_role"
+)
+
+func TestRoleOrderDimensionRefs(t *testing.T) {
+	ctx := context.Background()
+	ref := func(id string) resource_role.DimensionRefsValue {
+		v, diags := resource_role.NewDimensionRefsValue(
+			resource_role.DimensionRefsValue{}.AttributeTypes(ctx),
+			map[string]attr.Value{
+				"id":   types.StringValue(id),
+				"name": types.StringValue("name-" + id),
+				"type": types.StringValue(roleDimensionRefType),
+			},
+		)
+		if diags.HasError() {
+			t.Fatalf("NewDimensionRefsValue: %v", diags)
+		}
+		return v
+	}
+	ids := func(values []resource_role.DimensionRefsValue) []string {
+		var out []string
+		for _, v := range values {
+			out = append(out, v.Id.ValueString())
+		}
+		return out
+	}
+
+	configured, diags := types.ListValueFrom(ctx, resource_role.DimensionRefsValue{}.Type(ctx), []resource_role.DimensionRefsValue{ref("dim-3"), ref("dim-1"), ref("gone")})
+	if diags.HasError() {
+		t.Fatalf("ListValueFrom: %v", diags)
+	}
+	live := []resource_role.DimensionRefsValue{ref("dim-1"), ref("other"), ref("dim-3")}
+
+	got, diags := roleOrderDimensionRefs(ctx, live, configured)
+	if diags.HasError() {
+		t.Fatalf("roleOrderDimensionRefs returned diagnostics: %v", diags)
+	}
+	if want := []string{"dim-3", "dim-1", "other"}; !reflect.DeepEqual(ids(got), want) {
+		t.Errorf("ordered ids = %v, want %v", ids(got), want)
+	}
+
+	got, _ = roleOrderDimensionRefs(ctx, live, types.ListUnknown(resource_role.DimensionRefsValue{}.Type(ctx)))
+	if want := []string{"dim-1", "other", "dim-3"}; !reflect.DeepEqual(ids(got), want) {
+		t.Errorf("unconfigured dimension_refs should keep API order, got %v", ids(got))
+	}
+}
+
+func TestRoleDimensionCriteriaKeyProblems(t *testing.T) {
+	var node interface{}
+	raw := `{"operation": "OR", "children": [
+  {"operation": "EQUALS", "key": {"type": "IDENTITY", "property": "attribute.location"}, "stringValue": "Austin"},
+  {"operation": "EQUALS", "key": {"type": "ACCOUNT", "property": "attribute.office", "sourceId": "src-1"}, "stringValue": "ATX"}
+]}`
+	if err := json.Unmarshal([]byte(raw), &node); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if problems := validateRoleCriteriaNode(node, ""); len(problems) != 0 {
+		t.Fatalf("criteria should be structurally valid, got %v", problems)
+	}
+	problems := roleDimensionCriteriaKeyProblems(node, "")
+	if len(problems) != 1 || !strings.HasPrefix(problems[0], "children[1].key.type:") {
+		t.Errorf("problems = %v, want one for children[1].key.type", problems)
+	}
+}
+
+func TestRoleDimensionPatchOps(t *testing.T) {
+	desc := "Austin office"
+	dto := roleDimension{
+		Name:           "Austin",
+		Description:    &desc,
+		AccessProfiles: []roleDimensionRef{{Type: roleAccessProfileRefType, Id: "ap-1"}},
+	}
+	var paths []string
+	for _, op := range roleDimensionPatchOps(dto, false) {
+		paths = append(paths, op.Path)
+	}
+	if want := []string{"/name", "/description", "/accessProfiles"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+
+	// A removed description is cleared and a removed access profile list
+	// is sent as empty rather than left alone.
+	ops := roleDimensionPatchOps(roleDimension{Name: "Austin"}, true)
+	b, err := json.Marshal(ops)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `[{"op":"replace","path":"/name","value":"Austin"},{"op":"replace","path":"/description","value":""},{"op":"replace","path":"/accessProfiles","value":[]}]`
+	if string(b) != want {
+		t.Errorf("ops = %s, want %s", b, want)
+	}
+}
+
+func TestRoleDimensionCriteriaJSON(t *testing.T) {
+	membership := map[string]interface{}{
+		"type": roleMembershipTypeStandard,
+		"criteria": map[string]interface{}{
+			"operation":   "EQUALS",
+			"key":         map[string]interface{}{"type": "IDENTITY", "property": "attribute.location", "sourceId": nil},
+			"stringValue": "Austin",
+			"children":    []interface{}{},
+		},
+	}
+
+	prior := jsontypes.NewNormalizedValue(`{"operation":"EQUALS","key":{"type":"IDENTITY","property":"attribute.location"},"stringValue":"Austin"}`)
+	got, diags := roleDimensionCriteriaJSON(membership, prior)
+	if diags.HasError() {
+		t.Fatalf("roleDimensionCriteriaJSON returned diagnostics: %v", diags)
+	}
+	if !got.Equal(prior) {
+		t.Errorf("equivalent criteria should keep prior, got %s", got.ValueString())
+	}
+
+	// Imported: nothing configured yet, so the API's tree is read back.
+	got, _ = roleDimensionCriteriaJSON(membership, jsontypes.NewNormalizedNull())
+	if got.IsNull() || !strings.Contains(got.ValueString(), "attribute.location") {
+		t.Errorf("criteria should be read back after import, got %v", got)
+	}
+
+	got, _ = roleDimensionCriteriaJSON(nil, prior)
+	if !got.IsNull() {
+		t.Errorf("missing membership should read back null, got %s", got.ValueString())
+	}
+}
+
+func TestRoleDimensionIDToParts(t *testing.T) {
+	roleID, dimensionID, err := roleDimensionIDToParts(roleDimensionID("role-1", "dim-1"))
+	if err != nil || roleID != "role-1" || dimensionID != "dim-1" {
+		t.Errorf("roleDimensionIDToParts() = %q, %q, %v", roleID, dimensionID, err)
+	}
+
+	for _, bad := range []string{"role-1", "/dim-1", "role-1/"} {
+		if _, _, err := roleDimensionIDToParts(bad); err == nil {
+			t.Errorf("roleDimensionIDToParts(%q) returned no error", bad)
+		}
+	}
+}
+
+func TestRoleDimensionURL(t *testing.T) {
+	if got, want := roleDimensionURL("https://acme.api.identitynow.com/", "role 1", ""), "https://acme.api.identitynow.com/roles/v1/role%201/dimensions"; got != want {
+		t.Errorf("roleDimensionURL() = %q, want %q", got, want)
+	}
+	if got, want := roleDimensionURL("https://acme.api.identitynow.com", "role-1", "dim-1"), "https://acme.api.identitynow.com/roles/v1/role-1/dimensions/dim-1"; got != want {
+		t.Errorf("roleDimensionURL() = %q, want %q", got, want)
+	}
+}
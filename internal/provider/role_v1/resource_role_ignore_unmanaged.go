@@ -0,0 +1,80 @@
+// This file implements "ignore_unmanaged", a hand-added opt-in that lets
+// identitynow_role_v1 share its role's "access_profiles" with
+// identitynow_role_access_profile_attachment_v1 resources.
+//
+// With it set, "access_profiles" only describes the entries this resource
+// configured: Update sends the difference between prior state and plan as
+// targeted add/remove operations (see resource_role_links.go) instead of
+// replacing the whole list, and the read-back keeps only the live entries
+// that were configured, so access profiles attached by anyone else are
+// neither removed nor reported as drift.
+package role_v1
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-identitynow/internal/provider/role_v1/resource_role"
+)
+
+const defaultRoleIgnoreUnmanaged = false
+
+func applyRoleIgnoreUnmanagedField(attrs *map[string]schema.Attribute) {
+	if *attrs == nil {
+		*attrs = map[string]schema.Attribute{}
+	}
+	(*attrs)["ignore_unmanaged"] = schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(defaultRoleIgnoreUnmanaged),
+		Description: "When true, access_profiles only manages the access profiles listed in configuration; ones attached " +
+			"outside this resource are neither removed nor reported as drift.",
+		MarkdownDescription: "When `true`, `access_profiles` only manages the access profiles listed in configuration: " +
+			"updates add and remove just those entries instead of replacing the whole list, and access profiles attached " +
+			"outside this resource (for example by `identitynow_role_access_profile_attachment_v1`) are neither removed nor " +
+			"reported as drift. Defaults to `false`.",
+	}
+}
+
+// roleAccessProfileRefIds returns the ids of a model's access_profiles list;
+// a null or unknown list has none.
+func roleAccessProfileRefIds(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+
+	var items []resource_role.AccessProfilesValue
+	diags := list.ElementsAs(ctx, &items, false)
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		if !item.Id.IsNull() && !item.Id.IsUnknown() {
+			ids = append(ids, item.Id.ValueString())
+		}
+	}
+	return ids, diags
+}
+
+// roleManagedAccessProfiles narrows live (the API's access profiles) to the
+// ids in managed, in managed's order so a configured list reads back in the
+// order it was written. Managed ids no longer on the role are dropped, which
+// surfaces as drift on the next plan.
+func roleManagedAccessProfiles(ctx context.Context, live []resource_role.AccessProfilesValue, managed types.List) ([]resource_role.AccessProfilesValue, diag.Diagnostics) {
+	ids, diags := roleAccessProfileRefIds(ctx, managed)
+
+	byID := make(map[string]resource_role.AccessProfilesValue, len(live))
+	for _, v := range live {
+		byID[v.Id.ValueString()] = v
+	}
+
+	out := make([]resource_role.AccessProfilesValue, 0, len(ids))
+	for _, id := range ids {
+		if v, ok := byID[id]; ok {
+			out = append(out, v)
+		}
+	}
+	return out, diags
+}
@@ -0,0 +1,239 @@
+// This file holds the helpers shared by the resources that edit part of a
+// role's child lists without owning the whole list:
+// identitynow_role_membership_identities_v1 (membership.identities),
+// identitynow_role_access_profile_attachment_v1 (accessProfiles),
+// identitynow_role_dimension_v1 (dimensionRefs) and identitynow_role_v1
+// itself when ignore_unmanaged is set or dimension_refs changes.
+//
+// accessProfiles and dimensionRefs are edited with targeted JSON Patch
+// operations rather than a whole-list replace: additions append to
+// "<list>/-", and each removal is guarded by a "test" of the id at the index
+// being removed, so a list that was reordered or shrunk by someone else
+// fails the patch instead of losing the wrong entry. patchRoleRefLinks
+// re-reads the role and rebuilds the patch when that happens.
+package role_v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/roles"
+)
+
+const (
+	roleAccessProfileRefType = "ACCESS_PROFILE"
+	roleDimensionRefType     = "DIMENSION"
+)
+
+// roleRefList describes one of a role's lists of {id, type} references.
+type roleRefList struct {
+	path    string
+	refType string
+	ids     func(*roles.Role) []string
+}
+
+var (
+	roleAccessProfileRefList = roleRefList{path: "/accessProfiles", refType: roleAccessProfileRefType, ids: roleAccessProfileIds}
+	roleDimensionRefList     = roleRefList{path: "/dimensionRefs", refType: roleDimensionRefType, ids: roleDimensionRefIds}
+)
+
+// roleRefEdit is one list's share of a patchRoleRefLinks call.
+type roleRefEdit struct {
+	list     roleRefList
+	toAdd    []string
+	toRemove []string
+}
+
+// roleLinkPatchAttempts/-RetryInterval bound how often patchRoleRefLinks
+// retries after a concurrent change to one of the edited lists.
+const (
+	roleLinkPatchAttempts      = 5
+	roleLinkPatchRetryInterval = 2 * time.Second
+)
+
+// roleLocks serializes read-modify-writes of a role's child lists per role
+// ID across every resource instance in this provider process.
+var roleLocks sync.Map
+
+func lockRole(roleID string) func() {
+	mu, _ := roleLocks.LoadOrStore(roleID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// patchRoleAccessProfileLinks adds toAdd to and removes toRemove from
+// roleID's access profiles with targeted operations, sent in one PATCH
+// together with extra (e.g. identitynow_role_v1's other field replaces).
+func patchRoleAccessProfileLinks(ctx context.Context, client *sailpoint.APIClient, roleID string, toAdd, toRemove []string, extra []roles.JsonPatchOperation) (*roles.Role, *http.Response, error) {
+	return patchRoleRefLinks(ctx, client, roleID, []roleRefEdit{{list: roleAccessProfileRefList, toAdd: toAdd, toRemove: toRemove}}, extra)
+}
+
+// patchRoleRefLinks applies edits to roleID's reference lists with targeted
+// operations, sent in one PATCH together with extra. When the PATCH fails
+// and a fresh read shows one of the edited lists moved in the meantime, the
+// patch is rebuilt from that read and retried. Returns the patched role, or
+// the role as read if there was nothing to do.
+func patchRoleRefLinks(ctx context.Context, client *sailpoint.APIClient, roleID string, edits []roleRefEdit, extra []roles.JsonPatchOperation) (*roles.Role, *http.Response, error) {
+	unlock := lockRole(roleID)
+	defer unlock()
+
+	role, httpResp, err := client.RolesAPI.GetRoleV1(ctx, roleID).Execute()
+	if err != nil {
+		return nil, httpResp, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		patch := append([]roles.JsonPatchOperation{}, extra...)
+		for _, e := range edits {
+			patch = append(patch, e.list.linkOps(e.list.ids(role), e.toAdd, e.toRemove)...)
+		}
+		if len(patch) == 0 {
+			return role, httpResp, nil
+		}
+
+		tflog.Debug(ctx, "Patching Role reference lists", map[string]interface{}{
+			"id":        roleID,
+			"attempt":   attempt,
+			"patch_ops": len(patch),
+		})
+
+		patched, patchResp, patchErr := client.RolesAPI.
+			PatchRoleV1(ctx, roleID).
+			JsonPatchOperation(patch).
+			Execute()
+		if patchErr == nil {
+			return patched, patchResp, nil
+		}
+		if attempt >= roleLinkPatchAttempts {
+			return nil, patchResp, patchErr
+		}
+
+		// Only a list that changed since it was read explains a failed
+		// "test" guard; any other failure is returned as-is.
+		latest, _, getErr := client.RolesAPI.GetRoleV1(ctx, roleID).Execute()
+		if getErr != nil || !roleRefListsMoved(edits, role, latest) {
+			return nil, patchResp, patchErr
+		}
+
+		tflog.Warn(ctx, "Role reference lists changed concurrently, retrying patch", map[string]interface{}{
+			"id":      roleID,
+			"attempt": attempt,
+		})
+		role = latest
+
+		timer := time.NewTimer(roleLinkPatchRetryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, patchResp, patchErr
+		case <-timer.C:
+		}
+	}
+}
+
+func roleRefListsMoved(edits []roleRefEdit, before, after *roles.Role) bool {
+	for _, e := range edits {
+		if !roleIdsEqual(e.list.ids(before), e.list.ids(after)) {
+			return true
+		}
+	}
+	return false
+}
+
+// linkOps returns the JSON Patch operations that take current (the list's
+// ids, in API order) to current minus toRemove plus toAdd. Removals run from
+// the highest index down so earlier removals don't shift later ones; ids
+// already in the right state produce no operation.
+func (l roleRefList) linkOps(current, toAdd, toRemove []string) []roles.JsonPatchOperation {
+	removeSet := make(map[string]struct{}, len(toRemove))
+	for _, id := range toRemove {
+		removeSet[id] = struct{}{}
+	}
+
+	var ops []roles.JsonPatchOperation
+	kept := make(map[string]struct{}, len(current))
+	for i := len(current) - 1; i >= 0; i-- {
+		id := current[i]
+		if _, remove := removeSet[id]; !remove {
+			kept[id] = struct{}{}
+			continue
+		}
+		entryPath := fmt.Sprintf("%s/%d", l.path, i)
+		ops = append(ops,
+			roleJSONPatchTest(entryPath+"/id", roles.StringAsJsonPatchOperationValue(&id)),
+			roleJSONPatchRemove(entryPath),
+		)
+	}
+
+	for _, id := range toAdd {
+		if _, present := kept[id]; id == "" || present {
+			continue
+		}
+		kept[id] = struct{}{}
+		ref := map[string]interface{}{"id": id, "type": l.refType}
+		ops = append(ops, roleJSONPatchAdd(l.path+"/-", roles.MapmapOfStringAnyAsJsonPatchOperationValue(&ref)))
+	}
+	return ops
+}
+
+// roleAccessProfileIds returns role's access profile ids, in API order.
+func roleAccessProfileIds(role *roles.Role) []string {
+	if role == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(role.AccessProfiles))
+	for _, ref := range role.AccessProfiles {
+		if ref.Id != nil {
+			ids = append(ids, *ref.Id)
+		}
+	}
+	return ids
+}
+
+// roleDimensionRefIds returns role's dimension ref ids, in API order.
+func roleDimensionRefIds(role *roles.Role) []string {
+	if role == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(role.DimensionRefs))
+	for _, ref := range role.DimensionRefs {
+		if ref.Id != nil {
+			ids = append(ids, *ref.Id)
+		}
+	}
+	return ids
+}
+
+// retainRoleIds returns the tracked ids still present in current.
+func retainRoleIds(current, tracked []string) []string {
+	currentSet := make(map[string]struct{}, len(current))
+	for _, id := range current {
+		currentSet[id] = struct{}{}
+	}
+
+	retained := make([]string, 0, len(tracked))
+	for _, id := range tracked {
+		if _, ok := currentSet[id]; ok {
+			retained = append(retained, id)
+		}
+	}
+	return retained
+}
+
+func roleIdsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
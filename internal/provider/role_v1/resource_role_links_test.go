@@ -0,0 +1,136 @@
+package role_v1
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sailpoint-oss/golang-sdk/v3/roles"
+
+	"terraform-provider-identitynow/internal/provider/role_v1/resource_role"
+)
+
+// describeRoleLinkOps renders ops as "op path value" strings so tests can
+// compare them without depending on the SDK's JSON Patch value wrapper.
+func describeRoleLinkOps(t *testing.T, ops []roles.JsonPatchOperation) []string {
+	t.Helper()
+	out := make([]string, 0, len(ops))
+	for _, op := range ops {
+		s := op.Op + " " + op.Path
+		if op.Value != nil {
+			b, err := json.Marshal(op.Value)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			s += " " + string(b)
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestRoleRefListLinkOps(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     roleRefList
+		current  []string
+		toAdd    []string
+		toRemove []string
+		want     []string
+	}{
+		{
+			name:     "removes from the highest index down, guarding each with a test",
+			list:     roleAccessProfileRefList,
+			current:  []string{"ap-1", "ap-2", "ap-3", "ap-4"},
+			toRemove: []string{"ap-2", "ap-4"},
+			want: []string{
+				`test /accessProfiles/3/id "ap-4"`,
+				`remove /accessProfiles/3`,
+				`test /accessProfiles/1/id "ap-2"`,
+				`remove /accessProfiles/1`,
+			},
+		},
+		{
+			name:    "appends only ids not already attached",
+			list:    roleAccessProfileRefList,
+			current: []string{"ap-1"},
+			toAdd:   []string{"ap-1", "ap-2", "ap-2", ""},
+			want: []string{
+				`add /accessProfiles/- {"id":"ap-2","type":"ACCESS_PROFILE"}`,
+			},
+		},
+		{
+			name:     "dimension refs use their own path and type",
+			list:     roleDimensionRefList,
+			current:  []string{"dim-1", "dim-2"},
+			toAdd:    []string{"dim-3"},
+			toRemove: []string{"dim-1"},
+			want: []string{
+				`test /dimensionRefs/0/id "dim-1"`,
+				`remove /dimensionRefs/0`,
+				`add /dimensionRefs/- {"id":"dim-3","type":"DIMENSION"}`,
+			},
+		},
+		{
+			name:     "nothing to do",
+			list:     roleAccessProfileRefList,
+			current:  []string{"ap-1"},
+			toAdd:    []string{"ap-1"},
+			toRemove: []string{"ap-9"},
+			want:     []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := describeRoleLinkOps(t, tt.list.linkOps(tt.current, tt.toAdd, tt.toRemove))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("linkOps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoleManagedAccessProfiles(t *testing.T) {
+	ctx := context.Background()
+	ref := func(id string) resource_role.AccessProfilesValue {
+		v, diags := resource_role.NewAccessProfilesValue(
+			resource_role.AccessProfilesValue{}.AttributeTypes(ctx),
+			map[string]attr.Value{
+				"id":   types.StringValue(id),
+				"name": types.StringValue("name-" + id),
+				"type": types.StringValue(roleAccessProfileRefType),
+			},
+		)
+		if diags.HasError() {
+			t.Fatalf("NewAccessProfilesValue: %v", diags)
+		}
+		return v
+	}
+
+	managed, diags := types.ListValueFrom(ctx, resource_role.AccessProfilesValue{}.Type(ctx), []resource_role.AccessProfilesValue{ref("mine-2"), ref("mine-1"), ref("gone")})
+	if diags.HasError() {
+		t.Fatalf("ListValueFrom: %v", diags)
+	}
+	live := []resource_role.AccessProfilesValue{ref("other-1"), ref("mine-1"), ref("mine-2"), ref("other-2")}
+
+	got, diags := roleManagedAccessProfiles(ctx, live, managed)
+	if diags.HasError() {
+		t.Fatalf("roleManagedAccessProfiles returned diagnostics: %v", diags)
+	}
+	var ids []string
+	for _, v := range got {
+		ids = append(ids, v.Id.ValueString())
+	}
+	if want := []string{"mine-2", "mine-1"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("managed ids = %v, want %v", ids, want)
+	}
+
+	got, _ = roleManagedAccessProfiles(ctx, live, types.ListUnknown(resource_role.AccessProfilesValue{}.Type(ctx)))
+	if len(got) != 0 {
+		t.Errorf("unconfigured access_profiles should read back empty, got %d entries", len(got))
+	}
+}
@@ -0,0 +1,316 @@
+// This file implements "membership_criteria_json", a hand-added alternative
+// to the generated "membership" block for STANDARD (criteria-based) role
+// membership. The generated block is only 3 levels deep (criteria ->
+// children -> grandchildren), matching how tfplugingen-framework flattened
+// the recursive RoleCriteriaLevel1/2/3 schema, so deeper AND/OR nesting can
+// neither be configured nor read back without being truncated.
+//
+// The JSON form is the API's own criteria object ({operation, key,
+// stringValue, children}) with no depth limit. It is sent verbatim as
+// membership.criteria on Create/Update and read back from the API response
+// the same way, so - unlike "membership" - it has real write support and
+// drift detection. The two are mutually exclusive (see ConfigValidators).
+package role_v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+
+	"github.com/sailpoint-oss/golang-sdk/v3/roles"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+const roleMembershipTypeStandard = "STANDARD"
+
+// roleCriteriaCompositeOperations combine their children; every other
+// operation in roleCriteriaOperations is a leaf comparison against a key.
+var roleCriteriaCompositeOperations = []string{"AND", "OR"}
+
+// roleCriteriaOperations is RoleCriteriaOperation's enum from the v1 spec.
+var roleCriteriaOperations = []string{
+	"EQUALS", "NOT_EQUALS", "CONTAINS", "DOES_NOT_CONTAIN", "STARTS_WITH", "ENDS_WITH",
+	"GREATER_THAN", "LESS_THAN", "GREATER_THAN_EQUALS", "LESS_THAN_EQUALS", "AND", "OR",
+}
+
+// roleCriteriaKeyTypes is RoleCriteriaKeyType's enum from the v1 spec.
+// ACCOUNT and ENTITLEMENT keys are scoped to a source and require sourceId.
+var roleCriteriaKeyTypes = []string{"IDENTITY", "ACCOUNT", "ENTITLEMENT"}
+
+func applyRoleMembershipCriteriaJSONField(attrs *map[string]schema.Attribute) {
+	if *attrs == nil {
+		*attrs = map[string]schema.Attribute{}
+	}
+	(*attrs)["membership_criteria_json"] = schema.StringAttribute{
+		CustomType: jsontypes.NormalizedType{},
+		Optional:   true,
+		Description: "STANDARD membership criteria as a raw JSON object ({operation, key, stringValue, children}) with no " +
+			"nesting limit. Conflicts with membership.",
+		MarkdownDescription: "`STANDARD` membership criteria as a raw JSON object (`{operation, key, stringValue, children}`, " +
+			"the API's `membership.criteria` shape) with no nesting limit, for criteria trees deeper than the 3 levels the " +
+			"`membership` block can express. Operators, key types and required fields are validated at plan time. " +
+			"Conflicts with `membership`.",
+	}
+}
+
+func (r *roleResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("membership"),
+			path.MatchRoot("membership_criteria_json"),
+		),
+	}
+}
+
+func (r *roleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var criteria jsontypes.Normalized
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("membership_criteria_json"), &criteria)...)
+	if resp.Diagnostics.HasError() || criteria.IsNull() || criteria.IsUnknown() {
+		return
+	}
+
+	var node interface{}
+	if err := json.Unmarshal([]byte(criteria.ValueString()), &node); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("membership_criteria_json"),
+			"Invalid membership criteria JSON",
+			fmt.Sprintf("Could not decode \"membership_criteria_json\" as JSON: %s", err.Error()),
+		)
+		return
+	}
+	for _, problem := range validateRoleCriteriaNode(node, "") {
+		resp.Diagnostics.AddAttributeError(path.Root("membership_criteria_json"), "Invalid membership criteria", problem)
+	}
+}
+
+// validateRoleCriteriaNode checks one criteria node and, recursively, its
+// children against the rules the API enforces on create, returning one
+// message per problem prefixed with the node's JSON path (e.g.
+// "children[1].key.type").
+func validateRoleCriteriaNode(v interface{}, at string) []string {
+	node, ok := v.(map[string]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("%s: must be a JSON object", roleCriteriaPath(at))}
+	}
+
+	var problems []string
+	for _, k := range util.SortedKeys(node) {
+		switch k {
+		case "operation", "key", "stringValue", "children":
+		default:
+			problems = append(problems, fmt.Sprintf("%s: unknown field (expected operation, key, stringValue or children)", roleCriteriaPath(roleCriteriaJoin(at, k))))
+		}
+	}
+
+	op, _ := node["operation"].(string)
+	if !util.ContainsString(roleCriteriaOperations, op) {
+		return append(problems, fmt.Sprintf("%s: must be one of %s, got %s",
+			roleCriteriaPath(roleCriteriaJoin(at, "operation")), strings.Join(roleCriteriaOperations, ", "), util.DescribeJSONValue(node["operation"])))
+	}
+
+	children, hasChildren := roleCriteriaField(node, "children")
+	if util.ContainsString(roleCriteriaCompositeOperations, op) {
+		for _, k := range []string{"key", "stringValue"} {
+			if _, ok := roleCriteriaField(node, k); ok {
+				problems = append(problems, fmt.Sprintf("%s: must not be set when operation is %s", roleCriteriaPath(roleCriteriaJoin(at, k)), op))
+			}
+		}
+		list, ok := children.([]interface{})
+		if !ok || len(list) == 0 {
+			return append(problems, fmt.Sprintf("%s: must be a non-empty list when operation is %s", roleCriteriaPath(roleCriteriaJoin(at, "children")), op))
+		}
+		for i, child := range list {
+			problems = append(problems, validateRoleCriteriaNode(child, fmt.Sprintf("%s[%d]", roleCriteriaJoin(at, "children"), i))...)
+		}
+		return problems
+	}
+
+	if list, ok := children.([]interface{}); hasChildren && (!ok || len(list) > 0) {
+		problems = append(problems, fmt.Sprintf("%s: must not be set when operation is %s", roleCriteriaPath(roleCriteriaJoin(at, "children")), op))
+	}
+	if s, ok := node["stringValue"].(string); !ok || s == "" {
+		problems = append(problems, fmt.Sprintf("%s: is required when operation is %s", roleCriteriaPath(roleCriteriaJoin(at, "stringValue")), op))
+	}
+	return append(problems, validateRoleCriteriaKey(node["key"], roleCriteriaJoin(at, "key"), op)...)
+}
+
+func validateRoleCriteriaKey(v interface{}, at, op string) []string {
+	key, ok := v.(map[string]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("%s: an object is required when operation is %s", roleCriteriaPath(at), op)}
+	}
+
+	var problems []string
+	for _, k := range util.SortedKeys(key) {
+		switch k {
+		case "type", "property", "sourceId":
+		default:
+			problems = append(problems, fmt.Sprintf("%s: unknown field (expected type, property or sourceId)", roleCriteriaPath(roleCriteriaJoin(at, k))))
+		}
+	}
+
+	typ, _ := key["type"].(string)
+	if !util.ContainsString(roleCriteriaKeyTypes, typ) {
+		problems = append(problems, fmt.Sprintf("%s: must be one of %s, got %s",
+			roleCriteriaPath(roleCriteriaJoin(at, "type")), strings.Join(roleCriteriaKeyTypes, ", "), util.DescribeJSONValue(key["type"])))
+	}
+	if s, ok := key["property"].(string); !ok || s == "" {
+		problems = append(problems, fmt.Sprintf("%s: is required", roleCriteriaPath(roleCriteriaJoin(at, "property"))))
+	}
+	if typ == "ACCOUNT" || typ == "ENTITLEMENT" {
+		if s, ok := key["sourceId"].(string); !ok || s == "" {
+			problems = append(problems, fmt.Sprintf("%s: is required when key type is %s", roleCriteriaPath(roleCriteriaJoin(at, "sourceId")), typ))
+		}
+	}
+	return problems
+}
+
+// roleMembershipSelectorFromCriteriaJSON wraps the configured criteria in a
+// STANDARD membership selector, returned as a generic map so it can be sent
+// as-is in a JSON Patch value. Create decodes the same map into
+// roles.RoleMembershipSelector; criteria deeper than RoleCriteriaLevel3
+// survive that decode in the SDK models' AdditionalProperties.
+func roleMembershipSelectorFromCriteriaJSON(v jsontypes.Normalized) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var criteria map[string]interface{}
+	if err := json.Unmarshal([]byte(v.ValueString()), &criteria); err != nil {
+		diags.AddError(
+			"Invalid membership criteria JSON",
+			fmt.Sprintf("Could not decode \"membership_criteria_json\" as a JSON object: %s", err.Error()),
+		)
+		return nil, diags
+	}
+	return map[string]interface{}{
+		"type":     roleMembershipTypeStandard,
+		"criteria": criteria,
+	}, diags
+}
+
+func roleMembershipSelectorToApi(selector map[string]interface{}) (*roles.RoleMembershipSelector, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	b, err := json.Marshal(selector)
+	if err == nil {
+		var dto roles.RoleMembershipSelector
+		if err = json.Unmarshal(b, &dto); err == nil {
+			return &dto, diags
+		}
+	}
+	diags.AddError(
+		"Invalid membership criteria JSON",
+		fmt.Sprintf("Could not convert \"membership_criteria_json\" into a role membership selector: %s", err.Error()),
+	)
+	return nil, diags
+}
+
+// roleMembershipCriteriaJSONFromApi re-encodes the API's membership.criteria
+// for "membership_criteria_json". prior (the plan or prior state value) is
+// kept whenever it describes the same tree, since the API echoes criteria
+// back with explicit nulls (sourceId on IDENTITY keys, stringValue on AND/OR
+// nodes) and empty children lists that jsontypes.Normalized alone would
+// report as a diff. A null prior means the practitioner isn't using this
+// attribute, so it stays null rather than shadowing "membership".
+func roleMembershipCriteriaJSONFromApi(dto *roles.RoleMembershipSelector, prior jsontypes.Normalized) (jsontypes.Normalized, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if prior.IsNull() {
+		return prior, diags
+	}
+	if dto == nil || dto.Criteria.Get() == nil {
+		return jsontypes.NewNormalizedNull(), diags
+	}
+
+	b, err := json.Marshal(dto.Criteria.Get())
+	if err != nil {
+		diags.AddError(
+			"Error encoding membership criteria from API response",
+			fmt.Sprintf("Could not re-encode the API's membership criteria as JSON: %s", err.Error()),
+		)
+		return prior, diags
+	}
+	var remote interface{}
+	if err := json.Unmarshal(b, &remote); err != nil {
+		diags.AddError(
+			"Error encoding membership criteria from API response",
+			fmt.Sprintf("Could not re-encode the API's membership criteria as JSON: %s", err.Error()),
+		)
+		return prior, diags
+	}
+	remote = compactRoleCriteria(remote)
+
+	if !prior.IsUnknown() {
+		var local interface{}
+		if err := json.Unmarshal([]byte(prior.ValueString()), &local); err == nil && reflect.DeepEqual(compactRoleCriteria(local), remote) {
+			return prior, diags
+		}
+	}
+
+	out, err := json.Marshal(remote)
+	if err != nil {
+		diags.AddError(
+			"Error encoding membership criteria from API response",
+			fmt.Sprintf("Could not re-encode the API's membership criteria as JSON: %s", err.Error()),
+		)
+		return prior, diags
+	}
+	return jsontypes.NewNormalizedValue(string(out)), diags
+}
+
+// compactRoleCriteria drops null values and empty lists/objects at every
+// depth so a criteria tree compares equal however the API or the
+// practitioner spells "not set".
+func compactRoleCriteria(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, child := range t {
+			if c := compactRoleCriteria(child); c != nil {
+				out[k] = c
+			}
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, 0, len(t))
+		for _, child := range t {
+			if c := compactRoleCriteria(child); c != nil {
+				out = append(out, c)
+			}
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func roleCriteriaField(m map[string]interface{}, k string) (interface{}, bool) {
+	v, ok := m[k]
+	return v, ok && v != nil
+}
+
+func roleCriteriaJoin(at, field string) string {
+	if at == "" {
+		return field
+	}
+	return at + "." + field
+}
+
+func roleCriteriaPath(at string) string {
+	if at == "" {
+		return "criteria"
+	}
+	return at
+}
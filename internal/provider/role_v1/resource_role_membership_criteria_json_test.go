@@ -0,0 +1,238 @@
+package role_v1
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/sailpoint-oss/golang-sdk/v3/roles"
+)
+
+// testDeepRoleCriteria nests AND/OR four levels deep - one more than the
+// generated "membership" block can express.
+const testDeepRoleCriteria = `{
+  "operation": "AND",
+  "children": [
+    {"operation": "EQUALS", "key": {"type": "IDENTITY", "property": "attribute.department"}, "stringValue": "Engineering"},
+    {"operation": "OR", "children": [
+      {"operation": "AND", "children": [
+        {"operation": "EQUALS", "key": {"type": "ACCOUNT", "property": "attribute.type", "sourceId": "src-1"}, "stringValue": "employee"},
+        {"operation": "OR", "children": [
+          {"operation": "STARTS_WITH", "key": {"type": "IDENTITY", "property": "attribute.location"}, "stringValue": "US-"},
+          {"operation": "CONTAINS", "key": {"type": "ENTITLEMENT", "property": "attribute.memberOf", "sourceId": "src-2"}, "stringValue": "CN=Staff"}
+        ]}
+      ]}
+    ]}
+  ]
+}`
+
+func TestValidateRoleCriteriaNode_Valid(t *testing.T) {
+	var node interface{}
+	if err := json.Unmarshal([]byte(testDeepRoleCriteria), &node); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if problems := validateRoleCriteriaNode(node, ""); len(problems) != 0 {
+		t.Errorf("validateRoleCriteriaNode returned problems for a valid tree: %v", problems)
+	}
+}
+
+func TestValidateRoleCriteriaNode_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{
+			name: "unknown operation",
+			raw:  `{"operation": "MATCHES", "key": {"type": "IDENTITY", "property": "x"}, "stringValue": "y"}`,
+			want: "operation: must be one of",
+		},
+		{
+			name: "bad key type deep in the tree",
+			raw:  `{"operation": "OR", "children": [{"operation": "AND", "children": [{"operation": "EQUALS", "key": {"type": "ROLE", "property": "x"}, "stringValue": "y"}]}]}`,
+			want: "children[0].children[0].key.type: must be one of",
+		},
+		{
+			name: "missing sourceId for ACCOUNT key",
+			raw:  `{"operation": "EQUALS", "key": {"type": "ACCOUNT", "property": "x"}, "stringValue": "y"}`,
+			want: "key.sourceId: is required when key type is ACCOUNT",
+		},
+		{
+			name: "composite without children",
+			raw:  `{"operation": "AND", "children": []}`,
+			want: "children: must be a non-empty list",
+		},
+		{
+			name: "composite with a key",
+			raw:  `{"operation": "OR", "key": {"type": "IDENTITY", "property": "x"}, "children": [{"operation": "EQUALS", "key": {"type": "IDENTITY", "property": "x"}, "stringValue": "y"}]}`,
+			want: "key: must not be set when operation is OR",
+		},
+		{
+			name: "leaf with children",
+			raw:  `{"operation": "EQUALS", "key": {"type": "IDENTITY", "property": "x"}, "stringValue": "y", "children": [{"operation": "AND"}]}`,
+			want: "children: must not be set when operation is EQUALS",
+		},
+		{
+			name: "leaf without stringValue",
+			raw:  `{"operation": "EQUALS", "key": {"type": "IDENTITY", "property": "x"}}`,
+			want: "stringValue: is required",
+		},
+		{
+			name: "unknown field",
+			raw:  `{"operation": "EQUALS", "key": {"type": "IDENTITY", "property": "x"}, "stringValue": "y", "value": "z"}`,
+			want: "value: unknown field",
+		},
+		{
+			name: "not an object",
+			raw:  `["AND"]`,
+			want: "criteria: must be a JSON object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var node interface{}
+			if err := json.Unmarshal([]byte(tt.raw), &node); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			problems := validateRoleCriteriaNode(node, "")
+			for _, p := range problems {
+				if strings.HasPrefix(p, tt.want) {
+					return
+				}
+			}
+			t.Errorf("validateRoleCriteriaNode problems = %v, want one starting with %q", problems, tt.want)
+		})
+	}
+}
+
+func TestRoleModelToDto_MembershipCriteriaJSON(t *testing.T) {
+	model := minimalRoleModel()
+	model.Owner = ownerModel(t, "owner-id", "", "IDENTITY")
+	model.MembershipCriteriaJson = jsontypes.NewNormalizedValue(testDeepRoleCriteria)
+
+	dto, diags := roleModelToDto(context.Background(), model)
+	if diags.HasError() {
+		t.Fatalf("roleModelToDto returned diagnostics: %v", diags)
+	}
+	membership := dto.Membership.Get()
+	if membership == nil {
+		t.Fatal("Membership = nil, want a STANDARD selector")
+	}
+	if membership.Type == nil || string(*membership.Type) != roleMembershipTypeStandard {
+		t.Errorf("Membership.Type = %v, want %q", membership.Type, roleMembershipTypeStandard)
+	}
+
+	// The SDK models stop at RoleCriteriaLevel3; the fourth level must still
+	// survive a marshal of the request body.
+	b, err := json.Marshal(membership.Criteria.Get())
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(b), "CN=Staff") {
+		t.Errorf("criteria lost its deepest level: %s", b)
+	}
+}
+
+// TestRoleMembershipSelectorToApi_DeepCriteria checks that criteria nested
+// past RoleCriteriaLevel3 come back out of the SDK model unchanged, whether
+// re-encoded directly or through roleMembershipCriteriaJSONFromApi.
+func TestRoleMembershipSelectorToApi_DeepCriteria(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{
+			name: "depth 4",
+			raw: `{"operation": "OR", "children": [
+  {"operation": "AND", "children": [
+    {"operation": "OR", "children": [
+      {"operation": "EQUALS", "key": {"type": "ACCOUNT", "property": "attribute.type", "sourceId": "src-1"}, "stringValue": "contractor"},
+      {"operation": "NOT_EQUALS", "key": {"type": "IDENTITY", "property": "attribute.cloudLifecycleState"}, "stringValue": "inactive"}
+    ]},
+    {"operation": "ENDS_WITH", "key": {"type": "IDENTITY", "property": "attribute.email"}, "stringValue": "@example.com"}
+  ]}
+]}`,
+		},
+		{name: "depth 5", raw: testDeepRoleCriteria},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var want interface{}
+			if err := json.Unmarshal([]byte(tt.raw), &want); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			selector, diags := roleMembershipSelectorFromCriteriaJSON(jsontypes.NewNormalizedValue(tt.raw))
+			if diags.HasError() {
+				t.Fatalf("roleMembershipSelectorFromCriteriaJSON returned diagnostics: %v", diags)
+			}
+			dto, diags := roleMembershipSelectorToApi(selector)
+			if diags.HasError() {
+				t.Fatalf("roleMembershipSelectorToApi returned diagnostics: %v", diags)
+			}
+
+			b, err := json.Marshal(dto.Criteria.Get())
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			var got interface{}
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if !reflect.DeepEqual(compactRoleCriteria(got), compactRoleCriteria(want)) {
+				t.Errorf("criteria changed in the SDK model:\n got %s\nwant %s", b, tt.raw)
+			}
+
+			remote, diags := roleMembershipCriteriaJSONFromApi(dto, jsontypes.NewNormalizedUnknown())
+			if diags.HasError() {
+				t.Fatalf("roleMembershipCriteriaJSONFromApi returned diagnostics: %v", diags)
+			}
+			var reencoded interface{}
+			if err := json.Unmarshal([]byte(remote.ValueString()), &reencoded); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if !reflect.DeepEqual(reencoded, compactRoleCriteria(want)) {
+				t.Errorf("roleMembershipCriteriaJSONFromApi = %s, want %s", remote.ValueString(), tt.raw)
+			}
+		})
+	}
+}
+
+func TestRoleMembershipCriteriaJSONFromApi(t *testing.T) {
+	var dto roles.RoleMembershipSelector
+	// The API echoes explicit nulls and empty children lists back.
+	raw := `{"type": "STANDARD", "criteria": {"operation": "OR", "key": null, "stringValue": null, "children": [
+  {"operation": "EQUALS", "key": {"type": "IDENTITY", "property": "attribute.department", "sourceId": null}, "stringValue": "Engineering", "children": []}
+]}}`
+	if err := json.Unmarshal([]byte(raw), &dto); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	prior := jsontypes.NewNormalizedValue(`{"operation": "OR", "children": [{"operation": "EQUALS", "key": {"type": "IDENTITY", "property": "attribute.department"}, "stringValue": "Engineering"}]}`)
+	got, diags := roleMembershipCriteriaJSONFromApi(&dto, prior)
+	if diags.HasError() {
+		t.Fatalf("roleMembershipCriteriaJSONFromApi returned diagnostics: %v", diags)
+	}
+	if got.ValueString() != prior.ValueString() {
+		t.Errorf("equivalent criteria were not kept as configured: %s", got.ValueString())
+	}
+
+	drifted := jsontypes.NewNormalizedValue(`{"operation": "OR", "children": [{"operation": "EQUALS", "key": {"type": "IDENTITY", "property": "attribute.department"}, "stringValue": "Sales"}]}`)
+	got, diags = roleMembershipCriteriaJSONFromApi(&dto, drifted)
+	if diags.HasError() {
+		t.Fatalf("roleMembershipCriteriaJSONFromApi returned diagnostics: %v", diags)
+	}
+	if !strings.Contains(got.ValueString(), "Engineering") {
+		t.Errorf("drift was not surfaced, got %s", got.ValueString())
+	}
+
+	got, _ = roleMembershipCriteriaJSONFromApi(&dto, jsontypes.NewNormalizedNull())
+	if !got.IsNull() {
+		t.Errorf("unconfigured attribute = %s, want null", got.ValueString())
+	}
+}
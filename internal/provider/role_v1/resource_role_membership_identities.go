@@ -0,0 +1,461 @@
+// This file implements identitynow_role_membership_identities_v1, a fully
+// hand-written, non-authoritative resource for the identities of a role with
+// IDENTITY_LIST membership.
+//
+// There is no role membership endpoint to CRUD: the identity list lives in
+// the role's own membership.identities and can only be written by replacing
+// /membership through PATCH /roles/v1/{id}. Several instances of this
+// resource may therefore share one role, each owning only the identity IDs
+// it tracks, with the same subset semantics as
+// application_access_association_v1:
+//   - Create adds this resource's identity_ids to the role's live list.
+//   - Read self-heals by intersecting the tracked ids with the live list,
+//     shrinking state only for ids that truly disappeared.
+//   - Update diffs the old and new tracked ids (util.DiffIds, the same
+//     reconciliation as governance_group_v1's members) and applies only that
+//     delta to the live list.
+//   - Delete removes only this resource's tracked ids.
+//
+// Every write is a read-modify-write of the whole list, so writes to the same
+// role from this provider are serialized with lockRole. Writes from other
+// tools between the read and the PATCH can still be lost.
+//
+// Identity entries are handled as generic JSON so everything the API returns
+// for identities this resource doesn't own (name, aliasName, ...) is written
+// back untouched.
+package role_v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/roles"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+const (
+	roleMembershipTypeIdentityList = "IDENTITY_LIST"
+	roleMembershipIdentityType     = "IDENTITY"
+)
+
+var (
+	_ resource.Resource                = (*roleMembershipIdentitiesResource)(nil)
+	_ resource.ResourceWithConfigure   = (*roleMembershipIdentitiesResource)(nil)
+	_ resource.ResourceWithImportState = (*roleMembershipIdentitiesResource)(nil)
+)
+
+func NewRoleMembershipIdentitiesResource() resource.Resource {
+	return &roleMembershipIdentitiesResource{}
+}
+
+type roleMembershipIdentitiesResource struct {
+	client *sailpoint.APIClient
+}
+
+type roleMembershipIdentitiesResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	RoleId      types.String `tfsdk:"role_id"`
+	IdentityIds types.Set    `tfsdk:"identity_ids"`
+}
+
+func (r *roleMembershipIdentitiesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_membership_identities_v1"
+}
+
+func (r *roleMembershipIdentitiesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceschema.Schema{
+		Description: "Manages an additive subset of the identities in a Role's IDENTITY_LIST membership in IdentityNow/ISC.",
+		MarkdownDescription: "Manages an additive subset of the identities in a [Role](https://documentation.sailpoint.com/saas/help/access/roles.html)'s " +
+			"`IDENTITY_LIST` membership in IdentityNow/ISC. This is a fully hand-written `_v1` resource: it edits the role's " +
+			"`membership.identities` through `PATCH /roles/v1/{id}`, adding and removing only the identities it tracks so several " +
+			"resources (and teams) can each own part of the same role's identity list.",
+		Attributes: map[string]resourceschema.Attribute{
+			"id": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "Same value as role_id.",
+				MarkdownDescription: "Same value as `role_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role_id": resourceschema.StringAttribute{
+				Required:            true,
+				Description:         "ID of the Role whose identity list is managed.",
+				MarkdownDescription: "ID of the Role whose identity list is managed. The role must have `IDENTITY_LIST` membership or no membership at all. Changing this forces replacement.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"identity_ids": resourceschema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				Description:         "Subset of identity IDs this resource adds to the Role's identity list.",
+				MarkdownDescription: "Subset of identity IDs this resource adds to the Role's identity list. This is **not** necessarily the role's full live identity list; identities added by anyone else are left alone.",
+			},
+		},
+	}
+}
+
+func (r *roleMembershipIdentitiesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = cp.GetClient()
+}
+
+func (r *roleMembershipIdentitiesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan roleMembershipIdentitiesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleID := plan.RoleId.ValueString()
+	plan.Id = plan.RoleId
+
+	desired, diags := roleMembershipSetToStrings(ctx, plan.IdentityIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating Role membership identities", map[string]interface{}{"role_id": roleID, "tracked_ids": len(desired)})
+
+	if err := r.reconcile(ctx, roleID, desired, nil); err != nil {
+		resp.Diagnostics.AddError("Error adding Role membership identities", err.Error())
+		return
+	}
+
+	tflog.Info(ctx, "Created Role membership identities", map[string]interface{}{"role_id": roleID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *roleMembershipIdentitiesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state roleMembershipIdentitiesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleID := state.RoleId.ValueString()
+	state.Id = state.RoleId
+
+	tflog.Debug(ctx, "Reading Role membership identities", map[string]interface{}{"role_id": roleID})
+
+	selector, httpResp, err := r.getMembership(ctx, roleID)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Warn(ctx, "Role not found, removing membership identities from state", map[string]interface{}{"role_id": roleID})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading Role membership identities", roleErrDetail(err, httpResp))
+		return
+	}
+
+	tracked, diags := roleMembershipSetToStrings(ctx, state.IdentityIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	retained := retainRoleIds(roleMembershipIdentityIds(selector), tracked)
+	set, diags := types.SetValueFrom(ctx, types.StringType, retained)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.IdentityIds = set
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *roleMembershipIdentitiesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan roleMembershipIdentitiesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state roleMembershipIdentitiesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleID := plan.RoleId.ValueString()
+	plan.Id = plan.RoleId
+
+	oldTracked, diags := roleMembershipSetToStrings(ctx, state.IdentityIds)
+	resp.Diagnostics.Append(diags...)
+	newTracked, diags := roleMembershipSetToStrings(ctx, plan.IdentityIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	toAdd, toRemove := util.DiffIds(oldTracked, newTracked)
+
+	tflog.Debug(ctx, "Updating Role membership identities", map[string]interface{}{
+		"role_id":   roleID,
+		"to_add":    len(toAdd),
+		"to_remove": len(toRemove),
+	})
+
+	if err := r.reconcile(ctx, roleID, toAdd, toRemove); err != nil {
+		resp.Diagnostics.AddError("Error updating Role membership identities", err.Error())
+		return
+	}
+
+	tflog.Info(ctx, "Updated Role membership identities", map[string]interface{}{"role_id": roleID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *roleMembershipIdentitiesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state roleMembershipIdentitiesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleID := state.RoleId.ValueString()
+	tracked, diags := roleMembershipSetToStrings(ctx, state.IdentityIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting Role membership identities", map[string]interface{}{"role_id": roleID, "tracked_ids": len(tracked)})
+
+	if err := r.reconcile(ctx, roleID, nil, tracked); err != nil {
+		var notFound *roleNotFoundError
+		if errors.As(err, &notFound) {
+			tflog.Warn(ctx, "Role already absent on membership identities delete", map[string]interface{}{"role_id": roleID})
+			return
+		}
+		resp.Diagnostics.AddError("Error removing Role membership identities", err.Error())
+		return
+	}
+
+	tflog.Info(ctx, "Deleted Role membership identities", map[string]interface{}{"role_id": roleID})
+}
+
+func (r *roleMembershipIdentitiesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	roleID, identityIDs, err := parseRoleMembershipIdentitiesImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import identifier", err.Error())
+		return
+	}
+
+	set, diags := types.SetValueFrom(ctx, types.StringType, identityIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), roleID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_id"), roleID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("identity_ids"), set)...)
+}
+
+// roleNotFoundError marks a reconcile that failed because the role itself is
+// gone, which Delete treats as already satisfied.
+type roleNotFoundError struct {
+	detail string
+}
+
+func (e *roleNotFoundError) Error() string {
+	return e.detail
+}
+
+// reconcile re-reads roleID's membership under its lock, removes toRemove
+// and adds toAdd, and PATCHes /membership only if the identity list actually
+// changed.
+func (r *roleMembershipIdentitiesResource) reconcile(ctx context.Context, roleID string, toAdd, toRemove []string) error {
+	unlock := lockRole(roleID)
+	defer unlock()
+
+	selector, httpResp, err := r.getMembership(ctx, roleID)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return &roleNotFoundError{detail: roleErrDetail(err, httpResp)}
+		}
+		return errors.New(roleErrDetail(err, httpResp))
+	}
+
+	if t, _ := selector["type"].(string); t != "" && t != roleMembershipTypeIdentityList {
+		return fmt.Errorf("role %q has %s membership; identitynow_role_membership_identities_v1 can only manage roles with %s membership (or no membership yet)",
+			roleID, t, roleMembershipTypeIdentityList)
+	}
+
+	before := roleMembershipIdentityIds(selector)
+	updated := applyRoleMembershipIdentityChanges(selector, toAdd, toRemove)
+	after := roleMembershipIdentityIds(updated)
+
+	tflog.Debug(ctx, "Reconciling Role membership identities", map[string]interface{}{
+		"role_id":            roleID,
+		"existing_total_ids": len(before),
+		"patched_total_ids":  len(after),
+	})
+
+	if roleIdsEqual(before, after) && selector["type"] == roleMembershipTypeIdentityList {
+		return nil
+	}
+
+	patch := []roles.JsonPatchOperation{
+		roleJSONPatchReplace("/membership", roles.MapmapOfStringAnyAsJsonPatchOperationValue(&updated)),
+	}
+	_, httpResp, err = r.client.RolesAPI.
+		PatchRoleV1(ctx, roleID).
+		JsonPatchOperation(patch).
+		Execute()
+	if err != nil {
+		return errors.New(roleErrDetail(err, httpResp))
+	}
+	return nil
+}
+
+// getMembership returns roleID's membership selector as generic JSON (an
+// empty map when the role has none).
+func (r *roleMembershipIdentitiesResource) getMembership(ctx context.Context, roleID string) (map[string]interface{}, *http.Response, error) {
+	role, httpResp, err := r.client.RolesAPI.GetRoleV1(ctx, roleID).Execute()
+	if err != nil {
+		return nil, httpResp, err
+	}
+	selector, err := roleStructToMap(role.Membership.Get())
+	if err != nil {
+		return nil, httpResp, fmt.Errorf("could not decode the membership of role %q: %w", roleID, err)
+	}
+	if selector == nil {
+		selector = map[string]interface{}{}
+	}
+	return selector, httpResp, nil
+}
+
+// roleMembershipIdentityIds returns the ids in selector's identities list,
+// in order.
+func roleMembershipIdentityIds(selector map[string]interface{}) []string {
+	items, _ := selector["identities"].([]interface{})
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		entry, _ := item.(map[string]interface{})
+		if id, ok := entry["id"].(string); ok && id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// applyRoleMembershipIdentityChanges returns a copy of selector as an
+// IDENTITY_LIST selector whose identities are the existing entries minus
+// toRemove, plus a new {type: IDENTITY, id} entry for each id in toAdd not
+// already present. Existing entries are carried over verbatim.
+func applyRoleMembershipIdentityChanges(selector map[string]interface{}, toAdd, toRemove []string) map[string]interface{} {
+	removeSet := make(map[string]struct{}, len(toRemove))
+	for _, id := range toRemove {
+		removeSet[id] = struct{}{}
+	}
+
+	items, _ := selector["identities"].([]interface{})
+	identities := make([]interface{}, 0, len(items)+len(toAdd))
+	seen := make(map[string]struct{}, len(items)+len(toAdd))
+	for _, item := range items {
+		entry, _ := item.(map[string]interface{})
+		id, _ := entry["id"].(string)
+		if _, remove := removeSet[id]; remove {
+			continue
+		}
+		if _, duplicate := seen[id]; id != "" && duplicate {
+			continue
+		}
+		seen[id] = struct{}{}
+		identities = append(identities, item)
+	}
+	for _, id := range toAdd {
+		if _, duplicate := seen[id]; id == "" || duplicate {
+			continue
+		}
+		seen[id] = struct{}{}
+		identities = append(identities, map[string]interface{}{"type": roleMembershipIdentityType, "id": id})
+	}
+
+	out := make(map[string]interface{}, len(selector)+2)
+	for k, v := range selector {
+		out[k] = v
+	}
+	out["type"] = roleMembershipTypeIdentityList
+	out["identities"] = identities
+	delete(out, "criteria")
+	return out
+}
+
+func roleMembershipSetToStrings(ctx context.Context, s types.Set) ([]string, diag.Diagnostics) {
+	if s.IsNull() || s.IsUnknown() {
+		return nil, nil
+	}
+
+	var ids []string
+	diags := s.ElementsAs(ctx, &ids, false)
+	return ids, diags
+}
+
+// parseRoleMembershipIdentitiesImportID parses
+// "<role_id>,<identity_id>/<identity_id>/...", the same shape
+// application_access_association_v1 imports with, since the tracked subset
+// can't be recovered from the role alone.
+func parseRoleMembershipIdentitiesImportID(id string) (string, []string, error) {
+	parts := strings.Split(id, ",")
+	if len(parts) != 2 {
+		return "", nil, fmt.Errorf("expected import id in the format <role_id>,<identity_id>/<identity_id>/...; got %q", id)
+	}
+
+	roleID := strings.TrimSpace(parts[0])
+	if roleID == "" {
+		return "", nil, fmt.Errorf("role_id component must not be empty")
+	}
+
+	raw := strings.TrimSpace(parts[1])
+	if raw == "" {
+		return "", nil, fmt.Errorf("identity_ids component must not be empty")
+	}
+
+	identityIDs := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, rawID := range strings.Split(raw, "/") {
+		identityID := strings.TrimSpace(rawID)
+		if identityID == "" {
+			return "", nil, fmt.Errorf("identity_ids component contains an empty identity id")
+		}
+		if _, duplicate := seen[identityID]; duplicate {
+			continue
+		}
+		identityIDs = append(identityIDs, identityID)
+		seen[identityID] = struct{}{}
+	}
+	return roleID, identityIDs, nil
+}
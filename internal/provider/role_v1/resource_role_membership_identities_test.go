@@ -0,0 +1,80 @@
+package role_v1
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRetainRoleIds(t *testing.T) {
+	got := retainRoleIds([]string{"other-1", "mine-2", "other-2"}, []string{"mine-1", "mine-2"})
+	if want := []string{"mine-2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("retainRoleIds() = %v, want %v", got, want)
+	}
+}
+
+func TestApplyRoleMembershipIdentityChanges(t *testing.T) {
+	var selector map[string]interface{}
+	raw := `{"type": "IDENTITY_LIST", "criteria": null, "identities": [
+  {"type": "IDENTITY", "id": "other-1", "name": "Other One", "aliasName": "other.one"},
+  {"type": "IDENTITY", "id": "mine-1", "name": "Mine One", "aliasName": null},
+  {"type": "IDENTITY", "id": "other-2", "name": "Other Two", "aliasName": "other.two"}
+]}`
+	if err := json.Unmarshal([]byte(raw), &selector); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	got := applyRoleMembershipIdentityChanges(selector, []string{"mine-2", "other-1"}, []string{"mine-1"})
+
+	if ids, want := roleMembershipIdentityIds(got), []string{"other-1", "other-2", "mine-2"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("identity ids = %v, want %v", ids, want)
+	}
+	identities := got["identities"].([]interface{})
+	if first := identities[0].(map[string]interface{}); first["aliasName"] != "other.one" {
+		t.Errorf("existing entry was not carried over verbatim: %v", first)
+	}
+	if added := identities[2].(map[string]interface{}); added["type"] != roleMembershipIdentityType {
+		t.Errorf("added entry type = %v, want %q", added["type"], roleMembershipIdentityType)
+	}
+	if _, ok := got["criteria"]; ok {
+		t.Errorf("criteria should be dropped from an IDENTITY_LIST selector: %v", got)
+	}
+	if len(roleMembershipIdentityIds(selector)) != 3 {
+		t.Errorf("input selector was modified: %v", selector)
+	}
+}
+
+func TestApplyRoleMembershipIdentityChanges_NoMembership(t *testing.T) {
+	got := applyRoleMembershipIdentityChanges(map[string]interface{}{}, []string{"a"}, nil)
+	if got["type"] != roleMembershipTypeIdentityList {
+		t.Errorf("type = %v, want %q", got["type"], roleMembershipTypeIdentityList)
+	}
+	if ids := roleMembershipIdentityIds(got); !reflect.DeepEqual(ids, []string{"a"}) {
+		t.Errorf("identity ids = %v, want [a]", ids)
+	}
+
+	// Removing every identity still leaves an (empty) IDENTITY_LIST.
+	got = applyRoleMembershipIdentityChanges(got, nil, []string{"a"})
+	if list, ok := got["identities"].([]interface{}); !ok || len(list) != 0 {
+		t.Errorf("identities = %v, want an empty list", got["identities"])
+	}
+}
+
+func TestParseRoleMembershipIdentitiesImportID(t *testing.T) {
+	roleID, ids, err := parseRoleMembershipIdentitiesImportID("role-1,id-1/id-2/id-1")
+	if err != nil {
+		t.Fatalf("parseRoleMembershipIdentitiesImportID() error = %v", err)
+	}
+	if roleID != "role-1" {
+		t.Errorf("roleID = %q, want role-1", roleID)
+	}
+	if want := []string{"id-1", "id-2"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+
+	for _, bad := range []string{"role-1", ",id-1", "role-1,", "role-1,id-1//id-2"} {
+		if _, _, err := parseRoleMembershipIdentitiesImportID(bad); err == nil {
+			t.Errorf("parseRoleMembershipIdentitiesImportID(%q) returned no error", bad)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/sailpoint-oss/golang-sdk/v3/roles"
@@ -14,8 +15,8 @@ import (
 // minimalRoleModel builds a RoleModel with all nested object/list attributes
 // explicitly null, suitable as a base for roleModelToDto/roleDtoToModel
 // round-trip tests that only care about top-level scalar fields.
-func minimalRoleModel() resource_role.RoleModel {
-	return resource_role.RoleModel{
+func minimalRoleModel() roleResourceModel {
+	return roleResourceModel{
 		AccessModelMetadata:     resource_role.NewAccessModelMetadataValueNull(),
 		AccessProfiles:          types.ListNull(resource_role.AccessProfilesValue{}.Type(context.Background())),
 		AccessRequestConfig:     resource_role.NewAccessRequestConfigValueNull(),
@@ -29,6 +30,7 @@ func minimalRoleModel() resource_role.RoleModel {
 		Id:                      types.StringNull(),
 		LegacyMembershipInfo:    resource_role.NewLegacyMembershipInfoValueNull(),
 		Membership:              resource_role.NewMembershipValueNull(),
+		MembershipCriteriaJson:  jsontypes.NewNormalizedNull(),
 		Modified:                types.StringNull(),
 		Name:                    types.StringValue("test-role"),
 		Owner:                   resource_role.OwnerValue{},
@@ -19,7 +19,9 @@
 //   - The API's visibility criteria tree is capped here at two levels exactly
 //     as requested for this target: visibility_criteria.expression.children
 //     exists, but each child's own children field is intentionally omitted from
-//     Terraform and is always sent to the API as explicit null.
+//     Terraform and is always sent to the API as explicit null. Deeper trees
+//     use "visibility_criteria_json" instead (see
+//     resource_segment_visibility_criteria_json.go).
 //   - owner is mapped via associated_external_type for the resource and
 //     singular data source only, so those wrappers use the generated
 //     ToApi_betaOwnerReferenceSegments/FromApi_betaOwnerReferenceSegments
@@ -33,6 +35,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -67,14 +70,15 @@ type clientProvider interface {
 const segmentVisibilityCriteriaDescription = "Visibility criteria controlling which identities the segment applies to. This hand-written schema intentionally supports exactly two levels: visibility_criteria.expression plus visibility_criteria.expression.children; each child element's own API children field is always sent as null and is therefore omitted from Terraform."
 
 type segmentResourceModel struct {
-	Active             types.Bool                  `tfsdk:"active"`
-	Created            types.String                `tfsdk:"created"`
-	Description        types.String                `tfsdk:"description"`
-	Id                 types.String                `tfsdk:"id"`
-	Modified           types.String                `tfsdk:"modified"`
-	Name               types.String                `tfsdk:"name"`
-	Owner              resource_segment.OwnerValue `tfsdk:"owner"`
-	VisibilityCriteria types.Object                `tfsdk:"visibility_criteria"`
+	Active                 types.Bool                  `tfsdk:"active"`
+	Created                types.String                `tfsdk:"created"`
+	Description            types.String                `tfsdk:"description"`
+	Id                     types.String                `tfsdk:"id"`
+	Modified               types.String                `tfsdk:"modified"`
+	Name                   types.String                `tfsdk:"name"`
+	Owner                  resource_segment.OwnerValue `tfsdk:"owner"`
+	VisibilityCriteria     types.Object                `tfsdk:"visibility_criteria"`
+	VisibilityCriteriaJson jsontypes.Normalized        `tfsdk:"visibility_criteria_json"`
 }
 
 type visibilityCriteriaModel struct {
@@ -158,17 +162,58 @@ func (r *segmentResource) Create(ctx context.Context, req resource.CreateRequest
 		CreateSegmentV1(ctx).
 		Segment(*dto).
 		Execute()
+	apiResp, expression, err := segmentDecodeVisibility(ctx, apiResp, httpResp, err)
 	if err != nil {
 		tflog.Error(ctx, "Error creating Segment", map[string]interface{}{"name": plan.Name.ValueString(), "error": err.Error()})
 		resp.Diagnostics.AddError("Error creating Segment", segmentErrDetail(err, httpResp))
 		return
 	}
 
+	if plan.VisibilityCriteriaJson.IsNull() {
+		state, diags := segmentResourceStateFromAPI(ctx, apiResp, expression, plan.Id, plan.VisibilityCriteriaJson)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	// visibility_criteria_json can't go through segments.Segment, so the
+	// segment is created without criteria and they are patched in. The
+	// segment is saved to state first so a failed patch leaves it tainted
+	// rather than orphaned.
 	state, diags := segmentResourceDTOToModel(ctx, apiResp, plan.Id)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	patch, diags := segmentVisibilityCriteriaJSONPatchOps(plan.VisibilityCriteriaJson, jsontypes.NewNormalizedNull())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	apiResp, httpResp, err = r.client.SegmentsAPI.
+		PatchSegmentV1(ctx, state.Id.ValueString()).
+		RequestBody(segmentPatchRequestBody(patch)).
+		Execute()
+	apiResp, expression, err = segmentDecodeVisibility(ctx, apiResp, httpResp, err)
+	if err != nil {
+		tflog.Error(ctx, "Error setting Segment visibility criteria", map[string]interface{}{"id": state.Id.ValueString(), "error": err.Error()})
+		resp.Diagnostics.AddError("Error creating Segment", "The segment was created but setting visibility_criteria_json failed: "+segmentErrDetail(err, httpResp))
+		return
+	}
+
+	state, diags = segmentResourceStateFromAPI(ctx, apiResp, expression, state.Id, plan.VisibilityCriteriaJson)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -185,6 +230,7 @@ func (r *segmentResource) Read(ctx context.Context, req resource.ReadRequest, re
 	apiResp, httpResp, err := r.client.SegmentsAPI.
 		GetSegmentV1(ctx, state.Id.ValueString()).
 		Execute()
+	apiResp, expression, err := segmentDecodeVisibility(ctx, apiResp, httpResp, err)
 	if err != nil {
 		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
 			tflog.Warn(ctx, "Segment not found, removing from state", map[string]interface{}{"id": state.Id.ValueString()})
@@ -196,7 +242,7 @@ func (r *segmentResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	newState, diags := segmentResourceDTOToModel(ctx, apiResp, state.Id)
+	newState, diags := segmentResourceStateFromAPI(ctx, apiResp, expression, state.Id, state.VisibilityCriteriaJson)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -235,13 +281,14 @@ func (r *segmentResource) Update(ctx context.Context, req resource.UpdateRequest
 		PatchSegmentV1(ctx, state.Id.ValueString()).
 		RequestBody(segmentPatchRequestBody(patch)).
 		Execute()
+	apiResp, expression, err := segmentDecodeVisibility(ctx, apiResp, httpResp, err)
 	if err != nil {
 		tflog.Error(ctx, "Error updating Segment", map[string]interface{}{"id": state.Id.ValueString(), "error": err.Error()})
 		resp.Diagnostics.AddError("Error updating Segment", segmentErrDetail(err, httpResp))
 		return
 	}
 
-	newState, diags := segmentResourceDTOToModel(ctx, apiResp, state.Id)
+	newState, diags := segmentResourceStateFromAPI(ctx, apiResp, expression, state.Id, plan.VisibilityCriteriaJson)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -276,6 +323,7 @@ func (r *segmentResource) Delete(ctx context.Context, req resource.DeleteRequest
 func segmentResourceSchema(ctx context.Context) resourceschema.Schema {
 	s := resource_segment.SegmentResourceSchema(ctx)
 	applyResourceVisibilityCriteriaField(&s.Attributes)
+	applyResourceVisibilityCriteriaJSONField(&s.Attributes)
 	return s
 }
 
@@ -313,14 +361,15 @@ func segmentResourceDTOToModel(ctx context.Context, dto *segments.Segment, fallb
 	var diags diag.Diagnostics
 
 	model := segmentResourceModel{
-		Active:             types.BoolNull(),
-		Created:            types.StringNull(),
-		Description:        types.StringNull(),
-		Id:                 fallbackID,
-		Modified:           types.StringNull(),
-		Name:               types.StringNull(),
-		Owner:              resource_segment.NewOwnerValueNull(),
-		VisibilityCriteria: types.ObjectNull(visibilityCriteriaAttrTypes()),
+		Active:                 types.BoolNull(),
+		Created:                types.StringNull(),
+		Description:            types.StringNull(),
+		Id:                     fallbackID,
+		Modified:               types.StringNull(),
+		Name:                   types.StringNull(),
+		Owner:                  resource_segment.NewOwnerValueNull(),
+		VisibilityCriteria:     types.ObjectNull(visibilityCriteriaAttrTypes()),
+		VisibilityCriteriaJson: jsontypes.NewNormalizedNull(),
 	}
 
 	if dto == nil {
@@ -352,6 +401,29 @@ func segmentResourceDTOToModel(ctx context.Context, dto *segments.Segment, fallb
 	return model, diags
 }
 
+// segmentResourceStateFromAPI is segmentResourceDTOToModel plus the raw
+// expression read from the response body. When "visibility_criteria_json"
+// is in use it owns the criteria and "visibility_criteria" is left null;
+// otherwise a tree too deep for "visibility_criteria" is reported as a
+// warning, since the block can only show it as null.
+func segmentResourceStateFromAPI(ctx context.Context, dto *segments.Segment, expression interface{}, fallbackID types.String, priorJSON jsontypes.Normalized) (segmentResourceModel, diag.Diagnostics) {
+	model, diags := segmentResourceDTOToModel(ctx, dto, fallbackID)
+
+	criteria, d := segmentVisibilityCriteriaJSONFromAPI(expression, priorJSON)
+	diags.Append(d...)
+	model.VisibilityCriteriaJson = criteria
+
+	if !priorJSON.IsNull() {
+		model.VisibilityCriteria = types.ObjectNull(visibilityCriteriaAttrTypes())
+	} else if depth := segmentExpressionDepth(expression); depth > 2 {
+		diags.AddWarning(
+			"Visibility criteria too deep for visibility_criteria",
+			fmt.Sprintf("Segment %q has visibility criteria %d levels deep, but visibility_criteria only supports two, so it is shown as null. Use visibility_criteria_json to manage this segment's criteria.", model.Id.ValueString(), depth),
+		)
+	}
+	return model, diags
+}
+
 func segmentPatchOps(ctx context.Context, plan, state segmentResourceModel) ([]segmentJSONPatchOp, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	patch := make([]segmentJSONPatchOp, 0, 5)
@@ -368,6 +440,10 @@ func segmentPatchOps(ctx context.Context, plan, state segmentResourceModel) ([]s
 	diags.Append(d...)
 	patch = append(patch, visibilityOps...)
 
+	visibilityJSONOps, d := segmentVisibilityCriteriaJSONPatchOps(plan.VisibilityCriteriaJson, state.VisibilityCriteriaJson)
+	diags.Append(d...)
+	patch = append(patch, visibilityJSONOps...)
+
 	return patch, diags
 }
 
@@ -457,6 +533,27 @@ func segmentVisibilityCriteriaPatchOps(ctx context.Context, plan, state types.Ob
 	return []segmentJSONPatchOp{segmentJSONPatchReplace("/visibilityCriteria", value)}, diags
 }
 
+// segmentVisibilityCriteriaJSONPatchOps sends "visibility_criteria_json"
+// whenever it changes. Removing it from configuration leaves the segment's
+// criteria as they are, the same as unsetting the Optional+Computed
+// "visibility_criteria" block.
+func segmentVisibilityCriteriaJSONPatchOps(plan, state jsontypes.Normalized) ([]segmentJSONPatchOp, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if plan.IsNull() || plan.IsUnknown() || plan.Equal(state) {
+		return nil, diags
+	}
+
+	vc, d := segmentVisibilityCriteriaFromJSON(plan)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if state.IsNull() || state.IsUnknown() {
+		return []segmentJSONPatchOp{segmentJSONPatchAdd("/visibilityCriteria", vc)}, diags
+	}
+	return []segmentJSONPatchOp{segmentJSONPatchReplace("/visibilityCriteria", vc)}, diags
+}
+
 func applyResourceVisibilityCriteriaField(attrs *map[string]resourceschema.Attribute) {
 	if *attrs == nil {
 		*attrs = map[string]resourceschema.Attribute{}
@@ -627,7 +724,7 @@ func dataSourceVisibilityValueAttributes() map[string]datasourceschema.Attribute
 }
 
 func segmentVisibilityOperatorValidators() []validator.String {
-	return []validator.String{stringvalidator.OneOf("AND", "EQUALS")}
+	return []validator.String{stringvalidator.OneOf(segmentVisibilityOperators...)}
 }
 
 func visibilityCriteriaAttrTypes() map[string]attr.Type {
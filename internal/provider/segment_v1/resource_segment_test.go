@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -17,14 +18,15 @@ import (
 // top-level scalar fields.
 func minimalSegmentModel() segmentResourceModel {
 	return segmentResourceModel{
-		Active:             types.BoolValue(true),
-		Created:            types.StringNull(),
-		Description:        types.StringValue("a test segment"),
-		Id:                 types.StringNull(),
-		Modified:           types.StringNull(),
-		Name:               types.StringValue("test-segment"),
-		Owner:              resource_segment.NewOwnerValueNull(),
-		VisibilityCriteria: types.ObjectNull(visibilityCriteriaAttrTypes()),
+		Active:                 types.BoolValue(true),
+		Created:                types.StringNull(),
+		Description:            types.StringValue("a test segment"),
+		Id:                     types.StringNull(),
+		Modified:               types.StringNull(),
+		Name:                   types.StringValue("test-segment"),
+		Owner:                  resource_segment.NewOwnerValueNull(),
+		VisibilityCriteria:     types.ObjectNull(visibilityCriteriaAttrTypes()),
+		VisibilityCriteriaJson: jsontypes.NewNormalizedNull(),
 	}
 }
 
@@ -0,0 +1,423 @@
+// This file implements "visibility_criteria_json", a hand-added alternative
+// to the hand-written "visibility_criteria" block. That block stops at two
+// levels (expression -> children) because the v1 spec types each child's own
+// children as an always-null string, so criteria such as
+// location == X AND (department == Y OR costCenter == Z) can't be written
+// with it.
+//
+// The JSON form is the API's own visibilityCriteria.expression object
+// ({operator, attribute, value: {type, value}, children}) with no depth
+// limit. Operators and field names are validated at plan time, the tree is
+// sent verbatim as a JSON Patch value, and it is read back from the raw
+// response body for drift detection. golang-sdk v3's segments.Segment can't
+// decode a child with children of its own (the field is a NullableString),
+// so segmentDecodeVisibility re-decodes such responses without
+// visibilityCriteria instead of failing the whole call, the same way
+// service_desk_integration_v1/sdk_fallback.go works around its SDK defect.
+package segment_v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/sailpoint-oss/golang-sdk/v3/segments"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+var (
+	_ resource.ResourceWithConfigValidators = (*segmentResource)(nil)
+	_ resource.ResourceWithValidateConfig   = (*segmentResource)(nil)
+)
+
+// segmentVisibilityOperators are the operators a visibility expression node
+// may use. The v1 spec only enumerates AND and EQUALS, but OR is what makes
+// nested criteria useful, so it is accepted here and left to the API to
+// reject if a tenant doesn't support it.
+var segmentVisibilityOperators = []string{"AND", "OR", "EQUALS"}
+
+// segmentVisibilityCompositeOperators combine their children; EQUALS is the
+// only leaf comparison.
+var segmentVisibilityCompositeOperators = []string{"AND", "OR"}
+
+func applyResourceVisibilityCriteriaJSONField(attrs *map[string]resourceschema.Attribute) {
+	if *attrs == nil {
+		*attrs = map[string]resourceschema.Attribute{}
+	}
+	(*attrs)["visibility_criteria_json"] = resourceschema.StringAttribute{
+		CustomType: jsontypes.NormalizedType{},
+		Optional:   true,
+		Description: "Visibility criteria expression as a raw JSON object ({operator, attribute, value, children}) with no " +
+			"nesting limit. Conflicts with visibility_criteria.",
+		MarkdownDescription: "Visibility criteria expression as a raw JSON object (`{operator, attribute, value: {type, value}, children}`, " +
+			"the API's `visibilityCriteria.expression` shape) with no nesting limit, for criteria deeper than the two levels " +
+			"`visibility_criteria` can express. Operators and field names are validated at plan time, and the full tree is read " +
+			"back for drift detection. Conflicts with `visibility_criteria`.",
+	}
+}
+
+func applyDataSourceVisibilityCriteriaJSONField(attrs *map[string]datasourceschema.Attribute) {
+	if *attrs == nil {
+		*attrs = map[string]datasourceschema.Attribute{}
+	}
+	(*attrs)["visibility_criteria_json"] = datasourceschema.StringAttribute{
+		CustomType:          jsontypes.NormalizedType{},
+		Computed:            true,
+		Description:         "The full visibility criteria expression as a raw JSON object ({operator, attribute, value, children}), at any depth.",
+		MarkdownDescription: "The full visibility criteria expression as a raw JSON object (`{operator, attribute, value: {type, value}, children}`), at any depth. Unlike `visibility_criteria`, this is populated for trees deeper than two levels.",
+	}
+}
+
+func (r *segmentResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("visibility_criteria"),
+			path.MatchRoot("visibility_criteria_json"),
+		),
+	}
+}
+
+func (r *segmentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var criteria jsontypes.Normalized
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("visibility_criteria_json"), &criteria)...)
+	if resp.Diagnostics.HasError() || criteria.IsNull() || criteria.IsUnknown() {
+		return
+	}
+
+	var node interface{}
+	if err := json.Unmarshal([]byte(criteria.ValueString()), &node); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("visibility_criteria_json"),
+			"Invalid visibility criteria JSON",
+			fmt.Sprintf("Could not decode \"visibility_criteria_json\" as JSON: %s", err.Error()),
+		)
+		return
+	}
+	for _, problem := range validateSegmentExpressionNode(node, "") {
+		resp.Diagnostics.AddAttributeError(path.Root("visibility_criteria_json"), "Invalid visibility criteria", problem)
+	}
+}
+
+// validateSegmentExpressionNode checks one expression node and, recursively,
+// its children, returning one message per problem prefixed with the node's
+// JSON path (e.g. "children[1].value.value").
+func validateSegmentExpressionNode(v interface{}, at string) []string {
+	node, ok := v.(map[string]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("%s: must be a JSON object", segmentExpressionPath(at))}
+	}
+
+	var problems []string
+	for _, k := range util.SortedKeys(node) {
+		switch k {
+		case "operator", "attribute", "value", "children":
+		default:
+			problems = append(problems, fmt.Sprintf("%s: unknown field (expected operator, attribute, value or children)", segmentExpressionPath(segmentExpressionJoin(at, k))))
+		}
+	}
+
+	op, _ := node["operator"].(string)
+	if !util.ContainsString(segmentVisibilityOperators, op) {
+		return append(problems, fmt.Sprintf("%s: must be one of %s, got %s",
+			segmentExpressionPath(segmentExpressionJoin(at, "operator")), strings.Join(segmentVisibilityOperators, ", "), util.DescribeJSONValue(node["operator"])))
+	}
+
+	children, hasChildren := segmentField(node, "children")
+	if util.ContainsString(segmentVisibilityCompositeOperators, op) {
+		for _, k := range []string{"attribute", "value"} {
+			if _, ok := segmentField(node, k); ok {
+				problems = append(problems, fmt.Sprintf("%s: must not be set when operator is %s", segmentExpressionPath(segmentExpressionJoin(at, k)), op))
+			}
+		}
+		list, ok := children.([]interface{})
+		if !ok || len(list) == 0 {
+			return append(problems, fmt.Sprintf("%s: must be a non-empty list when operator is %s", segmentExpressionPath(segmentExpressionJoin(at, "children")), op))
+		}
+		for i, child := range list {
+			problems = append(problems, validateSegmentExpressionNode(child, fmt.Sprintf("%s[%d]", segmentExpressionJoin(at, "children"), i))...)
+		}
+		return problems
+	}
+
+	if list, ok := children.([]interface{}); hasChildren && (!ok || len(list) > 0) {
+		problems = append(problems, fmt.Sprintf("%s: must not be set when operator is %s", segmentExpressionPath(segmentExpressionJoin(at, "children")), op))
+	}
+	if s, ok := node["attribute"].(string); !ok || s == "" {
+		problems = append(problems, fmt.Sprintf("%s: is required when operator is %s", segmentExpressionPath(segmentExpressionJoin(at, "attribute")), op))
+	}
+	return append(problems, validateSegmentExpressionValue(node["value"], segmentExpressionJoin(at, "value"), op)...)
+}
+
+func validateSegmentExpressionValue(v interface{}, at, op string) []string {
+	value, ok := v.(map[string]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("%s: an object is required when operator is %s", segmentExpressionPath(at), op)}
+	}
+
+	var problems []string
+	for _, k := range util.SortedKeys(value) {
+		switch k {
+		case "type", "value":
+		default:
+			problems = append(problems, fmt.Sprintf("%s: unknown field (expected type or value)", segmentExpressionPath(segmentExpressionJoin(at, k))))
+		}
+	}
+	if t, ok := segmentField(value, "type"); ok {
+		if _, isString := t.(string); !isString {
+			problems = append(problems, fmt.Sprintf("%s: must be a string, got %s", segmentExpressionPath(segmentExpressionJoin(at, "type")), util.DescribeJSONValue(t)))
+		}
+	}
+	if s, ok := value["value"].(string); !ok || s == "" {
+		problems = append(problems, fmt.Sprintf("%s: is required", segmentExpressionPath(segmentExpressionJoin(at, "value"))))
+	}
+	return problems
+}
+
+// segmentVisibilityCriteriaFromJSON wraps the configured expression in a
+// visibilityCriteria object, returned as a generic map so it can be sent
+// as-is in a JSON Patch value.
+func segmentVisibilityCriteriaFromJSON(v jsontypes.Normalized) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var expression map[string]interface{}
+	if err := json.Unmarshal([]byte(v.ValueString()), &expression); err != nil {
+		diags.AddError(
+			"Invalid visibility criteria JSON",
+			fmt.Sprintf("Could not decode \"visibility_criteria_json\" as a JSON object: %s", err.Error()),
+		)
+		return nil, diags
+	}
+	return map[string]interface{}{"expression": expression}, diags
+}
+
+// segmentVisibilityCriteriaJSONFromAPI re-encodes the API's raw
+// visibilityCriteria.expression for "visibility_criteria_json". prior (the
+// plan or prior state value) is kept whenever it describes the same tree,
+// since the API echoes expressions back with explicit nulls (attribute and
+// value on AND/OR nodes, children on leaves) that jsontypes.Normalized alone
+// would report as a diff. A null prior means the practitioner isn't using
+// this attribute, so it stays null rather than shadowing
+// "visibility_criteria".
+func segmentVisibilityCriteriaJSONFromAPI(remote interface{}, prior jsontypes.Normalized) (jsontypes.Normalized, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if prior.IsNull() {
+		return prior, diags
+	}
+	remote = compactSegmentExpression(remote)
+	if remote == nil {
+		return jsontypes.NewNormalizedNull(), diags
+	}
+
+	if !prior.IsUnknown() {
+		var local interface{}
+		if err := json.Unmarshal([]byte(prior.ValueString()), &local); err == nil && reflect.DeepEqual(compactSegmentExpression(local), remote) {
+			return prior, diags
+		}
+	}
+
+	out, err := json.Marshal(remote)
+	if err != nil {
+		diags.AddError(
+			"Error encoding visibility criteria from API response",
+			fmt.Sprintf("Could not re-encode the API's visibility criteria as JSON: %s", err.Error()),
+		)
+		return prior, diags
+	}
+	return jsontypes.NewNormalizedValue(string(out)), diags
+}
+
+// segmentVisibilityCriteriaJSONValue is segmentVisibilityCriteriaJSONFromAPI
+// for the data sources, which always report the tree.
+func segmentVisibilityCriteriaJSONValue(remote interface{}) (jsontypes.Normalized, diag.Diagnostics) {
+	return segmentVisibilityCriteriaJSONFromAPI(remote, jsontypes.NewNormalizedUnknown())
+}
+
+// segmentDecodeVisibility wraps the (dto, httpResp, err) triple returned by
+// the SDK's single-segment Execute() calls and also returns the response's
+// raw visibilityCriteria.expression. If the SDK failed to decode a 2xx
+// response - which it does for any expression nested deeper than two levels
+// - the body is re-decoded without visibilityCriteria, so the rest of the
+// segment is still usable and the tree is only available raw.
+func segmentDecodeVisibility(ctx context.Context, dto *segments.Segment, httpResp *http.Response, err error) (*segments.Segment, interface{}, error) {
+	body, ok := segmentSuccessBody(httpResp)
+	if !ok {
+		return dto, nil, err
+	}
+
+	var raw map[string]interface{}
+	if jsonErr := json.Unmarshal(body, &raw); jsonErr != nil {
+		if err != nil {
+			return dto, nil, err
+		}
+		return dto, nil, fmt.Errorf("decoding segment response: %w", jsonErr)
+	}
+	expression := segmentRawExpression(raw)
+	if err == nil {
+		return dto, expression, nil
+	}
+
+	tflog.Debug(ctx, "Re-decoding Segment response without visibilityCriteria", map[string]interface{}{"sdk_error": err.Error()})
+	fallback, fallbackErr := segmentWithoutVisibility(raw)
+	if fallbackErr != nil {
+		tflog.Warn(ctx, "Segment fallback decode also failed; surfacing original SDK error", map[string]interface{}{"fallback_error": fallbackErr.Error()})
+		return dto, nil, err
+	}
+	return fallback, expression, nil
+}
+
+// segmentsDecodeVisibility is segmentDecodeVisibility for ListSegmentsV1,
+// returning each segment's raw expression at the same index as its
+// segment.
+func segmentsDecodeVisibility(ctx context.Context, dtos []segments.Segment, httpResp *http.Response, err error) ([]segments.Segment, []interface{}, error) {
+	body, ok := segmentSuccessBody(httpResp)
+	if !ok {
+		return dtos, make([]interface{}, len(dtos)), err
+	}
+
+	var raw []map[string]interface{}
+	if jsonErr := json.Unmarshal(body, &raw); jsonErr != nil {
+		if err != nil {
+			return dtos, nil, err
+		}
+		return dtos, nil, fmt.Errorf("decoding segments response: %w", jsonErr)
+	}
+	expressions := make([]interface{}, len(raw))
+	for i := range raw {
+		expressions[i] = segmentRawExpression(raw[i])
+	}
+	if err == nil {
+		return dtos, expressions, nil
+	}
+
+	tflog.Debug(ctx, "Re-decoding Segments response without visibilityCriteria", map[string]interface{}{"sdk_error": err.Error()})
+	out := make([]segments.Segment, 0, len(raw))
+	for i := range raw {
+		fallback, fallbackErr := segmentWithoutVisibility(raw[i])
+		if fallbackErr != nil {
+			tflog.Warn(ctx, "Segments fallback decode also failed; surfacing original SDK error", map[string]interface{}{"fallback_error": fallbackErr.Error()})
+			return dtos, nil, err
+		}
+		out = append(out, *fallback)
+	}
+	return out, expressions, nil
+}
+
+// segmentSuccessBody returns a 2xx response's body, leaving it readable for
+// anyone after us.
+func segmentSuccessBody(httpResp *http.Response) ([]byte, bool) {
+	if httpResp == nil || httpResp.Body == nil || httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return nil, false
+	}
+	body, err := io.ReadAll(httpResp.Body)
+	httpResp.Body = io.NopCloser(bytes.NewBuffer(body))
+	if err != nil {
+		return nil, false
+	}
+	return body, true
+}
+
+func segmentRawExpression(raw map[string]interface{}) interface{} {
+	vc, _ := raw["visibilityCriteria"].(map[string]interface{})
+	if vc == nil {
+		return nil
+	}
+	return vc["expression"]
+}
+
+func segmentWithoutVisibility(raw map[string]interface{}) (*segments.Segment, error) {
+	stripped := make(map[string]interface{}, len(raw))
+	for k, v := range raw {
+		if k != "visibilityCriteria" {
+			stripped[k] = v
+		}
+	}
+	b, err := json.Marshal(stripped)
+	if err != nil {
+		return nil, err
+	}
+	var dto segments.Segment
+	if err := json.Unmarshal(b, &dto); err != nil {
+		return nil, err
+	}
+	return &dto, nil
+}
+
+// compactSegmentExpression drops null values and empty lists/objects at
+// every depth so an expression tree compares equal however the API or the
+// practitioner spells "not set".
+func compactSegmentExpression(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, child := range t {
+			if c := compactSegmentExpression(child); c != nil {
+				out[k] = c
+			}
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, 0, len(t))
+		for _, child := range t {
+			if c := compactSegmentExpression(child); c != nil {
+				out = append(out, c)
+			}
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// segmentExpressionDepth reports how many levels deep an expression tree
+// is, counting the root as 1.
+func segmentExpressionDepth(v interface{}) int {
+	node, ok := v.(map[string]interface{})
+	if !ok {
+		return 0
+	}
+	deepest := 0
+	children, _ := node["children"].([]interface{})
+	for _, child := range children {
+		deepest = max(deepest, segmentExpressionDepth(child))
+	}
+	return deepest + 1
+}
+
+func segmentField(m map[string]interface{}, k string) (interface{}, bool) {
+	v, ok := m[k]
+	return v, ok && v != nil
+}
+
+func segmentExpressionJoin(at, field string) string {
+	if at == "" {
+		return field
+	}
+	return at + "." + field
+}
+
+func segmentExpressionPath(at string) string {
+	if at == "" {
+		return "expression"
+	}
+	return at
+}
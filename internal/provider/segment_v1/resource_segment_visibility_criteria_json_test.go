@@ -0,0 +1,214 @@
+package segment_v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testDeepVisibilityCriteria is location == Austin AND (department ==
+// Engineering OR costCenter == 42), one level deeper than the
+// "visibility_criteria" block can express.
+const testDeepVisibilityCriteria = `{
+  "operator": "AND",
+  "children": [
+    {"operator": "EQUALS", "attribute": "location", "value": {"type": "STRING", "value": "Austin"}},
+    {"operator": "OR", "children": [
+      {"operator": "EQUALS", "attribute": "department", "value": {"type": "STRING", "value": "Engineering"}},
+      {"operator": "EQUALS", "attribute": "costCenter", "value": {"type": "STRING", "value": "42"}}
+    ]}
+  ]
+}`
+
+func TestValidateSegmentExpressionNode_Valid(t *testing.T) {
+	var node interface{}
+	if err := json.Unmarshal([]byte(testDeepVisibilityCriteria), &node); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if problems := validateSegmentExpressionNode(node, ""); len(problems) != 0 {
+		t.Errorf("validateSegmentExpressionNode returned problems for a valid tree: %v", problems)
+	}
+}
+
+func TestValidateSegmentExpressionNode_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{
+			name: "unknown operator",
+			raw:  `{"operator": "NOT_EQUALS", "attribute": "location", "value": {"value": "Austin"}}`,
+			want: "operator: must be one of",
+		},
+		{
+			name: "missing attribute deep in the tree",
+			raw:  `{"operator": "AND", "children": [{"operator": "OR", "children": [{"operator": "EQUALS", "value": {"value": "x"}}]}]}`,
+			want: "children[0].children[0].attribute: is required",
+		},
+		{
+			name: "composite without children",
+			raw:  `{"operator": "OR", "children": []}`,
+			want: "children: must be a non-empty list",
+		},
+		{
+			name: "composite with an attribute",
+			raw:  `{"operator": "AND", "attribute": "location", "children": [{"operator": "EQUALS", "attribute": "x", "value": {"value": "y"}}]}`,
+			want: "attribute: must not be set when operator is AND",
+		},
+		{
+			name: "leaf with children",
+			raw:  `{"operator": "EQUALS", "attribute": "x", "value": {"value": "y"}, "children": [{"operator": "AND"}]}`,
+			want: "children: must not be set when operator is EQUALS",
+		},
+		{
+			name: "leaf without a value",
+			raw:  `{"operator": "EQUALS", "attribute": "x", "value": {"type": "STRING"}}`,
+			want: "value.value: is required",
+		},
+		{
+			name: "unknown field",
+			raw:  `{"operator": "EQUALS", "attribute": "x", "value": {"value": "y"}, "stringValue": "y"}`,
+			want: "stringValue: unknown field",
+		},
+		{
+			name: "unknown value field",
+			raw:  `{"operator": "EQUALS", "attribute": "x", "value": {"value": "y", "values": ["y"]}}`,
+			want: "value.values: unknown field",
+		},
+		{
+			name: "not an object",
+			raw:  `["AND"]`,
+			want: "expression: must be a JSON object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var node interface{}
+			if err := json.Unmarshal([]byte(tt.raw), &node); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			problems := validateSegmentExpressionNode(node, "")
+			for _, p := range problems {
+				if strings.HasPrefix(p, tt.want) {
+					return
+				}
+			}
+			t.Errorf("validateSegmentExpressionNode problems = %v, want one starting with %q", problems, tt.want)
+		})
+	}
+}
+
+func TestSegmentVisibilityCriteriaJSONFromAPI(t *testing.T) {
+	// The API echoes explicit nulls back on every node.
+	var remote interface{}
+	raw := `{"operator": "OR", "attribute": null, "value": null, "children": [
+  {"operator": "EQUALS", "attribute": "department", "value": {"type": "STRING", "value": "Engineering"}, "children": null}
+]}`
+	if err := json.Unmarshal([]byte(raw), &remote); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	prior := jsontypes.NewNormalizedValue(`{"operator": "OR", "children": [{"operator": "EQUALS", "attribute": "department", "value": {"type": "STRING", "value": "Engineering"}}]}`)
+	got, diags := segmentVisibilityCriteriaJSONFromAPI(remote, prior)
+	if diags.HasError() {
+		t.Fatalf("segmentVisibilityCriteriaJSONFromAPI returned diagnostics: %v", diags)
+	}
+	if got.ValueString() != prior.ValueString() {
+		t.Errorf("equivalent criteria were not kept as configured: %s", got.ValueString())
+	}
+
+	drifted := jsontypes.NewNormalizedValue(`{"operator": "OR", "children": [{"operator": "EQUALS", "attribute": "department", "value": {"type": "STRING", "value": "Sales"}}]}`)
+	got, diags = segmentVisibilityCriteriaJSONFromAPI(remote, drifted)
+	if diags.HasError() {
+		t.Fatalf("segmentVisibilityCriteriaJSONFromAPI returned diagnostics: %v", diags)
+	}
+	if !strings.Contains(got.ValueString(), "Engineering") {
+		t.Errorf("drift was not surfaced, got %s", got.ValueString())
+	}
+
+	got, _ = segmentVisibilityCriteriaJSONFromAPI(remote, jsontypes.NewNormalizedNull())
+	if !got.IsNull() {
+		t.Errorf("unconfigured attribute = %s, want null", got.ValueString())
+	}
+}
+
+func TestSegmentDecodeVisibility_DeepTreeFallback(t *testing.T) {
+	body := `{"id": "segment-id", "name": "deep", "active": true, "visibilityCriteria": {"expression": ` + testDeepVisibilityCriteria + `}}`
+	httpResp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(body))}
+	sdkErr := errors.New("json: cannot unmarshal array into Go struct field ExpressionChildrenInner.children of type string")
+
+	dto, expression, err := segmentDecodeVisibility(context.Background(), nil, httpResp, sdkErr)
+	if err != nil {
+		t.Fatalf("segmentDecodeVisibility returned error: %v", err)
+	}
+	if dto == nil || dto.Id == nil || *dto.Id != "segment-id" {
+		t.Fatalf("dto = %+v, want the segment decoded without visibilityCriteria", dto)
+	}
+	if dto.VisibilityCriteria != nil {
+		t.Errorf("VisibilityCriteria = %+v, want nil after the fallback decode", dto.VisibilityCriteria)
+	}
+	if depth := segmentExpressionDepth(expression); depth != 3 {
+		t.Errorf("raw expression depth = %d, want 3", depth)
+	}
+
+	model, diags := segmentResourceStateFromAPI(context.Background(), dto, expression, types.StringNull(), jsontypes.NewNormalizedValue(testDeepVisibilityCriteria))
+	if diags.HasError() {
+		t.Fatalf("segmentResourceStateFromAPI returned diagnostics: %v", diags)
+	}
+	if model.VisibilityCriteriaJson.ValueString() != testDeepVisibilityCriteria {
+		t.Errorf("visibility_criteria_json = %s, want the configured tree kept", model.VisibilityCriteriaJson.ValueString())
+	}
+	if !model.VisibilityCriteria.IsNull() {
+		t.Error("visibility_criteria should be null while visibility_criteria_json is in use")
+	}
+
+	_, diags = segmentResourceStateFromAPI(context.Background(), dto, expression, types.StringNull(), jsontypes.NewNormalizedNull())
+	if diags.WarningsCount() != 1 {
+		t.Errorf("warnings = %d, want 1 for a tree too deep for visibility_criteria", diags.WarningsCount())
+	}
+}
+
+func TestSegmentDecodeVisibility_ErrorResponse(t *testing.T) {
+	httpResp := &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(bytes.NewBufferString(`{"detailCode": "400.0 Bad request syntax"}`))}
+	sdkErr := errors.New("400 Bad Request")
+
+	if _, _, err := segmentDecodeVisibility(context.Background(), nil, httpResp, sdkErr); !errors.Is(err, sdkErr) {
+		t.Errorf("err = %v, want the SDK error surfaced for a non-2xx response", err)
+	}
+}
+
+func TestSegmentVisibilityCriteriaJSONPatchOps(t *testing.T) {
+	plan := jsontypes.NewNormalizedValue(testDeepVisibilityCriteria)
+
+	ops, diags := segmentVisibilityCriteriaJSONPatchOps(plan, jsontypes.NewNormalizedNull())
+	if diags.HasError() {
+		t.Fatalf("segmentVisibilityCriteriaJSONPatchOps returned diagnostics: %v", diags)
+	}
+	if len(ops) != 1 || ops[0].Op != "add" || ops[0].Path != "/visibilityCriteria" {
+		t.Fatalf("ops = %+v, want one add of /visibilityCriteria", ops)
+	}
+	b, err := json.Marshal(ops[0].Value)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(b), `"expression":{`) || !strings.Contains(string(b), "costCenter") {
+		t.Errorf("patch value %s does not carry the full expression tree", b)
+	}
+
+	if ops, _ := segmentVisibilityCriteriaJSONPatchOps(plan, plan); len(ops) != 0 {
+		t.Errorf("ops = %+v, want none when unchanged", ops)
+	}
+	if ops, _ := segmentVisibilityCriteriaJSONPatchOps(jsontypes.NewNormalizedNull(), plan); len(ops) != 0 {
+		t.Errorf("ops = %+v, want none when the attribute is removed", ops)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -28,14 +29,15 @@ var (
 )
 
 type segmentDataSourceModel struct {
-	Active             types.Bool                    `tfsdk:"active"`
-	Created            types.String                  `tfsdk:"created"`
-	Description        types.String                  `tfsdk:"description"`
-	Id                 types.String                  `tfsdk:"id"`
-	Modified           types.String                  `tfsdk:"modified"`
-	Name               types.String                  `tfsdk:"name"`
-	Owner              datasource_segment.OwnerValue `tfsdk:"owner"`
-	VisibilityCriteria types.Object                  `tfsdk:"visibility_criteria"`
+	Active                 types.Bool                    `tfsdk:"active"`
+	Created                types.String                  `tfsdk:"created"`
+	Description            types.String                  `tfsdk:"description"`
+	Id                     types.String                  `tfsdk:"id"`
+	Modified               types.String                  `tfsdk:"modified"`
+	Name                   types.String                  `tfsdk:"name"`
+	Owner                  datasource_segment.OwnerValue `tfsdk:"owner"`
+	VisibilityCriteria     types.Object                  `tfsdk:"visibility_criteria"`
+	VisibilityCriteriaJson jsontypes.Normalized          `tfsdk:"visibility_criteria_json"`
 }
 
 func NewSegmentDataSource() datasource.DataSource {
@@ -53,7 +55,7 @@ func (d *segmentDataSource) Metadata(ctx context.Context, req datasource.Metadat
 func (d *segmentDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = segmentDataSourceSchema(ctx)
 	resp.Schema.Description = "Reads a Segment from IdentityNow/ISC by id or exact name."
-	resp.Schema.MarkdownDescription = "Reads a Segment from IdentityNow/ISC by `id` or exact `name`. Exactly one of those arguments must be set. Returns the same generated fields as the Phase 1 codegen plus the hand-written `visibility_criteria` tree."
+	resp.Schema.MarkdownDescription = "Reads a Segment from IdentityNow/ISC by `id` or exact `name`. Exactly one of those arguments must be set. Returns the same generated fields as the Phase 1 codegen plus the hand-written `visibility_criteria` tree, and the full tree at any depth as `visibility_criteria_json`."
 }
 
 func segmentDataSourceSchema(ctx context.Context) datasourceschema.Schema {
@@ -75,6 +77,7 @@ func segmentDataSourceSchema(ctx context.Context) datasourceschema.Schema {
 	s.Attributes["name"] = nameAttr
 
 	applyDataSourceVisibilityCriteriaField(&s.Attributes)
+	applyDataSourceVisibilityCriteriaJSONField(&s.Attributes)
 	return s
 }
 
@@ -110,7 +113,7 @@ func (d *segmentDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	dto, diags := d.lookupSegment(ctx, config)
+	dto, expression, diags := d.lookupSegment(ctx, config)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -121,11 +124,18 @@ func (d *segmentDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.VisibilityCriteriaJson, diags = segmentVisibilityCriteriaJSONValue(expression)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
-func (d *segmentDataSource) lookupSegment(ctx context.Context, config segmentDataSourceModel) (*segments.Segment, diag.Diagnostics) {
+// lookupSegment returns the segment plus its raw visibility expression (see
+// segmentDecodeVisibility).
+func (d *segmentDataSource) lookupSegment(ctx context.Context, config segmentDataSourceModel) (*segments.Segment, interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	if !config.Id.IsNull() && !config.Id.IsUnknown() && config.Id.ValueString() != "" {
@@ -133,18 +143,20 @@ func (d *segmentDataSource) lookupSegment(ctx context.Context, config segmentDat
 		dto, httpResp, err := d.client.SegmentsAPI.
 			GetSegmentV1(ctx, config.Id.ValueString()).
 			Execute()
+		dto, expression, err := segmentDecodeVisibility(ctx, dto, httpResp, err)
 		if err != nil {
 			tflog.Error(ctx, "Error reading Segment data source by id", map[string]interface{}{"id": config.Id.ValueString(), "error": err.Error()})
 			diags.AddError("Error reading Segment", segmentErrDetail(err, httpResp))
-			return nil, diags
+			return nil, nil, diags
 		}
-		return dto, diags
+		return dto, expression, diags
 	}
 
 	lookupName := strings.TrimSpace(config.Name.ValueString())
 	tflog.Debug(ctx, "Reading Segment data source by name", map[string]interface{}{"name": lookupName})
 
 	matches := make([]segments.Segment, 0, 2)
+	expressions := make([]interface{}, 0, 2)
 	var offset int32
 	for {
 		items, httpResp, err := d.client.SegmentsAPI.
@@ -152,15 +164,17 @@ func (d *segmentDataSource) lookupSegment(ctx context.Context, config segmentDat
 			Limit(segmentLookupPageSize).
 			Offset(offset).
 			Execute()
+		items, itemExpressions, err := segmentsDecodeVisibility(ctx, items, httpResp, err)
 		if err != nil {
 			tflog.Error(ctx, "Error listing Segments for name lookup", map[string]interface{}{"name": lookupName, "error": err.Error()})
 			diags.AddError("Error reading Segment by name", segmentErrDetail(err, httpResp))
-			return nil, diags
+			return nil, nil, diags
 		}
 
 		for i := range items {
 			if items[i].Name != nil && *items[i].Name == lookupName {
 				matches = append(matches, items[i])
+				expressions = append(expressions, itemExpressions[i])
 			}
 		}
 
@@ -176,9 +190,9 @@ func (d *segmentDataSource) lookupSegment(ctx context.Context, config segmentDat
 			"Segment not found by name",
 			fmt.Sprintf("No segment with exact name %q was found. Set `id` instead if the segment name is not unique or has changed.", lookupName),
 		)
-		return nil, diags
+		return nil, nil, diags
 	case 1:
-		return &matches[0], diags
+		return &matches[0], expressions[0], diags
 	default:
 		ids := make([]string, 0, len(matches))
 		for i := range matches {
@@ -190,7 +204,7 @@ func (d *segmentDataSource) lookupSegment(ctx context.Context, config segmentDat
 			"Segment name is not unique",
 			fmt.Sprintf("Found %d segments with exact name %q. Use `id` instead. Matching segment ids: %s.", len(matches), lookupName, strings.Join(ids, ", ")),
 		)
-		return nil, diags
+		return nil, nil, diags
 	}
 }
 
@@ -198,14 +212,15 @@ func segmentDataSourceDTOToModel(ctx context.Context, dto *segments.Segment, fal
 	var diags diag.Diagnostics
 
 	model := segmentDataSourceModel{
-		Active:             types.BoolNull(),
-		Created:            types.StringNull(),
-		Description:        types.StringNull(),
-		Id:                 fallbackID,
-		Modified:           types.StringNull(),
-		Name:               types.StringNull(),
-		Owner:              datasource_segment.NewOwnerValueNull(),
-		VisibilityCriteria: types.ObjectNull(visibilityCriteriaAttrTypes()),
+		Active:                 types.BoolNull(),
+		Created:                types.StringNull(),
+		Description:            types.StringNull(),
+		Id:                     fallbackID,
+		Modified:               types.StringNull(),
+		Name:                   types.StringNull(),
+		Owner:                  datasource_segment.NewOwnerValueNull(),
+		VisibilityCriteria:     types.ObjectNull(visibilityCriteriaAttrTypes()),
+		VisibilityCriteriaJson: jsontypes.NewNormalizedNull(),
 	}
 
 	if dto == nil {
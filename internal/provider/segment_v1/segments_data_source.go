@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -30,14 +31,15 @@ type segmentsDataSourceModel struct {
 }
 
 type segmentsListItemModel struct {
-	Active             types.Bool   `tfsdk:"active"`
-	Created            types.String `tfsdk:"created"`
-	Description        types.String `tfsdk:"description"`
-	Id                 types.String `tfsdk:"id"`
-	Modified           types.String `tfsdk:"modified"`
-	Name               types.String `tfsdk:"name"`
-	Owner              types.Object `tfsdk:"owner"`
-	VisibilityCriteria types.Object `tfsdk:"visibility_criteria"`
+	Active                 types.Bool           `tfsdk:"active"`
+	Created                types.String         `tfsdk:"created"`
+	Description            types.String         `tfsdk:"description"`
+	Id                     types.String         `tfsdk:"id"`
+	Modified               types.String         `tfsdk:"modified"`
+	Name                   types.String         `tfsdk:"name"`
+	Owner                  types.Object         `tfsdk:"owner"`
+	VisibilityCriteria     types.Object         `tfsdk:"visibility_criteria"`
+	VisibilityCriteriaJson jsontypes.Normalized `tfsdk:"visibility_criteria_json"`
 }
 
 func NewSegmentsDataSource() datasource.DataSource {
@@ -55,7 +57,7 @@ func (d *segmentsDataSource) Metadata(ctx context.Context, req datasource.Metada
 func (d *segmentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = segmentsDataSourceSchema(ctx)
 	resp.Schema.Description = "Lists Segments from IdentityNow/ISC, optionally paginated."
-	resp.Schema.MarkdownDescription = "Lists Segments from IdentityNow/ISC via `GET /segments`, optionally paginated. Returns the same generated fields as Phase 1 codegen plus the hand-written `visibility_criteria` tree for each segment."
+	resp.Schema.MarkdownDescription = "Lists Segments from IdentityNow/ISC via `GET /segments`, optionally paginated. Returns the same generated fields as Phase 1 codegen plus the hand-written `visibility_criteria` tree, and the full tree at any depth as `visibility_criteria_json`, for each segment."
 }
 
 func (d *segmentsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -92,6 +94,7 @@ func (d *segmentsDataSource) Read(ctx context.Context, req datasource.ReadReques
 	}
 
 	dtos, httpResp, err := apiReq.Execute()
+	dtos, expressions, err := segmentsDecodeVisibility(ctx, dtos, httpResp, err)
 	if err != nil {
 		tflog.Error(ctx, "Error reading Segments data source", map[string]interface{}{"error": err.Error()})
 		resp.Diagnostics.AddError("Error listing Segments", segmentErrDetail(err, httpResp))
@@ -108,6 +111,11 @@ func (d *segmentsDataSource) Read(ctx context.Context, req datasource.ReadReques
 		if resp.Diagnostics.HasError() {
 			return
 		}
+		item.VisibilityCriteriaJson, diags = segmentVisibilityCriteriaJSONValue(expressions[i])
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 		items = append(items, item)
 	}
 
@@ -125,6 +133,7 @@ func segmentsDataSourceSchema(ctx context.Context) datasourceschema.Schema {
 	s := datasource_segments.SegmentsDataSourceSchema(ctx)
 	if segmentsAttr, ok := s.Attributes["segments"].(datasourceschema.SetNestedAttribute); ok {
 		applyDataSourceVisibilityCriteriaField(&segmentsAttr.NestedObject.Attributes)
+		applyDataSourceVisibilityCriteriaJSONField(&segmentsAttr.NestedObject.Attributes)
 		// The generated NestedObject carries a fixed `CustomType` (SegmentsType)
 		// derived at codegen time from the (visibilityCriteria-ignored)
 		// generated model, which takes precedence over the Attributes map when
@@ -142,14 +151,15 @@ func segmentsDataSourceSchema(ctx context.Context) datasourceschema.Schema {
 func segmentListItemFromDTO(ctx context.Context, dto *segments.Segment) (segmentsListItemModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	item := segmentsListItemModel{
-		Active:             types.BoolNull(),
-		Created:            types.StringNull(),
-		Description:        types.StringNull(),
-		Id:                 types.StringNull(),
-		Modified:           types.StringNull(),
-		Name:               types.StringNull(),
-		Owner:              types.ObjectNull(datasource_segments.OwnerValue{}.AttributeTypes(ctx)),
-		VisibilityCriteria: types.ObjectNull(visibilityCriteriaAttrTypes()),
+		Active:                 types.BoolNull(),
+		Created:                types.StringNull(),
+		Description:            types.StringNull(),
+		Id:                     types.StringNull(),
+		Modified:               types.StringNull(),
+		Name:                   types.StringNull(),
+		Owner:                  types.ObjectNull(datasource_segments.OwnerValue{}.AttributeTypes(ctx)),
+		VisibilityCriteria:     types.ObjectNull(visibilityCriteriaAttrTypes()),
+		VisibilityCriteriaJson: jsontypes.NewNormalizedNull(),
 	}
 
 	if dto == nil {
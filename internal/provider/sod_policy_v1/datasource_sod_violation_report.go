@@ -0,0 +1,227 @@
+// This file implements identitynow_sod_violation_report_v1, which downloads
+// a completed SOD violation report
+// (GET /sod-violation-report/v1/{reportResultId}/download, i.e.
+// SODPoliciesAPI.GetDefaultViolationReportV1) and returns its rows, for
+// check blocks and outputs. Reports are generated by
+// identitynow_sod_violation_report_run_v1 (or a policy schedule); archive
+// parsing lives in sod_violation_report_csv.go.
+package sod_policy_v1
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+)
+
+var (
+	_ datasource.DataSource              = (*sodViolationReportDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*sodViolationReportDataSource)(nil)
+)
+
+func NewSodViolationReportDataSource() datasource.DataSource {
+	return &sodViolationReportDataSource{}
+}
+
+type sodViolationReportDataSource struct {
+	client *sailpoint.APIClient
+}
+
+type sodViolationReportDataSourceModel struct {
+	ReportResultId types.String `tfsdk:"report_result_id"`
+	Violations     types.List   `tfsdk:"violations"`
+}
+
+func sodViolationAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"file_name":     types.StringType,
+		"policy_name":   types.StringType,
+		"identity_id":   types.StringType,
+		"identity_name": types.StringType,
+		"left_access":   types.StringType,
+		"right_access":  types.StringType,
+		"columns":       types.MapType{ElemType: types.StringType},
+	}
+}
+
+func (d *sodViolationReportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sod_violation_report_v1"
+}
+
+func (d *sodViolationReportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = datasourceschema.Schema{
+		Description: "Downloads a completed Separation of Duties (SOD) violation report and returns its violations.",
+		MarkdownDescription: "Downloads a completed [Separation of Duties (SOD)](https://documentation.sailpoint.com/saas/help/sod/manage-policies.html) " +
+			"violation report and returns its violations, for use in `check` blocks and outputs. Reports are generated with " +
+			"`identitynow_sod_violation_report_run_v1`.",
+		Attributes: map[string]datasourceschema.Attribute{
+			"report_result_id": datasourceschema.StringAttribute{
+				Required:            true,
+				Description:         "ID of the report result to download, e.g. identitynow_sod_violation_report_run_v1's report_result_id.",
+				MarkdownDescription: "ID of the report result to download, e.g. `identitynow_sod_violation_report_run_v1`'s `report_result_id`.",
+			},
+			"violations": datasourceschema.ListNestedAttribute{
+				Computed:            true,
+				Description:         "One entry per row of the report, in report order. Empty when the report found no violations.",
+				MarkdownDescription: "One entry per row of the report, in report order. Empty when the report found no violations.",
+				NestedObject: datasourceschema.NestedAttributeObject{
+					Attributes: map[string]datasourceschema.Attribute{
+						"file_name": datasourceschema.StringAttribute{
+							Computed:            true,
+							Description:         "Name of the CSV file in the report archive the row came from.",
+							MarkdownDescription: "Name of the CSV file in the report archive the row came from.",
+						},
+						"policy_name": datasourceschema.StringAttribute{
+							Computed:            true,
+							Description:         "Name of the violated policy.",
+							MarkdownDescription: "Name of the violated policy.",
+						},
+						"identity_id": datasourceschema.StringAttribute{
+							Computed:            true,
+							Description:         "ID of the identity in violation, when the report includes it.",
+							MarkdownDescription: "ID of the identity in violation, when the report includes it.",
+						},
+						"identity_name": datasourceschema.StringAttribute{
+							Computed:            true,
+							Description:         "Name of the identity in violation.",
+							MarkdownDescription: "Name of the identity in violation.",
+						},
+						"left_access": datasourceschema.StringAttribute{
+							Computed:            true,
+							Description:         "The identity's access matching the policy's left criteria.",
+							MarkdownDescription: "The identity's access matching the policy's left criteria.",
+						},
+						"right_access": datasourceschema.StringAttribute{
+							Computed:            true,
+							Description:         "The identity's access matching the policy's right criteria.",
+							MarkdownDescription: "The identity's access matching the policy's right criteria.",
+						},
+						"columns": datasourceschema.MapAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							Description:         "Every cell of the row, keyed by the report's column header.",
+							MarkdownDescription: "Every cell of the row, keyed by the report's column header.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *sodViolationReportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = cp.GetClient()
+}
+
+func (d *sodViolationReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config sodViolationReportDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reportResultID := config.ReportResultId.ValueString()
+	tflog.Debug(ctx, "Downloading SOD violation report", map[string]interface{}{"report_result_id": reportResultID})
+
+	file, httpResp, err := d.client.SODPoliciesAPI.GetDefaultViolationReportV1(ctx, reportResultID).Execute()
+	if err != nil {
+		tflog.Error(ctx, "Error downloading SOD violation report", map[string]interface{}{"report_result_id": reportResultID, "error": err.Error()})
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"SOD violation report not found",
+				fmt.Sprintf("Report result %q does not exist, has expired, or has not completed yet.", reportResultID),
+			)
+			return
+		}
+		resp.Diagnostics.AddError("Error downloading SOD violation report", errDetail(err, httpResp))
+		return
+	}
+
+	// The SDK spools binary responses to a temp file; read and remove it.
+	data, err := readReportFile(file)
+	if err != nil {
+		resp.Diagnostics.AddError("Error downloading SOD violation report", fmt.Sprintf("Could not read the report archive: %s", err.Error()))
+		return
+	}
+
+	rows, err := parseSodViolationReportZip(data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing SOD violation report",
+			fmt.Sprintf("Could not parse report result %q: %s", reportResultID, err.Error()),
+		)
+		return
+	}
+
+	elems := make([]attr.Value, 0, len(rows))
+	for _, row := range rows {
+		columns, diags := types.MapValueFrom(ctx, types.StringType, row.Columns)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		obj, diags := types.ObjectValue(sodViolationAttrTypes(), map[string]attr.Value{
+			"file_name":     types.StringValue(row.FileName),
+			"policy_name":   types.StringValue(row.PolicyName),
+			"identity_id":   types.StringValue(row.IdentityId),
+			"identity_name": types.StringValue(row.IdentityName),
+			"left_access":   types.StringValue(row.LeftAccess),
+			"right_access":  types.StringValue(row.RightAccess),
+			"columns":       columns,
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		elems = append(elems, obj)
+	}
+	violations, diags := types.ListValue(types.ObjectType{AttrTypes: sodViolationAttrTypes()}, elems)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := sodViolationReportDataSourceModel{
+		ReportResultId: config.ReportResultId,
+		Violations:     violations,
+	}
+
+	tflog.Debug(ctx, "Downloaded SOD violation report", map[string]interface{}{"report_result_id": reportResultID, "violations": len(rows)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func readReportFile(f *os.File) ([]byte, error) {
+	if f == nil {
+		return nil, fmt.Errorf("the download returned no file")
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(f)
+}
@@ -41,10 +41,17 @@
 //     own lack of a readOnly marker; practitioners managing a
 //     CONFLICTING_ACCESS_BASED policy should leave policy_query unconfigured
 //     and let the API compute/return it).
-//   - "schedule" (GET/PUT /sod-policies/v1/{id}/schedule) and the
-//     evaluate/violation-report/violation-report-status endpoints are
-//     intentionally out of scope for this CRUD resource - see the package's
-//     "Known Limitations & Live Testing Notes" doc section.
+//   - "schedule" (GET/PUT/DELETE /sod-policies/v1/{id}/schedule) is its own
+//     resource, identitynow_sod_policy_schedule_v1 (see
+//     resource_sod_policy_schedule.go); it flips this resource's
+//     "scheduled" flag as a side effect.
+//   - The violation-report run/status/download endpoints back the
+//     identitynow_sod_violation_report_run_v1 resource and
+//     identitynow_sod_violation_report_v1 data source (see
+//     resource_sod_violation_report_run.go and
+//     datasource_sod_violation_report.go). The evaluate endpoint is
+//     intentionally out of scope - see the package's "Known Limitations &
+//     Live Testing Notes" doc section.
 //   - SDK path note: every sod_policies.SODPoliciesAPIService method is annotated
 //     "Deprecated" in the SDK's generated doc comments - this is a blanket
 //     annotation applied uniformly across the whole api_beta package as part
@@ -0,0 +1,378 @@
+// This file implements identitynow_sod_policy_schedule_v1, which manages the
+// violation-scan schedule of one SOD policy via
+// GET/PUT/DELETE /sod-policies/v1/{id}/schedule
+// (sod_policies.SODPoliciesAPI.GetSodPolicyScheduleV1/PutPolicyScheduleV1/
+// DeleteSodPolicyScheduleV1) - the follow-up to the "schedule" note in
+// resource_sod_policy.go's package doc.
+//
+// A policy has at most one schedule, addressed by the policy's own ID, so
+// the resource is keyed (and imported) by policy_id, like
+// identitynow_governance_group_members_v1 is by its group. PUT both creates
+// and replaces the schedule; Delete removes only the schedule, never the
+// policy. Model conversion lives in resource_sod_policy_schedule_model.go.
+package sod_policy_v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+)
+
+var (
+	_ resource.Resource                = (*sodPolicyScheduleResource)(nil)
+	_ resource.ResourceWithConfigure   = (*sodPolicyScheduleResource)(nil)
+	_ resource.ResourceWithImportState = (*sodPolicyScheduleResource)(nil)
+)
+
+func NewSodPolicyScheduleResource() resource.Resource {
+	return &sodPolicyScheduleResource{}
+}
+
+type sodPolicyScheduleResource struct {
+	client *sailpoint.APIClient
+}
+
+func (r *sodPolicyScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sod_policy_schedule_v1"
+}
+
+func (r *sodPolicyScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceschema.Schema{
+		Description: "Manages the violation-scan schedule of a Separation of Duties (SOD) Policy in IdentityNow/ISC.",
+		MarkdownDescription: "Manages the violation-scan schedule of a [Separation of Duties (SOD) Policy](https://documentation.sailpoint.com/saas/help/sod/manage-policies.html) " +
+			"in IdentityNow/ISC: when the policy is evaluated, who is emailed the resulting violation report, and whether " +
+			"a report with no violations is sent at all. A policy has at most one schedule, so the resource is keyed and " +
+			"imported by `policy_id`. Destroying it deletes the schedule only; the policy itself is left untouched.\n\n" +
+			"~> Creating or deleting a schedule also flips the policy's own `scheduled` flag. Leave `scheduled` unset on " +
+			"`identitynow_sod_policy_v1` when managing the schedule with this resource, or the two will keep undoing each " +
+			"other.",
+		Attributes: map[string]resourceschema.Attribute{
+			"id": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "Same as policy_id.",
+				MarkdownDescription: "Same as `policy_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"policy_id": resourceschema.StringAttribute{
+				Required:            true,
+				Description:         "ID of the SOD policy to schedule.",
+				MarkdownDescription: "ID of the SOD policy to schedule.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": resourceschema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Schedule name. Generated by the API (e.g. SCH-1584312283015) when not set.",
+				MarkdownDescription: "Schedule name. Generated by the API (e.g. `SCH-1584312283015`) when not set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": resourceschema.StringAttribute{
+				Optional:            true,
+				Description:         "Schedule description.",
+				MarkdownDescription: "Schedule description.",
+			},
+			"schedule": resourceschema.SingleNestedAttribute{
+				Required:            true,
+				Description:         "When the policy is evaluated.",
+				MarkdownDescription: "When the policy is evaluated.",
+				Attributes: map[string]resourceschema.Attribute{
+					"type": resourceschema.StringAttribute{
+						Required:            true,
+						Description:         "Schedule type: DAILY, WEEKLY, MONTHLY, CALENDAR or ANNUALLY.",
+						MarkdownDescription: "Schedule type: `DAILY`, `WEEKLY`, `MONTHLY`, `CALENDAR` or `ANNUALLY`.",
+					},
+					"hours": sodScheduleSelectorAttribute(true,
+						"The hours to run at, 0-23 (e.g. `values = [\"9\"]`, or `type = \"RANGE\"`, `values = [\"9\", \"18\"]`, `interval = 3`)."),
+					"days": sodScheduleSelectorAttribute(false,
+						"The days to run on. `MON`-`SUN` for a `WEEKLY` schedule; day numbers such as `\"1\"` or `\"28\"` for "+
+							"`MONTHLY` and `ANNUALLY` schedules, where `\"L\"` is the last day of the month."),
+					"months": sodScheduleSelectorAttribute(false,
+						"The months to run in, `\"1\"`-`\"12\"`. Only applies to `ANNUALLY` schedules."),
+					"expiration": resourceschema.StringAttribute{
+						Optional: true,
+						Description: "RFC 3339 timestamp after which the schedule stops running. The latest possible " +
+							"expiration is 2038-01-19T03:14:07Z.",
+						MarkdownDescription: "RFC 3339 timestamp after which the schedule stops running. The latest possible " +
+							"expiration is `2038-01-19T03:14:07Z`.",
+					},
+					"time_zone_id": resourceschema.StringAttribute{
+						Optional:            true,
+						Computed:            true,
+						Description:         "Canonical TZ identifier to run in (e.g. America/Chicago). Defaults to the tenant's time zone.",
+						MarkdownDescription: "Canonical TZ identifier to run in (e.g. `America/Chicago`). Defaults to the tenant's time zone.",
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
+			"recipients": resourceschema.ListNestedAttribute{
+				Optional:            true,
+				Description:         "Identities emailed the violation report after each scheduled run.",
+				MarkdownDescription: "Identities emailed the violation report after each scheduled run.",
+				NestedObject: resourceschema.NestedAttributeObject{
+					Attributes: map[string]resourceschema.Attribute{
+						"type": resourceschema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("IDENTITY"),
+							Description:         "Recipient type. Only IDENTITY is supported.",
+							MarkdownDescription: "Recipient type. Only `IDENTITY` is supported.",
+						},
+						"id": resourceschema.StringAttribute{
+							Required:            true,
+							Description:         "ID of the recipient identity.",
+							MarkdownDescription: "ID of the recipient identity.",
+						},
+						"name": resourceschema.StringAttribute{
+							Computed:            true,
+							Description:         "Display name of the recipient identity.",
+							MarkdownDescription: "Display name of the recipient identity.",
+						},
+					},
+				},
+			},
+			"email_empty_results": resourceschema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				Description:         "Whether to email recipients when a run finds no violations. Defaults to false.",
+				MarkdownDescription: "Whether to email recipients when a run finds no violations. Defaults to `false`.",
+			},
+			"created": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "Date/time the schedule was created, in RFC3339 format.",
+				MarkdownDescription: "Date/time the schedule was created, in RFC3339 format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modified": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "Date/time the schedule was last modified, in RFC3339 format.",
+				MarkdownDescription: "Date/time the schedule was last modified, in RFC3339 format.",
+			},
+			"creator_id": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "ID of the identity that created the schedule.",
+				MarkdownDescription: "ID of the identity that created the schedule.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modifier_id": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "ID of the identity that last modified the schedule.",
+				MarkdownDescription: "ID of the identity that last modified the schedule.",
+			},
+		},
+	}
+}
+
+func sodScheduleSelectorAttribute(required bool, desc string) resourceschema.SingleNestedAttribute {
+	return resourceschema.SingleNestedAttribute{
+		Required:            required,
+		Optional:            !required,
+		Description:         desc,
+		MarkdownDescription: desc,
+		Attributes: map[string]resourceschema.Attribute{
+			"type": resourceschema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("LIST"),
+				Description: "LIST (values holds one or more distinct values) or RANGE (values holds the start and end " +
+					"of the range, inclusive). Defaults to LIST.",
+				MarkdownDescription: "`LIST` (`values` holds one or more distinct values) or `RANGE` (`values` holds the " +
+					"start and end of the range, inclusive). Defaults to `LIST`.",
+			},
+			"values": resourceschema.ListAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				Description:         "The selected values.",
+				MarkdownDescription: "The selected values.",
+			},
+			"interval": resourceschema.Int64Attribute{
+				Optional:            true,
+				Description:         "Step between the selected values of a RANGE (e.g. every 3 hours).",
+				MarkdownDescription: "Step between the selected values of a `RANGE` (e.g. every 3 hours).",
+			},
+		},
+	}
+}
+
+func (r *sodPolicyScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = cp.GetClient()
+}
+
+func (r *sodPolicyScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policy_id"), req.ID)...)
+}
+
+func (r *sodPolicyScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan sodPolicyScheduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyId := plan.PolicyId.ValueString()
+	tflog.Debug(ctx, "Creating SOD Policy schedule", map[string]interface{}{"policy_id": policyId})
+
+	dto, diags := sodPolicyScheduleModelToDTO(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiResp, httpResp, err := r.client.SODPoliciesAPI.
+		PutPolicyScheduleV1(ctx, policyId).
+		SodPolicySchedule(*dto).
+		Execute()
+	if err != nil {
+		tflog.Error(ctx, "Error creating SOD Policy schedule", map[string]interface{}{"policy_id": policyId, "error": err.Error()})
+		resp.Diagnostics.AddError("Error creating SOD Policy schedule", errDetail(err, httpResp))
+		return
+	}
+
+	state, diags := sodPolicyScheduleDTOToModel(ctx, policyId, apiResp, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Created SOD Policy schedule", map[string]interface{}{"policy_id": policyId})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *sodPolicyScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state sodPolicyScheduleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyId := state.PolicyId.ValueString()
+	tflog.Debug(ctx, "Reading SOD Policy schedule", map[string]interface{}{"policy_id": policyId})
+
+	apiResp, httpResp, err := r.client.SODPoliciesAPI.
+		GetSodPolicyScheduleV1(ctx, policyId).
+		Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Warn(ctx, "SOD Policy schedule not found, removing from state", map[string]interface{}{"policy_id": policyId})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		tflog.Error(ctx, "Error reading SOD Policy schedule", map[string]interface{}{"policy_id": policyId, "error": err.Error()})
+		resp.Diagnostics.AddError("Error reading SOD Policy schedule", errDetail(err, httpResp))
+		return
+	}
+
+	newState, diags := sodPolicyScheduleDTOToModel(ctx, policyId, apiResp, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if newState.Schedule.IsNull() {
+		tflog.Warn(ctx, "SOD Policy has no schedule, removing from state", map[string]interface{}{"policy_id": policyId})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *sodPolicyScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan sodPolicyScheduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyId := plan.PolicyId.ValueString()
+	tflog.Debug(ctx, "Updating SOD Policy schedule", map[string]interface{}{"policy_id": policyId})
+
+	dto, diags := sodPolicyScheduleModelToDTO(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiResp, httpResp, err := r.client.SODPoliciesAPI.
+		PutPolicyScheduleV1(ctx, policyId).
+		SodPolicySchedule(*dto).
+		Execute()
+	if err != nil {
+		tflog.Error(ctx, "Error updating SOD Policy schedule", map[string]interface{}{"policy_id": policyId, "error": err.Error()})
+		resp.Diagnostics.AddError("Error updating SOD Policy schedule", errDetail(err, httpResp))
+		return
+	}
+
+	state, diags := sodPolicyScheduleDTOToModel(ctx, policyId, apiResp, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Updated SOD Policy schedule", map[string]interface{}{"policy_id": policyId})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *sodPolicyScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state sodPolicyScheduleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyId := state.PolicyId.ValueString()
+	tflog.Debug(ctx, "Deleting SOD Policy schedule", map[string]interface{}{"policy_id": policyId})
+
+	httpResp, err := r.client.SODPoliciesAPI.
+		DeleteSodPolicyScheduleV1(ctx, policyId).
+		Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Warn(ctx, "SOD Policy schedule already absent on delete", map[string]interface{}{"policy_id": policyId})
+			return
+		}
+		tflog.Error(ctx, "Error deleting SOD Policy schedule", map[string]interface{}{"policy_id": policyId, "error": err.Error()})
+		resp.Diagnostics.AddError("Error deleting SOD Policy schedule", errDetail(err, httpResp))
+		return
+	}
+
+	tflog.Info(ctx, "Deleted SOD Policy schedule", map[string]interface{}{"policy_id": policyId})
+}
@@ -0,0 +1,372 @@
+package sod_policy_v1
+
+// Model conversion for identitynow_sod_policy_schedule_v1
+// (resource_sod_policy_schedule.go). Nested values are plain types.Object/
+// types.List, as in resource_sod_policy_criteria.go.
+//
+// Unlike the criteria helpers, these go through sodPolicyScheduleWire - a
+// plain struct mirroring the "Sod Policy Schedule" JSON in
+// api-specs/dereferenced/deref-sod-policies.v1.yaml - rather than the SDK's
+// nested types: the spec leaves the schedule and its days/hours/months
+// selectors untitled, so their generated Go names are an accident of
+// generation order and have changed between SDK releases. Only the
+// top-level sod_policies.SodPolicySchedule is referenced by name, and the
+// wire struct is converted to and from it as JSON.
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/sailpoint-oss/golang-sdk/v3/sod_policies"
+)
+
+type sodPolicyScheduleResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	PolicyId          types.String `tfsdk:"policy_id"`
+	Name              types.String `tfsdk:"name"`
+	Description       types.String `tfsdk:"description"`
+	Schedule          types.Object `tfsdk:"schedule"`
+	Recipients        types.List   `tfsdk:"recipients"`
+	EmailEmptyResults types.Bool   `tfsdk:"email_empty_results"`
+	Created           types.String `tfsdk:"created"`
+	Modified          types.String `tfsdk:"modified"`
+	CreatorId         types.String `tfsdk:"creator_id"`
+	ModifierId        types.String `tfsdk:"modifier_id"`
+}
+
+type sodScheduleModel struct {
+	Type       types.String `tfsdk:"type"`
+	Hours      types.Object `tfsdk:"hours"`
+	Days       types.Object `tfsdk:"days"`
+	Months     types.Object `tfsdk:"months"`
+	Expiration types.String `tfsdk:"expiration"`
+	TimeZoneId types.String `tfsdk:"time_zone_id"`
+}
+
+type sodScheduleSelectorModel struct {
+	Type     types.String `tfsdk:"type"`
+	Values   types.List   `tfsdk:"values"`
+	Interval types.Int64  `tfsdk:"interval"`
+}
+
+type sodRecipientModel struct {
+	Type types.String `tfsdk:"type"`
+	Id   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func sodScheduleSelectorAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"type":     types.StringType,
+		"values":   types.ListType{ElemType: types.StringType},
+		"interval": types.Int64Type,
+	}
+}
+
+func sodScheduleAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"type":         types.StringType,
+		"hours":        types.ObjectType{AttrTypes: sodScheduleSelectorAttrTypes()},
+		"days":         types.ObjectType{AttrTypes: sodScheduleSelectorAttrTypes()},
+		"months":       types.ObjectType{AttrTypes: sodScheduleSelectorAttrTypes()},
+		"expiration":   types.StringType,
+		"time_zone_id": types.StringType,
+	}
+}
+
+func sodRecipientAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"type": types.StringType,
+		"id":   types.StringType,
+		"name": types.StringType,
+	}
+}
+
+type sodPolicyScheduleWire struct {
+	Name              *string            `json:"name,omitempty"`
+	Description       *string            `json:"description,omitempty"`
+	Schedule          *sodScheduleWire   `json:"schedule,omitempty"`
+	Recipients        []sodRecipientWire `json:"recipients,omitempty"`
+	EmailEmptyResults *bool              `json:"emailEmptyResults,omitempty"`
+	Created           *string            `json:"created,omitempty"`
+	Modified          *string            `json:"modified,omitempty"`
+	CreatorId         *string            `json:"creatorId,omitempty"`
+	ModifierId        *string            `json:"modifierId,omitempty"`
+}
+
+type sodScheduleWire struct {
+	Type       string                   `json:"type"`
+	Hours      *sodScheduleSelectorWire `json:"hours,omitempty"`
+	Days       *sodScheduleSelectorWire `json:"days,omitempty"`
+	Months     *sodScheduleSelectorWire `json:"months,omitempty"`
+	Expiration *string                  `json:"expiration,omitempty"`
+	TimeZoneId *string                  `json:"timeZoneId,omitempty"`
+}
+
+type sodScheduleSelectorWire struct {
+	Type     string   `json:"type"`
+	Values   []string `json:"values"`
+	Interval *int64   `json:"interval,omitempty"`
+}
+
+type sodRecipientWire struct {
+	Type *string `json:"type,omitempty"`
+	Id   *string `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+// sodPolicyScheduleModelToDTO builds the PUT body. The read-only fields
+// (created, modified, creator_id, modifier_id) are never sent.
+func sodPolicyScheduleModelToDTO(ctx context.Context, m sodPolicyScheduleResourceModel) (*sod_policies.SodPolicySchedule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var wire sodPolicyScheduleWire
+	if !m.Name.IsNull() && !m.Name.IsUnknown() {
+		wire.Name = m.Name.ValueStringPointer()
+	}
+	if !m.Description.IsNull() && !m.Description.IsUnknown() {
+		wire.Description = m.Description.ValueStringPointer()
+	}
+	if !m.EmailEmptyResults.IsNull() && !m.EmailEmptyResults.IsUnknown() {
+		wire.EmailEmptyResults = m.EmailEmptyResults.ValueBoolPointer()
+	}
+
+	schedule, d := sodScheduleObjectToWire(ctx, m.Schedule)
+	diags.Append(d...)
+	wire.Schedule = schedule
+
+	if !m.Recipients.IsNull() && !m.Recipients.IsUnknown() {
+		var items []sodRecipientModel
+		diags.Append(m.Recipients.ElementsAs(ctx, &items, false)...)
+		wire.Recipients = make([]sodRecipientWire, 0, len(items))
+		for _, item := range items {
+			recipient := sodRecipientWire{Id: item.Id.ValueStringPointer()}
+			if !item.Type.IsNull() && !item.Type.IsUnknown() {
+				recipient.Type = item.Type.ValueStringPointer()
+			}
+			wire.Recipients = append(wire.Recipients, recipient)
+		}
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var dto sod_policies.SodPolicySchedule
+	b, err := json.Marshal(wire)
+	if err == nil {
+		err = json.Unmarshal(b, &dto)
+	}
+	if err != nil {
+		diags.AddError(
+			"Error building SOD Policy schedule request",
+			"Could not convert the configured schedule into an API request body: "+err.Error(),
+		)
+		return nil, diags
+	}
+	return &dto, diags
+}
+
+func sodScheduleObjectToWire(ctx context.Context, obj types.Object) (*sodScheduleWire, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if obj.IsNull() {
+		return nil, diags
+	}
+	if obj.IsUnknown() {
+		diags.AddError("schedule is unknown", "schedule must be known before it can be sent to the API.")
+		return nil, diags
+	}
+
+	var model sodScheduleModel
+	diags.Append(obj.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	wire := &sodScheduleWire{Type: model.Type.ValueString()}
+	var d diag.Diagnostics
+	wire.Hours, d = sodScheduleSelectorObjectToWire(ctx, model.Hours)
+	diags.Append(d...)
+	wire.Days, d = sodScheduleSelectorObjectToWire(ctx, model.Days)
+	diags.Append(d...)
+	wire.Months, d = sodScheduleSelectorObjectToWire(ctx, model.Months)
+	diags.Append(d...)
+	if !model.Expiration.IsNull() && !model.Expiration.IsUnknown() {
+		wire.Expiration = model.Expiration.ValueStringPointer()
+	}
+	if !model.TimeZoneId.IsNull() && !model.TimeZoneId.IsUnknown() {
+		wire.TimeZoneId = model.TimeZoneId.ValueStringPointer()
+	}
+	return wire, diags
+}
+
+func sodScheduleSelectorObjectToWire(ctx context.Context, obj types.Object) (*sodScheduleSelectorWire, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil, diags
+	}
+
+	var model sodScheduleSelectorModel
+	diags.Append(obj.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	wire := &sodScheduleSelectorWire{Type: model.Type.ValueString(), Values: []string{}}
+	if !model.Values.IsNull() && !model.Values.IsUnknown() {
+		diags.Append(model.Values.ElementsAs(ctx, &wire.Values, false)...)
+	}
+	if !model.Interval.IsNull() && !model.Interval.IsUnknown() {
+		wire.Interval = model.Interval.ValueInt64Pointer()
+	}
+	return wire, diags
+}
+
+// sodPolicyScheduleDTOToModel converts an API schedule into the resource
+// model. prior (the plan or prior state) is used to keep values the API
+// reports in a different but equivalent form - a recipients list that was
+// left unset, and an expiration written with another offset or precision.
+// A response without a schedule yields a null "schedule".
+func sodPolicyScheduleDTOToModel(ctx context.Context, policyId string, dto *sod_policies.SodPolicySchedule, prior sodPolicyScheduleResourceModel) (sodPolicyScheduleResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	model := sodPolicyScheduleResourceModel{
+		Id:                types.StringValue(policyId),
+		PolicyId:          types.StringValue(policyId),
+		Name:              types.StringNull(),
+		Description:       types.StringNull(),
+		Schedule:          types.ObjectNull(sodScheduleAttrTypes()),
+		Recipients:        types.ListNull(types.ObjectType{AttrTypes: sodRecipientAttrTypes()}),
+		EmailEmptyResults: types.BoolValue(false),
+		Created:           types.StringNull(),
+		Modified:          types.StringNull(),
+		CreatorId:         types.StringNull(),
+		ModifierId:        types.StringNull(),
+	}
+	if dto == nil {
+		return model, diags
+	}
+
+	var wire sodPolicyScheduleWire
+	b, err := json.Marshal(dto)
+	if err == nil {
+		err = json.Unmarshal(b, &wire)
+	}
+	if err != nil {
+		diags.AddError(
+			"Error reading SOD Policy schedule response",
+			"Could not decode the API's SOD Policy schedule: "+err.Error(),
+		)
+		return model, diags
+	}
+
+	model.Name = types.StringPointerValue(wire.Name)
+	if wire.Description != nil && (*wire.Description != "" || !prior.Description.IsNull()) {
+		model.Description = types.StringValue(*wire.Description)
+	}
+	if wire.EmailEmptyResults != nil {
+		model.EmailEmptyResults = types.BoolValue(*wire.EmailEmptyResults)
+	}
+	model.Created = types.StringPointerValue(wire.Created)
+	model.Modified = types.StringPointerValue(wire.Modified)
+	model.CreatorId = types.StringPointerValue(wire.CreatorId)
+	model.ModifierId = types.StringPointerValue(wire.ModifierId)
+
+	schedule, d := sodScheduleWireToObject(ctx, wire.Schedule, prior.Schedule)
+	diags.Append(d...)
+	model.Schedule = schedule
+
+	if len(wire.Recipients) > 0 || !prior.Recipients.IsNull() {
+		values := make([]attr.Value, 0, len(wire.Recipients))
+		for _, recipient := range wire.Recipients {
+			obj, d := types.ObjectValue(sodRecipientAttrTypes(), map[string]attr.Value{
+				"type": types.StringPointerValue(recipient.Type),
+				"id":   types.StringPointerValue(recipient.Id),
+				"name": types.StringPointerValue(recipient.Name),
+			})
+			diags.Append(d...)
+			values = append(values, obj)
+		}
+		recipients, d := types.ListValue(types.ObjectType{AttrTypes: sodRecipientAttrTypes()}, values)
+		diags.Append(d...)
+		model.Recipients = recipients
+	}
+
+	return model, diags
+}
+
+func sodScheduleWireToObject(ctx context.Context, wire *sodScheduleWire, prior types.Object) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if wire == nil {
+		return types.ObjectNull(sodScheduleAttrTypes()), diags
+	}
+
+	expiration := types.StringPointerValue(wire.Expiration)
+	if !prior.IsNull() && !prior.IsUnknown() {
+		var priorModel sodScheduleModel
+		if d := prior.As(ctx, &priorModel, basetypes.ObjectAsOptions{}); !d.HasError() {
+			expiration = sodScheduleExpirationValue(priorModel.Expiration, wire.Expiration)
+		}
+	}
+
+	hours, d := sodScheduleSelectorWireToObject(ctx, wire.Hours)
+	diags.Append(d...)
+	days, d := sodScheduleSelectorWireToObject(ctx, wire.Days)
+	diags.Append(d...)
+	months, d := sodScheduleSelectorWireToObject(ctx, wire.Months)
+	diags.Append(d...)
+
+	obj, d := types.ObjectValue(sodScheduleAttrTypes(), map[string]attr.Value{
+		"type":         types.StringValue(wire.Type),
+		"hours":        hours,
+		"days":         days,
+		"months":       months,
+		"expiration":   expiration,
+		"time_zone_id": types.StringPointerValue(wire.TimeZoneId),
+	})
+	diags.Append(d...)
+	return obj, diags
+}
+
+func sodScheduleSelectorWireToObject(ctx context.Context, wire *sodScheduleSelectorWire) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if wire == nil {
+		return types.ObjectNull(sodScheduleSelectorAttrTypes()), diags
+	}
+
+	values, d := types.ListValueFrom(ctx, types.StringType, wire.Values)
+	diags.Append(d...)
+	obj, d := types.ObjectValue(sodScheduleSelectorAttrTypes(), map[string]attr.Value{
+		"type":     types.StringValue(wire.Type),
+		"values":   values,
+		"interval": types.Int64PointerValue(wire.Interval),
+	})
+	diags.Append(d...)
+	return obj, diags
+}
+
+// sodScheduleExpirationValue keeps the configured expiration when the API
+// returns the same instant in another form (e.g. "2030-01-01T00:00:00Z" as
+// "2030-01-01T00:00:00.000Z").
+func sodScheduleExpirationValue(prior types.String, api *string) types.String {
+	if api == nil {
+		return types.StringNull()
+	}
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.StringValue(*api)
+	}
+	priorTime, err := time.Parse(time.RFC3339, prior.ValueString())
+	if err != nil {
+		return types.StringValue(*api)
+	}
+	apiTime, err := time.Parse(time.RFC3339, *api)
+	if err != nil || !priorTime.Equal(apiTime) {
+		return types.StringValue(*api)
+	}
+	return prior
+}
@@ -0,0 +1,260 @@
+package sod_policy_v1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sailpoint-oss/golang-sdk/v3/sod_policies"
+)
+
+func testSodSelector(typ string, interval *int64, values ...string) types.Object {
+	elems := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elems = append(elems, types.StringValue(v))
+	}
+	return types.ObjectValueMust(sodScheduleSelectorAttrTypes(), map[string]attr.Value{
+		"type":     types.StringValue(typ),
+		"values":   types.ListValueMust(types.StringType, elems),
+		"interval": types.Int64PointerValue(interval),
+	})
+}
+
+func testSodSchedule(typ string, hours, days types.Object, expiration, timeZone types.String) types.Object {
+	return types.ObjectValueMust(sodScheduleAttrTypes(), map[string]attr.Value{
+		"type":         types.StringValue(typ),
+		"hours":        hours,
+		"days":         days,
+		"months":       types.ObjectNull(sodScheduleSelectorAttrTypes()),
+		"expiration":   expiration,
+		"time_zone_id": timeZone,
+	})
+}
+
+func testSodRecipients(ids ...string) types.List {
+	elems := make([]attr.Value, 0, len(ids))
+	for _, id := range ids {
+		elems = append(elems, types.ObjectValueMust(sodRecipientAttrTypes(), map[string]attr.Value{
+			"type": types.StringValue("IDENTITY"),
+			"id":   types.StringValue(id),
+			"name": types.StringNull(),
+		}))
+	}
+	return types.ListValueMust(types.ObjectType{AttrTypes: sodRecipientAttrTypes()}, elems)
+}
+
+func testSodScheduleModel(schedule types.Object, recipients types.List) sodPolicyScheduleResourceModel {
+	return sodPolicyScheduleResourceModel{
+		Id:                types.StringUnknown(),
+		PolicyId:          types.StringValue("policy-1"),
+		Name:              types.StringValue("Weekly SOD scan"),
+		Description:       types.StringValue("Scans the finance policy"),
+		Schedule:          schedule,
+		Recipients:        recipients,
+		EmailEmptyResults: types.BoolValue(true),
+		Created:           types.StringUnknown(),
+		Modified:          types.StringUnknown(),
+		CreatorId:         types.StringUnknown(),
+		ModifierId:        types.StringUnknown(),
+	}
+}
+
+func emptySodScheduleModel() sodPolicyScheduleResourceModel {
+	return sodPolicyScheduleResourceModel{
+		Description: types.StringNull(),
+		Schedule:    types.ObjectNull(sodScheduleAttrTypes()),
+		Recipients:  types.ListNull(types.ObjectType{AttrTypes: sodRecipientAttrTypes()}),
+	}
+}
+
+// roundTripSodSchedule converts m to the PUT body and back, with prior as
+// the plan or state the read-back is reconciled against.
+func roundTripSodSchedule(t *testing.T, m, prior sodPolicyScheduleResourceModel) sodPolicyScheduleResourceModel {
+	t.Helper()
+	ctx := context.Background()
+	dto, diags := sodPolicyScheduleModelToDTO(ctx, m)
+	if diags.HasError() {
+		t.Fatalf("sodPolicyScheduleModelToDTO: %v", diags)
+	}
+	got, diags := sodPolicyScheduleDTOToModel(ctx, m.PolicyId.ValueString(), dto, prior)
+	if diags.HasError() {
+		t.Fatalf("sodPolicyScheduleDTOToModel: %v", diags)
+	}
+	return got
+}
+
+func assertSodScheduleRoundTrip(t *testing.T, got, want sodPolicyScheduleResourceModel) {
+	t.Helper()
+	if !got.Id.Equal(want.PolicyId) || !got.PolicyId.Equal(want.PolicyId) {
+		t.Errorf("id/policy_id = %s/%s, want %s", got.Id, got.PolicyId, want.PolicyId)
+	}
+	if !got.Name.Equal(want.Name) || !got.Description.Equal(want.Description) || !got.EmailEmptyResults.Equal(want.EmailEmptyResults) {
+		t.Errorf("name/description/email_empty_results = %s/%s/%s, want %s/%s/%s",
+			got.Name, got.Description, got.EmailEmptyResults, want.Name, want.Description, want.EmailEmptyResults)
+	}
+	if !got.Schedule.Equal(want.Schedule) {
+		t.Errorf("schedule =\n%s\nwant\n%s", got.Schedule, want.Schedule)
+	}
+	if !got.Recipients.Equal(want.Recipients) {
+		t.Errorf("recipients =\n%s\nwant\n%s", got.Recipients, want.Recipients)
+	}
+}
+
+func TestSodPolicyScheduleRoundTripOnImport(t *testing.T) {
+	schedule := testSodSchedule("WEEKLY",
+		testSodSelector("LIST", nil, "9"),
+		testSodSelector("LIST", nil, "MON", "WED"),
+		types.StringValue("2030-01-01T00:00:00Z"),
+		types.StringValue("America/Chicago"),
+	)
+	m := testSodScheduleModel(schedule, testSodRecipients("identity-1", "identity-2"))
+
+	// Import reads with an empty prior, so nothing from the configuration
+	// can paper over a lossy conversion.
+	assertSodScheduleRoundTrip(t, roundTripSodSchedule(t, m, emptySodScheduleModel()), m)
+	assertSodScheduleRoundTrip(t, roundTripSodSchedule(t, m, m), m)
+}
+
+func TestSodPolicyScheduleRoundTripSelectors(t *testing.T) {
+	interval := int64(2)
+	tests := []struct {
+		name     string
+		schedule types.Object
+	}{
+		{
+			name: "list",
+			schedule: testSodSchedule("DAILY",
+				testSodSelector("LIST", nil, "0", "12"),
+				types.ObjectNull(sodScheduleSelectorAttrTypes()),
+				types.StringNull(), types.StringNull()),
+		},
+		{
+			name: "range",
+			schedule: testSodSchedule("DAILY",
+				testSodSelector("RANGE", nil, "9", "17"),
+				types.ObjectNull(sodScheduleSelectorAttrTypes()),
+				types.StringNull(), types.StringValue("UTC")),
+		},
+		{
+			name: "range with interval",
+			schedule: testSodSchedule("HOURLY",
+				testSodSelector("RANGE", &interval, "8", "18"),
+				types.ObjectNull(sodScheduleSelectorAttrTypes()),
+				types.StringNull(), types.StringNull()),
+		},
+		{
+			name: "weekly list and range",
+			schedule: testSodSchedule("WEEKLY",
+				testSodSelector("LIST", nil, "6"),
+				testSodSelector("RANGE", nil, "MON", "FRI"),
+				types.StringNull(), types.StringNull()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testSodScheduleModel(tt.schedule, types.ListNull(types.ObjectType{AttrTypes: sodRecipientAttrTypes()}))
+			assertSodScheduleRoundTrip(t, roundTripSodSchedule(t, m, emptySodScheduleModel()), m)
+		})
+	}
+}
+
+func TestSodPolicyScheduleRecipients(t *testing.T) {
+	schedule := testSodSchedule("DAILY", testSodSelector("LIST", nil, "6"), types.ObjectNull(sodScheduleSelectorAttrTypes()), types.StringNull(), types.StringNull())
+
+	// The API fills in recipient names; they are read back as returned.
+	var dto sod_policies.SodPolicySchedule
+	if err := json.Unmarshal([]byte(`{"name":"s","schedule":{"type":"DAILY","hours":{"type":"LIST","values":["6"]}},
+		"recipients":[{"type":"IDENTITY","id":"identity-1","name":"Jane Doe"}]}`), &dto); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	got, diags := sodPolicyScheduleDTOToModel(context.Background(), "policy-1", &dto, emptySodScheduleModel())
+	if diags.HasError() {
+		t.Fatalf("sodPolicyScheduleDTOToModel: %v", diags)
+	}
+	var recipients []sodRecipientModel
+	if diags := got.Recipients.ElementsAs(context.Background(), &recipients, false); diags.HasError() {
+		t.Fatalf("recipients: %v", diags)
+	}
+	if len(recipients) != 1 || recipients[0].Id.ValueString() != "identity-1" || recipients[0].Name.ValueString() != "Jane Doe" {
+		t.Errorf("recipients = %v, want identity-1 named Jane Doe", got.Recipients)
+	}
+
+	// Unset recipients and an empty description stay null when the
+	// configuration left them unset...
+	m := testSodScheduleModel(schedule, types.ListNull(types.ObjectType{AttrTypes: sodRecipientAttrTypes()}))
+	m.Description = types.StringNull()
+	got = roundTripSodSchedule(t, m, emptySodScheduleModel())
+	if !got.Recipients.IsNull() || !got.Description.IsNull() {
+		t.Errorf("recipients/description = %s/%s, want null/null", got.Recipients, got.Description)
+	}
+
+	// ...but an explicitly empty list is kept.
+	m.Recipients = testSodRecipients()
+	prior := emptySodScheduleModel()
+	prior.Recipients = m.Recipients
+	got = roundTripSodSchedule(t, m, prior)
+	if got.Recipients.IsNull() || len(got.Recipients.Elements()) != 0 {
+		t.Errorf("recipients = %s, want an empty list", got.Recipients)
+	}
+}
+
+func TestSodScheduleWireToObjectExpiration(t *testing.T) {
+	ctx := context.Background()
+	apiExpiration := "2030-01-01T00:00:00.000Z"
+	wire := &sodScheduleWire{
+		Type:       "DAILY",
+		Hours:      &sodScheduleSelectorWire{Type: "LIST", Values: []string{"6"}},
+		Expiration: &apiExpiration,
+	}
+	configured := types.StringValue("2029-12-31T19:00:00-05:00")
+	prior := testSodSchedule("DAILY", testSodSelector("LIST", nil, "6"), types.ObjectNull(sodScheduleSelectorAttrTypes()), configured, types.StringNull())
+
+	got, diags := sodScheduleWireToObject(ctx, wire, prior)
+	if diags.HasError() {
+		t.Fatalf("sodScheduleWireToObject: %v", diags)
+	}
+	if !got.Equal(prior) {
+		t.Errorf("schedule =\n%s\nwant the configured expiration kept:\n%s", got, prior)
+	}
+
+	got, diags = sodScheduleWireToObject(ctx, wire, types.ObjectNull(sodScheduleAttrTypes()))
+	if diags.HasError() {
+		t.Fatalf("sodScheduleWireToObject: %v", diags)
+	}
+	if v := got.Attributes()["expiration"]; !v.Equal(types.StringValue(apiExpiration)) {
+		t.Errorf("expiration without prior = %s, want %q", v, apiExpiration)
+	}
+
+	if got, _ := sodScheduleWireToObject(ctx, nil, prior); !got.IsNull() {
+		t.Errorf("sodScheduleWireToObject(nil) = %s, want null", got)
+	}
+}
+
+func TestSodScheduleExpirationValue(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		name  string
+		prior types.String
+		api   *string
+		want  types.String
+	}{
+		{name: "no expiration", prior: types.StringValue("2030-01-01T00:00:00Z"), api: nil, want: types.StringNull()},
+		{name: "no prior", prior: types.StringNull(), api: str("2030-01-01T00:00:00.000Z"), want: types.StringValue("2030-01-01T00:00:00.000Z")},
+		{name: "unknown prior", prior: types.StringUnknown(), api: str("2030-01-01T00:00:00Z"), want: types.StringValue("2030-01-01T00:00:00Z")},
+		{name: "same instant, other precision", prior: types.StringValue("2030-01-01T00:00:00Z"), api: str("2030-01-01T00:00:00.000Z"), want: types.StringValue("2030-01-01T00:00:00Z")},
+		{name: "same instant, other time zone", prior: types.StringValue("2030-01-01T09:00:00+09:00"), api: str("2030-01-01T00:00:00Z"), want: types.StringValue("2030-01-01T09:00:00+09:00")},
+		{name: "different instant", prior: types.StringValue("2030-01-01T09:00:00+09:00"), api: str("2030-01-01T09:00:00Z"), want: types.StringValue("2030-01-01T09:00:00Z")},
+		{name: "unparsable prior", prior: types.StringValue("next year"), api: str("2030-01-01T00:00:00Z"), want: types.StringValue("2030-01-01T00:00:00Z")},
+		{name: "unparsable api", prior: types.StringValue("2030-01-01T00:00:00Z"), api: str("soon"), want: types.StringValue("soon")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sodScheduleExpirationValue(tt.prior, tt.api); !got.Equal(tt.want) {
+				t.Errorf("sodScheduleExpirationValue = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,356 @@
+// This file implements identitynow_sod_violation_report_run_v1, a
+// trigger-style resource that generates an SOD violation report on demand:
+//   - with policy_id, POST /sod-policies/v1/{id}/violation-report/run
+//     (SODPoliciesAPI.StartSodPolicyV1) for that one policy;
+//   - otherwise POST /sod-violation-report/v1/run
+//     (StartSodAllPoliciesForOrgV1) for every policy in the tenant, or only
+//     policy_ids when set.
+//
+// Create then polls GET /sod-policies/v1/sod-violation-report-status/
+// {reportResultId} (GetSodViolationReportRunStatusV1) until the run leaves
+// PENDING, and records the report result ID for
+// identitynow_sod_violation_report_v1 to download. Like
+// source_load_entitlement_wait_v1 there is no persistent upstream object:
+// Read is a no-op, Delete only drops state, and changing policy_id,
+// policy_ids or triggers replaces the resource, which runs a new report.
+package sod_policy_v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/sod_policies"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+// defaultReportRunTimeoutString bounds a report run. A tenant-wide run
+// evaluates every policy, and each policy stops at 5000 violations.
+const defaultReportRunTimeoutString = "30m"
+
+var (
+	_ resource.Resource              = (*sodViolationReportRunResource)(nil)
+	_ resource.ResourceWithConfigure = (*sodViolationReportRunResource)(nil)
+)
+
+func NewSodViolationReportRunResource() resource.Resource {
+	return &sodViolationReportRunResource{}
+}
+
+type sodViolationReportRunResource struct {
+	client *sailpoint.APIClient
+}
+
+type sodViolationReportRunResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	PolicyId       types.String `tfsdk:"policy_id"`
+	PolicyIds      types.List   `tfsdk:"policy_ids"`
+	Triggers       types.Map    `tfsdk:"triggers"`
+	CreateTimeout  types.String `tfsdk:"create_timeout"`
+	ReportResultId types.String `tfsdk:"report_result_id"`
+	Name           types.String `tfsdk:"name"`
+	Status         types.String `tfsdk:"status"`
+}
+
+func (r *sodViolationReportRunResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sod_violation_report_run_v1"
+}
+
+func (r *sodViolationReportRunResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceschema.Schema{
+		Description: "Runs a Separation of Duties (SOD) violation report, for one policy or the whole tenant, and waits for it to complete.",
+		MarkdownDescription: "Runs a [Separation of Duties (SOD)](https://documentation.sailpoint.com/saas/help/sod/manage-policies.html) " +
+			"violation report, for one policy or the whole tenant, and waits for it to complete. The resulting " +
+			"`report_result_id` can be passed to the `identitynow_sod_violation_report_v1` data source to read the " +
+			"violations. This is an action resource with `null_resource`-style replacement behavior: changing " +
+			"`policy_id`, `policy_ids` or `triggers` runs a new report, and destroying it only removes it from state.",
+		Attributes: map[string]resourceschema.Attribute{
+			"id": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "Same as report_result_id.",
+				MarkdownDescription: "Same as `report_result_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"policy_id": resourceschema.StringAttribute{
+				Optional:            true,
+				Description:         "ID of the SOD policy to report on. When not set, the report covers every policy in the tenant, or policy_ids.",
+				MarkdownDescription: "ID of the SOD policy to report on. When not set, the report covers every policy in the tenant, or `policy_ids`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("policy_ids")),
+				},
+			},
+			"policy_ids": resourceschema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Description:         "IDs of the SOD policies a tenant-wide (multi-policy) report is limited to.",
+				MarkdownDescription: "IDs of the SOD policies a tenant-wide (multi-policy) report is limited to.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"triggers": resourceschema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Description:         "Arbitrary key/value pairs that force replacement when changed, running a new report.",
+				MarkdownDescription: "Arbitrary key/value pairs that force replacement when changed, running a new report.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"create_timeout": resourceschema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultReportRunTimeoutString),
+				Description:         "How long Create waits for the report run to complete, as a Go duration.",
+				MarkdownDescription: "How long Create waits for the report run to complete, as a Go duration (e.g. `\"10m\"`).",
+			},
+			"report_result_id": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "ID of the report result, used to download the report.",
+				MarkdownDescription: "ID of the report result, used to download the report with `identitynow_sod_violation_report_v1`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "Name of the report result.",
+				MarkdownDescription: "Name of the report result.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "Final status of the report run: SUCCESS or WARNING.",
+				MarkdownDescription: "Final status of the report run: `SUCCESS` or `WARNING`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *sodViolationReportRunResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = cp.GetClient()
+}
+
+func (r *sodViolationReportRunResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan sodViolationReportRunResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, err := util.ParseWaitTimeout("create_timeout", plan.CreateTimeout, defaultReportRunTimeoutString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid create_timeout", err.Error())
+		return
+	}
+	createCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var result *sod_policies.SodReportResultDto
+	if !plan.PolicyId.IsNull() {
+		policyID := plan.PolicyId.ValueString()
+		tflog.Debug(ctx, "Running SOD Policy violation report", map[string]interface{}{"policy_id": policyID})
+
+		dto, httpResp, err := r.client.SODPoliciesAPI.StartSodPolicyV1(createCtx, policyID).Execute()
+		if err != nil {
+			tflog.Error(ctx, "Error running SOD Policy violation report", map[string]interface{}{"policy_id": policyID, "error": err.Error()})
+			resp.Diagnostics.AddError("Error running SOD violation report", errDetail(err, httpResp))
+			return
+		}
+		result = dto
+	} else {
+		var policyIDs []string
+		resp.Diagnostics.Append(plan.PolicyIds.ElementsAs(ctx, &policyIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tflog.Debug(ctx, "Running SOD violation report for all policies", map[string]interface{}{"policy_ids": policyIDs})
+
+		apiReq := r.client.SODPoliciesAPI.StartSodAllPoliciesForOrgV1(createCtx)
+		if len(policyIDs) > 0 {
+			multi := sod_policies.NewMultiPolicyRequest()
+			multi.SetFilteredPolicyList(policyIDs)
+			apiReq = apiReq.MultiPolicyRequest(*multi)
+		}
+		dto, httpResp, err := apiReq.Execute()
+		if err != nil {
+			tflog.Error(ctx, "Error running SOD violation report for all policies", map[string]interface{}{"error": err.Error()})
+			resp.Diagnostics.AddError("Error running SOD violation report", errDetail(err, httpResp))
+			return
+		}
+		result = dto
+	}
+
+	if result == nil || result.GetId() == "" {
+		resp.Diagnostics.AddError(
+			"Error running SOD violation report",
+			"The report run did not return a report result ID to poll.",
+		)
+		return
+	}
+
+	reportResultID := result.GetId()
+	tflog.Info(ctx, "Started SOD violation report", map[string]interface{}{"report_result_id": reportResultID})
+
+	final, err := r.waitForReportResult(createCtx, result)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for SOD violation report",
+			fmt.Sprintf("Report result %q did not complete successfully: %s", reportResultID, err.Error()),
+		)
+		return
+	}
+
+	status := normalizedReportStatus(final.GetStatus())
+	if status == "WARNING" {
+		resp.Diagnostics.AddWarning(
+			"SOD violation report completed with warnings",
+			fmt.Sprintf("Report result %q finished with status WARNING. The report may be incomplete, for example "+
+				"when a policy exceeded the 5000-violation limit.", reportResultID),
+		)
+	}
+
+	state := plan
+	state.Id = types.StringValue(reportResultID)
+	state.ReportResultId = types.StringValue(reportResultID)
+	state.Name = types.StringValue(final.GetName())
+	state.Status = types.StringValue(status)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *sodViolationReportRunResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// No-op by design: the resource records a completed report run, not an
+	// object to keep in sync. Report results can expire server-side; the
+	// data source reports that when downloading.
+	var state sodViolationReportRunResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *sodViolationReportRunResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan sodViolationReportRunResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state sodViolationReportRunResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := util.ParseWaitTimeout("create_timeout", plan.CreateTimeout, defaultReportRunTimeoutString); err != nil {
+		resp.Diagnostics.AddError("Invalid create_timeout", err.Error())
+		return
+	}
+
+	// Everything else forces replacement, so only create_timeout can change
+	// here, and it must not run a new report on its own.
+	state.CreateTimeout = plan.CreateTimeout
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *sodViolationReportRunResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// No API call: a report result cannot be deleted.
+	resp.State.RemoveResource(ctx)
+}
+
+// waitForReportResult polls the run status until it leaves PENDING. The
+// run's own response is checked first, since a small report can already be
+// finished when it is returned.
+func (r *sodViolationReportRunResource) waitForReportResult(ctx context.Context, result *sod_policies.SodReportResultDto) (*sod_policies.SodReportResultDto, error) {
+	reportResultID := result.GetId()
+	for attempt := 0; ; attempt++ {
+		status := normalizedReportStatus(result.GetStatus())
+		switch {
+		case status == "PENDING" || status == "":
+		case isSuccessfulReportStatus(status):
+			tflog.Info(ctx, "SOD violation report completed", map[string]interface{}{
+				"report_result_id": reportResultID,
+				"status":           status,
+			})
+			return result, nil
+		default:
+			return nil, fmt.Errorf("completed with status %q", status)
+		}
+
+		interval := util.PollInterval(attempt)
+		tflog.Debug(ctx, "Waiting for SOD violation report", map[string]interface{}{
+			"report_result_id": reportResultID,
+			"poll_interval":    interval.String(),
+		})
+		if err := util.SleepWithContext(ctx, interval); err != nil {
+			return nil, fmt.Errorf("timed out while waiting for the report to complete: %w", err)
+		}
+
+		next, httpResp, err := r.client.SODPoliciesAPI.GetSodViolationReportRunStatusV1(ctx, reportResultID).Execute()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("timed out while polling report status: %w", ctx.Err())
+			}
+			return nil, fmt.Errorf("retrieving report status: %s", errDetail(err, httpResp))
+		}
+		if next == nil {
+			return nil, fmt.Errorf("report status response for %q was empty", reportResultID)
+		}
+		result = next
+	}
+}
+
+func normalizedReportStatus(status string) string {
+	return strings.ToUpper(strings.TrimSpace(status))
+}
+
+// isSuccessfulReportStatus reports whether a finished run produced a report.
+// ERROR, TERMINATED and TEMP_ERROR runs have nothing to download.
+func isSuccessfulReportStatus(status string) bool {
+	return status == "SUCCESS" || status == "WARNING"
+}
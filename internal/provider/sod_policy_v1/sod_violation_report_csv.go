@@ -0,0 +1,194 @@
+// This file parses the PolicyReport.zip returned by
+// GET /sod-violation-report/v1/{reportResultId}/download for
+// identitynow_sod_violation_report_v1.
+//
+// The spec only says the archive "contains the violation report file" and
+// doesn't describe its columns, so rows are mapped by normalized header
+// (lower case, letters and digits only, so "Policy Name" and "policyName"
+// both match) against a short alias list per field, falling
+// back to the first "left..."/"right..." column for the two sides of the
+// conflict. Every cell is also kept verbatim in the row's columns map, so a
+// column the aliases miss is still usable.
+package sod_policy_v1
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"unicode"
+)
+
+// sodViolationRow is one violation: an identity holding access on both
+// sides of a policy.
+type sodViolationRow struct {
+	FileName     string
+	PolicyName   string
+	IdentityId   string
+	IdentityName string
+	LeftAccess   string
+	RightAccess  string
+	Columns      map[string]string
+}
+
+// sodViolationColumnAliases lists, per mapped field, the normalized headers
+// it is read from, in order of preference.
+var sodViolationColumnAliases = map[string][]string{
+	"policy_name":   {"policyname", "policy", "sodpolicyname", "sodpolicy"},
+	"identity_id":   {"identityid", "identityuuid"},
+	"identity_name": {"identityname", "identity", "identitydisplayname", "displayname", "username"},
+	"left_access":   {"leftaccess", "leftaccessname", "leftaccessitems", "leftcriteria", "leftentitlement", "leftentitlements"},
+	"right_access":  {"rightaccess", "rightaccessname", "rightaccessitems", "rightcriteria", "rightentitlement", "rightentitlements"},
+}
+
+// parseSodViolationReportZip returns the rows of every CSV file in a report
+// archive, in archive order.
+func parseSodViolationReportZip(data []byte) ([]sodViolationRow, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("reading report archive: %w", err)
+	}
+
+	rows := []sodViolationRow{}
+	found := false
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !strings.EqualFold(path.Ext(f.Name), ".csv") {
+			continue
+		}
+		found = true
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("opening %s: %w", f.Name, err)
+		}
+		fileRows, err := parseSodViolationReportCSV(f.Name, rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, fileRows...)
+	}
+	if !found {
+		return nil, errors.New("the report archive contains no CSV file")
+	}
+	return rows, nil
+}
+
+// parseSodViolationReportCSV maps the records of one report CSV to rows.
+// Blank records are skipped; an empty file has no rows.
+func parseSodViolationReportCSV(fileName string, r io.Reader) ([]sodViolationRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", fileName, err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	index := sodViolationColumnIndex(header)
+
+	var rows []sodViolationRow
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", fileName, err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		cell := func(field string) string {
+			i, ok := index[field]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		row := sodViolationRow{
+			FileName:     fileName,
+			PolicyName:   cell("policy_name"),
+			IdentityId:   cell("identity_id"),
+			IdentityName: cell("identity_name"),
+			LeftAccess:   cell("left_access"),
+			RightAccess:  cell("right_access"),
+			Columns:      map[string]string{},
+		}
+		for i, name := range header {
+			if name == "" || i >= len(record) {
+				continue
+			}
+			row.Columns[name] = strings.TrimSpace(record[i])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// sodViolationColumnIndex resolves each mapped field to a header position
+// (see the file comment). Fields with no matching column are left out.
+func sodViolationColumnIndex(header []string) map[string]int {
+	normalized := make([]string, len(header))
+	for i, h := range header {
+		normalized[i] = normalizeColumnName(h)
+	}
+
+	index := map[string]int{}
+	for field, aliases := range sodViolationColumnAliases {
+	aliasLoop:
+		for _, alias := range aliases {
+			for i, n := range normalized {
+				if n == alias {
+					index[field] = i
+					break aliasLoop
+				}
+			}
+		}
+	}
+	for field, prefix := range map[string]string{"left_access": "left", "right_access": "right"} {
+		if _, ok := index[field]; ok {
+			continue
+		}
+		for i, n := range normalized {
+			if strings.HasPrefix(n, prefix) {
+				index[field] = i
+				break
+			}
+		}
+	}
+	return index
+}
+
+func normalizeColumnName(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
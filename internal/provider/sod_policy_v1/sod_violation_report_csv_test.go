@@ -0,0 +1,101 @@
+package sod_policy_v1
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func reportZip(t *testing.T, files map[string]string, order ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range order {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("zip create %s: %v", name, err)
+		}
+		if _, err := w.Write([]byte(files[name])); err != nil {
+			t.Fatalf("zip write %s: %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseSodViolationReportZip(t *testing.T) {
+	files := map[string]string{
+		"PolicyReport/Policy 1.csv": "\ufeffPolicy Name,Identity ID,Identity Name,Left Access Items,Right Access Items\r\n" +
+			"Policy 1,2c918084,john.doe,\"AP Clerk, Finance\",AP Approver\r\n" +
+			",,,,\r\n",
+		"PolicyReport/readme.txt": "not a report",
+		"PolicyReport/Policy 2.csv": "policyName,identity,leftEntitlements,rightEntitlements,extra\n" +
+			"Policy 2,jane.doe,Admin,Auditor\n",
+	}
+	data := reportZip(t, files, "PolicyReport/Policy 1.csv", "PolicyReport/readme.txt", "PolicyReport/Policy 2.csv")
+
+	got, err := parseSodViolationReportZip(data)
+	if err != nil {
+		t.Fatalf("parseSodViolationReportZip: %v", err)
+	}
+	want := []sodViolationRow{
+		{
+			FileName:     "PolicyReport/Policy 1.csv",
+			PolicyName:   "Policy 1",
+			IdentityId:   "2c918084",
+			IdentityName: "john.doe",
+			LeftAccess:   "AP Clerk, Finance",
+			RightAccess:  "AP Approver",
+			Columns: map[string]string{
+				"Policy Name":        "Policy 1",
+				"Identity ID":        "2c918084",
+				"Identity Name":      "john.doe",
+				"Left Access Items":  "AP Clerk, Finance",
+				"Right Access Items": "AP Approver",
+			},
+		},
+		{
+			FileName:     "PolicyReport/Policy 2.csv",
+			PolicyName:   "Policy 2",
+			IdentityName: "jane.doe",
+			LeftAccess:   "Admin",
+			RightAccess:  "Auditor",
+			Columns: map[string]string{
+				"policyName":        "Policy 2",
+				"identity":          "jane.doe",
+				"leftEntitlements":  "Admin",
+				"rightEntitlements": "Auditor",
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSodViolationReportZip =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestParseSodViolationReportZipEmpty(t *testing.T) {
+	data := reportZip(t, map[string]string{"PolicyReport.csv": "Policy Name,Identity Name\n"}, "PolicyReport.csv")
+	got, err := parseSodViolationReportZip(data)
+	if err != nil {
+		t.Fatalf("parseSodViolationReportZip: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("parseSodViolationReportZip = %#v, want no rows", got)
+	}
+}
+
+func TestParseSodViolationReportZipErrors(t *testing.T) {
+	if _, err := parseSodViolationReportZip([]byte("not a zip")); err == nil {
+		t.Error("parseSodViolationReportZip(not a zip): want error")
+	}
+
+	data := reportZip(t, map[string]string{"readme.txt": "x"}, "readme.txt")
+	_, err := parseSodViolationReportZip(data)
+	if err == nil || !strings.Contains(err.Error(), "no CSV file") {
+		t.Errorf("parseSodViolationReportZip(no csv) error = %v, want no CSV file", err)
+	}
+}
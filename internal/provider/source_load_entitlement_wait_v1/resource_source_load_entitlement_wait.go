@@ -44,8 +44,6 @@ import (
 
 const (
 	defaultCreateTimeoutString         = "30m"
-	initialPollInterval                = 2 * time.Second
-	maxPollInterval                    = 15 * time.Second
 	importStatePartCount               = 3
 	waitForActiveJobsImportIDComponent = 2
 	sourceIDImportIDComponent          = 0
@@ -158,7 +156,7 @@ func (r *SourceLoadEntitlementWaitResource) Create(ctx context.Context, req reso
 		return
 	}
 
-	createTimeout, err := parseCreateTimeout(plan.CreateTimeout)
+	createTimeout, err := util.ParseWaitTimeout("create_timeout", plan.CreateTimeout, defaultCreateTimeoutString)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid create_timeout", err.Error())
 		return
@@ -220,7 +218,7 @@ func (r *SourceLoadEntitlementWaitResource) Create(ctx context.Context, req reso
 	taskID := task.GetId()
 	tflog.Info(createCtx, "Triggered entitlement aggregation", map[string]interface{}{"source_id": sourceID, "task_id": taskID})
 
-	if err := r.waitForTaskCompletion(createCtx, sourceID, taskID); err != nil {
+	if err := r.waitForTaskCompletion(createCtx, sourceID, taskID, createTimeout); err != nil {
 		resp.Diagnostics.AddError(
 			"Error waiting for entitlement aggregation task",
 			fmt.Sprintf("Task %q for source %q did not complete successfully: %s", taskID, sourceID, err.Error()),
@@ -259,7 +257,7 @@ func (r *SourceLoadEntitlementWaitResource) Update(ctx context.Context, req reso
 		return
 	}
 
-	if _, err := parseCreateTimeout(plan.CreateTimeout); err != nil {
+	if _, err := util.ParseWaitTimeout("create_timeout", plan.CreateTimeout, defaultCreateTimeoutString); err != nil {
 		resp.Diagnostics.AddError("Invalid create_timeout", err.Error())
 		return
 	}
@@ -313,7 +311,7 @@ func (r *SourceLoadEntitlementWaitResource) waitForNoActiveEntitlementImports(ct
 			return nil
 		}
 
-		pollInterval := pollInterval(attempt)
+		pollInterval := util.PollInterval(attempt)
 		tflog.Info(ctx, "Waiting for active entitlement aggregation tasks to finish", map[string]interface{}{
 			"source_id":      sourceID,
 			"active_tasks":   len(tasks),
@@ -321,53 +319,28 @@ func (r *SourceLoadEntitlementWaitResource) waitForNoActiveEntitlementImports(ct
 			"filter_applied": entitlementImportTaskStatusListFilter(sourceID),
 		})
 
-		if err := sleepWithContext(ctx, pollInterval); err != nil {
+		if err := util.SleepWithContext(ctx, pollInterval); err != nil {
 			return fmt.Errorf("timed out while waiting for active entitlement aggregation tasks to finish: %w", err)
 		}
 	}
 }
 
-func (r *SourceLoadEntitlementWaitResource) waitForTaskCompletion(ctx context.Context, sourceID, taskID string) error {
-	for attempt := 0; ; attempt++ {
-		status, httpResp, err := r.client.TaskManagementAPI.GetTaskStatusV1(ctx, taskID).Execute()
-		if err != nil {
-			if ctx.Err() != nil {
-				return fmt.Errorf("timed out while polling task status: %w", ctx.Err())
-			}
-			return fmt.Errorf("retrieving task status: %s", util.SailpointErrorDetail(err, httpResp))
-		}
-		if status == nil {
-			return fmt.Errorf("task status response for task %q was empty", taskID)
-		}
-
-		// Live testing showed `completed` and `completionStatus` are not
-		// always written atomically: a poll can observe `completed` already
-		// set while `completionStatus` is still empty/null for a brief
-		// window. Treat that combination as "not yet finished" and keep
-		// polling, rather than misreporting it as a failed/unknown
-		// completion status.
-		if finished, completionStatus := taskCompletionResult(status); finished {
-			if !isSuccessfulCompletionStatus(completionStatus) {
-				return fmt.Errorf("completed with status %q", completionStatus)
-			}
-			tflog.Info(ctx, "Entitlement aggregation task completed", map[string]interface{}{
-				"source_id":         sourceID,
-				"task_id":           taskID,
-				"completion_status": completionStatus,
-			})
-			return nil
-		}
-
-		pollInterval := pollInterval(attempt)
-		tflog.Debug(ctx, "Waiting for entitlement aggregation task completion", map[string]interface{}{
-			"source_id":     sourceID,
-			"task_id":       taskID,
-			"poll_interval": pollInterval.String(),
-		})
-		if err := sleepWithContext(ctx, pollInterval); err != nil {
-			return fmt.Errorf("timed out while waiting for task completion: %w", err)
-		}
+// waitForTaskCompletion waits up to timeout for the aggregation task with
+// util.WaitForTask.
+func (r *SourceLoadEntitlementWaitResource) waitForTaskCompletion(ctx context.Context, sourceID, taskID string, timeout time.Duration) error {
+	completionStatus, err := util.WaitForTask(ctx, r.client, taskID, timeout)
+	if err != nil {
+		return err
+	}
+	if !util.IsSuccessfulCompletionStatus(completionStatus) {
+		return fmt.Errorf("completed with status %q", completionStatus)
 	}
+	tflog.Info(ctx, "Entitlement aggregation task completed", map[string]interface{}{
+		"source_id":         sourceID,
+		"task_id":           taskID,
+		"completion_status": completionStatus,
+	})
+	return nil
 }
 
 type parsedImportState struct {
@@ -433,21 +406,6 @@ func parseImportTriggers(raw string) (types.Map, error) {
 	return mapValue, nil
 }
 
-func parseCreateTimeout(v types.String) (time.Duration, error) {
-	if v.IsNull() || v.IsUnknown() {
-		return time.ParseDuration(defaultCreateTimeoutString)
-	}
-
-	d, err := time.ParseDuration(v.ValueString())
-	if err != nil {
-		return 0, fmt.Errorf("create_timeout must be a valid Go duration such as %q: %w", defaultCreateTimeoutString, err)
-	}
-	if d <= 0 {
-		return 0, fmt.Errorf("create_timeout must be greater than zero")
-	}
-	return d, nil
-}
-
 // entitlementImportTaskStatusListFilter intentionally does not filter on
 // `type`. The task-status-v1 spec documents a `type` filter enum that
 // includes CLOUD_ENTITLEMENT_IMPORT, but live testing against a real tenant
@@ -475,58 +433,3 @@ func emptyImportEntitlementsFile() (*os.File, error) {
 func entitlementImportTaskStatusListFilter(sourceID string) string {
 	return fmt.Sprintf("sourceId eq %q and completionStatus isnull", sourceID)
 }
-
-func normalizedCompletionStatus(status task_management.NullableString) string {
-	if !status.IsSet() || status.Get() == nil {
-		return ""
-	}
-	return strings.ToUpper(strings.TrimSpace(*status.Get()))
-}
-
-func isSuccessfulCompletionStatus(status string) bool {
-	return status == "SUCCESS" || status == "WARNING"
-}
-
-// taskCompletionResult decides whether a polled task status should be
-// treated as finished. `completed` and `completionStatus` are not always
-// written atomically by the API: a poll can observe `completed` already set
-// while `completionStatus` is still empty/null for a brief window. That
-// combination is treated as "not yet finished" (finished=false) so the
-// caller keeps polling instead of misreporting a false completion status.
-func taskCompletionResult(status *task_management.TaskStatus) (finished bool, completionStatus string) {
-	if status == nil {
-		return false, ""
-	}
-	completionStatus = normalizedCompletionStatus(status.CompletionStatus)
-	if status.Completed.IsSet() && status.Completed.Get() != nil && completionStatus != "" {
-		return true, completionStatus
-	}
-	return false, ""
-}
-
-func pollInterval(attempt int) time.Duration {
-	if attempt <= 0 {
-		return initialPollInterval
-	}
-
-	interval := initialPollInterval
-	for i := 0; i < attempt && interval < maxPollInterval; i++ {
-		interval *= 2
-		if interval >= maxPollInterval {
-			return maxPollInterval
-		}
-	}
-	return interval
-}
-
-func sleepWithContext(ctx context.Context, d time.Duration) error {
-	timer := time.NewTimer(d)
-	defer timer.Stop()
-
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-timer.C:
-		return nil
-	}
-}
@@ -3,10 +3,8 @@ package source_load_entitlement_wait_v1
 import (
 	"os"
 	"testing"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/sailpoint-oss/golang-sdk/v3/task_management"
 )
 
 func TestParseImportStateID(t *testing.T) {
@@ -91,136 +89,6 @@ func TestEntitlementImportTaskStatusListFilter(t *testing.T) {
 	}
 }
 
-func TestNormalizedCompletionStatus(t *testing.T) {
-	tests := []struct {
-		name string
-		in   task_management.NullableString
-		want string
-	}{
-		{name: "unset", in: task_management.NullableString{}, want: ""},
-		{name: "success", in: *task_management.NewNullableString(strPtr("success")), want: "SUCCESS"},
-		{name: "warning with spaces", in: *task_management.NewNullableString(strPtr(" warning ")), want: "WARNING"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := normalizedCompletionStatus(tt.in); got != tt.want {
-				t.Fatalf("normalizedCompletionStatus() = %q, want %q", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestIsSuccessfulCompletionStatus(t *testing.T) {
-	tests := []struct {
-		status string
-		want   bool
-	}{
-		{status: "SUCCESS", want: true},
-		{status: "WARNING", want: true},
-		{status: "ERROR", want: false},
-		{status: "TEMPERROR", want: false},
-		{status: "TERMINATED", want: false},
-		{status: "", want: false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.status, func(t *testing.T) {
-			if got := isSuccessfulCompletionStatus(tt.status); got != tt.want {
-				t.Fatalf("isSuccessfulCompletionStatus(%q) = %v, want %v", tt.status, got, tt.want)
-			}
-		})
-	}
-}
-
-func TestTaskCompletionResult(t *testing.T) {
-	now := task_management.SailPointTime{Time: time.Now()}
-
-	tests := []struct {
-		name           string
-		status         *task_management.TaskStatus
-		wantFinished   bool
-		wantCompletion string
-	}{
-		{
-			name:         "nil status",
-			status:       nil,
-			wantFinished: false,
-		},
-		{
-			name:         "not yet completed",
-			status:       &task_management.TaskStatus{},
-			wantFinished: false,
-		},
-		{
-			name: "completed set but completionStatus still empty (observed race condition)",
-			status: &task_management.TaskStatus{
-				Completed:        *task_management.NewNullableTime(&now),
-				CompletionStatus: task_management.NullableString{},
-			},
-			wantFinished: false,
-		},
-		{
-			name: "completionStatus set but completed not yet set",
-			status: &task_management.TaskStatus{
-				CompletionStatus: *task_management.NewNullableString(strPtr("SUCCESS")),
-			},
-			wantFinished: false,
-		},
-		{
-			name: "completed and completionStatus both set",
-			status: &task_management.TaskStatus{
-				Completed:        *task_management.NewNullableTime(&now),
-				CompletionStatus: *task_management.NewNullableString(strPtr("success")),
-			},
-			wantFinished:   true,
-			wantCompletion: "SUCCESS",
-		},
-		{
-			name: "completed and completionStatus both set with failure status",
-			status: &task_management.TaskStatus{
-				Completed:        *task_management.NewNullableTime(&now),
-				CompletionStatus: *task_management.NewNullableString(strPtr("ERROR")),
-			},
-			wantFinished:   true,
-			wantCompletion: "ERROR",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			finished, completionStatus := taskCompletionResult(tt.status)
-			if finished != tt.wantFinished {
-				t.Fatalf("finished = %v, want %v", finished, tt.wantFinished)
-			}
-			if completionStatus != tt.wantCompletion {
-				t.Fatalf("completionStatus = %q, want %q", completionStatus, tt.wantCompletion)
-			}
-		})
-	}
-}
-
-func TestPollInterval(t *testing.T) {
-	tests := []struct {
-		attempt int
-		want    string
-	}{
-		{attempt: 0, want: "2s"},
-		{attempt: 1, want: "4s"},
-		{attempt: 2, want: "8s"},
-		{attempt: 3, want: "15s"},
-		{attempt: 5, want: "15s"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.want, func(t *testing.T) {
-			if got := pollInterval(tt.attempt).String(); got != tt.want {
-				t.Fatalf("pollInterval(%d) = %q, want %q", tt.attempt, got, tt.want)
-			}
-		})
-	}
-}
-
 func TestEmptyImportEntitlementsFile(t *testing.T) {
 	f, err := emptyImportEntitlementsFile()
 	if err != nil {
@@ -243,5 +111,3 @@ func TestEmptyImportEntitlementsFile(t *testing.T) {
 		t.Fatalf("file size = %d, want 0 (empty file)", info.Size())
 	}
 }
-
-func strPtr(v string) *string { return &v }
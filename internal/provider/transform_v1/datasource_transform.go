@@ -5,11 +5,14 @@ package transform_v1
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -17,11 +20,13 @@ import (
 	"github.com/sailpoint-oss/golang-sdk/v3/transforms"
 
 	"terraform-provider-identitynow/internal/provider/transform_v1/datasource_transform"
+	"terraform-provider-identitynow/internal/provider/util"
 )
 
 var (
-	_ datasource.DataSource              = (*transformDataSource)(nil)
-	_ datasource.DataSourceWithConfigure = (*transformDataSource)(nil)
+	_ datasource.DataSource                     = (*transformDataSource)(nil)
+	_ datasource.DataSourceWithConfigure        = (*transformDataSource)(nil)
+	_ datasource.DataSourceWithConfigValidators = (*transformDataSource)(nil)
 )
 
 func NewTransformDataSource() datasource.DataSource {
@@ -49,14 +54,48 @@ func (d *transformDataSource) Metadata(ctx context.Context, req datasource.Metad
 }
 
 func (d *transformDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
-	resp.Schema = datasource_transform.TransformDataSourceSchema(ctx)
-	resp.Schema.Description = "Reads a Transform from IdentityNow/ISC by id."
+	resp.Schema = transformDataSourceSchema(ctx)
+	resp.Schema.Description = "Reads a Transform from IdentityNow/ISC by id or exact name."
 	resp.Schema.MarkdownDescription = "Reads a [Transform](https://developer.sailpoint.com/docs/extensibility/transforms/) " +
-		"from IdentityNow/ISC by `id`.\n\n" +
+		"from IdentityNow/ISC by `id` or exact `name`. Exactly one of those arguments must be set. Identity profile " +
+		"mappings and `reference` transforms refer to transforms by name, so `name` is usually the more convenient key.\n\n" +
 		"~> This is a `_v1` pilot data source - see \"Known Limitations & Live Testing Notes\" below before relying on it " +
 		"in production configurations.\n\n" +
 		transformGuidanceMarkdown
-	applyTransformAttributesFieldDataSource(&resp.Schema.Attributes)
+}
+
+// transformDataSourceSchema relaxes the generated schema's Required "id" so
+// a transform can also be looked up by "name", which is Computed-only in the
+// generated schema.
+func transformDataSourceSchema(ctx context.Context) dsschema.Schema {
+	s := datasource_transform.TransformDataSourceSchema(ctx)
+
+	idAttr := s.Attributes["id"].(dsschema.StringAttribute)
+	idAttr.Required = false
+	idAttr.Optional = true
+	idAttr.Computed = true
+	idAttr.Description = "ID of the transform to retrieve. Exactly one of id or name must be set."
+	idAttr.MarkdownDescription = "ID of the transform to retrieve. Exactly one of `id` or `name` must be set."
+	s.Attributes["id"] = idAttr
+
+	nameAttr := s.Attributes["name"].(dsschema.StringAttribute)
+	nameAttr.Optional = true
+	nameAttr.Computed = true
+	nameAttr.Description = "Exact name of the transform to retrieve when id is not set. Exactly one of id or name must be set."
+	nameAttr.MarkdownDescription = "Exact name of the transform to retrieve when `id` is not set. Exactly one of `id` or `name` must be set."
+	s.Attributes["name"] = nameAttr
+
+	applyTransformAttributesFieldDataSource(&s.Attributes)
+	return s
+}
+
+func (d *transformDataSource) ConfigValidators(context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
 }
 
 // applyTransformAttributesFieldDataSource mirrors applyTransformAttributesField
@@ -100,14 +139,9 @@ func (d *transformDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	tflog.Debug(ctx, "Reading Transform data source", map[string]interface{}{"id": config.Id.ValueString()})
-
-	dto, httpResp, err := d.client.TransformsAPI.
-		GetTransformV1(ctx, config.Id.ValueString()).
-		Execute()
-	if err != nil {
-		tflog.Error(ctx, "Error reading Transform data source", map[string]interface{}{"id": config.Id.ValueString(), "error": err.Error()})
-		resp.Diagnostics.AddError("Error reading Transform", errDetail(err, httpResp))
+	dto, diags := d.lookupTransform(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -122,6 +156,69 @@ func (d *transformDataSource) Read(ctx context.Context, req datasource.ReadReque
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
+func (d *transformDataSource) lookupTransform(ctx context.Context, config transformDataSourceModel) (*transforms.TransformRead, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !config.Id.IsNull() && !config.Id.IsUnknown() && config.Id.ValueString() != "" {
+		tflog.Debug(ctx, "Reading Transform data source by id", map[string]interface{}{"id": config.Id.ValueString()})
+		dto, httpResp, err := d.client.TransformsAPI.
+			GetTransformV1(ctx, config.Id.ValueString()).
+			Execute()
+		if err != nil {
+			tflog.Error(ctx, "Error reading Transform data source", map[string]interface{}{"id": config.Id.ValueString(), "error": err.Error()})
+			diags.AddError("Error reading Transform", errDetail(err, httpResp))
+			return nil, diags
+		}
+		return dto, diags
+	}
+
+	lookupName := strings.TrimSpace(config.Name.ValueString())
+	tflog.Debug(ctx, "Reading Transform data source by name", map[string]interface{}{"name": lookupName})
+
+	// The exact comparison below guards against "name eq" matching
+	// case-insensitively, as IdentityNow filters commonly do.
+	items, httpResp, err := listTransforms(ctx, d.client, "name eq "+util.FilterQuote(lookupName))
+	if err != nil {
+		tflog.Error(ctx, "Error listing Transforms for name lookup", map[string]interface{}{"name": lookupName, "error": err.Error()})
+		diags.AddError("Error reading Transform by name", errDetail(err, httpResp))
+		return nil, diags
+	}
+	return transformWithExactName(items, lookupName)
+}
+
+// transformWithExactName returns the one transform in items named exactly
+// lookupName, or an error when there is none or more than one.
+func transformWithExactName(items []transforms.TransformRead, lookupName string) (*transforms.TransformRead, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	matches := make([]transforms.TransformRead, 0, 1)
+	for i := range items {
+		if items[i].Name == lookupName {
+			matches = append(matches, items[i])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		diags.AddError(
+			"Transform not found by name",
+			fmt.Sprintf("No transform with exact name %q was found. Set `id` instead if the transform name has changed.", lookupName),
+		)
+		return nil, diags
+	case 1:
+		return &matches[0], diags
+	default:
+		ids := make([]string, 0, len(matches))
+		for i := range matches {
+			ids = append(ids, matches[i].Id)
+		}
+		diags.AddError(
+			"Transform name is not unique",
+			fmt.Sprintf("Found %d transforms with exact name %q. Use `id` instead. Matching transform ids: %s.", len(matches), lookupName, strings.Join(ids, ", ")),
+		)
+		return nil, diags
+	}
+}
+
 // datasourceDtoToModel mirrors transformReadToModel in resource_transform.go
 // but against the data source's model type.
 func datasourceDtoToModel(dto *transforms.TransformRead, fallback transformDataSourceModel) (transformDataSourceModel, diag.Diagnostics) {
@@ -0,0 +1,195 @@
+// This file implements identitynow_transform_preview_v1, which evaluates a
+// transform definition against sample inputs entirely offline, using the
+// evaluator in transform_evaluator.go. Unlike
+// identitynow_identity_profile_preview_v1 it never calls the API (it has no
+// Configure), so it works in plans without tenant access and for
+// transforms that haven't been created yet - the intended use is a check
+// block asserting that a nested chain produces the expected value.
+//
+// "type" and "attributes" take the same values as identitynow_transform_v1,
+// so a resource's own attributes can be passed straight through.
+package transform_v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = (*transformPreviewDataSource)(nil)
+
+func NewTransformPreviewDataSource() datasource.DataSource {
+	return &transformPreviewDataSource{}
+}
+
+type transformPreviewDataSource struct{}
+
+type transformPreviewDataSourceModel struct {
+	Type               types.String         `tfsdk:"type"`
+	Attributes         jsontypes.Normalized `tfsdk:"attributes"`
+	Input              types.String         `tfsdk:"input"`
+	IdentityAttributes types.Map            `tfsdk:"identity_attributes"`
+	AccountAttributes  types.Map            `tfsdk:"account_attributes"`
+	References         types.Map            `tfsdk:"references"`
+	Now                types.String         `tfsdk:"now"`
+	Output             types.String         `tfsdk:"output"`
+}
+
+func (d *transformPreviewDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_transform_preview_v1"
+}
+
+func (d *transformPreviewDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Evaluates a transform definition against sample input attributes entirely offline, without calling the API.",
+		MarkdownDescription: "Evaluates a [Transform](https://developer.sailpoint.com/docs/extensibility/transforms/) definition " +
+			"against sample input attributes entirely offline - no API call is made and nothing is saved - so `check` blocks " +
+			"can assert what a nested transform chain produces before identities refresh.\n\n" +
+			"Supported types: `" + strings.Join(transformEvaluableTypes, "`, `") + "`. Types whose result depends on the " +
+			"tenant or on randomness (`rule`, `usernameGenerator`, `uuid`, `randomAlphaNumeric`, ...) fail with an error naming " +
+			"the nested transform, rather than returning a guess.\n\n" +
+			"~> This is a `_v1` pilot data source. The evaluator follows SailPoint's published transform documentation, not " +
+			"the tenant's implementation - see \"Known Limitations\" below.",
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Required:            true,
+				Description:         "Type of the root transform, as in identitynow_transform_v1.",
+				MarkdownDescription: "Type of the root transform, as in `identitynow_transform_v1`.",
+			},
+			"attributes": schema.StringAttribute{
+				CustomType:  jsontypes.NormalizedType{},
+				Required:    true,
+				Description: "Attributes of the root transform as a raw JSON object, in the same shape as identitynow_transform_v1's attributes.",
+				MarkdownDescription: "Attributes of the root transform as a raw JSON object, in the same shape as " +
+					"`identitynow_transform_v1`'s `attributes`. Nested transforms are evaluated recursively.",
+			},
+			"input": schema.StringAttribute{
+				Optional:    true,
+				Description: "Implicit input: the value fed to every transform in the chain that has no explicit input.",
+				MarkdownDescription: "Implicit input - the source attribute value an identity profile mapping would feed the " +
+					"transform. Used by every transform in the chain that has no explicit `input`. Omit for a null input.",
+			},
+			"identity_attributes": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Description:         "Sample identity attribute values keyed by name, read by identityAttribute transforms.",
+				MarkdownDescription: "Sample identity attribute values keyed by attribute name, read by `identityAttribute` transforms.",
+			},
+			"account_attributes": schema.MapAttribute{
+				ElementType:         types.MapType{ElemType: types.StringType},
+				Optional:            true,
+				Description:         "Sample account attribute values keyed by source name, then attribute name, read by accountAttribute transforms.",
+				MarkdownDescription: "Sample account attribute values keyed by source name, then attribute name, read by `accountAttribute` transforms.",
+			},
+			"references": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Transforms that reference transforms may point at, keyed by transform id or name. Each value is a JSON {type, attributes} object.",
+				MarkdownDescription: "Transforms that `reference` transforms may point at, keyed by the transform id or name used " +
+					"in `attributes.id`. Each value is a JSON `{\"type\": ..., \"attributes\": {...}}` object.",
+			},
+			"now": schema.StringAttribute{
+				Optional:    true,
+				Description: "RFC3339 timestamp used as \"now\" by dateMath and dateCompare. Defaults to the current time.",
+				MarkdownDescription: "RFC3339 timestamp used as `now` by `dateMath` and `dateCompare`. Defaults to the current " +
+					"time; set it to keep date-based output stable between plans.",
+			},
+			"output": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Value the transform produced, or null when it produced no value.",
+				MarkdownDescription: "Value the transform produced, or `null` when it produced no value.",
+			},
+		},
+	}
+}
+
+func (d *transformPreviewDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config transformPreviewDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Evaluating Transform preview", map[string]interface{}{"type": config.Type.ValueString()})
+
+	state, diags := transformPreviewFromConfig(ctx, config, time.Now())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// transformPreviewFromConfig builds an evaluator from config's sample data
+// and evaluates the root transform. now is used unless config sets "now".
+func transformPreviewFromConfig(ctx context.Context, config transformPreviewDataSourceModel, now time.Time) (transformPreviewDataSourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	model := config
+
+	attrs, d := attributesToMap(config.Attributes)
+	diags.Append(d...)
+	if diags.HasError() {
+		return model, diags
+	}
+
+	e := &transformEvaluator{
+		identityAttributes: map[string]string{},
+		accountAttributes:  map[string]map[string]string{},
+		references:         map[string]transformDefinition{},
+		now:                now,
+	}
+	if !config.IdentityAttributes.IsNull() {
+		diags.Append(config.IdentityAttributes.ElementsAs(ctx, &e.identityAttributes, false)...)
+	}
+	if !config.AccountAttributes.IsNull() {
+		diags.Append(config.AccountAttributes.ElementsAs(ctx, &e.accountAttributes, false)...)
+	}
+	if !config.References.IsNull() {
+		raw := map[string]string{}
+		diags.Append(config.References.ElementsAs(ctx, &raw, false)...)
+		for key, s := range raw {
+			var v interface{}
+			if err := json.Unmarshal([]byte(s), &v); err != nil {
+				diags.AddAttributeError(path.Root("references").AtMapKey(key), "Invalid transform reference",
+					fmt.Sprintf("Could not decode the reference as JSON: %s", err.Error()))
+				continue
+			}
+			def, err := parseTransformDefinition(v)
+			if err != nil {
+				diags.AddAttributeError(path.Root("references").AtMapKey(key), "Invalid transform reference", err.Error())
+				continue
+			}
+			e.references[key] = def
+		}
+	}
+	if !config.Now.IsNull() {
+		t, err := time.Parse(time.RFC3339, config.Now.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("now"), "Invalid \"now\"",
+				fmt.Sprintf("Expected an RFC3339 timestamp: %s", err.Error()))
+		}
+		e.now = t
+	}
+	if diags.HasError() {
+		return model, diags
+	}
+
+	output, err := e.evaluate(transformDefinition{Type: config.Type.ValueString(), Attributes: attrs}, config.Input.ValueStringPointer())
+	if err != nil {
+		diags.AddAttributeError(path.Root("attributes"), "Transform preview failed", err.Error())
+		return model, diags
+	}
+	model.Output = types.StringPointerValue(output)
+	return model, diags
+}
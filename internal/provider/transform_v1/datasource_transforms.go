@@ -0,0 +1,270 @@
+// This file adds a plural "list" data source alongside the singular
+// identitynow_transform_v1 data source in datasource_transform.go, following
+// the same pattern as sources_v1's datasource_sources.go. It queries
+// GET /transforms/v1 (transforms.TransformsAPI.ListTransformsV1) and returns
+// every matching Transform with the same attributes as the singular data
+// source.
+//
+// Unlike the other plural data sources there is no limit/offset: transforms
+// are few enough per tenant that every page is always read, so a filter
+// can't silently miss a match past the first page. The typed "filter"
+// block compiles to GET /transforms/v1's filters, which only support name
+// and internal, so its "type" is matched here after listing.
+package transform_v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/transforms"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+// transformsListMaxLimit matches GET /transforms/v1's documented maximum
+// "limit" value, used as the page size.
+const transformsListMaxLimit = 250
+
+// transformFilterFields are the "filter" block criteria GET /transforms/v1
+// can apply; "type" is added to the block separately and matched after
+// listing.
+var transformFilterFields = []util.FilterField{
+	{Attribute: "name", Property: "name", Operator: "eq", Kind: util.FilterKindString, Description: "Only the transform with exactly this name."},
+	{Attribute: "name_starts_with", Property: "name", Operator: "sw", Kind: util.FilterKindString, Description: "Only transforms whose name starts with this prefix."},
+}
+
+var (
+	_ datasource.DataSource              = (*transformsDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*transformsDataSource)(nil)
+)
+
+func NewTransformsDataSource() datasource.DataSource {
+	return &transformsDataSource{}
+}
+
+type transformsDataSource struct {
+	client *sailpoint.APIClient
+}
+
+type transformsDataSourceModel struct {
+	Filter          types.Object `tfsdk:"filter"`
+	IncludeInternal types.Bool   `tfsdk:"include_internal"`
+	Transforms      types.List   `tfsdk:"transforms"`
+}
+
+// transformAttrTypes is the attr.Type map of one "transforms" item, shared
+// by the schema and the Read method's types.ListValueFrom call.
+func transformAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":         types.StringType,
+		"internal":   types.BoolType,
+		"name":       types.StringType,
+		"type":       types.StringType,
+		"attributes": jsontypes.NormalizedType{},
+	}
+}
+
+func (d *transformsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_transforms_v1"
+}
+
+func (d *transformsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributesDesc := "Meta-data about the transform, as a raw JSON object."
+	resp.Schema = schema.Schema{
+		Description: "Lists Transforms from IdentityNow/ISC, optionally filtered by name and type.",
+		MarkdownDescription: "Lists [Transforms](https://developer.sailpoint.com/docs/extensibility/transforms/) from " +
+			"IdentityNow/ISC via `GET /transforms/v1`, optionally filtered by name and type. Every page is read, so the " +
+			"result is always complete. Returns the same attributes per transform as the singular `identitynow_transform_v1` " +
+			"data source.\n\n" +
+			"~> This is a `_v1` pilot data source - see `identitynow_transform_v1`'s (the resource) \"Known Limitations & " +
+			"Live Testing Notes\" section before relying on it in production configurations; the same limitations apply " +
+			"to each transform returned here.",
+		Attributes: map[string]schema.Attribute{
+			"filter": util.FilterAttribute(
+				"Typed filter criteria, AND-ed together and compiled into `GET /transforms/v1`'s `filters` expression for you.",
+				transformFilterFields,
+				map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Optional: true,
+						MarkdownDescription: "Only transforms of this `type`. `GET /transforms/v1` can't filter on type, so " +
+							"this is matched by the provider after every page has been read.",
+						Validators: []validator.String{
+							stringvalidator.OneOf(transformTypes...),
+						},
+					},
+				},
+			),
+			"include_internal": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Also return SailPoint-managed built-in transforms (`internal = true`, e.g. `ToUpper`). " +
+					"Defaults to `false`.",
+			},
+			"transforms": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Transforms matching the query, each with the same attributes as `identitynow_transform_v1`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Transform ID.",
+						},
+						"internal": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Indicates whether this is an internal SailPoint-created transform or a customer-created transform.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Unique name of this transform.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The type of transform operation.",
+						},
+						"attributes": schema.StringAttribute{
+							CustomType:          jsontypes.NormalizedType{},
+							Computed:            true,
+							Description:         attributesDesc,
+							MarkdownDescription: attributesDesc,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *transformsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = cp.GetClient()
+}
+
+func (d *transformsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config transformsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filters, diags := transformsFilters(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	transformType, _ := util.FilterBlockString(config.Filter, "type")
+
+	tflog.Debug(ctx, "Reading Transforms data source", map[string]interface{}{"filters": filters, "type": transformType})
+
+	dtos, httpResp, err := listTransforms(ctx, d.client, filters)
+	if err != nil {
+		tflog.Error(ctx, "Error reading Transforms data source", map[string]interface{}{"error": err.Error()})
+		resp.Diagnostics.AddError("Error listing Transforms", errDetail(err, httpResp))
+		return
+	}
+
+	dtos = transformsOfType(dtos, transformType)
+	models := make([]transformDataSourceModel, 0, len(dtos))
+	for i := range dtos {
+		model, diags := datasourceDtoToModel(&dtos[i], transformDataSourceModel{})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		models = append(models, model)
+	}
+
+	transformsList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: transformAttrTypes()}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.Transforms = transformsList
+
+	tflog.Debug(ctx, "Read Transforms data source", map[string]interface{}{"count": len(models)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// transformsFilters compiles config's filter block into GET /transforms/v1's
+// filters, adding "internal eq false" unless include_internal is set.
+func transformsFilters(config transformsDataSourceModel) (string, diag.Diagnostics) {
+	filters, diags := util.BuildFilterExpression(config.Filter, path.Root("filter"), transformFilterFields)
+	if config.IncludeInternal.ValueBool() {
+		return filters, diags
+	}
+	if filters == "" {
+		return "internal eq false", diags
+	}
+	return filters + " and internal eq false", diags
+}
+
+// transformsOfType returns the transforms in items of type transformType,
+// or all of them when it is "".
+func transformsOfType(items []transforms.TransformRead, transformType string) []transforms.TransformRead {
+	if transformType == "" {
+		return items
+	}
+	out := make([]transforms.TransformRead, 0, len(items))
+	for i := range items {
+		if items[i].Type == transformType {
+			out = append(out, items[i])
+		}
+	}
+	return out
+}
+
+// listTransforms reads every page of GET /transforms/v1 for filters ("" for
+// none).
+func listTransforms(ctx context.Context, client *sailpoint.APIClient, filters string) ([]transforms.TransformRead, *http.Response, error) {
+	return listTransformPages(func(offset int32) ([]transforms.TransformRead, *http.Response, error) {
+		apiReq := client.TransformsAPI.
+			ListTransformsV1(ctx).
+			Offset(offset).
+			Limit(transformsListMaxLimit)
+		if filters != "" {
+			apiReq = apiReq.Filters(filters)
+		}
+		return apiReq.Execute()
+	})
+}
+
+// listTransformPages calls listPage at successive offsets, transformsListMaxLimit
+// apart, until a page comes back short.
+func listTransformPages(listPage func(offset int32) ([]transforms.TransformRead, *http.Response, error)) ([]transforms.TransformRead, *http.Response, error) {
+	var all []transforms.TransformRead
+	var offset int32
+	for {
+		page, httpResp, err := listPage(offset)
+		if err != nil {
+			return nil, httpResp, err
+		}
+		all = append(all, page...)
+		if len(page) < transformsListMaxLimit {
+			return all, httpResp, nil
+		}
+		offset += transformsListMaxLimit
+	}
+}
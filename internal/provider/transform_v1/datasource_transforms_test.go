@@ -0,0 +1,188 @@
+package transform_v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sailpoint-oss/golang-sdk/v3/transforms"
+)
+
+// transformsFilterBlock builds a "filter" block value with the given string
+// attributes set and the rest null.
+func transformsFilterBlock(set map[string]string) types.Object {
+	attrTypes := map[string]attr.Type{"name": types.StringType, "name_starts_with": types.StringType, "type": types.StringType}
+	values := map[string]attr.Value{}
+	for name := range attrTypes {
+		values[name] = types.StringNull()
+		if v, ok := set[name]; ok {
+			values[name] = types.StringValue(v)
+		}
+	}
+	return types.ObjectValueMust(attrTypes, values)
+}
+
+func transformNames(items []transforms.TransformRead) []string {
+	out := make([]string, 0, len(items))
+	for i := range items {
+		out = append(out, items[i].Name)
+	}
+	return out
+}
+
+func TestTransformsFilters(t *testing.T) {
+	tests := []struct {
+		name            string
+		filter          types.Object
+		includeInternal types.Bool
+		want            string
+	}{
+		{
+			name:            "no filter excludes internal transforms",
+			filter:          types.ObjectNull(nil),
+			includeInternal: types.BoolNull(),
+			want:            "internal eq false",
+		},
+		{
+			name:            "no filter with include_internal",
+			filter:          types.ObjectNull(nil),
+			includeInternal: types.BoolValue(true),
+			want:            "",
+		},
+		{
+			name:            "include_internal false",
+			filter:          transformsFilterBlock(map[string]string{"name": "Dept"}),
+			includeInternal: types.BoolValue(false),
+			want:            `name eq "Dept" and internal eq false`,
+		},
+		{
+			name:            "name criteria with include_internal",
+			filter:          transformsFilterBlock(map[string]string{"name": `Say "hi"`, "name_starts_with": "Say"}),
+			includeInternal: types.BoolValue(true),
+			want:            `name eq "Say \"hi\"" and name sw "Say"`,
+		},
+		{
+			name:            "type is not sent",
+			filter:          transformsFilterBlock(map[string]string{"type": "lookup"}),
+			includeInternal: types.BoolNull(),
+			want:            "internal eq false",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := transformsFilters(transformsDataSourceModel{Filter: tt.filter, IncludeInternal: tt.includeInternal})
+			if diags.HasError() {
+				t.Fatalf("diagnostics: %v", diags)
+			}
+			if got != tt.want {
+				t.Errorf("transformsFilters = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransformsOfType(t *testing.T) {
+	items := []transforms.TransformRead{
+		{Name: "a", Type: "lookup"},
+		{Name: "b", Type: "concat"},
+		{Name: "c", Type: "lookup"},
+	}
+
+	if got := transformNames(transformsOfType(items, "lookup")); !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Errorf("transformsOfType(lookup) = %v, want [a c]", got)
+	}
+	if got := transformNames(transformsOfType(items, "")); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("transformsOfType(\"\") = %v, want all", got)
+	}
+	if got := transformsOfType(items, "static"); len(got) != 0 {
+		t.Errorf("transformsOfType(static) = %v, want none", transformNames(got))
+	}
+}
+
+func TestListTransformPages(t *testing.T) {
+	pageOf := func(prefix string, n int) []transforms.TransformRead {
+		page := make([]transforms.TransformRead, n)
+		for i := range page {
+			page[i].Name = fmt.Sprintf("%s-%d", prefix, i)
+		}
+		return page
+	}
+
+	t.Run("reads until a short page", func(t *testing.T) {
+		var offsets []int32
+		pages := map[int32][]transforms.TransformRead{
+			0:                          pageOf("p0", transformsListMaxLimit),
+			transformsListMaxLimit:     pageOf("p1", transformsListMaxLimit),
+			2 * transformsListMaxLimit: pageOf("p2", 3),
+		}
+		got, _, err := listTransformPages(func(offset int32) ([]transforms.TransformRead, *http.Response, error) {
+			offsets = append(offsets, offset)
+			return pages[offset], nil, nil
+		})
+		if err != nil {
+			t.Fatalf("listTransformPages: %v", err)
+		}
+		if want := []int32{0, transformsListMaxLimit, 2 * transformsListMaxLimit}; !reflect.DeepEqual(offsets, want) {
+			t.Errorf("offsets = %v, want %v", offsets, want)
+		}
+		if len(got) != 2*transformsListMaxLimit+3 || got[len(got)-1].Name != "p2-2" {
+			t.Errorf("got %d transforms, want %d ending with p2-2", len(got), 2*transformsListMaxLimit+3)
+		}
+	})
+
+	t.Run("full last page ends on an empty one", func(t *testing.T) {
+		calls := 0
+		got, _, err := listTransformPages(func(offset int32) ([]transforms.TransformRead, *http.Response, error) {
+			calls++
+			if offset == 0 {
+				return pageOf("p0", transformsListMaxLimit), nil, nil
+			}
+			return nil, nil, nil
+		})
+		if err != nil || len(got) != transformsListMaxLimit || calls != 2 {
+			t.Errorf("got %d transforms in %d calls (err %v), want %d in 2", len(got), calls, err, transformsListMaxLimit)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		errList := errors.New("boom")
+		_, _, err := listTransformPages(func(offset int32) ([]transforms.TransformRead, *http.Response, error) {
+			if offset == 0 {
+				return pageOf("p0", transformsListMaxLimit), nil, nil
+			}
+			return nil, nil, errList
+		})
+		if !errors.Is(err, errList) {
+			t.Errorf("listTransformPages error = %v, want %v", err, errList)
+		}
+	})
+}
+
+func TestTransformWithExactName(t *testing.T) {
+	items := []transforms.TransformRead{
+		{Id: "1", Name: "Dept Lookup"},
+		{Id: "2", Name: "dept lookup"},
+		{Id: "3", Name: "Dup"},
+		{Id: "4", Name: "Dup"},
+	}
+
+	got, diags := transformWithExactName(items, "Dept Lookup")
+	if diags.HasError() || got == nil || got.Id != "1" {
+		t.Errorf("transformWithExactName(Dept Lookup) = %v, %v, want id 1", got, diags)
+	}
+
+	_, diags = transformWithExactName(items, "DEPT LOOKUP")
+	if !diags.HasError() || !strings.Contains(diags[0].Summary(), "not found") {
+		t.Errorf("transformWithExactName(DEPT LOOKUP) diagnostics = %v, want not found", diags)
+	}
+
+	_, diags = transformWithExactName(items, "Dup")
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), "3, 4") {
+		t.Errorf("transformWithExactName(Dup) diagnostics = %v, want not unique listing 3, 4", diags)
+	}
+}
@@ -0,0 +1,372 @@
+// This file implements plan-time validation of transformResource's
+// "attributes" JSON. The package doc explains why "attributes" is an
+// opaque jsontypes.Normalized string; without this, a misspelled key or a
+// missing required one deep inside a nested "input" chain was only
+// reported by the API at apply.
+//
+// transformAttributeSpecs records, per "type", the attribute keys
+// SailPoint's operation docs
+// (https://developer.sailpoint.com/docs/extensibility/transforms/operations)
+// define, which of them are required, and which may hold a nested
+// transform. ValidateConfig walks the tree from the root transform down
+// through every nested transform, and reports each problem as a separate
+// diagnostic prefixed with its JSON path inside "attributes" (e.g.
+// "input.attributes.values[1].attributes.name").
+package transform_v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+var _ resource.ResourceWithValidateConfig = (*transformResource)(nil)
+
+// transformMaxDocumentBytes is the documented size limit of a whole
+// transform document (name, type and attributes).
+const transformMaxDocumentBytes = 400 * 1024
+
+// transformTypes is the v1 spec's "type" enum: one entry per
+// transformAttributeSpecs type, matching the OneOf validator in the
+// generated resource_transform schema.
+var transformTypes = util.SortedKeys(transformAttributeSpecs)
+
+// transformKeyKind is the JSON shape an attribute key accepts.
+type transformKeyKind int
+
+const (
+	// transformKeyString is a plain string.
+	transformKeyString transformKeyKind = iota
+	// transformKeyInt is an integer, as a JSON number or a numeric string.
+	transformKeyInt
+	// transformKeyBool is a boolean, as a JSON bool or "true"/"false".
+	transformKeyBool
+	// transformKeyValue is a literal or a nested transform object.
+	transformKeyValue
+	// transformKeyValues is a list of transformKeyValue.
+	transformKeyValues
+	// transformKeyStringList is a list of strings.
+	transformKeyStringList
+	// transformKeyTable is an object of string values.
+	transformKeyTable
+)
+
+type transformAttributeSpec struct {
+	keys     map[string]transformKeyKind
+	required []string
+	// variables allows any other key, holding a literal or a nested
+	// transform (conditional/static variables, usernameGenerator tokens).
+	variables bool
+	// open allows any other key with any value (rule arguments).
+	open bool
+	// enums restricts string keys to a fixed set of values.
+	enums map[string][]string
+}
+
+// transformCommonKeys are accepted on every transform type.
+var transformCommonKeys = map[string]transformKeyKind{
+	"input":                   transformKeyValue,
+	"requiresPeriodicRefresh": transformKeyBool,
+}
+
+var transformAttributeSpecs = map[string]transformAttributeSpec{
+	"accountAttribute": {keys: map[string]transformKeyKind{
+		"sourceName": transformKeyString, "applicationId": transformKeyString, "applicationName": transformKeyString,
+		"attributeName": transformKeyString, "accountSortAttribute": transformKeyString, "accountSortDescending": transformKeyBool,
+		"accountReturnFirstLink": transformKeyBool, "accountFilter": transformKeyString, "accountPropertyFilter": transformKeyString,
+	}, required: []string{"attributeName"}},
+	"base64Decode": {},
+	"base64Encode": {},
+	"concat":       {keys: map[string]transformKeyKind{"values": transformKeyValues}, required: []string{"values"}},
+	"conditional": {keys: map[string]transformKeyKind{
+		"expression": transformKeyString, "positiveCondition": transformKeyString, "negativeCondition": transformKeyString,
+	}, required: []string{"expression", "positiveCondition", "negativeCondition"}, variables: true},
+	"dateCompare": {keys: map[string]transformKeyKind{
+		"firstDate": transformKeyValue, "secondDate": transformKeyValue, "operator": transformKeyString,
+		"positiveCondition": transformKeyValue, "negativeCondition": transformKeyValue,
+	}, required: []string{"firstDate", "secondDate", "operator", "positiveCondition", "negativeCondition"},
+		enums: map[string][]string{"operator": {"LT", "LTE", "GT", "GTE"}}},
+	"dateFormat": {keys: map[string]transformKeyKind{"inputFormat": transformKeyString, "outputFormat": transformKeyString}},
+	"dateMath": {keys: map[string]transformKeyKind{"expression": transformKeyString, "roundUp": transformKeyBool},
+		required: []string{"expression"}},
+	"decomposeDiacriticalMarks": {},
+	// displayName and rfc5646 attributes aren't documented in enough detail
+	// to reject unknown keys.
+	"displayName": {open: true},
+	"e164phone":   {keys: map[string]transformKeyKind{"defaultRegion": transformKeyString}},
+	"firstValid": {keys: map[string]transformKeyKind{"values": transformKeyValues, "ignoreErrors": transformKeyBool},
+		required: []string{"values"}},
+	"identityAttribute": {keys: map[string]transformKeyKind{"name": transformKeyString}, required: []string{"name"}},
+	"indexOf":           {keys: map[string]transformKeyKind{"substring": transformKeyString}, required: []string{"substring"}},
+	"iso3166": {keys: map[string]transformKeyKind{"format": transformKeyString},
+		enums: map[string][]string{"format": {"alpha2", "alpha3", "numeric"}}},
+	"lastIndexOf": {keys: map[string]transformKeyKind{"substring": transformKeyString}, required: []string{"substring"}},
+	"leftPad": {keys: map[string]transformKeyKind{"length": transformKeyInt, "padding": transformKeyString},
+		required: []string{"length"}},
+	"lookup":             {keys: map[string]transformKeyKind{"table": transformKeyTable}, required: []string{"table"}},
+	"lower":              {},
+	"normalizeNames":     {},
+	"randomAlphaNumeric": {keys: map[string]transformKeyKind{"length": transformKeyInt}},
+	"randomNumeric":      {keys: map[string]transformKeyKind{"length": transformKeyInt}},
+	"reference":          {keys: map[string]transformKeyKind{"id": transformKeyString}, required: []string{"id"}},
+	"replace": {keys: map[string]transformKeyKind{"regex": transformKeyString, "replacement": transformKeyString},
+		required: []string{"regex", "replacement"}},
+	"replaceAll": {keys: map[string]transformKeyKind{"table": transformKeyTable}, required: []string{"table"}},
+	"rfc5646":    {open: true},
+	"rightPad": {keys: map[string]transformKeyKind{"length": transformKeyInt, "padding": transformKeyString},
+		required: []string{"length"}},
+	"rule": {keys: map[string]transformKeyKind{"name": transformKeyString}, required: []string{"name"}, open: true},
+	"split": {keys: map[string]transformKeyKind{"delimiter": transformKeyString, "index": transformKeyInt, "throws": transformKeyBool},
+		required: []string{"delimiter", "index"}},
+	"static": {keys: map[string]transformKeyKind{"value": transformKeyString}, required: []string{"value"}, variables: true},
+	"substring": {keys: map[string]transformKeyKind{
+		"begin": transformKeyInt, "beginOffset": transformKeyInt, "end": transformKeyInt, "endOffset": transformKeyInt,
+	}, required: []string{"begin"}},
+	"trim":  {},
+	"upper": {},
+	"usernameGenerator": {keys: map[string]transformKeyKind{
+		"patterns": transformKeyStringList, "sourceCheck": transformKeyBool, "cloudMaxUniqueChecks": transformKeyInt,
+		"cloudMaxSize": transformKeyInt, "cloudRequired": transformKeyBool,
+	}, required: []string{"patterns"}, variables: true},
+	"uuid": {},
+}
+
+func (r *transformResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var name, typ types.String
+	var attributes jsontypes.Normalized
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &typ)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("attributes"), &attributes)...)
+	if resp.Diagnostics.HasError() || attributes.IsNull() || attributes.IsUnknown() {
+		return
+	}
+
+	var attrs interface{}
+	if err := json.Unmarshal([]byte(attributes.ValueString()), &attrs); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("attributes"),
+			"Invalid \"attributes\" JSON",
+			fmt.Sprintf("Could not decode \"attributes\" as JSON: %s", err.Error()),
+		)
+		return
+	}
+
+	if size := transformDocumentSize(name.ValueString(), typ.ValueString(), attrs); size > transformMaxDocumentBytes {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("attributes"),
+			"Transform too large",
+			fmt.Sprintf("The transform document is %d bytes; SailPoint rejects transforms larger than %d bytes (400KB).", size, transformMaxDocumentBytes),
+		)
+	}
+
+	// Without a known root type there's nothing to check the root keys
+	// against; the generated OneOf validator reports an invalid one.
+	spec, ok := transformAttributeSpecs[typ.ValueString()]
+	if typ.IsUnknown() || !ok {
+		return
+	}
+	obj, ok := attrs.(map[string]interface{})
+	if !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("attributes"), "Invalid transform attributes", "attributes must be a JSON object")
+		return
+	}
+	for _, problem := range validateTransformAttributes(typ.ValueString(), spec, obj, "") {
+		resp.Diagnostics.AddAttributeError(path.Root("attributes"), "Invalid transform attributes", problem)
+	}
+}
+
+// transformDocumentSize is the size of the compact JSON document the API
+// stores for the transform.
+func transformDocumentSize(name, typ string, attrs interface{}) int {
+	b, err := json.Marshal(map[string]interface{}{"name": name, "type": typ, "attributes": attrs})
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
+
+// validateTransformAttributes checks one transform's attributes object
+// against its type's spec and recurses into every nested transform. at is
+// the object's JSON path inside the root "attributes" ("" for the root).
+func validateTransformAttributes(typ string, spec transformAttributeSpec, attrs map[string]interface{}, at string) []string {
+	var problems []string
+	for _, k := range spec.required {
+		if v, ok := attrs[k]; !ok || v == nil {
+			problems = append(problems, fmt.Sprintf("%s: is required for type %s", transformPath(transformJoin(at, k)), typ))
+		}
+	}
+	if typ == "accountAttribute" && attrs["sourceName"] == nil && attrs["applicationId"] == nil && attrs["applicationName"] == nil {
+		problems = append(problems, fmt.Sprintf("%s: is required for type %s (or the older applicationId/applicationName)",
+			transformPath(transformJoin(at, "sourceName")), typ))
+	}
+
+	for _, k := range util.SortedKeys(attrs) {
+		v := attrs[k]
+		if v == nil {
+			continue
+		}
+		kind, ok := spec.keys[k]
+		if !ok {
+			kind, ok = transformCommonKeys[k]
+		}
+		switch {
+		case ok:
+		case spec.variables:
+			kind = transformKeyValue
+		case spec.open:
+			continue
+		default:
+			problems = append(problems, fmt.Sprintf("%s: unknown field for type %s (expected %s)",
+				transformPath(transformJoin(at, k)), typ, transformExpectedKeys(spec)))
+			continue
+		}
+		problems = append(problems, validateTransformKey(kind, v, transformJoin(at, k))...)
+		if allowed, ok := spec.enums[k]; ok {
+			if s, isString := v.(string); isString && !util.ContainsString(allowed, s) {
+				problems = append(problems, fmt.Sprintf("%s: must be one of %s, got %q",
+					transformPath(transformJoin(at, k)), strings.Join(allowed, ", "), s))
+			}
+		}
+	}
+	return problems
+}
+
+func validateTransformKey(kind transformKeyKind, v interface{}, at string) []string {
+	switch kind {
+	case transformKeyString:
+		if _, ok := v.(string); !ok {
+			return []string{fmt.Sprintf("%s: must be a string, got %s", transformPath(at), util.DescribeJSONValue(v))}
+		}
+	case transformKeyInt:
+		switch n := v.(type) {
+		case float64:
+			if n == float64(int64(n)) {
+				return nil
+			}
+		case string:
+			if _, err := strconv.Atoi(strings.TrimSpace(n)); err == nil {
+				return nil
+			}
+		}
+		return []string{fmt.Sprintf("%s: must be an integer, got %s", transformPath(at), util.DescribeJSONValue(v))}
+	case transformKeyBool:
+		switch b := v.(type) {
+		case bool:
+			return nil
+		case string:
+			if _, err := strconv.ParseBool(b); err == nil {
+				return nil
+			}
+		}
+		return []string{fmt.Sprintf("%s: must be a boolean, got %s", transformPath(at), util.DescribeJSONValue(v))}
+	case transformKeyValue:
+		return validateTransformValue(v, at)
+	case transformKeyValues:
+		list, ok := v.([]interface{})
+		if !ok || len(list) == 0 {
+			return []string{fmt.Sprintf("%s: must be a non-empty list", transformPath(at))}
+		}
+		var problems []string
+		for i, item := range list {
+			problems = append(problems, validateTransformValue(item, fmt.Sprintf("%s[%d]", at, i))...)
+		}
+		return problems
+	case transformKeyStringList:
+		list, ok := v.([]interface{})
+		if !ok || len(list) == 0 {
+			return []string{fmt.Sprintf("%s: must be a non-empty list of strings", transformPath(at))}
+		}
+		for i, item := range list {
+			if _, ok := item.(string); !ok {
+				return []string{fmt.Sprintf("%s[%d]: must be a string, got %s", transformPath(at), i, util.DescribeJSONValue(item))}
+			}
+		}
+	case transformKeyTable:
+		table, ok := v.(map[string]interface{})
+		if !ok || len(table) == 0 {
+			return []string{fmt.Sprintf("%s: must be a non-empty JSON object", transformPath(at))}
+		}
+		var problems []string
+		for _, k := range util.SortedKeys(table) {
+			if _, ok := table[k].(string); !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s: must be a string, got %s", transformPath(at), k, util.DescribeJSONValue(table[k])))
+			}
+		}
+		return problems
+	}
+	return nil
+}
+
+// validateTransformValue checks a value that may be a literal or a nested
+// {type, attributes} transform, recursing into the latter.
+func validateTransformValue(v interface{}, at string) []string {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		if _, isList := v.([]interface{}); isList {
+			return []string{fmt.Sprintf("%s: must be a literal or a nested transform object, got a list", transformPath(at))}
+		}
+		return nil
+	}
+
+	var problems []string
+	for _, k := range util.SortedKeys(obj) {
+		if k != "type" && k != "attributes" {
+			problems = append(problems, fmt.Sprintf("%s: unknown field on a nested transform (expected type and attributes)",
+				transformPath(transformJoin(at, k))))
+		}
+	}
+	typ, _ := obj["type"].(string)
+	spec, ok := transformAttributeSpecs[typ]
+	if !ok {
+		return append(problems, fmt.Sprintf("%s: must be one of %s, got %s",
+			transformPath(transformJoin(at, "type")), strings.Join(transformTypes, ", "), util.DescribeJSONValue(obj["type"])))
+	}
+
+	attrs := map[string]interface{}{}
+	switch a := obj["attributes"].(type) {
+	case nil:
+	case map[string]interface{}:
+		attrs = a
+	default:
+		return append(problems, fmt.Sprintf("%s: must be a JSON object", transformPath(transformJoin(at, "attributes"))))
+	}
+	return append(problems, validateTransformAttributes(typ, spec, attrs, transformJoin(at, "attributes"))...)
+}
+
+func transformExpectedKeys(spec transformAttributeSpec) string {
+	keys := make([]string, 0, len(spec.keys)+len(transformCommonKeys))
+	for k := range spec.keys {
+		keys = append(keys, k)
+	}
+	for k := range transformCommonKeys {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
+
+func transformJoin(at, key string) string {
+	if at == "" {
+		return key
+	}
+	return at + "." + key
+}
+
+// transformPath labels a problem's location; the root object itself is
+// "attributes".
+func transformPath(at string) string {
+	if at == "" {
+		return "attributes"
+	}
+	return at
+}
@@ -0,0 +1,175 @@
+package transform_v1
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-identitynow/internal/provider/transform_v1/resource_transform"
+)
+
+func validateTestTransform(t *testing.T, typ, raw string) []string {
+	t.Helper()
+	var attrs map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &attrs); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return validateTransformAttributes(typ, transformAttributeSpecs[typ], attrs, "")
+}
+
+func TestValidateTransformAttributes_Valid(t *testing.T) {
+	tests := []struct {
+		typ string
+		raw string
+	}{
+		{"lower", `{"input": {"type": "lookup", "attributes": {"table": {"ENG": "Engineering", "default": "Other"},
+			"input": {"type": "concat", "attributes": {"values": [
+				{"type": "identityAttribute", "attributes": {"name": "department"}}, "-", 42
+			]}}}}}`},
+		{"conditional", `{"expression": "$dept eq ENG", "positiveCondition": "$dept", "negativeCondition": "none",
+			"dept": {"type": "identityAttribute", "attributes": {"name": "department"}}}`},
+		{"rule", `{"name": "Cloud Rule", "operation": "getReferenceIdentityAttribute", "uid": "manager"}`},
+		{"accountAttribute", `{"applicationName": "Active Directory", "attributeName": "mail", "accountReturnFirstLink": "true"}`},
+		{"split", `{"delimiter": ",", "index": "1", "throws": false, "requiresPeriodicRefresh": true}`},
+		{"usernameGenerator", `{"patterns": ["$fn.$ln", "$fn.$ln${uniqueCounter}"], "sourceCheck": true,
+			"fn": {"type": "identityAttribute", "attributes": {"name": "firstname"}}}`},
+		{"uuid", `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			if problems := validateTestTransform(t, tt.typ, tt.raw); len(problems) != 0 {
+				t.Errorf("validateTransformAttributes returned problems for valid attributes: %v", problems)
+			}
+		})
+	}
+}
+
+func TestValidateTransformAttributes_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  string
+		raw  string
+		want string
+	}{
+		{
+			name: "missing required key at the root",
+			typ:  "lookup",
+			raw:  `{}`,
+			want: "table: is required for type lookup",
+		},
+		{
+			name: "missing required key deep in the tree",
+			typ:  "lower",
+			raw:  `{"input": {"type": "concat", "attributes": {"values": ["a", {"type": "identityAttribute", "attributes": {}}]}}}`,
+			want: "input.attributes.values[1].attributes.name: is required for type identityAttribute",
+		},
+		{
+			name: "unknown key",
+			typ:  "identityAttribute",
+			raw:  `{"name": "email", "sourceName": "AD"}`,
+			want: "sourceName: unknown field for type identityAttribute (expected input, name, requiresPeriodicRefresh)",
+		},
+		{
+			name: "unknown nested type",
+			typ:  "upper",
+			raw:  `{"input": {"type": "toUpper", "attributes": {}}}`,
+			want: "input.type: must be one of",
+		},
+		{
+			name: "name on a nested transform",
+			typ:  "upper",
+			raw:  `{"input": {"name": "Nested", "type": "trim", "attributes": {}}}`,
+			want: "input.name: unknown field on a nested transform",
+		},
+		{
+			name: "wrong value kind",
+			typ:  "leftPad",
+			raw:  `{"length": "eight"}`,
+			want: `length: must be an integer, got "eight"`,
+		},
+		{
+			name: "non-string table value",
+			typ:  "lookup",
+			raw:  `{"table": {"a": {"type": "static"}}}`,
+			want: "table.a: must be a string, got an object",
+		},
+		{
+			name: "enum",
+			typ:  "dateCompare",
+			raw:  `{"firstDate": "now", "secondDate": "now", "operator": "EQ", "positiveCondition": "y", "negativeCondition": "n"}`,
+			want: `operator: must be one of LT, LTE, GT, GTE, got "EQ"`,
+		},
+		{
+			name: "accountAttribute without a source",
+			typ:  "accountAttribute",
+			raw:  `{"attributeName": "mail"}`,
+			want: "sourceName: is required for type accountAttribute",
+		},
+		{
+			name: "empty values",
+			typ:  "firstValid",
+			raw:  `{"values": []}`,
+			want: "values: must be a non-empty list",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := validateTestTransform(t, tt.typ, tt.raw)
+			for _, p := range problems {
+				if strings.HasPrefix(p, tt.want) {
+					return
+				}
+			}
+			t.Errorf("validateTransformAttributes problems = %v, want one starting with %q", problems, tt.want)
+		})
+	}
+}
+
+// TestTransformTypesMatchGeneratedSchema keeps transformTypes, derived from
+// transformAttributeSpecs, in step with the generated schema's OneOf list.
+func TestTransformTypesMatchGeneratedSchema(t *testing.T) {
+	ctx := context.Background()
+	typeAttr := resource_transform.TransformResourceSchema(ctx).Attributes["type"].(schema.StringAttribute)
+
+	validate := func(v string) bool {
+		for _, vv := range typeAttr.Validators {
+			var resp validator.StringResponse
+			vv.ValidateString(ctx, validator.StringRequest{Path: path.Root("type"), ConfigValue: types.StringValue(v)}, &resp)
+			if resp.Diagnostics.HasError() {
+				return false
+			}
+		}
+		return true
+	}
+	for _, typ := range transformTypes {
+		if !validate(typ) {
+			t.Errorf("generated schema rejects type %q", typ)
+		}
+	}
+	if validate("notATransform") {
+		t.Error("generated schema accepts type \"notATransform\"")
+	}
+
+	// OneOf describes its values as ["a" "b" ...].
+	var listed int
+	for _, vv := range typeAttr.Validators {
+		listed += strings.Count(vv.Description(ctx), `"`) / 2
+	}
+	if listed != len(transformTypes) {
+		t.Errorf("generated schema lists %d types, transformTypes has %d", listed, len(transformTypes))
+	}
+}
+
+func TestTransformDocumentSize(t *testing.T) {
+	attrs := map[string]interface{}{"value": strings.Repeat("x", transformMaxDocumentBytes)}
+	if size := transformDocumentSize("Big", "static", attrs); size <= transformMaxDocumentBytes {
+		t.Errorf("transformDocumentSize = %d, want more than %d", size, transformMaxDocumentBytes)
+	}
+}
@@ -0,0 +1,853 @@
+// This file is an offline, pure-Go evaluator for transform definitions (the
+// {type, attributes} documents identitynow_transform_v1 manages). It backs
+// identitynow_transform_preview_v1 (datasource_transform_preview.go) so a
+// nested chain like lower(lookup(concat(...))) can be exercised against
+// sample identity/account attributes at plan time, instead of only showing
+// its mistakes after identities refresh.
+//
+// Semantics follow SailPoint's published operation docs
+// (https://developer.sailpoint.com/docs/extensibility/transforms/operations)
+// rather than the tenant's implementation, which isn't available to us, so
+// the evaluator is deliberately conservative: types whose result depends on
+// tenant state or randomness (see transformNotEvaluable) fail with an
+// explicit error instead of guessing, and any attribute it can't interpret
+// is an error rather than being silently ignored. Attribute keys it doesn't
+// use (e.g. requiresPeriodicRefresh, accountSortAttribute) are ignored.
+//
+// A transform evaluates to a string or to null, represented here as a
+// *string. Dates (dateFormat, dateMath, dateCompare) live in
+// transform_evaluator_dates.go.
+package transform_v1
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// transformEvalMaxDepth bounds nesting, mainly so a "reference" that points
+// back at itself fails cleanly instead of recursing forever.
+const transformEvalMaxDepth = 64
+
+// transformEvaluableTypes lists every "type" transformEvaluator.eval
+// implements: transformTypes less transformNotEvaluable.
+var transformEvaluableTypes = transformEvaluable()
+
+// transformNotEvaluable maps the remaining API "type" values to why they
+// can't be evaluated offline.
+var transformNotEvaluable = map[string]string{
+	"rule":               "it runs a cloud rule inside the tenant",
+	"usernameGenerator":  "it checks candidate values for uniqueness against the tenant",
+	"displayName":        "it applies the tenant's display name rules",
+	"normalizeNames":     "its casing rules aren't published in enough detail to reproduce",
+	"iso3166":            "it needs SailPoint's country code table",
+	"rfc5646":            "it needs SailPoint's language tag table",
+	"uuid":               "its output is random",
+	"randomAlphaNumeric": "its output is random",
+	"randomNumeric":      "its output is random",
+}
+
+func transformEvaluable() []string {
+	var out []string
+	for _, typ := range transformTypes {
+		if _, ok := transformNotEvaluable[typ]; !ok {
+			out = append(out, typ)
+		}
+	}
+	return out
+}
+
+// transformDefinition is one transform document, either the root one being
+// previewed or a nested {type, attributes} object.
+type transformDefinition struct {
+	Type       string
+	Attributes map[string]interface{}
+}
+
+// parseTransformDefinition decodes a nested transform object. "name" and
+// "id" are tolerated so a full transform read from the API can be reused
+// as a reference.
+func parseTransformDefinition(v interface{}) (transformDefinition, error) {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return transformDefinition{}, fmt.Errorf("must be a JSON object with \"type\" and \"attributes\"")
+	}
+	typ, ok := obj["type"].(string)
+	if !ok || typ == "" {
+		return transformDefinition{}, fmt.Errorf("\"type\" must be a non-empty string")
+	}
+	def := transformDefinition{Type: typ, Attributes: map[string]interface{}{}}
+	switch attrs := obj["attributes"].(type) {
+	case nil:
+	case map[string]interface{}:
+		def.Attributes = attrs
+	default:
+		return transformDefinition{}, fmt.Errorf("\"attributes\" must be a JSON object")
+	}
+	return def, nil
+}
+
+// transformEvaluator holds the sample data a preview runs against.
+type transformEvaluator struct {
+	identityAttributes map[string]string
+	// accountAttributes is keyed by source name, then attribute name.
+	accountAttributes map[string]map[string]string
+	// references is keyed by transform id or name, for "reference".
+	references map[string]transformDefinition
+	now        time.Time
+	depth      int
+}
+
+// evaluate runs the root transform with the given implicit input.
+func (e *transformEvaluator) evaluate(def transformDefinition, implicit *string) (*string, error) {
+	return e.eval(def, "", implicit)
+}
+
+func (e *transformEvaluator) eval(def transformDefinition, at string, implicit *string) (*string, error) {
+	c := transformCall{e: e, typ: def.Type, attrs: def.Attributes, at: at, implicit: implicit}
+
+	e.depth++
+	defer func() { e.depth-- }()
+	if e.depth > transformEvalMaxDepth {
+		return nil, c.errorf("transforms are nested more than %d levels deep (does a reference point back at itself?)", transformEvalMaxDepth)
+	}
+
+	switch def.Type {
+	case "accountAttribute":
+		return c.accountAttribute()
+	case "base64Decode":
+		return c.base64Decode()
+	case "base64Encode":
+		return c.mapInput(func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) })
+	case "concat":
+		return c.concat()
+	case "conditional":
+		return c.conditional()
+	case "dateCompare":
+		return c.dateCompare()
+	case "dateFormat":
+		return c.dateFormat()
+	case "dateMath":
+		return c.dateMath()
+	case "decomposeDiacriticalMarks":
+		return c.mapInput(decomposeDiacriticalMarks)
+	case "e164phone":
+		return c.e164phone()
+	case "firstValid":
+		return c.firstValid()
+	case "identityAttribute":
+		return c.identityAttribute()
+	case "indexOf":
+		return c.indexOf(strings.Index)
+	case "lastIndexOf":
+		return c.indexOf(strings.LastIndex)
+	case "leftPad":
+		return c.pad(true)
+	case "lookup":
+		return c.lookup()
+	case "lower":
+		return c.mapInput(strings.ToLower)
+	case "reference":
+		return c.reference()
+	case "replace":
+		return c.replace()
+	case "replaceAll":
+		return c.replaceAll()
+	case "rightPad":
+		return c.pad(false)
+	case "split":
+		return c.split()
+	case "static":
+		return c.static()
+	case "substring":
+		return c.substring()
+	case "trim":
+		return c.mapInput(strings.TrimSpace)
+	case "upper":
+		return c.mapInput(strings.ToUpper)
+	}
+	if reason, ok := transformNotEvaluable[def.Type]; ok {
+		return nil, c.errorf("can't be evaluated offline because %s", reason)
+	}
+	return nil, c.errorf("unknown transform type")
+}
+
+// evalValue evaluates an attribute that may hold either a literal or a
+// nested transform object.
+func (e *transformEvaluator) evalValue(v interface{}, at string, implicit *string) (*string, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return &v, nil
+	case float64:
+		s := strconv.FormatFloat(v, 'f', -1, 64)
+		return &s, nil
+	case bool:
+		s := strconv.FormatBool(v)
+		return &s, nil
+	case map[string]interface{}:
+		def, err := parseTransformDefinition(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", at, err)
+		}
+		return e.eval(def, at, implicit)
+	}
+	return nil, fmt.Errorf("%s: must be a string or a nested transform object", at)
+}
+
+// transformCall is one transform being evaluated, with helpers for reading
+// its attributes. at is its path from the root transform ("" for the root).
+type transformCall struct {
+	e        *transformEvaluator
+	typ      string
+	attrs    map[string]interface{}
+	at       string
+	implicit *string
+}
+
+func (c transformCall) errorf(format string, args ...interface{}) error {
+	label := c.at
+	if label == "" {
+		label = "transform"
+	}
+	return fmt.Errorf("%s (%s): %s", label, c.typ, fmt.Sprintf(format, args...))
+}
+
+// path returns the path of one of this transform's attributes.
+func (c transformCall) path(key string) string {
+	if c.at == "" {
+		return "attributes." + key
+	}
+	return c.at + ".attributes." + key
+}
+
+// input evaluates "input" when it's set, and otherwise returns the implicit
+// input, matching how the tenant feeds a transform its source attribute.
+func (c transformCall) input() (*string, error) {
+	v, ok := c.attrs["input"]
+	if !ok {
+		return c.implicit, nil
+	}
+	return c.value("input", v)
+}
+
+func (c transformCall) value(key string, v interface{}) (*string, error) {
+	return c.e.evalValue(v, c.path(key), c.implicit)
+}
+
+// mapInput applies fn to the input, passing null through.
+func (c transformCall) mapInput(fn func(string) string) (*string, error) {
+	in, err := c.input()
+	if err != nil || in == nil {
+		return nil, err
+	}
+	out := fn(*in)
+	return &out, nil
+}
+
+// str reads a plain string attribute. ok is false when it isn't set.
+func (c transformCall) str(key string, required bool) (string, bool, error) {
+	v, set := c.attrs[key]
+	if !set || v == nil {
+		if required {
+			return "", false, c.errorf("%q is required", key)
+		}
+		return "", false, nil
+	}
+	s, isString := v.(string)
+	if !isString {
+		return "", false, c.errorf("%q must be a string", key)
+	}
+	return s, true, nil
+}
+
+// integer reads an integer attribute, accepting either a JSON number or a
+// numeric string (the API's own examples use both).
+func (c transformCall) integer(key string, required bool) (int, bool, error) {
+	switch v := c.attrs[key].(type) {
+	case nil:
+		if required {
+			return 0, false, c.errorf("%q is required", key)
+		}
+		return 0, false, nil
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), true, nil
+		}
+	case string:
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			return n, true, nil
+		}
+	}
+	return 0, false, c.errorf("%q must be an integer", key)
+}
+
+// boolean reads a boolean attribute, accepting "true"/"false" strings.
+func (c transformCall) boolean(key string, def bool) (bool, error) {
+	switch v := c.attrs[key].(type) {
+	case nil:
+		return def, nil
+	case bool:
+		return v, nil
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b, nil
+		}
+	}
+	return false, c.errorf("%q must be a boolean", key)
+}
+
+func (c transformCall) list(key string) ([]interface{}, error) {
+	v, ok := c.attrs[key].([]interface{})
+	if !ok {
+		return nil, c.errorf("%q must be a list", key)
+	}
+	return v, nil
+}
+
+// stringMap reads a JSON object of string values, such as lookup's table.
+func (c transformCall) stringMap(key string) (map[string]string, error) {
+	obj, ok := c.attrs[key].(map[string]interface{})
+	if !ok {
+		return nil, c.errorf("%q must be a JSON object", key)
+	}
+	m := make(map[string]string, len(obj))
+	for k, v := range obj {
+		s, ok := v.(string)
+		if !ok {
+			return nil, c.errorf("%s.%s must be a string", key, k)
+		}
+		m[k] = s
+	}
+	return m, nil
+}
+
+func (c transformCall) accountAttribute() (*string, error) {
+	source, ok, err := c.str("sourceName", false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// applicationName is the older spelling of sourceName.
+		if source, _, err = c.str("applicationName", false); err != nil {
+			return nil, err
+		}
+	}
+	if source == "" {
+		return nil, c.errorf("\"sourceName\" is required")
+	}
+	name, _, err := c.str("attributeName", true)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := c.e.accountAttributes[source][name]
+	if !ok {
+		return nil, nil
+	}
+	return &v, nil
+}
+
+func (c transformCall) identityAttribute() (*string, error) {
+	name, _, err := c.str("name", true)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := c.e.identityAttributes[name]
+	if !ok {
+		return nil, nil
+	}
+	return &v, nil
+}
+
+func (c transformCall) base64Decode() (*string, error) {
+	in, err := c.input()
+	if err != nil || in == nil {
+		return nil, err
+	}
+	b, err := base64.StdEncoding.DecodeString(*in)
+	if err != nil {
+		return nil, c.errorf("input is not valid base64: %s", err)
+	}
+	out := string(b)
+	return &out, nil
+}
+
+// concat joins "values" in order. Null values contribute nothing.
+func (c transformCall) concat() (*string, error) {
+	values, err := c.list("values")
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	for i, v := range values {
+		s, err := c.value(fmt.Sprintf("values[%d]", i), v)
+		if err != nil {
+			return nil, err
+		}
+		if s != nil {
+			b.WriteString(*s)
+		}
+	}
+	out := b.String()
+	return &out, nil
+}
+
+// transformConditionalReserved are conditional's own attributes; every
+// other attribute is a variable the expression can reference as $name.
+var transformConditionalReserved = map[string]bool{
+	"expression": true, "positiveCondition": true, "negativeCondition": true, "input": true, "requiresPeriodicRefresh": true,
+}
+
+// conditional supports the documented "ValueA eq ValueB" expression, where
+// either side (and either result) may be a literal or a $variable.
+func (c transformCall) conditional() (*string, error) {
+	expr, _, err := c.str("expression", true)
+	if err != nil {
+		return nil, err
+	}
+	sides := strings.Split(expr, " eq ")
+	if len(sides) != 2 {
+		return nil, c.errorf("\"expression\" must have the form \"ValueA eq ValueB\", got %q", expr)
+	}
+	left, err := c.operand(strings.TrimSpace(sides[0]))
+	if err != nil {
+		return nil, err
+	}
+	right, err := c.operand(strings.TrimSpace(sides[1]))
+	if err != nil {
+		return nil, err
+	}
+
+	key := "negativeCondition"
+	if (left == nil && right == nil) || (left != nil && right != nil && *left == *right) {
+		key = "positiveCondition"
+	}
+	result, _, err := c.str(key, true)
+	if err != nil {
+		return nil, err
+	}
+	return c.operand(result)
+}
+
+// operand resolves a conditional operand: $name or ${name} evaluates the
+// variable attribute of that name, anything else is a literal.
+func (c transformCall) operand(s string) (*string, error) {
+	if !strings.HasPrefix(s, "$") {
+		return &s, nil
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(s, "$"), "{"), "}")
+	v, ok := c.attrs[name]
+	if !ok || transformConditionalReserved[name] {
+		return nil, c.errorf("%s is not defined as an attribute", s)
+	}
+	return c.value(name, v)
+}
+
+// e164CountryCodes maps the regions accepted as e164phone's defaultRegion
+// to their calling codes. Numbers already in +<code> form don't need one.
+var e164CountryCodes = map[string]string{
+	"US": "1", "CA": "1", "GB": "44", "IE": "353", "DE": "49", "FR": "33", "ES": "34", "IT": "39", "NL": "31",
+	"BE": "32", "CH": "41", "AT": "43", "SE": "46", "NO": "47", "DK": "45", "FI": "358", "PL": "48", "PT": "351",
+	"IN": "91", "CN": "86", "JP": "81", "KR": "82", "SG": "65", "AU": "61", "NZ": "64", "BR": "55", "MX": "52",
+	"ZA": "27", "IL": "972", "AE": "971",
+}
+
+// e164phone normalizes the input to +<country code><number>. Like the
+// tenant, it returns null rather than failing for input that isn't a
+// plausible phone number; it doesn't check per-country number plans.
+func (c transformCall) e164phone() (*string, error) {
+	region, ok, err := c.str("defaultRegion", false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		region = "US"
+	}
+	code, known := e164CountryCodes[strings.ToUpper(region)]
+	if !known {
+		return nil, c.errorf("\"defaultRegion\" %q isn't one of the regions the offline evaluator knows", region)
+	}
+
+	in, err := c.input()
+	if err != nil || in == nil {
+		return nil, err
+	}
+	s := strings.TrimSpace(*in)
+	international := strings.HasPrefix(s, "+") || strings.HasPrefix(s, "00")
+	var digits strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case strings.ContainsRune(" -.()/+", r):
+		default:
+			return nil, nil
+		}
+	}
+	number := digits.String()
+	switch {
+	case strings.HasPrefix(s, "00"):
+		number = number[2:]
+	case international:
+	case code == "1":
+		number = strings.TrimPrefix(number, "1")
+		if len(number) != 10 {
+			return nil, nil
+		}
+		number = code + number
+	default:
+		number = code + strings.TrimPrefix(number, "0")
+	}
+	if len(number) < 8 || len(number) > 15 {
+		return nil, nil
+	}
+	out := "+" + number
+	return &out, nil
+}
+
+// firstValid returns the first non-null value. With ignoreErrors, values
+// that fail to evaluate are skipped instead of failing the transform.
+func (c transformCall) firstValid() (*string, error) {
+	values, err := c.list("values")
+	if err != nil {
+		return nil, err
+	}
+	ignoreErrors, err := c.boolean("ignoreErrors", false)
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range values {
+		s, err := c.value(fmt.Sprintf("values[%d]", i), v)
+		if err != nil {
+			if ignoreErrors {
+				continue
+			}
+			return nil, err
+		}
+		if s != nil {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+// indexOf returns the character (not byte) index of "substring", or -1.
+func (c transformCall) indexOf(find func(s, substr string) int) (*string, error) {
+	sub, _, err := c.str("substring", true)
+	if err != nil {
+		return nil, err
+	}
+	return c.mapInput(func(s string) string {
+		i := find(s, sub)
+		if i > 0 {
+			i = utf8.RuneCountInString(s[:i])
+		}
+		return strconv.Itoa(i)
+	})
+}
+
+// pad pads the input to "length" characters with "padding" (a space by
+// default). Input already at least that long is returned unchanged.
+func (c transformCall) pad(left bool) (*string, error) {
+	length, _, err := c.integer("length", true)
+	if err != nil {
+		return nil, err
+	}
+	padding, ok, err := c.str("padding", false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		padding = " "
+	}
+	if padding == "" {
+		return nil, c.errorf("\"padding\" must not be empty")
+	}
+	return c.mapInput(func(s string) string {
+		missing := length - utf8.RuneCountInString(s)
+		if missing <= 0 {
+			return s
+		}
+		fill := []rune(strings.Repeat(padding, missing))[:missing]
+		if left {
+			return string(fill) + s
+		}
+		return s + string(fill)
+	})
+}
+
+// lookup maps the input through "table", falling back to its "default"
+// entry. Like the tenant, it fails when neither matches.
+func (c transformCall) lookup() (*string, error) {
+	table, err := c.stringMap("table")
+	if err != nil {
+		return nil, err
+	}
+	in, err := c.input()
+	if err != nil {
+		return nil, err
+	}
+	if in != nil {
+		if v, ok := table[*in]; ok {
+			return &v, nil
+		}
+	}
+	if v, ok := table["default"]; ok {
+		return &v, nil
+	}
+	if in == nil {
+		return nil, c.errorf("input is null and \"table\" has no \"default\" entry")
+	}
+	return nil, c.errorf("no \"table\" entry matches %q and there is no \"default\" entry", *in)
+}
+
+// reference evaluates another transform, looked up by id or name in the
+// references the evaluator was given. Its "input", if any, becomes the
+// referenced transform's implicit input.
+func (c transformCall) reference() (*string, error) {
+	id, _, err := c.str("id", true)
+	if err != nil {
+		return nil, err
+	}
+	def, ok := c.e.references[id]
+	if !ok {
+		return nil, c.errorf("transform %q isn't one of the supplied references", id)
+	}
+	in, err := c.input()
+	if err != nil {
+		return nil, err
+	}
+	return c.e.eval(def, fmt.Sprintf("references[%q]", id), in)
+}
+
+// javaReplacementRef matches Java-style $1 group references, which Go's
+// regexp would otherwise read as the (longer) group name "$1abc".
+var javaReplacementRef = regexp.MustCompile(`\$(\d+)`)
+
+// compileTransformRegex compiles a transform regex. Go's RE2 syntax covers
+// the common Java patterns; lookarounds and backreferences fail here.
+func (c transformCall) compileTransformRegex(key, expr string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, c.errorf("%q is not a regular expression the offline evaluator supports: %s", key, err)
+	}
+	return re, nil
+}
+
+func (c transformCall) replace() (*string, error) {
+	expr, _, err := c.str("regex", true)
+	if err != nil {
+		return nil, err
+	}
+	replacement, _, err := c.str("replacement", true)
+	if err != nil {
+		return nil, err
+	}
+	re, err := c.compileTransformRegex("regex", expr)
+	if err != nil {
+		return nil, err
+	}
+	replacement = javaReplacementRef.ReplaceAllString(replacement, "$${$1}")
+	return c.mapInput(func(s string) string { return re.ReplaceAllString(s, replacement) })
+}
+
+// replaceAll applies every "table" entry (regex -> replacement). The
+// tenant doesn't document an order, so entries apply in sorted key order
+// to keep previews deterministic.
+func (c transformCall) replaceAll() (*string, error) {
+	table, err := c.stringMap("table")
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(table))
+	for k := range table {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	res := make([]*regexp.Regexp, len(keys))
+	for i, k := range keys {
+		if res[i], err = c.compileTransformRegex("table", k); err != nil {
+			return nil, err
+		}
+	}
+	return c.mapInput(func(s string) string {
+		for i, re := range res {
+			s = re.ReplaceAllString(s, javaReplacementRef.ReplaceAllString(table[keys[i]], "$${$1}"))
+		}
+		return s
+	})
+}
+
+// split splits the input on the "delimiter" regex (as Java's String.split
+// does, dropping trailing empty parts) and returns part "index". An index
+// past the end fails unless "throws" is false, in which case it's null.
+func (c transformCall) split() (*string, error) {
+	delimiter, _, err := c.str("delimiter", true)
+	if err != nil {
+		return nil, err
+	}
+	index, _, err := c.integer("index", true)
+	if err != nil {
+		return nil, err
+	}
+	throws, err := c.boolean("throws", true)
+	if err != nil {
+		return nil, err
+	}
+	re, err := c.compileTransformRegex("delimiter", delimiter)
+	if err != nil {
+		return nil, err
+	}
+	in, err := c.input()
+	if err != nil || in == nil {
+		return nil, err
+	}
+	parts := re.Split(*in, -1)
+	for len(parts) > 0 && parts[len(parts)-1] == "" {
+		parts = parts[:len(parts)-1]
+	}
+	if index < 0 || index >= len(parts) {
+		if throws {
+			return nil, c.errorf("\"index\" %d is out of range: input %q has %d parts", index, *in, len(parts))
+		}
+		return nil, nil
+	}
+	return &parts[index], nil
+}
+
+var (
+	// velocityDirective matches the Velocity control directives static
+	// values may use; only variable substitution is supported offline.
+	velocityDirective = regexp.MustCompile(`#\{?(if|elseif|else|end|set|foreach|macro|parse|include)\b`)
+	// velocityMethodCall matches $name.method(...) calls.
+	velocityMethodCall = regexp.MustCompile(`\$!?\{?[A-Za-z_]\w*\}?\.\w+\(`)
+	// velocityReference matches $name, $!name, ${name} and $!{name}.
+	velocityReference = regexp.MustCompile(`\$(!?)(?:\{([A-Za-z_]\w*)\}|([A-Za-z_]\w*))`)
+)
+
+// static returns "value", substituting $variable references to its other
+// attributes the way Velocity does: an undefined or null reference is left
+// as written, unless it's quiet ($!name), which renders as empty.
+func (c transformCall) static() (*string, error) {
+	value, _, err := c.str("value", true)
+	if err != nil {
+		return nil, err
+	}
+	if velocityDirective.MatchString(value) || velocityMethodCall.MatchString(value) {
+		return nil, c.errorf("\"value\" uses Velocity directives or method calls; only $variable substitution can be evaluated offline")
+	}
+
+	var firstErr error
+	out := velocityReference.ReplaceAllStringFunc(value, func(ref string) string {
+		m := velocityReference.FindStringSubmatch(ref)
+		name := m[2] + m[3]
+		v, ok := c.attrs[name]
+		if !ok || name == "value" || name == "input" || name == "requiresPeriodicRefresh" {
+			return ref
+		}
+		s, err := c.value(name, v)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			return ref
+		}
+		if s == nil {
+			if m[1] == "!" {
+				return ""
+			}
+			return ref
+		}
+		return *s
+	})
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return &out, nil
+}
+
+// substring returns the characters from begin+beginOffset up to
+// end+endOffset. A begin of -1 means the start of the input and an end of
+// -1 (or no end) means the end of the input.
+func (c transformCall) substring() (*string, error) {
+	begin, _, err := c.integer("begin", true)
+	if err != nil {
+		return nil, err
+	}
+	beginOffset, _, err := c.integer("beginOffset", false)
+	if err != nil {
+		return nil, err
+	}
+	end, hasEnd, err := c.integer("end", false)
+	if err != nil {
+		return nil, err
+	}
+	endOffset, _, err := c.integer("endOffset", false)
+	if err != nil {
+		return nil, err
+	}
+
+	in, err := c.input()
+	if err != nil || in == nil {
+		return nil, err
+	}
+	runes := []rune(*in)
+	if begin == -1 {
+		begin = 0
+	}
+	begin += beginOffset
+	if !hasEnd || end == -1 {
+		end = len(runes)
+	}
+	end += endOffset
+	if begin < 0 || end > len(runes) || begin > end {
+		return nil, c.errorf("range [%d, %d) is out of bounds for input %q (%d characters)", begin, end, *in, len(runes))
+	}
+	out := string(runes[begin:end])
+	return &out, nil
+}
+
+// diacriticalBases maps precomposed Latin letters to the base letter Java's
+// NFD decomposition leaves behind once the marks are stripped. Letters
+// without a canonical decomposition (ø, ł, ß, ...) are deliberately absent,
+// as the tenant leaves them alone too.
+var diacriticalBases = func() map[rune]rune {
+	groups := map[string]rune{
+		"ÀÁÂÃÄÅĀĂĄ": 'A', "àáâãäåāăą": 'a', "ÇĆĈĊČ": 'C', "çćĉċč": 'c', "Ď": 'D', "ď": 'd',
+		"ÈÉÊËĒĔĖĘĚ": 'E', "èéêëēĕėęě": 'e', "ĜĞĠĢ": 'G', "ĝğġģ": 'g', "Ĥ": 'H', "ĥ": 'h',
+		"ÌÍÎÏĨĪĬĮİ": 'I', "ìíîïĩīĭį": 'i', "Ĵ": 'J', "ĵ": 'j', "Ķ": 'K', "ķ": 'k', "ĹĻĽ": 'L', "ĺļľ": 'l',
+		"ÑŃŅŇ": 'N', "ñńņň": 'n', "ÒÓÔÕÖŌŎŐ": 'O', "òóôõöōŏő": 'o', "ŔŖŘ": 'R', "ŕŗř": 'r',
+		"ŚŜŞŠ": 'S', "śŝşš": 's', "ŢŤ": 'T', "ţť": 't', "ÙÚÛÜŨŪŬŮŰŲ": 'U', "ùúûüũūŭůűų": 'u',
+		"Ŵ": 'W', "ŵ": 'w', "ÝŶŸ": 'Y', "ýÿŷ": 'y', "ŹŻŽ": 'Z', "źżž": 'z',
+	}
+	m := map[rune]rune{}
+	for letters, base := range groups {
+		for _, r := range letters {
+			m[r] = base
+		}
+	}
+	return m
+}()
+
+// decomposeDiacriticalMarks strips accents from precomposed Latin letters
+// and drops any combining marks already present in decomposed input.
+func decomposeDiacriticalMarks(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case diacriticalBases[r] != 0:
+			b.WriteRune(diacriticalBases[r])
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,374 @@
+package transform_v1
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// transformDateMathLayout is dateMath's documented output format,
+// yyyy-MM-dd'T'HH:mm.
+const transformDateMathLayout = "2006-01-02T15:04"
+
+// win32EpochOffset is the number of 100ns intervals between 1601-01-01 and
+// the Unix epoch, for the EPOCH_TIME_WIN32 named format.
+const win32EpochOffset = 116444736000000000
+
+// iso8601Layouts are the ISO8601 variants accepted as date input, most
+// specific first.
+var iso8601Layouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+func parseISO8601(s string) (time.Time, bool) {
+	for _, layout := range iso8601Layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseTransformDate parses s in one of dateFormat's named formats or a
+// Java SimpleDateFormat pattern.
+func (c transformCall) parseTransformDate(s, format string) (time.Time, error) {
+	switch format {
+	case "ISO8601":
+		if t, ok := parseISO8601(s); ok {
+			return t, nil
+		}
+	case "LDAP":
+		for _, layout := range []string{"20060102150405Z0700", "20060102150405"} {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+	case "PEOPLE_SOFT":
+		if t, err := time.Parse("01/02/2006", s); err == nil {
+			return t, nil
+		}
+	case "EPOCH_TIME_JAVA":
+		if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return time.UnixMilli(ms).UTC(), nil
+		}
+	case "EPOCH_TIME_WIN32":
+		if ticks, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return time.Unix(0, (ticks-win32EpochOffset)*100).UTC(), nil
+		}
+	default:
+		layout, err := c.javaDateLayout(format)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, c.errorf("input %q doesn't match the %s date format", s, format)
+}
+
+// formatTransformDate is parseTransformDate's inverse. Named formats are
+// rendered in UTC.
+func (c transformCall) formatTransformDate(t time.Time, format string) (string, error) {
+	switch format {
+	case "ISO8601":
+		return t.UTC().Format("2006-01-02T15:04:05.000Z07:00"), nil
+	case "LDAP":
+		return t.UTC().Format("20060102150405.0Z"), nil
+	case "PEOPLE_SOFT":
+		return t.UTC().Format("01/02/2006"), nil
+	case "EPOCH_TIME_JAVA":
+		return strconv.FormatInt(t.UnixMilli(), 10), nil
+	case "EPOCH_TIME_WIN32":
+		return strconv.FormatInt(t.UnixNano()/100+win32EpochOffset, 10), nil
+	}
+	layout, err := c.javaDateLayout(format)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(layout), nil
+}
+
+// javaDateLayout converts a Java SimpleDateFormat pattern to a Go time
+// layout. Only the pattern letters with a Go equivalent are supported;
+// unpadded H is rendered padded, since Go has no unpadded 24-hour form.
+func (c transformCall) javaDateLayout(pattern string) (string, error) {
+	var b strings.Builder
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		if r == '\'' {
+			if i+1 < len(runes) && runes[i+1] == '\'' {
+				b.WriteRune('\'')
+				i += 2
+				continue
+			}
+			end := i + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			b.WriteString(string(runes[i+1 : end]))
+			i = end + 1
+			continue
+		}
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			b.WriteRune(r)
+			i++
+			continue
+		}
+
+		n := 1
+		for i+n < len(runes) && runes[i+n] == r {
+			n++
+		}
+		i += n
+		var elem string
+		switch r {
+		case 'y':
+			elem = "2006"
+			if n == 2 {
+				elem = "06"
+			}
+		case 'M':
+			elem = [...]string{"1", "01", "Jan", "January"}[min(n, 4)-1]
+		case 'd':
+			elem = [...]string{"2", "02"}[min(n, 2)-1]
+		case 'H':
+			elem = "15"
+		case 'h':
+			elem = [...]string{"3", "03"}[min(n, 2)-1]
+		case 'm':
+			elem = [...]string{"4", "04"}[min(n, 2)-1]
+		case 's':
+			elem = [...]string{"5", "05"}[min(n, 2)-1]
+		case 'S':
+			prev := b.String()
+			if !strings.HasSuffix(prev, ".") && !strings.HasSuffix(prev, ",") {
+				return "", c.errorf("date format %q: fractional seconds (S) must follow a '.' or ','", pattern)
+			}
+			elem = strings.Repeat("0", n)
+		case 'E':
+			elem = "Mon"
+			if n >= 4 {
+				elem = "Monday"
+			}
+		case 'a':
+			elem = "PM"
+		case 'z':
+			elem = "MST"
+		case 'Z':
+			elem = "-0700"
+		case 'X':
+			elem = [...]string{"Z07", "Z0700", "Z07:00"}[min(n, 3)-1]
+		default:
+			return "", c.errorf("date format %q: pattern letter %q isn't supported offline", pattern, string(r))
+		}
+		b.WriteString(elem)
+	}
+	return b.String(), nil
+}
+
+// dateInput evaluates the input as an ISO8601 date.
+func (c transformCall) dateInput() (*time.Time, error) {
+	in, err := c.input()
+	if err != nil || in == nil {
+		return nil, err
+	}
+	t, ok := parseISO8601(*in)
+	if !ok {
+		return nil, c.errorf("input %q is not an ISO8601 date", *in)
+	}
+	return &t, nil
+}
+
+func (c transformCall) dateFormat() (*string, error) {
+	inputFormat, ok, err := c.str("inputFormat", false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		inputFormat = "ISO8601"
+	}
+	outputFormat, ok, err := c.str("outputFormat", false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		outputFormat = "ISO8601"
+	}
+
+	in, err := c.input()
+	if err != nil || in == nil {
+		return nil, err
+	}
+	t, err := c.parseTransformDate(*in, inputFormat)
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.formatTransformDate(t, outputFormat)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// dateMathOp matches one dateMath step: +1d, -2w, or /M for rounding.
+var dateMathOp = regexp.MustCompile(`^(?:([+-])(\d+)|/)([yMwdhms])`)
+
+// dateMath applies an expression such as "now-5d/d" or "+3M" to now or to
+// the input date. Rounding truncates to the start of the unit, or with
+// roundUp to its last millisecond; weeks start on Monday.
+func (c transformCall) dateMath() (*string, error) {
+	expr, _, err := c.str("expression", true)
+	if err != nil {
+		return nil, err
+	}
+	roundUp, err := c.boolean("roundUp", false)
+	if err != nil {
+		return nil, err
+	}
+
+	var t time.Time
+	rest := expr
+	if strings.HasPrefix(rest, "now") {
+		t = c.e.now.UTC()
+		rest = rest[len("now"):]
+	} else {
+		in, err := c.dateInput()
+		if err != nil || in == nil {
+			return nil, err
+		}
+		t = in.UTC()
+	}
+
+	for rest != "" {
+		m := dateMathOp.FindStringSubmatch(rest)
+		if m == nil {
+			return nil, c.errorf("\"expression\" %q: can't parse %q", expr, rest)
+		}
+		rest = rest[len(m[0]):]
+		unit := m[3]
+		if m[1] == "" {
+			t = roundDateMath(t, unit, roundUp)
+			continue
+		}
+		n, _ := strconv.Atoi(m[2])
+		if m[1] == "-" {
+			n = -n
+		}
+		t = addDateMath(t, unit, n)
+	}
+	out := t.Format(transformDateMathLayout)
+	return &out, nil
+}
+
+func addDateMath(t time.Time, unit string, n int) time.Time {
+	switch unit {
+	case "y":
+		return t.AddDate(n, 0, 0)
+	case "M":
+		return t.AddDate(0, n, 0)
+	case "w":
+		return t.AddDate(0, 0, 7*n)
+	case "d":
+		return t.AddDate(0, 0, n)
+	case "h":
+		return t.Add(time.Duration(n) * time.Hour)
+	case "m":
+		return t.Add(time.Duration(n) * time.Minute)
+	}
+	return t.Add(time.Duration(n) * time.Second)
+}
+
+func roundDateMath(t time.Time, unit string, up bool) time.Time {
+	y, mo, d := t.Date()
+	var start time.Time
+	switch unit {
+	case "y":
+		start = time.Date(y, 1, 1, 0, 0, 0, 0, t.Location())
+	case "M":
+		start = time.Date(y, mo, 1, 0, 0, 0, 0, t.Location())
+	case "w":
+		start = time.Date(y, mo, d-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+	case "d":
+		start = time.Date(y, mo, d, 0, 0, 0, 0, t.Location())
+	case "h":
+		start = t.Truncate(time.Hour)
+	case "m":
+		start = t.Truncate(time.Minute)
+	default:
+		start = t.Truncate(time.Second)
+	}
+	if !up {
+		return start
+	}
+	return addDateMath(start, unit, 1).Add(-time.Millisecond)
+}
+
+// dateCompare compares firstDate and secondDate (each "now" or a value
+// evaluating to an ISO8601 date) with operator LT, LTE, GT or GTE.
+func (c transformCall) dateCompare() (*string, error) {
+	first, err := c.compareDate("firstDate")
+	if err != nil {
+		return nil, err
+	}
+	second, err := c.compareDate("secondDate")
+	if err != nil {
+		return nil, err
+	}
+	operator, _, err := c.str("operator", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var holds bool
+	switch strings.ToUpper(operator) {
+	case "LT":
+		holds = first.Before(second)
+	case "LTE":
+		holds = !first.After(second)
+	case "GT":
+		holds = first.After(second)
+	case "GTE":
+		holds = !first.Before(second)
+	default:
+		return nil, c.errorf("\"operator\" must be one of LT, LTE, GT, GTE, got %q", operator)
+	}
+
+	key := "negativeCondition"
+	if holds {
+		key = "positiveCondition"
+	}
+	v, ok := c.attrs[key]
+	if !ok {
+		return nil, c.errorf("%q is required", key)
+	}
+	return c.value(key, v)
+}
+
+func (c transformCall) compareDate(key string) (time.Time, error) {
+	v, ok := c.attrs[key]
+	if !ok {
+		return time.Time{}, c.errorf("%q is required", key)
+	}
+	if v == "now" {
+		return c.e.now, nil
+	}
+	s, err := c.value(key, v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if s == nil {
+		return time.Time{}, c.errorf("%q evaluated to null", key)
+	}
+	t, ok := parseISO8601(*s)
+	if !ok {
+		return time.Time{}, c.errorf("%q value %q is not an ISO8601 date", key, *s)
+	}
+	return t, nil
+}
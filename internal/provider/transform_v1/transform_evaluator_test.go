@@ -0,0 +1,243 @@
+package transform_v1
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+var testTransformNow = time.Date(2026, 3, 18, 14, 30, 0, 0, time.UTC)
+
+func testTransformEvaluator() *transformEvaluator {
+	return &transformEvaluator{
+		identityAttributes: map[string]string{
+			"firstname":  "Zoë",
+			"lastname":   "O'Brien",
+			"department": "ENG",
+			"startDate":  "2026-04-01T09:00:00Z",
+		},
+		accountAttributes: map[string]map[string]string{
+			"Active Directory": {"sAMAccountName": "zobrien", "phone": "(512) 555-0142"},
+		},
+		references: map[string]transformDefinition{},
+		now:        testTransformNow,
+	}
+}
+
+func evaluateTestTransform(t *testing.T, e *transformEvaluator, raw string, input *string) (*string, error) {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	def, err := parseTransformDefinition(v)
+	if err != nil {
+		t.Fatalf("parseTransformDefinition: %v", err)
+	}
+	return e.evaluate(def, input)
+}
+
+func TestTransformEvaluator(t *testing.T) {
+	input := "  Hello World  "
+	tests := []struct {
+		name  string
+		raw   string
+		input *string
+		want  *string
+	}{
+		{
+			name: "lower(lookup(concat(identityAttribute, identityAttribute)))",
+			raw: `{"type": "lower", "attributes": {"input": {"type": "lookup", "attributes": {
+				"table": {"ENG-Zoë": "Engineering-Austin", "default": "Unknown"},
+				"input": {"type": "concat", "attributes": {"values": [
+					{"type": "identityAttribute", "attributes": {"name": "department"}},
+					"-",
+					{"type": "identityAttribute", "attributes": {"name": "firstname"}}
+				]}}}}}}`,
+			want: strPtr("engineering-austin"),
+		},
+		{name: "implicit input", raw: `{"type": "trim", "attributes": {}}`, input: &input, want: strPtr("Hello World")},
+		{name: "null implicit input", raw: `{"type": "upper", "attributes": {}}`, want: nil},
+		{name: "lookup default", raw: `{"type": "lookup", "attributes": {"table": {"a": "b", "default": "d"}}}`, input: strPtr("z"), want: strPtr("d")},
+		{name: "accountAttribute", raw: `{"type": "accountAttribute", "attributes": {"sourceName": "Active Directory", "attributeName": "sAMAccountName"}}`, want: strPtr("zobrien")},
+		{name: "missing identity attribute", raw: `{"type": "identityAttribute", "attributes": {"name": "manager"}}`, want: nil},
+		{
+			name: "firstValid",
+			raw: `{"type": "firstValid", "attributes": {"values": [
+				{"type": "identityAttribute", "attributes": {"name": "nickname"}},
+				{"type": "identityAttribute", "attributes": {"name": "firstname"}}
+			]}}`,
+			want: strPtr("Zoë"),
+		},
+		{
+			name: "conditional",
+			raw: `{"type": "conditional", "attributes": {"expression": "$dept eq ENG", "positiveCondition": "true", "negativeCondition": "false",
+				"dept": {"type": "identityAttribute", "attributes": {"name": "department"}}}}`,
+			want: strPtr("true"),
+		},
+		{
+			name: "static with variables",
+			raw: `{"type": "static", "attributes": {"value": "${fn}.$ln$!missing",
+				"fn": {"type": "lower", "attributes": {"input": {"type": "identityAttribute", "attributes": {"name": "firstname"}}}},
+				"ln": {"type": "replace", "attributes": {"regex": "[^A-Za-z]", "replacement": "", "input": {"type": "identityAttribute", "attributes": {"name": "lastname"}}}}}}`,
+			want: strPtr("zoë.OBrien$!missing"),
+		},
+		{name: "replace group reference", raw: `{"type": "replace", "attributes": {"regex": "(\\w+)@(\\w+)", "replacement": "$2/$1"}}`, input: strPtr("zoe@example"), want: strPtr("example/zoe")},
+		{name: "replaceAll", raw: `{"type": "replaceAll", "attributes": {"table": {"-": " ", "\\.": ""}}}`, input: strPtr("a-b.c"), want: strPtr("a bc")},
+		{name: "split", raw: `{"type": "split", "attributes": {"delimiter": ",", "index": 1}}`, input: strPtr("a,b,c"), want: strPtr("b")},
+		{name: "split out of range without throws", raw: `{"type": "split", "attributes": {"delimiter": ",", "index": 5, "throws": false}}`, input: strPtr("a,b"), want: nil},
+		{name: "substring", raw: `{"type": "substring", "attributes": {"begin": 1, "end": 3}}`, input: strPtr("Zoë O"), want: strPtr("oë")},
+		{name: "substring offsets", raw: `{"type": "substring", "attributes": {"begin": -1, "beginOffset": 2, "endOffset": -1}}`, input: strPtr("abcdef"), want: strPtr("cde")},
+		{name: "leftPad", raw: `{"type": "leftPad", "attributes": {"length": "6", "padding": "0"}}`, input: strPtr("42"), want: strPtr("000042")},
+		{name: "rightPad unchanged", raw: `{"type": "rightPad", "attributes": {"length": 2}}`, input: strPtr("abc"), want: strPtr("abc")},
+		{name: "indexOf", raw: `{"type": "indexOf", "attributes": {"substring": "b"}}`, input: strPtr("éab"), want: strPtr("2")},
+		{name: "base64 round trip", raw: `{"type": "base64Decode", "attributes": {"input": {"type": "base64Encode", "attributes": {}}}}`, input: strPtr("héllo"), want: strPtr("héllo")},
+		{name: "decomposeDiacriticalMarks", raw: `{"type": "decomposeDiacriticalMarks", "attributes": {}}`, input: strPtr("Zoë Łódź"), want: strPtr("Zoe Łodz")},
+		{
+			name: "e164phone from account",
+			raw:  `{"type": "e164phone", "attributes": {"input": {"type": "accountAttribute", "attributes": {"sourceName": "Active Directory", "attributeName": "phone"}}}}`,
+			want: strPtr("+15125550142"),
+		},
+		{name: "e164phone region", raw: `{"type": "e164phone", "attributes": {"defaultRegion": "GB"}}`, input: strPtr("020 7946 0018"), want: strPtr("+442079460018")},
+		{name: "e164phone invalid", raw: `{"type": "e164phone", "attributes": {}}`, input: strPtr("ext. 12"), want: nil},
+		{name: "dateFormat named", raw: `{"type": "dateFormat", "attributes": {"inputFormat": "PEOPLE_SOFT", "outputFormat": "ISO8601"}}`, input: strPtr("04/01/2026"), want: strPtr("2026-04-01T00:00:00.000Z")},
+		{name: "dateFormat java pattern", raw: `{"type": "dateFormat", "attributes": {"outputFormat": "dd MMM yyyy 'at' HH:mm"}}`, input: strPtr("2026-04-01T09:05:00Z"), want: strPtr("01 Apr 2026 at 09:05")},
+		{name: "dateFormat epoch", raw: `{"type": "dateFormat", "attributes": {"inputFormat": "EPOCH_TIME_JAVA", "outputFormat": "LDAP"}}`, input: strPtr("1774953900000"), want: strPtr("20260331104500.0Z")},
+		{name: "dateMath now", raw: `{"type": "dateMath", "attributes": {"expression": "now-1w/d"}}`, want: strPtr("2026-03-11T00:00")},
+		{name: "dateMath input round up", raw: `{"type": "dateMath", "attributes": {"expression": "+1M/M", "roundUp": true}}`, input: strPtr("2026-01-15T10:00:00Z"), want: strPtr("2026-02-28T23:59")},
+		{
+			name: "dateCompare",
+			raw: `{"type": "dateCompare", "attributes": {"firstDate": "now", "operator": "LT", "positiveCondition": "future", "negativeCondition": "started",
+				"secondDate": {"type": "identityAttribute", "attributes": {"name": "startDate"}}}}`,
+			want: strPtr("future"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluateTestTransform(t, testTransformEvaluator(), tt.raw, tt.input)
+			if err != nil {
+				t.Fatalf("evaluate returned error: %v", err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("evaluate = %s, want %s", describeStrPtr(got), describeStrPtr(tt.want))
+			}
+		})
+	}
+}
+
+func TestTransformEvaluator_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{
+			name: "lookup without a match points at the nested transform",
+			raw:  `{"type": "upper", "attributes": {"input": {"type": "lookup", "attributes": {"table": {"a": "b"}, "input": "z"}}}}`,
+			want: `attributes.input (lookup): no "table" entry matches "z"`,
+		},
+		{
+			name: "not evaluable offline",
+			raw:  `{"type": "concat", "attributes": {"values": ["a", {"type": "rule", "attributes": {"name": "Cloud Rule"}}]}}`,
+			want: "attributes.values[1] (rule): can't be evaluated offline",
+		},
+		{name: "unknown type", raw: `{"type": "shout", "attributes": {}}`, want: "transform (shout): unknown transform type"},
+		{name: "missing required attribute", raw: `{"type": "identityAttribute", "attributes": {}}`, want: `transform (identityAttribute): "name" is required`},
+		{name: "split out of range", raw: `{"type": "split", "attributes": {"delimiter": ",", "index": 3, "input": "a,b"}}`, want: `"index" 3 is out of range`},
+		{name: "bad conditional", raw: `{"type": "conditional", "attributes": {"expression": "$a ne b", "positiveCondition": "x", "negativeCondition": "y"}}`, want: "must have the form"},
+		{name: "velocity directive", raw: `{"type": "static", "attributes": {"value": "#if($a)x#end", "a": "y"}}`, want: "Velocity directives"},
+		{name: "reference cycle", raw: `{"type": "reference", "attributes": {"id": "loop"}}`, want: "nested more than"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := testTransformEvaluator()
+			e.references["loop"] = transformDefinition{Type: "reference", Attributes: map[string]interface{}{"id": "loop"}}
+			_, err := evaluateTestTransform(t, e, tt.raw, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("evaluate error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransformEvaluableTypes(t *testing.T) {
+	for typ := range transformNotEvaluable {
+		if !util.ContainsString(transformTypes, typ) {
+			t.Errorf("transformNotEvaluable has %q, which isn't a transform type", typ)
+		}
+	}
+	if len(transformEvaluableTypes)+len(transformNotEvaluable) != len(transformTypes) {
+		t.Errorf("%d evaluable + %d not evaluable types, want %d", len(transformEvaluableTypes), len(transformNotEvaluable), len(transformTypes))
+	}
+
+	// Every evaluable type reaches its own case in eval, failing (if at all)
+	// on its empty attributes rather than as an unknown type.
+	for _, typ := range transformEvaluableTypes {
+		_, err := testTransformEvaluator().evaluate(transformDefinition{Type: typ, Attributes: map[string]interface{}{}}, nil)
+		if err != nil && strings.Contains(err.Error(), "unknown transform type") {
+			t.Errorf("eval doesn't implement evaluable type %q", typ)
+		}
+	}
+}
+
+func TestTransformPreviewFromConfig(t *testing.T) {
+	ctx := context.Background()
+	config := transformPreviewDataSourceModel{
+		Type:       types.StringValue("reference"),
+		Attributes: jsontypes.NewNormalizedValue(`{"id": "Email Local Part", "input": {"type": "identityAttribute", "attributes": {"name": "email"}}}`),
+		Input:      types.StringNull(),
+		IdentityAttributes: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"email": types.StringValue("Zoe.OBrien@example.com"),
+		}),
+		AccountAttributes: types.MapNull(types.MapType{ElemType: types.StringType}),
+		References: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"Email Local Part": types.StringValue(`{"name": "Email Local Part", "type": "lower", "attributes": {"input": {"type": "split", "attributes": {"delimiter": "@", "index": 0}}}}`),
+		}),
+		Now:    types.StringNull(),
+		Output: types.StringUnknown(),
+	}
+
+	model, diags := transformPreviewFromConfig(ctx, config, testTransformNow)
+	if diags.HasError() {
+		t.Fatalf("transformPreviewFromConfig returned diagnostics: %v", diags)
+	}
+	if got := model.Output.ValueString(); got != "zoe.obrien" {
+		t.Errorf("output = %q, want %q", got, "zoe.obrien")
+	}
+
+	config.Type = types.StringValue("identityAttribute")
+	config.Attributes = jsontypes.NewNormalizedValue(`{"name": "manager"}`)
+	model, diags = transformPreviewFromConfig(ctx, config, testTransformNow)
+	if diags.HasError() {
+		t.Fatalf("transformPreviewFromConfig returned diagnostics: %v", diags)
+	}
+	if !model.Output.IsNull() {
+		t.Errorf("output = %s, want null for a missing attribute", model.Output)
+	}
+
+	config.Now = types.StringValue("yesterday")
+	if _, diags = transformPreviewFromConfig(ctx, config, testTransformNow); !diags.HasError() {
+		t.Error("an invalid \"now\" should be reported")
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func describeStrPtr(s *string) string {
+	if s == nil {
+		return "null"
+	}
+	return `"` + *s + `"`
+}
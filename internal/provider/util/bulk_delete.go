@@ -0,0 +1,210 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+)
+
+// ErrBulkDeleteNotFound is the per-item result for an object that was
+// already gone, which resources report the same way as a 404 from their
+// single-object DELETE.
+var ErrBulkDeleteNotFound = errors.New("not found")
+
+// BulkDeleteFunc deletes ids in one bulk request and returns a per-id error
+// for each id that failed; ids with no entry were deleted. A non-nil error
+// fails every id in the batch.
+type BulkDeleteFunc func(ctx context.Context, ids []string) (map[string]error, error)
+
+// DeleteCoalescer batches Delete calls that Terraform runs concurrently for
+// the same kind of object (roles, access profiles, ...) into bulk-delete
+// requests, so destroying hundreds of objects doesn't mean hundreds of
+// DELETE calls. The first Delete of a kind opens a batch and sends it once
+// the window has passed or the batch is full; every Delete that joins it
+// waits for the one request and gets back its own result.
+//
+// One DeleteCoalescer is shared by every resource of a provider instance.
+type DeleteCoalescer struct {
+	window time.Duration
+
+	mu   sync.Mutex
+	open map[string]*deleteBatch
+}
+
+type deleteBatch struct {
+	ids  []string
+	sent bool
+	full chan struct{}
+	done chan struct{}
+
+	results map[string]error
+	err     error
+}
+
+// NewDeleteCoalescer returns a DeleteCoalescer that holds batches open for
+// window. A window of zero or less - the provider's default - disables
+// batching: Enabled reports false and resources delete one object at a
+// time.
+func NewDeleteCoalescer(window time.Duration) *DeleteCoalescer {
+	return &DeleteCoalescer{window: window, open: map[string]*deleteBatch{}}
+}
+
+// Enabled reports whether Deletes should go through Delete. It is false for
+// a nil DeleteCoalescer, e.g. a resource configured without a provider.
+func (c *DeleteCoalescer) Enabled() bool {
+	return c != nil && c.window > 0
+}
+
+// Delete adds id to the open batch for kind, opening one if needed, and
+// returns id's result once the batch has been sent with bulkDelete. Batches
+// never exceed maxBatch ids. A batch outlives the context of the Delete
+// that opened it, so one caller being cancelled doesn't fail the others.
+//
+// If ctx is cancelled before the batch is sent, id is taken back out of it
+// and ctx's error returned, so the object is not deleted behind a Delete
+// that reported failure. Once the request is in flight it can't be taken
+// back, so Delete waits for it and returns id's actual result.
+func (c *DeleteCoalescer) Delete(ctx context.Context, kind string, maxBatch int, id string, bulkDelete BulkDeleteFunc) error {
+	c.mu.Lock()
+	b := c.open[kind]
+	if b == nil {
+		b = &deleteBatch{full: make(chan struct{}), done: make(chan struct{})}
+		c.open[kind] = b
+		go c.send(context.WithoutCancel(ctx), kind, b, bulkDelete)
+	}
+	b.ids = append(b.ids, id)
+	if len(b.ids) >= maxBatch {
+		delete(c.open, kind)
+		close(b.full)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		c.mu.Lock()
+		if !b.sent {
+			b.ids = removeID(b.ids, id)
+			c.mu.Unlock()
+			return ctx.Err()
+		}
+		c.mu.Unlock()
+		<-b.done
+	}
+	if b.err != nil {
+		return b.err
+	}
+	return b.results[id]
+}
+
+func (c *DeleteCoalescer) send(ctx context.Context, kind string, b *deleteBatch, bulkDelete BulkDeleteFunc) {
+	timer := time.NewTimer(c.window)
+	select {
+	case <-timer.C:
+	case <-b.full:
+	}
+	timer.Stop()
+
+	c.mu.Lock()
+	if c.open[kind] == b {
+		delete(c.open, kind)
+	}
+	ids := b.ids
+	b.sent = true
+	c.mu.Unlock()
+
+	// Every caller may have been cancelled before the window passed.
+	if len(ids) > 0 {
+		b.results, b.err = bulkDelete(ctx, ids)
+	}
+	close(b.done)
+}
+
+// removeID returns ids without the first occurrence of id.
+func removeID(ids []string, id string) []string {
+	for i, v := range ids {
+		if v == id {
+			return append(ids[:i:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
+
+// BulkDeleteByTask builds a BulkDeleteFunc for bulk-delete endpoints that
+// start a background task instead of reporting per-item results. existing
+// lists which of ids still exist; ids already gone come back as
+// ErrBulkDeleteNotFound and are not sent. start sends the request for the
+// rest and returns the task to wait for (empty if there is none), plus any
+// per-item failures the response itself reports. Once the task finishes,
+// every id that still exists is reported as failed, which is how the
+// task's single outcome gets mapped back to each object.
+func BulkDeleteByTask(
+	client *sailpoint.APIClient,
+	label string,
+	timeout time.Duration,
+	existing func(ctx context.Context, ids []string) ([]string, error),
+	start func(ctx context.Context, ids []string) (string, map[string]error, error),
+) BulkDeleteFunc {
+	return func(ctx context.Context, ids []string) (map[string]error, error) {
+		live, err := existing(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		results := map[string]error{}
+		liveSet := make(map[string]bool, len(live))
+		for _, id := range live {
+			liveSet[id] = true
+		}
+		for _, id := range ids {
+			if !liveSet[id] {
+				results[id] = ErrBulkDeleteNotFound
+			}
+		}
+		if len(live) == 0 {
+			return results, nil
+		}
+
+		taskID, failed, err := start(ctx, live)
+		if err != nil {
+			return nil, err
+		}
+		var sent []string
+		for _, id := range live {
+			if err, ok := failed[id]; ok {
+				results[id] = err
+				continue
+			}
+			sent = append(sent, id)
+		}
+		if taskID == "" || len(sent) == 0 {
+			return results, nil
+		}
+
+		completionStatus, err := WaitForTask(ctx, client, taskID, timeout)
+		if err != nil {
+			return nil, err
+		}
+		remaining, err := existing(ctx, sent)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range remaining {
+			results[id] = fmt.Errorf("%s %q still exists after bulk-delete task %q finished with status %s", label, id, taskID, completionStatus)
+		}
+		return results, nil
+	}
+}
+
+// FilterIdsIn returns the "id in (...)" filter expression for ids.
+func FilterIdsIn(ids []string) string {
+	quoted := make([]string, 0, len(ids))
+	for _, id := range ids {
+		quoted = append(quoted, FilterQuote(id))
+	}
+	return "id in (" + strings.Join(quoted, ", ") + ")"
+}
@@ -0,0 +1,247 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeBulkDelete records the batches it is called with and returns the
+// per-id results in results.
+type fakeBulkDelete struct {
+	mu      sync.Mutex
+	batches [][]string
+	results map[string]error
+	err     error
+	release chan struct{}
+	started chan struct{}
+}
+
+func (f *fakeBulkDelete) fn(ctx context.Context, ids []string) (map[string]error, error) {
+	f.mu.Lock()
+	f.batches = append(f.batches, append([]string(nil), ids...))
+	f.mu.Unlock()
+	if f.started != nil {
+		close(f.started)
+	}
+	if f.release != nil {
+		<-f.release
+	}
+	out := map[string]error{}
+	for _, id := range ids {
+		if err, ok := f.results[id]; ok {
+			out[id] = err
+		}
+	}
+	return out, f.err
+}
+
+func (f *fakeBulkDelete) sortedBatches() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([][]string, len(f.batches))
+	for i, b := range f.batches {
+		out[i] = append([]string(nil), b...)
+		sort.Strings(out[i])
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i][0] < out[j][0] })
+	return out
+}
+
+// deleteAll runs one Delete per id concurrently and returns each id's
+// result.
+func deleteAll(c *DeleteCoalescer, maxBatch int, ids []string, f *fakeBulkDelete) map[string]error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := map[string]error{}
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			err := c.Delete(context.Background(), "things", maxBatch, id, f.fn)
+			mu.Lock()
+			results[id] = err
+			mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+	return results
+}
+
+// waitForPending waits until the open batch for kind holds n ids.
+func waitForPending(t *testing.T, c *DeleteCoalescer, kind string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		b := c.open[kind]
+		got := 0
+		if b != nil {
+			got = len(b.ids)
+		}
+		c.mu.Unlock()
+		if got == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("open %s batch never reached %d ids", kind, n)
+}
+
+func TestDeleteCoalescerEnabled(t *testing.T) {
+	var nilCoalescer *DeleteCoalescer
+	if nilCoalescer.Enabled() {
+		t.Error("nil DeleteCoalescer: Enabled = true")
+	}
+	if NewDeleteCoalescer(0).Enabled() {
+		t.Error("zero window: Enabled = true")
+	}
+	if !NewDeleteCoalescer(time.Second).Enabled() {
+		t.Error("1s window: Enabled = false")
+	}
+}
+
+func TestDeleteCoalescerBatches(t *testing.T) {
+	c := NewDeleteCoalescer(100 * time.Millisecond)
+	f := &fakeBulkDelete{}
+
+	results := deleteAll(c, 50, []string{"a", "b", "c", "d"}, f)
+
+	for id, err := range results {
+		if err != nil {
+			t.Errorf("Delete(%s) = %v, want nil", id, err)
+		}
+	}
+	batches := f.sortedBatches()
+	if len(batches) != 1 || len(batches[0]) != 4 {
+		t.Errorf("batches = %v, want one batch of 4", batches)
+	}
+}
+
+func TestDeleteCoalescerSplitsAtMaxBatch(t *testing.T) {
+	c := NewDeleteCoalescer(50 * time.Millisecond)
+	f := &fakeBulkDelete{}
+
+	ids := []string{"a", "b", "c", "d", "e"}
+	deleteAll(c, 2, ids, f)
+
+	// How the ids split depends on when each Delete lands relative to the
+	// window, so only the invariants are checked.
+	deleted := map[string]int{}
+	for _, b := range f.sortedBatches() {
+		if len(b) > 2 {
+			t.Errorf("batch %v exceeds maxBatch 2", b)
+		}
+		for _, id := range b {
+			deleted[id]++
+		}
+	}
+	for _, id := range ids {
+		if deleted[id] != 1 {
+			t.Errorf("%s deleted %d times, want once (batches = %v)", id, deleted[id], f.sortedBatches())
+		}
+	}
+}
+
+func TestDeleteCoalescerPartialFailure(t *testing.T) {
+	errInUse := errors.New("in use")
+	c := NewDeleteCoalescer(50 * time.Millisecond)
+	f := &fakeBulkDelete{results: map[string]error{"b": errInUse, "c": ErrBulkDeleteNotFound}}
+
+	results := deleteAll(c, 50, []string{"a", "b", "c"}, f)
+
+	if results["a"] != nil {
+		t.Errorf("Delete(a) = %v, want nil", results["a"])
+	}
+	if !errors.Is(results["b"], errInUse) {
+		t.Errorf("Delete(b) = %v, want %v", results["b"], errInUse)
+	}
+	if !errors.Is(results["c"], ErrBulkDeleteNotFound) {
+		t.Errorf("Delete(c) = %v, want ErrBulkDeleteNotFound", results["c"])
+	}
+}
+
+func TestDeleteCoalescerRequestFailure(t *testing.T) {
+	errDown := errors.New("service unavailable")
+	c := NewDeleteCoalescer(50 * time.Millisecond)
+	f := &fakeBulkDelete{err: errDown}
+
+	for id, err := range deleteAll(c, 50, []string{"a", "b"}, f) {
+		if !errors.Is(err, errDown) {
+			t.Errorf("Delete(%s) = %v, want %v", id, err, errDown)
+		}
+	}
+}
+
+func TestDeleteCoalescerCancelBeforeSend(t *testing.T) {
+	c := NewDeleteCoalescer(200 * time.Millisecond)
+	f := &fakeBulkDelete{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error, 1)
+	go func() { cancelled <- c.Delete(ctx, "things", 50, "a", f.fn) }()
+	waitForPending(t, c, "things", 1)
+
+	kept := make(chan error, 1)
+	go func() { kept <- c.Delete(context.Background(), "things", 50, "b", f.fn) }()
+	waitForPending(t, c, "things", 2)
+
+	cancel()
+	if err := <-cancelled; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled Delete = %v, want context.Canceled", err)
+	}
+	if err := <-kept; err != nil {
+		t.Errorf("Delete(b) = %v, want nil", err)
+	}
+	if batches := f.sortedBatches(); len(batches) != 1 || len(batches[0]) != 1 || batches[0][0] != "b" {
+		t.Errorf("batches = %v, want only [b] sent", batches)
+	}
+}
+
+func TestDeleteCoalescerCancelAllBeforeSend(t *testing.T) {
+	c := NewDeleteCoalescer(50 * time.Millisecond)
+	f := &fakeBulkDelete{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- c.Delete(ctx, "things", 50, "a", f.fn) }()
+	waitForPending(t, c, "things", 1)
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled Delete = %v, want context.Canceled", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if batches := f.sortedBatches(); len(batches) != 0 {
+		t.Errorf("batches = %v, want no request", batches)
+	}
+}
+
+func TestDeleteCoalescerCancelAfterSend(t *testing.T) {
+	c := NewDeleteCoalescer(10 * time.Millisecond)
+	f := &fakeBulkDelete{
+		results: map[string]error{"a": ErrBulkDeleteNotFound},
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- c.Delete(ctx, "things", 50, "a", f.fn) }()
+
+	<-f.started
+	cancel()
+	select {
+	case err := <-done:
+		t.Fatalf("Delete returned %v while its request was in flight", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(f.release)
+
+	if err := <-done; !errors.Is(err, ErrBulkDeleteNotFound) {
+		t.Errorf("Delete = %v, want the request's own result", err)
+	}
+}
@@ -0,0 +1,235 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// FilterKind says how a typed filter attribute is declared in the schema and
+// how its value is written into a filter expression.
+type FilterKind int
+
+const (
+	// FilterKindString is a string, written double-quoted.
+	FilterKindString FilterKind = iota
+	// FilterKindBool is a bool, written as true/false.
+	FilterKindBool
+	// FilterKindStringSet is a set of strings, written as ("a", "b") for the
+	// "in" operator.
+	FilterKindStringSet
+	// FilterKindTimestamp is an RFC 3339 timestamp, validated and written
+	// unquoted.
+	FilterKindTimestamp
+)
+
+// FilterField is one attribute of a list data source's typed "filter" block
+// and the filter expression term it compiles to - Property Operator value,
+// in the V3 standard collection parameter syntax. A field with an empty
+// Property is declared in the block but left out of the expression, for
+// values the data source sends some other way (a query parameter, or a
+// match it applies to the results itself).
+type FilterField struct {
+	Attribute   string
+	Property    string
+	Operator    string
+	Kind        FilterKind
+	Description string
+}
+
+// FilterAttribute returns the optional "filter" block for fields, plus any
+// extra attributes the data source handles itself. Each field's description
+// is completed with the term it compiles to.
+func FilterAttribute(description string, fields []FilterField, extra map[string]schema.Attribute) schema.SingleNestedAttribute {
+	attrs := make(map[string]schema.Attribute, len(fields)+len(extra))
+	for _, f := range fields {
+		desc := filterFieldDescription(f)
+		switch f.Kind {
+		case FilterKindBool:
+			attrs[f.Attribute] = schema.BoolAttribute{Optional: true, MarkdownDescription: desc}
+		case FilterKindStringSet:
+			attrs[f.Attribute] = schema.SetAttribute{ElementType: types.StringType, Optional: true, MarkdownDescription: desc}
+		default:
+			attrs[f.Attribute] = schema.StringAttribute{Optional: true, MarkdownDescription: desc}
+		}
+	}
+	for k, v := range extra {
+		attrs[k] = v
+	}
+	return schema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: description,
+		Attributes:          attrs,
+	}
+}
+
+// FilterResourceAttribute is FilterAttribute for resources that select the
+// objects they act on with the same criteria.
+func FilterResourceAttribute(description string, fields []FilterField) resourceschema.SingleNestedAttribute {
+	attrs := make(map[string]resourceschema.Attribute, len(fields))
+	for _, f := range fields {
+		desc := filterFieldDescription(f)
+		switch f.Kind {
+		case FilterKindBool:
+			attrs[f.Attribute] = resourceschema.BoolAttribute{Optional: true, MarkdownDescription: desc}
+		case FilterKindStringSet:
+			attrs[f.Attribute] = resourceschema.SetAttribute{ElementType: types.StringType, Optional: true, MarkdownDescription: desc}
+		default:
+			attrs[f.Attribute] = resourceschema.StringAttribute{Optional: true, MarkdownDescription: desc}
+		}
+	}
+	return resourceschema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: description,
+		Attributes:          attrs,
+	}
+}
+
+// BuildFilterExpression compiles the set attributes of a "filter" block into
+// one expression, AND-joining the terms in attribute name order so the
+// result is stable. A null block, or one with nothing set, compiles to "".
+func BuildFilterExpression(block types.Object, blockPath path.Path, fields []FilterField) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if block.IsNull() || block.IsUnknown() {
+		return "", diags
+	}
+	values := block.Attributes()
+
+	sorted := append([]FilterField(nil), fields...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Attribute < sorted[j].Attribute })
+
+	var terms []string
+	for _, f := range sorted {
+		if f.Property == "" {
+			continue
+		}
+		v, ok := values[f.Attribute]
+		if !ok || v.IsNull() || v.IsUnknown() {
+			continue
+		}
+		value, err := filterValue(f.Kind, v)
+		if err != nil {
+			diags.AddAttributeError(blockPath.AtName(f.Attribute), "Invalid filter value", err.Error())
+			continue
+		}
+		if value == "" {
+			continue
+		}
+		terms = append(terms, fmt.Sprintf("%s %s %s", f.Property, f.Operator, value))
+	}
+	return strings.Join(terms, " and "), diags
+}
+
+// FilterBlockString returns a string attribute of a "filter" block, and
+// whether it was set.
+func FilterBlockString(block types.Object, attribute string) (string, bool) {
+	if block.IsNull() || block.IsUnknown() {
+		return "", false
+	}
+	s, ok := block.Attributes()[attribute].(types.String)
+	if !ok || s.IsNull() || s.IsUnknown() {
+		return "", false
+	}
+	return s.ValueString(), true
+}
+
+// FilterBlockStrings returns a set-of-strings attribute of a "filter" block,
+// sorted; nil when unset.
+func FilterBlockStrings(ctx context.Context, block types.Object, attribute string) ([]string, diag.Diagnostics) {
+	if block.IsNull() || block.IsUnknown() {
+		return nil, nil
+	}
+	set, ok := block.Attributes()[attribute].(types.Set)
+	if !ok || set.IsNull() || set.IsUnknown() {
+		return nil, nil
+	}
+	var out []string
+	diags := set.ElementsAs(ctx, &out, false)
+	sort.Strings(out)
+	return out, diags
+}
+
+// FilterNameContains reports whether name contains substr, ignoring case -
+// the match list data sources apply themselves for "name_contains", since
+// none of these endpoints support the "co" operator on name.
+func FilterNameContains(name, substr string) bool {
+	return strings.Contains(strings.ToLower(name), strings.ToLower(substr))
+}
+
+// FilterQuote writes s as a double-quoted filter string literal.
+func FilterQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func filterValue(kind FilterKind, v attr.Value) (string, error) {
+	switch kind {
+	case FilterKindBool:
+		b, ok := v.(types.Bool)
+		if !ok {
+			return "", fmt.Errorf("expected a bool, got %T", v)
+		}
+		return fmt.Sprintf("%t", b.ValueBool()), nil
+	case FilterKindStringSet:
+		set, ok := v.(types.Set)
+		if !ok {
+			return "", fmt.Errorf("expected a set of strings, got %T", v)
+		}
+		var items []string
+		for _, e := range set.Elements() {
+			s, ok := e.(types.String)
+			if !ok || s.IsNull() || s.IsUnknown() {
+				continue
+			}
+			items = append(items, FilterQuote(s.ValueString()))
+		}
+		if len(items) == 0 {
+			return "", nil
+		}
+		sort.Strings(items)
+		return "(" + strings.Join(items, ", ") + ")", nil
+	case FilterKindTimestamp:
+		s, ok := v.(types.String)
+		if !ok {
+			return "", fmt.Errorf("expected a string, got %T", v)
+		}
+		if _, err := time.Parse(time.RFC3339, s.ValueString()); err != nil {
+			return "", fmt.Errorf("%q is not an RFC 3339 timestamp (e.g. 2024-01-31T00:00:00Z): %s", s.ValueString(), err.Error())
+		}
+		return s.ValueString(), nil
+	default:
+		s, ok := v.(types.String)
+		if !ok {
+			return "", fmt.Errorf("expected a string, got %T", v)
+		}
+		return FilterQuote(s.ValueString()), nil
+	}
+}
+
+func filterFieldDescription(f FilterField) string {
+	if f.Property == "" {
+		return f.Description
+	}
+	return f.Description + fmt.Sprintf(" Compiles to `%s %s %s`.", f.Property, f.Operator, filterPlaceholder(f.Kind))
+}
+
+func filterPlaceholder(kind FilterKind) string {
+	switch kind {
+	case FilterKindBool:
+		return "true|false"
+	case FilterKindStringSet:
+		return `("<value>", ...)`
+	case FilterKindTimestamp:
+		return "<timestamp>"
+	default:
+		return `"<value>"`
+	}
+}
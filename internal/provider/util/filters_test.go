@@ -0,0 +1,305 @@
+package util
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var testFilterFields = []FilterField{
+	{Attribute: "name", Property: "name", Operator: "eq", Kind: FilterKindString, Description: "Exact name."},
+	{Attribute: "enabled", Property: "enabled", Operator: "eq", Kind: FilterKindBool, Description: "Enabled state."},
+	{Attribute: "ids", Property: "id", Operator: "in", Kind: FilterKindStringSet, Description: "IDs."},
+	{Attribute: "created_after", Property: "created", Operator: "gt", Kind: FilterKindTimestamp, Description: "Created after."},
+	{Attribute: "name_contains", Kind: FilterKindString, Description: "Matched by the data source."},
+}
+
+var testFilterAttrTypes = map[string]attr.Type{
+	"name":          types.StringType,
+	"enabled":       types.BoolType,
+	"ids":           types.SetType{ElemType: types.StringType},
+	"created_after": types.StringType,
+	"name_contains": types.StringType,
+}
+
+// testFilterBlock builds a "filter" block value with the given attributes
+// set and the rest null.
+func testFilterBlock(set map[string]attr.Value) types.Object {
+	values := make(map[string]attr.Value, len(testFilterAttrTypes))
+	for name, typ := range testFilterAttrTypes {
+		if v, ok := set[name]; ok {
+			values[name] = v
+			continue
+		}
+		switch {
+		case typ.Equal(types.BoolType):
+			values[name] = types.BoolNull()
+		case typ.Equal(types.StringType):
+			values[name] = types.StringNull()
+		default:
+			values[name] = types.SetNull(types.StringType)
+		}
+	}
+	return types.ObjectValueMust(testFilterAttrTypes, values)
+}
+
+func testStringSet(values ...string) types.Set {
+	elems := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elems = append(elems, types.StringValue(v))
+	}
+	return types.SetValueMust(types.StringType, elems)
+}
+
+func TestFilterQuote(t *testing.T) {
+	tests := map[string]string{
+		"":              `""`,
+		"Engineering":   `"Engineering"`,
+		`Say "hi"`:      `"Say \"hi\""`,
+		`C:\temp`:       `"C:\\temp"`,
+		`a\"b`:          `"a\\\"b"`,
+		`trailing\`:     `"trailing\\"`,
+		"unicode ü é":   `"unicode ü é"`,
+		`"already"`:     `"\"already\""`,
+		`\\ two back`:   `"\\\\ two back"`,
+		`mixed \ and "`: `"mixed \\ and \""`,
+	}
+	for in, want := range tests {
+		if got := FilterQuote(in); got != want {
+			t.Errorf("FilterQuote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestBuildFilterExpression(t *testing.T) {
+	tests := []struct {
+		name  string
+		block types.Object
+		want  string
+	}{
+		{name: "null block", block: types.ObjectNull(testFilterAttrTypes), want: ""},
+		{name: "unknown block", block: types.ObjectUnknown(testFilterAttrTypes), want: ""},
+		{name: "nothing set", block: testFilterBlock(nil), want: ""},
+		{
+			name:  "string",
+			block: testFilterBlock(map[string]attr.Value{"name": types.StringValue(`Say "hi" \o/`)}),
+			want:  `name eq "Say \"hi\" \\o/"`,
+		},
+		{
+			name:  "bool",
+			block: testFilterBlock(map[string]attr.Value{"enabled": types.BoolValue(false)}),
+			want:  "enabled eq false",
+		},
+		{
+			name:  "string set is sorted and quoted",
+			block: testFilterBlock(map[string]attr.Value{"ids": testStringSet("b", `a"1`)}),
+			want:  `id in ("a\"1", "b")`,
+		},
+		{
+			name:  "empty string set is left out",
+			block: testFilterBlock(map[string]attr.Value{"ids": testStringSet()}),
+			want:  "",
+		},
+		{
+			name:  "timestamp is unquoted",
+			block: testFilterBlock(map[string]attr.Value{"created_after": types.StringValue("2024-01-31T00:00:00Z")}),
+			want:  "created gt 2024-01-31T00:00:00Z",
+		},
+		{
+			name:  "field without a property is left out",
+			block: testFilterBlock(map[string]attr.Value{"name_contains": types.StringValue("eng")}),
+			want:  "",
+		},
+		{
+			name:  "unknown value is left out",
+			block: testFilterBlock(map[string]attr.Value{"name": types.StringUnknown(), "enabled": types.BoolValue(true)}),
+			want:  "enabled eq true",
+		},
+		{
+			name: "terms are joined in attribute name order",
+			block: testFilterBlock(map[string]attr.Value{
+				"name":          types.StringValue("x"),
+				"enabled":       types.BoolValue(true),
+				"ids":           testStringSet("1"),
+				"created_after": types.StringValue("2024-01-31T00:00:00Z"),
+			}),
+			want: `created gt 2024-01-31T00:00:00Z and enabled eq true and id in ("1") and name eq "x"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := BuildFilterExpression(tt.block, path.Root("filter"), testFilterFields)
+			if diags.HasError() {
+				t.Fatalf("diagnostics: %v", diags)
+			}
+			if got != tt.want {
+				t.Errorf("BuildFilterExpression = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildFilterExpression_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		block    types.Object
+		fields   []FilterField
+		wantPath path.Path
+		want     string
+	}{
+		{
+			name:     "timestamp that isn't RFC 3339",
+			block:    testFilterBlock(map[string]attr.Value{"created_after": types.StringValue("2024-01-31")}),
+			fields:   testFilterFields,
+			wantPath: path.Root("filter").AtName("created_after"),
+			want:     `"2024-01-31" is not an RFC 3339 timestamp`,
+		},
+		{
+			name:     "bool kind on a string attribute",
+			block:    testFilterBlock(map[string]attr.Value{"name": types.StringValue("x")}),
+			fields:   []FilterField{{Attribute: "name", Property: "name", Operator: "eq", Kind: FilterKindBool}},
+			wantPath: path.Root("filter").AtName("name"),
+			want:     "expected a bool",
+		},
+		{
+			name:     "string set kind on a string attribute",
+			block:    testFilterBlock(map[string]attr.Value{"name": types.StringValue("x")}),
+			fields:   []FilterField{{Attribute: "name", Property: "name", Operator: "in", Kind: FilterKindStringSet}},
+			wantPath: path.Root("filter").AtName("name"),
+			want:     "expected a set of strings",
+		},
+		{
+			name:     "timestamp kind on a bool attribute",
+			block:    testFilterBlock(map[string]attr.Value{"enabled": types.BoolValue(true)}),
+			fields:   []FilterField{{Attribute: "enabled", Property: "created", Operator: "gt", Kind: FilterKindTimestamp}},
+			wantPath: path.Root("filter").AtName("enabled"),
+			want:     "expected a string",
+		},
+		{
+			name:     "string kind on a bool attribute",
+			block:    testFilterBlock(map[string]attr.Value{"enabled": types.BoolValue(true)}),
+			fields:   []FilterField{{Attribute: "enabled", Property: "enabled", Operator: "eq", Kind: FilterKindString}},
+			wantPath: path.Root("filter").AtName("enabled"),
+			want:     "expected a string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := BuildFilterExpression(tt.block, path.Root("filter"), tt.fields)
+			if got != "" {
+				t.Errorf("BuildFilterExpression = %q, want the invalid term left out", got)
+			}
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("diagnostics = %v, want one error", diags)
+			}
+			d, ok := diags[0].(interface{ Path() path.Path })
+			if !ok || !d.Path().Equal(tt.wantPath) {
+				t.Errorf("diagnostic %v is not on %s", diags[0], tt.wantPath)
+			}
+			if diags[0].Summary() != "Invalid filter value" || !strings.Contains(diags[0].Detail(), tt.want) {
+				t.Errorf("diagnostic = %q: %q, want Invalid filter value: ...%s...", diags[0].Summary(), diags[0].Detail(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterAttribute(t *testing.T) {
+	extra := map[string]schema.Attribute{"type": schema.StringAttribute{Optional: true}}
+	a := FilterAttribute("Filters.", testFilterFields, extra)
+
+	if !a.Optional || a.MarkdownDescription != "Filters." {
+		t.Errorf("FilterAttribute = Optional %v, %q, want an optional block described as Filters.", a.Optional, a.MarkdownDescription)
+	}
+	if len(a.Attributes) != len(testFilterFields)+len(extra) {
+		t.Errorf("FilterAttribute has %d attributes, want %d", len(a.Attributes), len(testFilterFields)+len(extra))
+	}
+	if _, ok := a.Attributes["enabled"].(schema.BoolAttribute); !ok {
+		t.Errorf("enabled is a %T, want schema.BoolAttribute", a.Attributes["enabled"])
+	}
+	if _, ok := a.Attributes["ids"].(schema.SetAttribute); !ok {
+		t.Errorf("ids is a %T, want schema.SetAttribute", a.Attributes["ids"])
+	}
+	for _, name := range []string{"name", "created_after", "name_contains", "type"} {
+		if _, ok := a.Attributes[name].(schema.StringAttribute); !ok {
+			t.Errorf("%s is a %T, want schema.StringAttribute", name, a.Attributes[name])
+		}
+	}
+
+	descriptions := map[string]string{
+		"name":          "Exact name. Compiles to `name eq \"<value>\"`.",
+		"enabled":       "Enabled state. Compiles to `enabled eq true|false`.",
+		"ids":           "IDs. Compiles to `id in (\"<value>\", ...)`.",
+		"created_after": "Created after. Compiles to `created gt <timestamp>`.",
+		"name_contains": "Matched by the data source.",
+	}
+	for name, want := range descriptions {
+		if got := a.Attributes[name].GetMarkdownDescription(); got != want {
+			t.Errorf("%s description = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestFilterResourceAttribute(t *testing.T) {
+	a := FilterResourceAttribute("Filters.", testFilterFields)
+	if len(a.Attributes) != len(testFilterFields) {
+		t.Errorf("FilterResourceAttribute has %d attributes, want %d", len(a.Attributes), len(testFilterFields))
+	}
+	if !a.Attributes["ids"].GetType().Equal(types.SetType{ElemType: types.StringType}) {
+		t.Errorf("ids type = %s, want a set of strings", a.Attributes["ids"].GetType())
+	}
+	if !a.Attributes["enabled"].GetType().Equal(types.BoolType) {
+		t.Errorf("enabled type = %s, want bool", a.Attributes["enabled"].GetType())
+	}
+}
+
+func TestFilterBlockString(t *testing.T) {
+	block := testFilterBlock(map[string]attr.Value{"name_contains": types.StringValue("eng")})
+
+	if got, ok := FilterBlockString(block, "name_contains"); !ok || got != "eng" {
+		t.Errorf("FilterBlockString(name_contains) = (%q, %v), want (eng, true)", got, ok)
+	}
+	if _, ok := FilterBlockString(block, "name"); ok {
+		t.Error("FilterBlockString(null name) reported it set")
+	}
+	if _, ok := FilterBlockString(block, "enabled"); ok {
+		t.Error("FilterBlockString(bool attribute) reported it set")
+	}
+	if _, ok := FilterBlockString(types.ObjectNull(testFilterAttrTypes), "name"); ok {
+		t.Error("FilterBlockString(null block) reported it set")
+	}
+}
+
+func TestFilterBlockStrings(t *testing.T) {
+	ctx := context.Background()
+	block := testFilterBlock(map[string]attr.Value{"ids": testStringSet("c", "a", "b")})
+
+	got, diags := FilterBlockStrings(ctx, block, "ids")
+	if diags.HasError() {
+		t.Fatalf("diagnostics: %v", diags)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterBlockStrings = %v, want %v", got, want)
+	}
+
+	if got, _ := FilterBlockStrings(ctx, testFilterBlock(nil), "ids"); got != nil {
+		t.Errorf("FilterBlockStrings(null set) = %v, want nil", got)
+	}
+	if got, _ := FilterBlockStrings(ctx, types.ObjectNull(testFilterAttrTypes), "ids"); got != nil {
+		t.Errorf("FilterBlockStrings(null block) = %v, want nil", got)
+	}
+}
+
+func TestFilterNameContains(t *testing.T) {
+	if !FilterNameContains("Engineering Admins", "ENG") {
+		t.Error("FilterNameContains is case sensitive")
+	}
+	if FilterNameContains("Sales", "eng") {
+		t.Error("FilterNameContains(Sales, eng) = true")
+	}
+}
@@ -0,0 +1,28 @@
+package util
+
+// DiffIds returns the ids present in desired but not current (toAdd), and
+// present in current but not desired (toRemove), each in the order it
+// appears in its input - the reconciliation behind every resource that adds
+// and removes individual members of a list it doesn't own outright.
+func DiffIds(current, desired []string) (toAdd, toRemove []string) {
+	currentSet := make(map[string]struct{}, len(current))
+	for _, id := range current {
+		currentSet[id] = struct{}{}
+	}
+	desiredSet := make(map[string]struct{}, len(desired))
+	for _, id := range desired {
+		desiredSet[id] = struct{}{}
+	}
+
+	for _, id := range desired {
+		if _, ok := currentSet[id]; !ok {
+			toAdd = append(toAdd, id)
+		}
+	}
+	for _, id := range current {
+		if _, ok := desiredSet[id]; !ok {
+			toRemove = append(toRemove, id)
+		}
+	}
+	return toAdd, toRemove
+}
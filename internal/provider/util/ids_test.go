@@ -0,0 +1,23 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffIds(t *testing.T) {
+	toAdd, toRemove := DiffIds([]string{"a", "b", "c"}, []string{"b", "c", "d", "e"})
+	if !reflect.DeepEqual(toAdd, []string{"d", "e"}) || !reflect.DeepEqual(toRemove, []string{"a"}) {
+		t.Errorf("DiffIds = (%v, %v), want ([d e], [a])", toAdd, toRemove)
+	}
+
+	toAdd, toRemove = DiffIds(nil, []string{"a"})
+	if !reflect.DeepEqual(toAdd, []string{"a"}) || toRemove != nil {
+		t.Errorf("DiffIds(nil, [a]) = (%v, %v), want ([a], nil)", toAdd, toRemove)
+	}
+
+	toAdd, toRemove = DiffIds([]string{"a"}, []string{"a"})
+	if len(toAdd) != 0 || len(toRemove) != 0 {
+		t.Errorf("DiffIds(same) = (%v, %v), want no changes", toAdd, toRemove)
+	}
+}
@@ -0,0 +1,47 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// SortedKeys returns m's keys in sorted order, so that plan-time validators
+// walking decoded JSON report problems in a stable order.
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ContainsString reports whether s is one of values.
+func ContainsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// DescribeJSONValue renders a decoded JSON value for a validation problem
+// message: scalars as JSON, lists and objects by kind since they may be
+// large.
+func DescribeJSONValue(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "nothing"
+	case []interface{}:
+		return "a list"
+	case map[string]interface{}:
+		return "an object"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
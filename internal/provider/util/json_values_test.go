@@ -0,0 +1,45 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortedKeys(t *testing.T) {
+	got := SortedKeys(map[string]interface{}{"b": 1, "a": nil, "c": "x"})
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortedKeys = %v, want %v", got, want)
+	}
+	if got := SortedKeys(map[string]int(nil)); len(got) != 0 {
+		t.Errorf("SortedKeys(nil) = %v, want none", got)
+	}
+}
+
+func TestContainsString(t *testing.T) {
+	values := []string{"and", "or"}
+	if !ContainsString(values, "or") {
+		t.Error("ContainsString(or) = false, want true")
+	}
+	if ContainsString(values, "OR") || ContainsString(nil, "or") {
+		t.Error("ContainsString matched a value not in the list")
+	}
+}
+
+func TestDescribeJSONValue(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{nil, "nothing"},
+		{"eq", `"eq"`},
+		{float64(3), "3"},
+		{true, "true"},
+		{[]interface{}{"a"}, "a list"},
+		{map[string]interface{}{"a": "b"}, "an object"},
+	}
+	for _, tt := range tests {
+		if got := DescribeJSONValue(tt.value); got != tt.want {
+			t.Errorf("DescribeJSONValue(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package util
+
+import "net/http"
+
+// ListAllPages reads every page of a list endpoint, pageSize items at a
+// time, for data sources that match results themselves (such as
+// "name_contains") and so have to see all of them before applying the
+// practitioner's offset and limit. page returns the items at offset; the
+// first short page ends the listing. On error, the failing page's response
+// is returned for the error detail.
+func ListAllPages[T any](pageSize int32, page func(offset, limit int32) ([]T, *http.Response, error)) ([]T, *http.Response, error) {
+	var all []T
+	var offset int32
+	for {
+		items, httpResp, err := page(offset, pageSize)
+		if err != nil {
+			return nil, httpResp, err
+		}
+		all = append(all, items...)
+		if int32(len(items)) < pageSize {
+			return all, httpResp, nil
+		}
+		offset += pageSize
+	}
+}
+
+// PageSlice applies offset and then limit to items a data source listed
+// with ListAllPages and matched itself. A nil offset or limit is not
+// applied.
+func PageSlice[T any](items []T, offset, limit *int32) []T {
+	if offset != nil && *offset > 0 {
+		if int(*offset) >= len(items) {
+			return items[:0]
+		}
+		items = items[*offset:]
+	}
+	if limit != nil && *limit >= 0 && len(items) > int(*limit) {
+		items = items[:*limit]
+	}
+	return items
+}
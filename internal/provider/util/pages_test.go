@@ -0,0 +1,79 @@
+package util
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestListAllPages(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	var offsets []int32
+	page := func(offset, limit int32) ([]int, *http.Response, error) {
+		offsets = append(offsets, offset)
+		end := min(int(offset+limit), len(items))
+		return items[offset:end], &http.Response{StatusCode: http.StatusOK}, nil
+	}
+
+	got, _, err := ListAllPages(2, page)
+	if err != nil {
+		t.Fatalf("ListAllPages returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, items) {
+		t.Errorf("ListAllPages = %v, want %v", got, items)
+	}
+	if want := []int32{0, 2, 4}; !reflect.DeepEqual(offsets, want) {
+		t.Errorf("offsets = %v, want %v", offsets, want)
+	}
+
+	// A full last page needs one more, empty, page to end the listing.
+	offsets = nil
+	items = items[:4]
+	if got, _, _ := ListAllPages(2, page); len(got) != 4 || len(offsets) != 3 {
+		t.Errorf("ListAllPages = %v after offsets %v, want 4 items after 3 pages", got, offsets)
+	}
+}
+
+func TestListAllPagesError(t *testing.T) {
+	errDown := errors.New("service unavailable")
+	page := func(offset, limit int32) ([]int, *http.Response, error) {
+		if offset > 0 {
+			return nil, &http.Response{StatusCode: http.StatusServiceUnavailable}, errDown
+		}
+		return []int{1, 2}, &http.Response{StatusCode: http.StatusOK}, nil
+	}
+
+	got, httpResp, err := ListAllPages(2, page)
+	if !errors.Is(err, errDown) || got != nil {
+		t.Errorf("ListAllPages = (%v, %v), want (nil, %v)", got, err, errDown)
+	}
+	if httpResp == nil || httpResp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("ListAllPages response = %v, want the failing page's", httpResp)
+	}
+}
+
+func TestPageSlice(t *testing.T) {
+	ptr := func(v int32) *int32 { return &v }
+	items := []string{"a", "b", "c", "d"}
+
+	tests := []struct {
+		name          string
+		offset, limit *int32
+		want          []string
+	}{
+		{"neither", nil, nil, items},
+		{"offset", ptr(1), nil, []string{"b", "c", "d"}},
+		{"limit", nil, ptr(2), []string{"a", "b"}},
+		{"both", ptr(1), ptr(2), []string{"b", "c"}},
+		{"limit past end", ptr(3), ptr(5), []string{"d"}},
+		{"offset past end", ptr(4), nil, []string{}},
+		{"zero limit", nil, ptr(0), []string{}},
+	}
+	for _, tt := range tests {
+		got := PageSlice(append([]string(nil), items...), tt.offset, tt.limit)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: PageSlice = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
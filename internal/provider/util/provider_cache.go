@@ -0,0 +1,59 @@
+package util
+
+import (
+	"context"
+	"sync"
+)
+
+// ProviderCache holds read-only lookups that don't change for the life of
+// a provider instance - the workflow library's action/trigger/operator
+// catalogs, for example - so every resource and data source that needs one
+// shares a single fetch instead of repeating it per object. Only successful
+// fetches are kept: after an error the next Get tries again.
+//
+// One ProviderCache is shared by every resource and data source of a
+// provider instance.
+type ProviderCache struct {
+	mu      sync.Mutex
+	entries map[string]*providerCacheEntry
+}
+
+type providerCacheEntry struct {
+	mu    sync.Mutex
+	done  bool
+	value interface{}
+}
+
+func NewProviderCache() *ProviderCache {
+	return &ProviderCache{entries: map[string]*providerCacheEntry{}}
+}
+
+// Get returns key's cached value, calling fetch to load it the first time.
+// Concurrent Gets for the same key wait for one fetch rather than racing.
+// A nil ProviderCache (e.g. a resource configured without a provider)
+// simply calls fetch.
+func (c *ProviderCache) Get(ctx context.Context, key string, fetch func(context.Context) (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return fetch(ctx)
+	}
+
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if !ok {
+		e = &providerCacheEntry{}
+		c.entries[key] = e
+	}
+	c.mu.Unlock()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.done {
+		return e.value, nil
+	}
+	v, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e.value, e.done = v, true
+	return v, nil
+}
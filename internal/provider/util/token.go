@@ -0,0 +1,134 @@
+package util
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+)
+
+// bearerTokenExpiryMargin is how long before its expiry a cached token is
+// already treated as expired, so a request isn't sent with a token that
+// lapses in flight.
+const bearerTokenExpiryMargin = time.Minute
+
+var (
+	bearerTokenMu sync.Mutex
+	// bearerTokens records, per provider configuration, the last token
+	// fetched here and when it expires.
+	bearerTokens = map[*sailpoint.Configuration]fetchedBearerToken{}
+)
+
+type fetchedBearerToken struct {
+	token  string
+	expiry time.Time
+}
+
+// BearerToken returns a bearer token for hand-rolled requests against
+// endpoints golang-sdk has no generated call for (see role_v1's dimensions
+// client). It reuses cfg's cached ClientConfiguration.Token - whether
+// fetched here or by a generated SDK call - while it is still valid, and
+// otherwise fetches and caches a fresh one with the OAuth2
+// client-credentials flow, the same request the SDK's own unexported
+// getAccessToken sends.
+//
+// Concurrent BearerToken calls are serialized on a package mutex, so only
+// one of them fetches a new token. The mutex does not cover the SDK's own
+// writes to cfg.ClientConfiguration.Token from generated calls.
+func BearerToken(ctx context.Context, cfg *sailpoint.Configuration) (string, error) {
+	bearerTokenMu.Lock()
+	defer bearerTokenMu.Unlock()
+
+	cc := &cfg.ClientConfiguration
+	if cc.Token != "" && bearerTokenValid(cfg, cc.Token, time.Now()) {
+		return cc.Token, nil
+	}
+	if cc.ClientId == "" || cc.ClientSecret == "" || cc.TokenURL == "" {
+		if cc.Token != "" {
+			// Nothing to refresh it with; let the API decide.
+			return cc.Token, nil
+		}
+		return "", fmt.Errorf("no cached token and no client credentials available to fetch one")
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {cc.ClientId},
+		"client_secret": {cc.ClientSecret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cc.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var tok struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &tok); err != nil {
+		return "", fmt.Errorf("could not parse token response: %w", err)
+	}
+	if tok.AccessToken == "" {
+		return "", fmt.Errorf("token endpoint returned an empty access_token (status %s)", resp.Status)
+	}
+
+	cc.Token = tok.AccessToken
+	if tok.ExpiresIn > 0 {
+		bearerTokens[cfg] = fetchedBearerToken{token: tok.AccessToken, expiry: time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second)}
+	} else {
+		delete(bearerTokens, cfg)
+	}
+	return cc.Token, nil
+}
+
+// bearerTokenValid reports whether token can still be used at now. Its
+// expiry is the one recorded when BearerToken fetched it, or else the JWT's
+// own "exp" claim; a token with neither is not trusted, so it gets
+// replaced.
+func bearerTokenValid(cfg *sailpoint.Configuration, token string, now time.Time) bool {
+	fetched, ok := bearerTokens[cfg]
+	expiry := fetched.expiry
+	if !ok || fetched.token != token {
+		expiry, ok = jwtExpiry(token)
+	}
+	return ok && now.Add(bearerTokenExpiryMargin).Before(expiry)
+}
+
+// jwtExpiry returns the "exp" claim of a JWT, without verifying it.
+func jwtExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Exp, 0), true
+}
@@ -0,0 +1,159 @@
+package util
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+)
+
+// tokenServer is a fake client-credentials token endpoint handing out
+// "token-1", "token-2", ... valid for expiresIn seconds.
+func tokenServer(t *testing.T, expiresIn int) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != "client_credentials" ||
+			r.Form.Get("client_id") != "id" || r.Form.Get("client_secret") != "secret" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		n := atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d}`, n, expiresIn)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func tokenConfig(tokenURL string) *sailpoint.Configuration {
+	cfg := &sailpoint.Configuration{}
+	cfg.ClientConfiguration.ClientId = "id"
+	cfg.ClientConfiguration.ClientSecret = "secret"
+	cfg.ClientConfiguration.TokenURL = tokenURL
+	return cfg
+}
+
+func testJWT(exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp.Unix())))
+	return "e30." + payload + ".sig"
+}
+
+func TestBearerTokenFetchesAndCaches(t *testing.T) {
+	srv, calls := tokenServer(t, 3600)
+	cfg := tokenConfig(srv.URL)
+
+	for i := 0; i < 2; i++ {
+		got, err := BearerToken(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("BearerToken: %v", err)
+		}
+		if got != "token-1" {
+			t.Errorf("BearerToken = %q, want token-1", got)
+		}
+	}
+	if *calls != 1 {
+		t.Errorf("token endpoint called %d times, want 1", *calls)
+	}
+	if cfg.ClientConfiguration.Token != "token-1" {
+		t.Errorf("cfg Token = %q, want token-1", cfg.ClientConfiguration.Token)
+	}
+}
+
+func TestBearerTokenRefreshesExpired(t *testing.T) {
+	// expires_in below bearerTokenExpiryMargin: already stale once fetched.
+	srv, calls := tokenServer(t, 30)
+	cfg := tokenConfig(srv.URL)
+
+	first, err := BearerToken(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("BearerToken: %v", err)
+	}
+	second, err := BearerToken(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("BearerToken: %v", err)
+	}
+	if first != "token-1" || second != "token-2" || *calls != 2 {
+		t.Errorf("BearerToken = %q then %q after %d fetches, want token-1 then token-2 after 2", first, second, *calls)
+	}
+}
+
+func TestBearerTokenReusesValidJWT(t *testing.T) {
+	srv, calls := tokenServer(t, 3600)
+	cfg := tokenConfig(srv.URL)
+	jwt := testJWT(time.Now().Add(time.Hour))
+	cfg.ClientConfiguration.Token = jwt
+
+	got, err := BearerToken(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("BearerToken: %v", err)
+	}
+	if got != jwt || *calls != 0 {
+		t.Errorf("BearerToken = %q after %d fetches, want the cached JWT and no fetch", got, *calls)
+	}
+}
+
+func TestBearerTokenReplacesExpiredJWT(t *testing.T) {
+	srv, calls := tokenServer(t, 3600)
+	cfg := tokenConfig(srv.URL)
+	cfg.ClientConfiguration.Token = testJWT(time.Now().Add(-time.Minute))
+
+	got, err := BearerToken(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("BearerToken: %v", err)
+	}
+	if got != "token-1" || *calls != 1 {
+		t.Errorf("BearerToken = %q after %d fetches, want token-1 after 1", got, *calls)
+	}
+}
+
+func TestBearerTokenConcurrentCallersFetchOnce(t *testing.T) {
+	srv, calls := tokenServer(t, 3600)
+	cfg := tokenConfig(srv.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got, err := BearerToken(context.Background(), cfg); err != nil || got != "token-1" {
+				t.Errorf("BearerToken = %q, %v, want token-1", got, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if *calls != 1 {
+		t.Errorf("token endpoint called %d times, want 1", *calls)
+	}
+}
+
+func TestBearerTokenWithoutCredentials(t *testing.T) {
+	cfg := &sailpoint.Configuration{}
+	if _, err := BearerToken(context.Background(), cfg); err == nil {
+		t.Error("BearerToken with no token or credentials: want error")
+	}
+
+	cfg.ClientConfiguration.Token = "opaque"
+	got, err := BearerToken(context.Background(), cfg)
+	if err != nil || got != "opaque" {
+		t.Errorf("BearerToken = %q, %v, want the cached token when it can't be refreshed", got, err)
+	}
+}
+
+func TestBearerTokenEndpointError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	if _, err := BearerToken(context.Background(), tokenConfig(srv.URL)); err == nil {
+		t.Error("BearerToken against a failing endpoint: want error")
+	}
+}
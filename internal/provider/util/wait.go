@@ -0,0 +1,135 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/task_management"
+)
+
+// Polling cadence for resources that wait on asynchronous server-side work.
+const (
+	InitialPollInterval = 2 * time.Second
+	MaxPollInterval     = 15 * time.Second
+)
+
+// ParseWaitTimeout parses a hand-rolled Optional+Computed duration attribute
+// (the same convention as source_load_entitlement_wait_v1's create_timeout),
+// falling back to defaultValue when it is null or unknown.
+func ParseWaitTimeout(name string, v types.String, defaultValue string) (time.Duration, error) {
+	if v.IsNull() || v.IsUnknown() {
+		return time.ParseDuration(defaultValue)
+	}
+
+	d, err := time.ParseDuration(v.ValueString())
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a valid Go duration such as %q: %w", name, defaultValue, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s must be greater than zero", name)
+	}
+	return d, nil
+}
+
+// PollInterval returns how long to wait before poll attempt+1: it starts at
+// InitialPollInterval and doubles up to MaxPollInterval.
+func PollInterval(attempt int) time.Duration {
+	if attempt <= 0 {
+		return InitialPollInterval
+	}
+
+	interval := InitialPollInterval
+	for i := 0; i < attempt && interval < MaxPollInterval; i++ {
+		interval *= 2
+		if interval >= MaxPollInterval {
+			return MaxPollInterval
+		}
+	}
+	return interval
+}
+
+// SleepWithContext waits for d, or returns ctx's error if it is done first.
+func SleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func normalizedCompletionStatus(status task_management.NullableString) string {
+	if !status.IsSet() || status.Get() == nil {
+		return ""
+	}
+	return strings.ToUpper(strings.TrimSpace(*status.Get()))
+}
+
+// IsSuccessfulCompletionStatus reports whether a task's completion status,
+// as returned by TaskCompletionResult, counts as success.
+func IsSuccessfulCompletionStatus(status string) bool {
+	return status == "SUCCESS" || status == "WARNING"
+}
+
+// TaskCompletionResult decides whether a polled task status should be
+// treated as finished, and returns its upper-cased completion status. Live
+// testing showed `completed` and `completionStatus` are not always written
+// atomically, so a status with `completed` set but `completionStatus` still
+// empty is treated as not yet finished.
+func TaskCompletionResult(status *task_management.TaskStatus) (finished bool, completionStatus string) {
+	if status == nil {
+		return false, ""
+	}
+	completionStatus = normalizedCompletionStatus(status.CompletionStatus)
+	if status.Completed.IsSet() && status.Completed.Get() != nil && completionStatus != "" {
+		return true, completionStatus
+	}
+	return false, ""
+}
+
+// WaitForTask polls GET /task-status/v1/{id} until the task completes or
+// timeout passes, and returns its upper-cased completion status (see
+// TaskCompletionResult). The task's progress is logged on every poll.
+func WaitForTask(ctx context.Context, client *sailpoint.APIClient, taskID string, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for attempt := 0; ; attempt++ {
+		status, httpResp, err := client.TaskManagementAPI.GetTaskStatusV1(ctx, taskID).Execute()
+		if err != nil {
+			if ctx.Err() != nil {
+				return "", fmt.Errorf("timed out waiting for task %q: %w", taskID, ctx.Err())
+			}
+			return "", fmt.Errorf("retrieving status of task %q: %s", taskID, SailpointErrorDetail(err, httpResp))
+		}
+		if finished, completionStatus := TaskCompletionResult(status); finished {
+			return completionStatus, nil
+		}
+
+		interval := PollInterval(attempt)
+		fields := map[string]interface{}{
+			"task_id":       taskID,
+			"poll_interval": interval.String(),
+		}
+		if status != nil {
+			if p := status.Progress.Get(); p != nil {
+				fields["progress"] = *p
+			}
+			fields["percent_complete"] = status.GetPercentComplete()
+		}
+		tflog.Debug(ctx, "Waiting for task completion", fields)
+
+		if err := SleepWithContext(ctx, interval); err != nil {
+			return "", fmt.Errorf("timed out waiting for task %q: %w", taskID, err)
+		}
+	}
+}
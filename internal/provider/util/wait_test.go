@@ -0,0 +1,147 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/task_management"
+)
+
+func TestParseWaitTimeout(t *testing.T) {
+	d, err := ParseWaitTimeout("timeout", types.StringNull(), "15m")
+	if err != nil || d != 15*time.Minute {
+		t.Errorf("ParseWaitTimeout(null) = (%v, %v), want (15m, nil)", d, err)
+	}
+
+	d, err = ParseWaitTimeout("timeout", types.StringUnknown(), "15m")
+	if err != nil || d != 15*time.Minute {
+		t.Errorf("ParseWaitTimeout(unknown) = (%v, %v), want (15m, nil)", d, err)
+	}
+
+	d, err = ParseWaitTimeout("timeout", types.StringValue("90s"), "15m")
+	if err != nil || d != 90*time.Second {
+		t.Errorf("ParseWaitTimeout(90s) = (%v, %v), want (90s, nil)", d, err)
+	}
+
+	for _, v := range []string{"soon", "0s", "-1m"} {
+		_, err := ParseWaitTimeout("create_timeout", types.StringValue(v), "15m")
+		if err == nil || !strings.HasPrefix(err.Error(), "create_timeout ") {
+			t.Errorf("ParseWaitTimeout(%q) error = %v, want one naming create_timeout", v, err)
+		}
+	}
+}
+
+func TestPollInterval(t *testing.T) {
+	cases := map[int]time.Duration{
+		-1: InitialPollInterval,
+		0:  InitialPollInterval,
+		1:  4 * time.Second,
+		2:  8 * time.Second,
+		3:  MaxPollInterval,
+		10: MaxPollInterval,
+	}
+	for attempt, want := range cases {
+		if got := PollInterval(attempt); got != want {
+			t.Errorf("PollInterval(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
+
+func TestSleepWithContext(t *testing.T) {
+	if err := SleepWithContext(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("SleepWithContext = %v, want nil", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := SleepWithContext(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("SleepWithContext(cancelled) = %v, want context.Canceled", err)
+	}
+}
+
+func taskStatus(completed bool, completionStatus *string) *task_management.TaskStatus {
+	status := &task_management.TaskStatus{}
+	if completed {
+		now := time.Now()
+		status.Completed = *task_management.NewNullableTime(&now)
+	}
+	if completionStatus != nil {
+		status.CompletionStatus = *task_management.NewNullableString(completionStatus)
+	}
+	return status
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestNormalizedCompletionStatus(t *testing.T) {
+	tests := map[string]struct {
+		in   task_management.NullableString
+		want string
+	}{
+		"unset":               {in: task_management.NullableString{}, want: ""},
+		"success":             {in: *task_management.NewNullableString(strPtr("success")), want: "SUCCESS"},
+		"warning with spaces": {in: *task_management.NewNullableString(strPtr(" warning ")), want: "WARNING"},
+	}
+	for name, tt := range tests {
+		if got := normalizedCompletionStatus(tt.in); got != tt.want {
+			t.Errorf("normalizedCompletionStatus(%s) = %q, want %q", name, got, tt.want)
+		}
+	}
+}
+
+func TestTaskCompletionResult(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       *task_management.TaskStatus
+		wantFinished bool
+		wantStatus   string
+	}{
+		{name: "nil", status: nil},
+		{name: "running", status: taskStatus(false, nil)},
+		{name: "status without completed", status: taskStatus(false, strPtr("SUCCESS"))},
+		{name: "completed without status", status: taskStatus(true, nil)},
+		{name: "completed with empty status", status: taskStatus(true, strPtr("  "))},
+		{name: "success", status: taskStatus(true, strPtr("Success")), wantFinished: true, wantStatus: "SUCCESS"},
+		{name: "error", status: taskStatus(true, strPtr(" error ")), wantFinished: true, wantStatus: "ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finished, completionStatus := TaskCompletionResult(tt.status)
+			if finished != tt.wantFinished || completionStatus != tt.wantStatus {
+				t.Errorf("TaskCompletionResult = (%v, %q), want (%v, %q)", finished, completionStatus, tt.wantFinished, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestIsSuccessfulCompletionStatus(t *testing.T) {
+	for status, want := range map[string]bool{"SUCCESS": true, "WARNING": true, "ERROR": false, "TEMPERROR": false, "TERMINATED": false, "": false} {
+		if got := IsSuccessfulCompletionStatus(status); got != want {
+			t.Errorf("IsSuccessfulCompletionStatus(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestWaitForTask(t *testing.T) {
+	api := &task_management.TaskManagementAPIService{Statuses: map[string][]*task_management.TaskStatus{
+		"done":    {taskStatus(true, strPtr("Warning"))},
+		"running": {taskStatus(true, nil)},
+	}}
+	client := &sailpoint.APIClient{TaskManagementAPI: api}
+
+	got, err := WaitForTask(context.Background(), client, "done", time.Minute)
+	if err != nil || got != "WARNING" {
+		t.Errorf("WaitForTask(done) = (%q, %v), want (WARNING, nil)", got, err)
+	}
+
+	_, err = WaitForTask(context.Background(), client, "running", 20*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "timed out") || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForTask(running) error = %v, want a timeout", err)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -40,7 +39,7 @@ type workflowDataSource struct {
 type workflowDataSourceModel struct {
 	Created        types.String                        `tfsdk:"created"`
 	Creator        datasource_workflow.CreatorValue    `tfsdk:"creator"`
-	Definition     jsontypes.Normalized                `tfsdk:"definition"`
+	Definition     workflowDefinitionValue             `tfsdk:"definition"`
 	Description    types.String                        `tfsdk:"description"`
 	Enabled        types.Bool                          `tfsdk:"enabled"`
 	ExecutionCount types.Int64                         `tfsdk:"execution_count"`
@@ -0,0 +1,255 @@
+// This file implements identitynow_workflow_executions_v1, which lists a
+// workflow's recent executions and their statuses via
+// GET /workflows/v1/{id}/executions (workflows.WorkflowsAPI.GetWorkflowExecutionsV1).
+// Executions are kept for 90 days before being archived; the endpoint is
+// flagged deprecated by SailPoint (removal announced for July 2028) but has
+// no replacement yet.
+package workflow_v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/workflows"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+// workflowExecutionsListMaxLimit matches GET /workflows/v1/{id}/executions'
+// documented maximum (and default) "limit" value.
+const workflowExecutionsListMaxLimit = 250
+
+var workflowExecutionFilterFields = []util.FilterField{
+	{Attribute: "status", Property: "status", Operator: "eq", Kind: util.FilterKindString, Description: "Only executions with this status: `Completed`, `Failed`, `Canceled`, `Running` or `Queued`."},
+	{Attribute: "started_after", Property: "start_time", Operator: "gt", Kind: util.FilterKindTimestamp, Description: "Only executions started after this RFC 3339 timestamp."},
+	{Attribute: "started_on_or_after", Property: "start_time", Operator: "ge", Kind: util.FilterKindTimestamp, Description: "Only executions started at or after this RFC 3339 timestamp."},
+	{Attribute: "started_before", Property: "start_time", Operator: "lt", Kind: util.FilterKindTimestamp, Description: "Only executions started before this RFC 3339 timestamp."},
+	{Attribute: "started_on_or_before", Property: "start_time", Operator: "le", Kind: util.FilterKindTimestamp, Description: "Only executions started at or before this RFC 3339 timestamp."},
+}
+
+var (
+	_ datasource.DataSource                     = (*workflowExecutionsDataSource)(nil)
+	_ datasource.DataSourceWithConfigure        = (*workflowExecutionsDataSource)(nil)
+	_ datasource.DataSourceWithConfigValidators = (*workflowExecutionsDataSource)(nil)
+)
+
+func NewWorkflowExecutionsDataSource() datasource.DataSource {
+	return &workflowExecutionsDataSource{}
+}
+
+type workflowExecutionsDataSource struct {
+	client *sailpoint.APIClient
+}
+
+type workflowExecutionsDataSourceModel struct {
+	WorkflowId types.String `tfsdk:"workflow_id"`
+	Filters    types.String `tfsdk:"filters"`
+	Filter     types.Object `tfsdk:"filter"`
+	Limit      types.Int64  `tfsdk:"limit"`
+	Offset     types.Int64  `tfsdk:"offset"`
+	Executions types.List   `tfsdk:"executions"`
+}
+
+type workflowExecutionModel struct {
+	Id         types.String `tfsdk:"id"`
+	WorkflowId types.String `tfsdk:"workflow_id"`
+	RequestId  types.String `tfsdk:"request_id"`
+	StartTime  types.String `tfsdk:"start_time"`
+	CloseTime  types.String `tfsdk:"close_time"`
+	Status     types.String `tfsdk:"status"`
+}
+
+func workflowExecutionAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":          types.StringType,
+		"workflow_id": types.StringType,
+		"request_id":  types.StringType,
+		"start_time":  types.StringType,
+		"close_time":  types.StringType,
+		"status":      types.StringType,
+	}
+}
+
+func (d *workflowExecutionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_executions_v1"
+}
+
+func (d *workflowExecutionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists a Workflow's recent executions and their statuses.",
+		MarkdownDescription: "Lists a [Workflow](https://developer.sailpoint.com/docs/extensibility/workflows/)'s recent " +
+			"executions and their statuses via `GET /workflows/v1/{id}/executions`, optionally filtered (by a raw `filters` " +
+			"expression or a typed `filter` block) and paginated. Executions are kept for 90 days before being archived.\n\n" +
+			"~> This is a `_v1` pilot data source, and SailPoint has flagged the endpoint behind it as deprecated (removal " +
+			"announced for July 2028).",
+		Attributes: map[string]schema.Attribute{
+			"workflow_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the workflow whose executions to list.",
+			},
+			"filters": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Filter expression used to query executions (e.g. `status eq \"Failed\"`). Filtering is " +
+					"supported for `start_time` (`eq`, `lt`, `le`, `gt`, `ge`) and `status` (`eq`). See [V3 API Standard " +
+					"Collection Parameters](https://developer.sailpoint.com/idn/api/standard-collection-parameters#filtering-results) " +
+					"for the general syntax. Conflicts with `filter`.",
+			},
+			"filter": util.FilterAttribute(
+				"Typed filter criteria, AND-ed together and compiled into the `filters` expression for you. Conflicts with `filters`.",
+				workflowExecutionFilterFields,
+				nil,
+			),
+			"limit": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Maximum number of executions to return. The API's documented maximum (and default) for " +
+					"this endpoint is 250; values above 250 are capped to 250 with a warning.",
+			},
+			"offset": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Offset into the full result set, usually used with `limit` to paginate.",
+			},
+			"executions": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Executions matching the query.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Workflow execution ID.",
+						},
+						"workflow_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Workflow ID.",
+						},
+						"request_id": schema.StringAttribute{
+							Computed: true,
+							MarkdownDescription: "Backend ID that tracks the workflow request in the system. Provide this ID in " +
+								"a customer support ticket for debugging purposes.",
+						},
+						"start_time": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Date/time when the execution started, in RFC3339 format.",
+						},
+						"close_time": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Date/time when the execution ended, in RFC3339 format. Null while it is still running.",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Execution status: `Completed`, `Failed`, `Canceled`, `Running` or `Queued`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *workflowExecutionsDataSource) ConfigValidators(context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("filters"),
+			path.MatchRoot("filter"),
+		),
+	}
+}
+
+func (d *workflowExecutionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = cp.GetClient()
+}
+
+func (d *workflowExecutionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config workflowExecutionsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filters := config.Filters.ValueString()
+	if !config.Filter.IsNull() && !config.Filter.IsUnknown() {
+		expr, diags := util.BuildFilterExpression(config.Filter, path.Root("filter"), workflowExecutionFilterFields)
+		resp.Diagnostics.Append(diags...)
+		filters = expr
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workflowId := config.WorkflowId.ValueString()
+	tflog.Debug(ctx, "Reading Workflow Executions data source", map[string]interface{}{"workflow_id": workflowId, "filters": filters})
+
+	apiReq := d.client.WorkflowsAPI.GetWorkflowExecutionsV1(ctx, workflowId)
+	if filters != "" {
+		apiReq = apiReq.Filters(filters)
+	}
+	if !config.Limit.IsNull() && !config.Limit.IsUnknown() {
+		requestedLimit := config.Limit.ValueInt64()
+		if requestedLimit > workflowExecutionsListMaxLimit {
+			resp.Diagnostics.AddWarning(
+				"Limit exceeds maximum",
+				fmt.Sprintf("The requested limit (%d) exceeds GET /workflows/v1/{id}/executions' documented maximum of %d. Using %d instead.",
+					requestedLimit, workflowExecutionsListMaxLimit, workflowExecutionsListMaxLimit),
+			)
+			requestedLimit = workflowExecutionsListMaxLimit
+		}
+		apiReq = apiReq.Limit(int32(requestedLimit))
+	}
+	if !config.Offset.IsNull() && !config.Offset.IsUnknown() {
+		apiReq = apiReq.Offset(int32(config.Offset.ValueInt64()))
+	}
+
+	dtos, httpResp, err := apiReq.Execute()
+	if err != nil {
+		tflog.Error(ctx, "Error reading Workflow Executions data source", map[string]interface{}{"error": err.Error()})
+		resp.Diagnostics.AddError("Error listing Workflow executions", errDetail(err, httpResp))
+		return
+	}
+
+	models := make([]workflowExecutionModel, 0, len(dtos))
+	for i := range dtos {
+		models = append(models, workflowExecutionDtoToModel(&dtos[i]))
+	}
+
+	executions, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: workflowExecutionAttrTypes()}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.Executions = executions
+
+	tflog.Debug(ctx, "Read Workflow Executions data source", map[string]interface{}{"count": len(models)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+func workflowExecutionDtoToModel(dto *workflows.GetWorkflowExecutionsV1200ResponseInner) workflowExecutionModel {
+	return workflowExecutionModel{
+		Id:         types.StringPointerValue(dto.Id),
+		WorkflowId: types.StringPointerValue(dto.WorkflowId),
+		RequestId:  types.StringPointerValue(dto.RequestId),
+		StartTime:  timeToStringValue(dto.StartTime),
+		CloseTime:  timeToStringValue(dto.CloseTime),
+		Status:     types.StringPointerValue(dto.Status),
+	}
+}
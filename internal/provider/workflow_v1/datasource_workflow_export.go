@@ -0,0 +1,136 @@
+// This file implements identitynow_workflow_export_v1, which emits a
+// workflow in the same importable shape the ISC UI exports (see
+// workflowExportDocument in workflow_definition.go): the Workflow object
+// from GET /workflows/v1/{id} without server-managed fields or null
+// values. The document can be written to a file, imported through the UI
+// into another tenant, or passed as-is to identitynow_workflow_v1's
+// "definition".
+package workflow_v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+)
+
+var (
+	_ datasource.DataSource              = (*workflowExportDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*workflowExportDataSource)(nil)
+)
+
+func NewWorkflowExportDataSource() datasource.DataSource {
+	return &workflowExportDataSource{}
+}
+
+type workflowExportDataSource struct {
+	client *sailpoint.APIClient
+}
+
+type workflowExportDataSourceModel struct {
+	Id         types.String            `tfsdk:"id"`
+	Name       types.String            `tfsdk:"name"`
+	Definition workflowDefinitionValue `tfsdk:"definition"`
+	Document   jsontypes.Normalized    `tfsdk:"document"`
+}
+
+func (d *workflowExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_export_v1"
+}
+
+func (d *workflowExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exports a Workflow as an importable JSON document, in the same shape as the UI's workflow export.",
+		MarkdownDescription: "Exports a [Workflow](https://developer.sailpoint.com/docs/extensibility/workflows/) as an " +
+			"importable JSON document, in the same shape as the UI's workflow export: the workflow's `name`, `description`, " +
+			"`owner`, `enabled`, `trigger` and `definition`, without server-managed fields (`id`, `created`, `modified`, " +
+			"`modifiedBy`, `executionCount`, `failureCount`, `creator`) or null values. `document` can be imported through " +
+			"the UI, or passed as-is to `identitynow_workflow_v1`'s `definition`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the workflow to export.",
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Workflow name.",
+			},
+			"definition": schema.StringAttribute{
+				CustomType: workflowDefinitionType{},
+				Computed:   true,
+				MarkdownDescription: "The workflow's definition alone (`{\"start\": \"...\", \"steps\": {...}}`), normalized " +
+					"the same way as `document`.",
+			},
+			"document": schema.StringAttribute{
+				CustomType:          jsontypes.NormalizedType{},
+				Computed:            true,
+				MarkdownDescription: "The export document, as indented JSON with keys in sorted order.",
+			},
+		},
+	}
+}
+
+func (d *workflowExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = cp.GetClient()
+}
+
+func (d *workflowExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config workflowExportDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := config.Id.ValueString()
+	tflog.Debug(ctx, "Exporting Workflow", map[string]interface{}{"id": id})
+
+	dto, httpResp, err := d.client.WorkflowsAPI.GetWorkflowV1(ctx, id).Execute()
+	if err != nil {
+		tflog.Error(ctx, "Error exporting Workflow", map[string]interface{}{"id": id, "error": err.Error()})
+		resp.Diagnostics.AddError("Error reading Workflow", errDetail(err, httpResp))
+		return
+	}
+
+	document, err := workflowExportDocument(dto)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error encoding Workflow export",
+			fmt.Sprintf("Could not encode workflow %q as an export document: %s", id, err.Error()),
+		)
+		return
+	}
+	definition, diags := definitionFromAPI(dto.Definition)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := workflowExportDataSourceModel{
+		Id:         config.Id,
+		Name:       types.StringValue(dto.GetName()),
+		Definition: definition,
+		Document:   jsontypes.NewNormalizedValue(document),
+	}
+
+	tflog.Debug(ctx, "Exported Workflow", map[string]interface{}{"id": id})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,321 @@
+// This file implements identitynow_workflow_library_actions_v1,
+// identitynow_workflow_library_triggers_v1 and
+// identitynow_workflow_library_operators_v1, which list the workflow
+// library (see workflow_library.go) so a configuration can look up an
+// actionId, a trigger id or the inputs a step needs instead of copying them
+// from the UI. The three share one implementation and differ only in which
+// catalog they return; they read the same provider-cached library as the
+// workflow resource's plan-time checks.
+package workflow_v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+// workflowLibraryKind selects one of the library's catalogs.
+type workflowLibraryKind struct {
+	typeName    string // data source type name suffix
+	plural      string // "actions", "triggers" or "operators"
+	endpoint    string
+	exampleAttr bool // whether items carry an input_example
+	items       func(*workflowLibrary) []workflowLibraryItem
+}
+
+var (
+	workflowLibraryActions = workflowLibraryKind{
+		typeName: "_workflow_library_actions_v1",
+		plural:   "actions",
+		endpoint: "GET /workflow-library/v1/actions",
+		items:    func(l *workflowLibrary) []workflowLibraryItem { return l.Actions },
+	}
+	workflowLibraryTriggers = workflowLibraryKind{
+		typeName:    "_workflow_library_triggers_v1",
+		plural:      "triggers",
+		endpoint:    "GET /workflow-library/v1/triggers",
+		exampleAttr: true,
+		items:       func(l *workflowLibrary) []workflowLibraryItem { return l.Triggers },
+	}
+	workflowLibraryOperators = workflowLibraryKind{
+		typeName: "_workflow_library_operators_v1",
+		plural:   "operators",
+		endpoint: "GET /workflow-library/v1/operators",
+		items:    func(l *workflowLibrary) []workflowLibraryItem { return l.Operators },
+	}
+)
+
+var (
+	_ datasource.DataSource              = (*workflowLibraryDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*workflowLibraryDataSource)(nil)
+)
+
+func NewWorkflowLibraryActionsDataSource() datasource.DataSource {
+	return &workflowLibraryDataSource{kind: workflowLibraryActions}
+}
+
+func NewWorkflowLibraryTriggersDataSource() datasource.DataSource {
+	return &workflowLibraryDataSource{kind: workflowLibraryTriggers}
+}
+
+func NewWorkflowLibraryOperatorsDataSource() datasource.DataSource {
+	return &workflowLibraryDataSource{kind: workflowLibraryOperators}
+}
+
+type workflowLibraryDataSource struct {
+	client *sailpoint.APIClient
+	cache  *util.ProviderCache
+	kind   workflowLibraryKind
+}
+
+type workflowLibraryDataSourceModel struct {
+	IncludeDeprecated types.Bool `tfsdk:"include_deprecated"`
+	Items             types.List `tfsdk:"items"`
+}
+
+type workflowLibraryItemModel struct {
+	Id           types.String         `tfsdk:"id"`
+	Name         types.String         `tfsdk:"name"`
+	Type         types.String         `tfsdk:"type"`
+	Description  types.String         `tfsdk:"description"`
+	Deprecated   types.Bool           `tfsdk:"deprecated"`
+	FormFields   types.List           `tfsdk:"form_fields"`
+	InputExample jsontypes.Normalized `tfsdk:"input_example"`
+}
+
+type workflowLibraryFormFieldModel struct {
+	Name        types.String `tfsdk:"name"`
+	Label       types.String `tfsdk:"label"`
+	Type        types.String `tfsdk:"type"`
+	Description types.String `tfsdk:"description"`
+	HelpText    types.String `tfsdk:"help_text"`
+	Required    types.Bool   `tfsdk:"required"`
+}
+
+func workflowLibraryFormFieldAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":        types.StringType,
+		"label":       types.StringType,
+		"type":        types.StringType,
+		"description": types.StringType,
+		"help_text":   types.StringType,
+		"required":    types.BoolType,
+	}
+}
+
+func workflowLibraryItemAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":            types.StringType,
+		"name":          types.StringType,
+		"type":          types.StringType,
+		"description":   types.StringType,
+		"deprecated":    types.BoolType,
+		"form_fields":   types.ListType{ElemType: types.ObjectType{AttrTypes: workflowLibraryFormFieldAttrTypes()}},
+		"input_example": jsontypes.NormalizedType{},
+	}
+}
+
+func (d *workflowLibraryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + d.kind.typeName
+}
+
+func (d *workflowLibraryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	inputExampleDescription := "Always null: only triggers carry an example input."
+	if d.kind.exampleAttr {
+		inputExampleDescription = "Example of the input the trigger passes to a workflow, as JSON."
+	}
+
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Lists the workflow library's %s.", d.kind.plural),
+		MarkdownDescription: fmt.Sprintf("Lists the [workflow library](https://developer.sailpoint.com/docs/extensibility/workflows/)'s "+
+			"%s via `%s`. The library is read once per provider instance and shared with the other workflow library data "+
+			"sources and the `identitynow_workflow_v1` resource's plan-time checks.\n\n"+
+			"~> This is a `_v1` pilot data source.", d.kind.plural, d.kind.endpoint),
+		Attributes: map[string]schema.Attribute{
+			"include_deprecated": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Whether to include deprecated %s. Defaults to `false`.", d.kind.plural),
+			},
+			"items": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: fmt.Sprintf("The library's %s.", d.kind.plural),
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID, e.g. `sp:send-email`. Used as a step's `actionId` or an EVENT trigger's `id`.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Display name.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Type, e.g. `ACTION`, `EVENT` or `OPERATOR`.",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Description.",
+						},
+						"deprecated": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether SailPoint has deprecated it.",
+						},
+						"form_fields": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "Inputs it accepts, as set in a step's `attributes`.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "Attribute key. A `.$` suffix marks a JSONPath-valued input.",
+									},
+									"label": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "Label shown in the UI.",
+									},
+									"type": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "Input type, e.g. `text` or `jsonpath`.",
+									},
+									"description": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "Description.",
+									},
+									"help_text": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "Help text shown in the UI.",
+									},
+									"required": schema.BoolAttribute{
+										Computed:            true,
+										MarkdownDescription: "Whether a step must set it.",
+									},
+								},
+							},
+						},
+						"input_example": schema.StringAttribute{
+							CustomType:          jsontypes.NormalizedType{},
+							Computed:            true,
+							MarkdownDescription: inputExampleDescription,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *workflowLibraryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = cp.GetClient()
+	d.cache = cp.GetProviderCache()
+}
+
+func (d *workflowLibraryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config workflowLibraryDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading Workflow Library data source", map[string]interface{}{"kind": d.kind.plural})
+
+	lib, err := loadWorkflowLibrary(ctx, d.client, d.cache)
+	if err != nil {
+		tflog.Error(ctx, "Error reading Workflow Library data source", map[string]interface{}{"error": err.Error()})
+		resp.Diagnostics.AddError("Error reading the workflow library", err.Error())
+		return
+	}
+
+	includeDeprecated := config.IncludeDeprecated.ValueBool()
+	items := d.kind.items(lib)
+	models := make([]workflowLibraryItemModel, 0, len(items))
+	for i := range items {
+		if items[i].Deprecated && !includeDeprecated {
+			continue
+		}
+		m, diags := workflowLibraryItemToModel(ctx, &items[i])
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		models = append(models, m)
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: workflowLibraryItemAttrTypes()}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.Items = list
+
+	tflog.Debug(ctx, "Read Workflow Library data source", map[string]interface{}{"kind": d.kind.plural, "count": len(models)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+func workflowLibraryItemToModel(ctx context.Context, item *workflowLibraryItem) (workflowLibraryItemModel, diag.Diagnostics) {
+	fields := make([]workflowLibraryFormFieldModel, 0, len(item.FormFields))
+	for _, f := range item.FormFields {
+		fields = append(fields, workflowLibraryFormFieldModel{
+			Name:        workflowLibraryString(f.Name),
+			Label:       workflowLibraryString(f.Label),
+			Type:        workflowLibraryString(f.Type),
+			Description: workflowLibraryString(f.Description),
+			HelpText:    workflowLibraryString(f.HelpText),
+			Required:    types.BoolValue(f.Required),
+		})
+	}
+	formFields, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: workflowLibraryFormFieldAttrTypes()}, fields)
+
+	inputExample := jsontypes.NewNormalizedNull()
+	if item.InputExample != nil {
+		b, err := json.Marshal(item.InputExample)
+		if err != nil {
+			diags.AddError("Error encoding workflow library input example", fmt.Sprintf("%s: %s", item.Id, err.Error()))
+		} else {
+			inputExample = jsontypes.NewNormalizedValue(string(b))
+		}
+	}
+
+	return workflowLibraryItemModel{
+		Id:           types.StringValue(item.Id),
+		Name:         workflowLibraryString(item.Name),
+		Type:         workflowLibraryString(item.Type),
+		Description:  workflowLibraryString(item.Description),
+		Deprecated:   types.BoolValue(item.Deprecated),
+		FormFields:   formFields,
+		InputExample: inputExample,
+	}, diags
+}
+
+// workflowLibraryString maps the library's omitted strings to null.
+func workflowLibraryString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
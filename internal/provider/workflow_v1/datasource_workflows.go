@@ -10,6 +10,10 @@
 // so practitioners get identical attribute names/types whether they read
 // one workflow by id or query many by filter.
 //
+// GET /workflows/v1 takes no query parameters, so "filters"/"filter",
+// "sorters", "offset" and "limit" are all applied client-side to the full
+// list - see workflow_list_query.go.
+//
 // A fully-known "filters" value WILL invoke a live API call during
 // `terraform plan` itself (confirmed on role_v1/access_profile_v1's
 // identically-shaped plural data sources) - be aware of this if wiring a
@@ -20,25 +24,37 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
 
+	"terraform-provider-identitynow/internal/provider/util"
 	"terraform-provider-identitynow/internal/provider/workflow_v1/datasource_workflow"
 )
 
-// workflowsListMaxLimit matches GET /workflows/v1's documented maximum
-// "limit" value (see the shared limit.yaml parameter definition - 250, the
-// same as most other IdentityNow v1 list APIs).
-const workflowsListMaxLimit = 250
+// workflowFilterFields are matched client-side (see workflow_list_query.go),
+// so none of them compiles to a filter expression term.
+var workflowFilterFields = []util.FilterField{
+	{Attribute: "name", Kind: util.FilterKindString, Description: "Only the workflow with exactly this name."},
+	{Attribute: "name_contains", Kind: util.FilterKindString, Description: "Only workflows whose name contains this value, ignoring case."},
+	{Attribute: "enabled", Kind: util.FilterKindBool, Description: "Only enabled (`true`) or disabled (`false`) workflows."},
+	{Attribute: "trigger_type", Kind: util.FilterKindString, Description: "Only workflows with this trigger type: `EVENT`, `EXTERNAL` or `SCHEDULED`."},
+	{Attribute: "trigger_id", Kind: util.FilterKindString, Description: "Only workflows whose trigger has this `id` attribute, e.g. `idn:identity-attributes-changed`."},
+	{Attribute: "owner_id", Kind: util.FilterKindString, Description: "Only workflows owned by this identity."},
+	{Attribute: "creator_id", Kind: util.FilterKindString, Description: "Only workflows created by this identity."},
+}
 
 var (
-	_ datasource.DataSource              = (*workflowsDataSource)(nil)
-	_ datasource.DataSourceWithConfigure = (*workflowsDataSource)(nil)
+	_ datasource.DataSource                     = (*workflowsDataSource)(nil)
+	_ datasource.DataSourceWithConfigure        = (*workflowsDataSource)(nil)
+	_ datasource.DataSourceWithConfigValidators = (*workflowsDataSource)(nil)
 )
 
 func NewWorkflowsDataSource() datasource.DataSource {
@@ -54,6 +70,7 @@ type workflowsDataSource struct {
 // hand-written workflowDataSourceModel shape (datasource_workflow.go).
 type WorkflowsDataSourceModel struct {
 	Filters   types.String `tfsdk:"filters"`
+	Filter    types.Object `tfsdk:"filter"`
 	Limit     types.Int64  `tfsdk:"limit"`
 	Offset    types.Int64  `tfsdk:"offset"`
 	Sorters   types.String `tfsdk:"sorters"`
@@ -91,8 +108,9 @@ func (d *workflowsDataSource) Schema(ctx context.Context, req datasource.SchemaR
 	resp.Schema = schema.Schema{
 		Description: "Lists Workflows from IdentityNow/ISC, optionally filtered, sorted, and paginated.",
 		MarkdownDescription: "Lists [Workflows](https://developer.sailpoint.com/docs/extensibility/workflows/) " +
-			"from IdentityNow/ISC via `GET /workflows/v1`, optionally filtered, sorted, and paginated. Returns the same " +
-			"attributes per workflow as the singular `identitynow_workflow_v1` data source.\n\n" +
+			"from IdentityNow/ISC via `GET /workflows/v1`, optionally filtered, sorted, and paginated. The endpoint " +
+			"returns every workflow at once, so filtering, sorting and pagination are applied by the provider. Returns " +
+			"the same attributes per workflow as the singular `identitynow_workflow_v1` data source.\n\n" +
 			"~> This is a `_v1` pilot data source - see `identitynow_workflow_v1`'s \"Known Limitations & Live Testing " +
 			"Notes\" section before relying on it in production configurations; the same limitations apply to each " +
 			"workflow returned here.",
@@ -100,15 +118,20 @@ func (d *workflowsDataSource) Schema(ctx context.Context, req datasource.SchemaR
 		Attributes: map[string]schema.Attribute{
 			"filters": schema.StringAttribute{
 				Optional: true,
-				MarkdownDescription: "Filter expression used to query workflows. Filtering is supported for `enabled` " +
-					"(`eq`), `connectorInstanceId` (`eq`), `triggerId` (`eq`). See [V3 API Standard Collection Parameters]" +
-					"(https://developer.sailpoint.com/idn/api/standard-collection-parameters#filtering-results) " +
-					"for the general syntax.",
+				MarkdownDescription: "Filter expression used to query workflows, e.g. `enabled eq true and triggerType eq " +
+					"\"EVENT\"`. Only `eq` terms joined by `and` are supported, on `enabled` (`true`/`false`) and on `name`, " +
+					"`triggerType`, `triggerId`, `owner.id` and `creator.id` (double-quoted strings). See [V3 API Standard " +
+					"Collection Parameters](https://developer.sailpoint.com/idn/api/standard-collection-parameters#filtering-results) " +
+					"for the general syntax. Conflicts with `filter`.",
 			},
+			"filter": util.FilterAttribute(
+				"Typed filter criteria, AND-ed together. Conflicts with `filters`.",
+				workflowFilterFields,
+				nil,
+			),
 			"limit": schema.Int64Attribute{
-				Optional: true,
-				MarkdownDescription: "Maximum number of workflows to return. The API's documented maximum for this " +
-					"endpoint is 250; values above 250 are capped to 250 with a warning.",
+				Optional:            true,
+				MarkdownDescription: "Maximum number of workflows to return, after `offset`. Unset returns every match.",
 			},
 			"offset": schema.Int64Attribute{
 				Optional:            true,
@@ -116,8 +139,9 @@ func (d *workflowsDataSource) Schema(ctx context.Context, req datasource.SchemaR
 			},
 			"sorters": schema.StringAttribute{
 				Optional: true,
-				MarkdownDescription: "Sort expression for the results. Sorting is supported for `modified`, `name`. " +
-					"See [V3 API Standard Collection Parameters]" +
+				MarkdownDescription: "Sort expression for the results: comma-separated properties, each prefixed with " +
+					"`-` for descending order (e.g. `-modified,name`). Sorting is supported for `name` (ignoring case), " +
+					"`created` and `modified`. See [V3 API Standard Collection Parameters]" +
 					"(https://developer.sailpoint.com/idn/api/standard-collection-parameters#sorting-results).",
 			},
 			"workflows": schema.ListNestedAttribute{
@@ -131,6 +155,15 @@ func (d *workflowsDataSource) Schema(ctx context.Context, req datasource.SchemaR
 	}
 }
 
+func (d *workflowsDataSource) ConfigValidators(context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("filters"),
+			path.MatchRoot("filter"),
+		),
+	}
+}
+
 func (d *workflowsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -154,50 +187,48 @@ func (d *workflowsDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	tflog.Debug(ctx, "Reading Workflows data source", map[string]interface{}{"filters": config.Filters.ValueString()})
-
-	apiReq := d.client.WorkflowsAPI.ListWorkflowsV1(ctx)
-
-	// golang-sdk v3's GET /workflows/v1 (ListWorkflowsV1) request builder
-	// exposes no server-side query parameters at all - unlike golang-sdk v2,
-	// which offered Filters/Sorters/Offset/Limit builder methods (the workflows
-	// list endpoint itself does not document these params; the v2 SDK exposed
-	// them regardless). Filters/sorters/offset are therefore not supported and
-	// are surfaced as a warning if set; limit is applied client-side by
-	// truncating the full result set below.
-	if (!config.Filters.IsNull() && !config.Filters.IsUnknown()) ||
-		(!config.Sorters.IsNull() && !config.Sorters.IsUnknown()) ||
-		(!config.Offset.IsNull() && !config.Offset.IsUnknown()) {
-		resp.Diagnostics.AddWarning(
-			"Server-side filtering not supported for workflows",
-			"golang-sdk v3's GET /workflows/v1 endpoint does not accept filters, sorters or offset "+
-				"query parameters, so these arguments are ignored. All workflows are returned; use "+
-				"limit to cap the number of rows.",
-		)
+	criteria, diags := workflowsQueryCriteria(config)
+	resp.Diagnostics.Append(diags...)
+	var sorters []workflowListSorter
+	if !config.Sorters.IsNull() && !config.Sorters.IsUnknown() {
+		var err error
+		sorters, err = parseWorkflowListSorters(config.Sorters.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("sorters"), "Invalid sorters", err.Error())
+		}
+	}
+	offset, limit := int64(0), int64(-1)
+	if !config.Offset.IsNull() && !config.Offset.IsUnknown() {
+		if offset = config.Offset.ValueInt64(); offset < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("offset"), "Invalid offset", "offset must not be negative.")
+		}
+	}
+	if !config.Limit.IsNull() && !config.Limit.IsUnknown() {
+		if limit = config.Limit.ValueInt64(); limit < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("limit"), "Invalid limit", "limit must not be negative.")
+		}
 	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading Workflows data source", map[string]interface{}{"filters": config.Filters.ValueString()})
 
-	dtos, httpResp, err := apiReq.Execute()
+	all, httpResp, err := d.client.WorkflowsAPI.ListWorkflowsV1(ctx).Execute()
 	if err != nil {
 		tflog.Error(ctx, "Error reading Workflows data source", map[string]interface{}{"error": err.Error()})
 		resp.Diagnostics.AddError("Error listing Workflows", errDetail(err, httpResp))
 		return
 	}
 
-	// Apply limit client-side (the endpoint has no limit query parameter in v3).
-	if !config.Limit.IsNull() && !config.Limit.IsUnknown() {
-		requestedLimit := config.Limit.ValueInt64()
-		if requestedLimit > workflowsListMaxLimit {
-			resp.Diagnostics.AddWarning(
-				"Limit exceeds maximum",
-				fmt.Sprintf("The requested limit (%d) exceeds GET /workflows/v1's documented maximum of %d. Using %d instead.",
-					requestedLimit, workflowsListMaxLimit, workflowsListMaxLimit),
-			)
-			requestedLimit = workflowsListMaxLimit
-		}
-		if requestedLimit >= 0 && int64(len(dtos)) > requestedLimit {
-			dtos = dtos[:requestedLimit]
+	dtos := all[:0]
+	for i := range all {
+		if criteria.matches(&all[i]) {
+			dtos = append(dtos, all[i])
 		}
 	}
+	sortWorkflows(dtos, sorters)
+	dtos = pageWorkflows(dtos, offset, limit)
 
 	rowType := workflowRowElemType(ctx)
 
@@ -218,11 +249,45 @@ func (d *workflowsDataSource) Read(ctx context.Context, req datasource.ReadReque
 	}
 	config.Workflows = workflowsList
 
-	tflog.Debug(ctx, "Read Workflows data source", map[string]interface{}{"count": len(dtos)})
+	tflog.Debug(ctx, "Read Workflows data source", map[string]interface{}{"count": len(dtos), "total": len(all)})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
 
+// workflowsQueryCriteria returns the criteria set by "filters" or by the
+// "filter" block (they conflict, so at most one is set).
+func workflowsQueryCriteria(config WorkflowsDataSourceModel) (workflowListCriteria, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !config.Filters.IsNull() && !config.Filters.IsUnknown() {
+		criteria, err := parseWorkflowListFilters(config.Filters.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("filters"), "Invalid filters", err.Error())
+		}
+		return criteria, diags
+	}
+
+	var criteria workflowListCriteria
+	for attribute, field := range map[string]**string{
+		"name":          &criteria.Name,
+		"name_contains": &criteria.NameContains,
+		"trigger_type":  &criteria.TriggerType,
+		"trigger_id":    &criteria.TriggerId,
+		"owner_id":      &criteria.OwnerId,
+		"creator_id":    &criteria.CreatorId,
+	} {
+		if v, ok := util.FilterBlockString(config.Filter, attribute); ok {
+			*field = &v
+		}
+	}
+	if !config.Filter.IsNull() && !config.Filter.IsUnknown() {
+		if enabled, ok := config.Filter.Attributes()["enabled"].(types.Bool); ok && !enabled.IsNull() && !enabled.IsUnknown() {
+			v := enabled.ValueBool()
+			criteria.Enabled = &v
+		}
+	}
+	return criteria, diags
+}
+
 // workflowRowElemType returns the attr.Type matching workflowDataSourceModel's
 // Go struct shape, used as the "workflows" list's element type for
 // types.ListValueFrom. Derived from the (hand-added field-augmented)
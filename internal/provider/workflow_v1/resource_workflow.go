@@ -29,14 +29,21 @@
 //     transform_v1's identical PUT-over-PATCH choice.
 //   - POST /workflows/v1/{id}/test, GET/DELETE /workflow-executions/v1/*,
 //     GET /workflows/v1/{id}/executions - execution history/testing are
-//     transient, non-declarative operations, not resource state.
-//   - GET /workflows/v1/{id}/external/oauth-clients, POST .../execute/external/{id} -
+//     transient, non-declarative operations, not resource state. (They are
+//     exposed separately by the trigger-style identitynow_workflow_test_v1
+//     resource and the identitynow_workflow_executions_v1 data source - see
+//     resource_workflow_test_execution.go and datasource_workflow_executions.go.)
+//   - POST /workflows/v1/{id}/external/oauth-clients, POST .../execute/external/{id} -
 //     external-trigger invocation plumbing, not workflow configuration.
+//     (Client generation is exposed separately by the trigger-style
+//     identitynow_workflow_external_client_v1 resource - see
+//     resource_workflow_external_client.go.)
 //   - GET /workflow-library/v1(/actions|/triggers|/operators) - read-only
 //     catalog/metadata endpoints describing what CAN go in a workflow
-//     definition's steps, not a workflow's own attributes; would be
-//     data-source candidates for a future session, not required to manage
-//     a workflow itself.
+//     definition's steps, not a workflow's own attributes. (They are
+//     exposed as the identitynow_workflow_library_*_v1 data sources and
+//     used by this resource's plan-time definition checks - see
+//     workflow_library.go and resource_workflow_validate.go.)
 //
 // "definition"/"trigger.attributes" dynamic-shape decision (see the
 // 2026-07-24 dynamic-attributes-pattern-research entry in
@@ -58,7 +65,10 @@
 // "trigger" object (not just "attributes") is schema.ignores'd and
 // hand-written in full here (schema + a plain types.Object-backed model),
 // following segment_v1's "visibility_criteria" precedent for a fully
-// hand-rolled nested block.
+// hand-rolled nested block. "definition" has since moved to its own
+// jsontypes.Normalized-like CustomType, workflowDefinitionValue, so that a
+// workflow exported from the UI can be pasted in as-is - see
+// workflow_definition.go.
 package workflow_v1
 
 import (
@@ -90,19 +100,32 @@ import (
 // this package needing to import it (which would create an import cycle).
 type clientProvider interface {
 	GetClient() *sailpoint.APIClient
+	GetProviderCache() *util.ProviderCache
 }
 
 const workflowGuidanceMarkdown = "" +
 	"### Known Limitations & Live Testing Notes\n\n" +
 	"- This is a `_v1` pilot resource. Only core CRUD (create/read/update/delete a workflow's own configuration) is " +
-	"implemented - workflow execution/testing (`POST .../test`), execution history (`GET .../executions`, " +
-	"`GET /workflow-executions/v1/...`), external-trigger invocation, and the read-only `/workflow-library/v1` " +
-	"action/trigger/operator catalogs are all out of scope for this pilot (see the package doc for the full list).\n" +
+	"implemented here - test runs (`POST .../test`) are covered by `identitynow_workflow_test_v1` and execution " +
+	"history (`GET .../executions`) by `identitynow_workflow_executions_v1`, and the read-only `/workflow-library/v1` " +
+	"action/trigger/operator catalogs by the `identitynow_workflow_library_*_v1` data sources, and external-trigger " +
+	"OAuth clients by `identitynow_workflow_external_client_v1`, while invoking an external trigger is out of scope for " +
+	"this pilot (see the package doc for the full list).\n" +
 	"- `enabled` workflows **cannot be deleted** - the live API rejects `DELETE` on an enabled workflow. Disable a " +
 	"workflow (`enabled = false`) before destroying it.\n" +
 	"- `definition` is a raw JSON string (`{\"start\": ..., \"steps\": {...}}`) because each step's shape varies by its " +
 	"own `type` (action/approval/success/etc.) with genuinely free-form `additionalProperties`. See " +
 	"https://developer.sailpoint.com/docs/extensibility/workflows/ for the JSON schema each step type expects.\n" +
+	"- A workflow exported from the UI (or by `identitynow_workflow_export_v1`) can be passed to `definition` as-is: " +
+	"only its `definition` is used, and values that differ only in key order, null-valued keys or server-managed " +
+	"fields (`id`, `created`, `modified`, `executionCount`, `creator`, ...) are treated as equal. Set `name`, " +
+	"`trigger` and the other attributes from the same document with `jsondecode`.\n" +
+	"- `definition` and `trigger` are checked at plan time: `start` and every `nextStep`/`defaultStep`/" +
+	"`choices[].nextStep` must name a step, every step must be reachable from `start`, and only `success`/`failure` " +
+	"steps may end the workflow. Once the provider is configured, each step's `actionId` and an `EVENT` trigger's " +
+	"`id` must also exist in the workflow library, and each action's required inputs must be set (as `name` or " +
+	"`name.$`). The library is read once per provider instance; if it can't be read the plan continues with a " +
+	"warning.\n" +
 	"- `trigger.attributes` is likewise a raw JSON string, since its shape depends entirely on the sibling " +
 	"`trigger.type` (`EVENT` -> `{id, filter.$, description, attributeToFilter, formDefinitionId}`, `EXTERNAL` -> " +
 	"`{name, description, clientId, url}`, `SCHEDULED` -> `{frequency, timeZone, cronString, weeklyDays, weeklyTimes, " +
@@ -126,6 +149,7 @@ func NewWorkflowResource() resource.Resource {
 
 type workflowResource struct {
 	client *sailpoint.APIClient
+	cache  *util.ProviderCache
 }
 
 // triggerModel is the hand-written model for the "trigger" nested block
@@ -151,25 +175,27 @@ func triggerAttrTypes() map[string]attr.Type {
 // generator-managed fields (owner/creator/modified_by reuse the generated
 // custom value types directly - they were `associated_external_type` mapped,
 // not hand-written) plus the two hand-added fields the generator was told
-// to ignore in full ("definition", "trigger" - see the package doc). Kept as
+// to ignore in full ("definition", "trigger" - see the package doc), and the
+// provider-side "warn_when_disabled" setting. Kept as
 // a distinct, hand-written struct (rather than embedding the generated
 // model) since Go doesn't allow adding a field to an imported struct type,
 // and req.Plan.Get/resp.State.Set match purely on `tfsdk` tags, not on which
 // struct type declares them.
 type workflowResourceModel struct {
-	Created        types.String                      `tfsdk:"created"`
-	Creator        resource_workflow.CreatorValue    `tfsdk:"creator"`
-	Definition     jsontypes.Normalized              `tfsdk:"definition"`
-	Description    types.String                      `tfsdk:"description"`
-	Enabled        types.Bool                        `tfsdk:"enabled"`
-	ExecutionCount types.Int64                       `tfsdk:"execution_count"`
-	FailureCount   types.Int64                       `tfsdk:"failure_count"`
-	Id             types.String                      `tfsdk:"id"`
-	Modified       types.String                      `tfsdk:"modified"`
-	ModifiedBy     resource_workflow.ModifiedByValue `tfsdk:"modified_by"`
-	Name           types.String                      `tfsdk:"name"`
-	Owner          resource_workflow.OwnerValue      `tfsdk:"owner"`
-	Trigger        types.Object                      `tfsdk:"trigger"`
+	Created          types.String                      `tfsdk:"created"`
+	Creator          resource_workflow.CreatorValue    `tfsdk:"creator"`
+	Definition       workflowDefinitionValue           `tfsdk:"definition"`
+	Description      types.String                      `tfsdk:"description"`
+	Enabled          types.Bool                        `tfsdk:"enabled"`
+	ExecutionCount   types.Int64                       `tfsdk:"execution_count"`
+	FailureCount     types.Int64                       `tfsdk:"failure_count"`
+	Id               types.String                      `tfsdk:"id"`
+	Modified         types.String                      `tfsdk:"modified"`
+	ModifiedBy       resource_workflow.ModifiedByValue `tfsdk:"modified_by"`
+	Name             types.String                      `tfsdk:"name"`
+	Owner            resource_workflow.OwnerValue      `tfsdk:"owner"`
+	Trigger          types.Object                      `tfsdk:"trigger"`
+	WarnWhenDisabled types.Bool                        `tfsdk:"warn_when_disabled"`
 }
 
 func (r *workflowResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -188,6 +214,7 @@ func (r *workflowResource) Schema(ctx context.Context, req resource.SchemaReques
 		workflowGuidanceMarkdown
 	applyWorkflowDefinitionField(&resp.Schema.Attributes)
 	applyWorkflowTriggerField(&resp.Schema.Attributes)
+	applyWorkflowWarnWhenDisabledField(&resp.Schema.Attributes)
 	applyWorkflowUseStateForUnknown(&resp.Schema)
 }
 
@@ -205,6 +232,7 @@ func (r *workflowResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 	r.client = cp.GetClient()
+	r.cache = cp.GetProviderCache()
 }
 
 func (r *workflowResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -268,6 +296,7 @@ func (r *workflowResource) Create(ctx context.Context, req resource.CreateReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.WarnWhenDisabled = workflowWarnWhenDisabledValue(plan.WarnWhenDisabled)
 
 	tflog.Info(ctx, "Created Workflow", map[string]interface{}{"id": state.Id.ValueString(), "name": state.Name.ValueString()})
 
@@ -302,6 +331,7 @@ func (r *workflowResource) Read(ctx context.Context, req resource.ReadRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	newState.WarnWhenDisabled = workflowWarnWhenDisabledValue(state.WarnWhenDisabled)
 
 	tflog.Debug(ctx, "Read Workflow", map[string]interface{}{"id": newState.Id.ValueString()})
 
@@ -374,6 +404,7 @@ func (r *workflowResource) Update(ctx context.Context, req resource.UpdateReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	newState.WarnWhenDisabled = workflowWarnWhenDisabledValue(plan.WarnWhenDisabled)
 
 	tflog.Info(ctx, "Updated Workflow", map[string]interface{}{"id": newState.Id.ValueString()})
 
@@ -475,14 +506,19 @@ func workflowDtoToModel(ctx context.Context, dto *workflows.Workflow) (workflowR
 // definitionToAPI decodes the practitioner-supplied "definition" JSON string
 // into an *workflows.WorkflowDefinition. A null/unknown/empty value returns
 // nil (definition genuinely omitted - valid for a workflow with no steps
-// configured yet).
-func definitionToAPI(v jsontypes.Normalized) (*workflows.WorkflowDefinition, diag.Diagnostics) {
+// configured yet). A full workflow export document is reduced to its
+// "definition" and null values are dropped first (see workflow_definition.go).
+func definitionToAPI(v workflowDefinitionValue) (*workflows.WorkflowDefinition, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if v.IsNull() || v.IsUnknown() || v.ValueString() == "" {
 		return nil, diags
 	}
+	normalized, err := normalizeWorkflowDefinitionJSON(v.ValueString())
 	var def workflows.WorkflowDefinition
-	if err := json.Unmarshal([]byte(v.ValueString()), &def); err != nil {
+	if err == nil {
+		err = json.Unmarshal([]byte(normalized), &def)
+	}
+	if err != nil {
 		diags.AddError(
 			"Invalid \"definition\" JSON",
 			fmt.Sprintf("Could not decode \"definition\" as a JSON object: %s", err.Error()),
@@ -493,21 +529,25 @@ func definitionToAPI(v jsontypes.Normalized) (*workflows.WorkflowDefinition, dia
 }
 
 // definitionFromAPI re-encodes an API-returned *workflows.WorkflowDefinition
-// as a jsontypes.Normalized JSON string.
-func definitionFromAPI(def *workflows.WorkflowDefinition) (jsontypes.Normalized, diag.Diagnostics) {
+// as a normalized workflowDefinitionValue JSON string.
+func definitionFromAPI(def *workflows.WorkflowDefinition) (workflowDefinitionValue, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if def == nil {
-		return jsontypes.NewNormalizedNull(), diags
+		return newWorkflowDefinitionNull(), diags
 	}
 	b, err := json.Marshal(def)
+	var normalized string
+	if err == nil {
+		normalized, err = normalizeWorkflowDefinitionJSON(string(b))
+	}
 	if err != nil {
 		diags.AddError(
 			"Error encoding \"definition\" from API response",
 			fmt.Sprintf("Could not re-encode the API's \"definition\" value as JSON: %s", err.Error()),
 		)
-		return jsontypes.NewNormalizedNull(), diags
+		return newWorkflowDefinitionNull(), diags
 	}
-	return jsontypes.NewNormalizedValue(string(b)), diags
+	return newWorkflowDefinitionValue(normalized), diags
 }
 
 // triggerObjectToAPI converts the hand-written "trigger" types.Object
@@ -615,15 +655,12 @@ func applyWorkflowDefinitionField(attrs *map[string]resourceschema.Attribute) {
 	if *attrs == nil {
 		*attrs = map[string]resourceschema.Attribute{}
 	}
-	desc := "The map of steps that the workflow will execute, as a raw JSON object (`{\"start\": \"...\", \"steps\": " +
-		"{...}}`). Each step's own shape varies by its \"type\" - see " +
-		"https://developer.sailpoint.com/docs/extensibility/workflows/ for the JSON schema each step type expects."
 	(*attrs)["definition"] = resourceschema.StringAttribute{
-		CustomType:          jsontypes.NormalizedType{},
+		CustomType:          workflowDefinitionType{},
 		Optional:            true,
 		Computed:            true,
-		Description:         desc,
-		MarkdownDescription: desc,
+		Description:         workflowDefinitionDescription,
+		MarkdownDescription: workflowDefinitionMarkdownDescription,
 		PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 	}
 }
@@ -0,0 +1,221 @@
+// This file implements identitynow_workflow_external_client_v1, which
+// generates the OAuth client an EXTERNAL-triggered workflow is invoked with,
+// via POST /workflows/v1/{id}/external/oauth-clients
+// (workflows.WorkflowsAPI.CreateWorkflowExternalTriggerV1). External systems
+// use the client to get an access token and then call the returned url
+// (POST /workflows/v1/execute/external/{id}).
+//
+// The API only has the POST: a client can't be read back, listed or
+// deleted, and its secret is only returned when it is generated. So this
+// is a trigger-style resource like identitynow_workflow_test_v1 - Create
+// generates a client and keeps it in state, Read is a no-op, Delete just
+// forgets it, and changing workflow_id or rotation_triggers replaces the
+// resource, generating a new client. There is no import, since an existing
+// client's secret can't be recovered.
+//
+// There is deliberately no ephemeral resource variant: Terraform opens an
+// ephemeral resource on every plan and apply, and since the API can only
+// generate clients, each run would mint (and possibly retire) one. The
+// sensitive attributes can still be passed to ephemeral outputs and
+// write-only arguments, but they stay in this resource's state.
+package workflow_v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+)
+
+var (
+	_ resource.Resource              = (*workflowExternalClientResource)(nil)
+	_ resource.ResourceWithConfigure = (*workflowExternalClientResource)(nil)
+)
+
+func NewWorkflowExternalClientResource() resource.Resource {
+	return &workflowExternalClientResource{}
+}
+
+type workflowExternalClientResource struct {
+	client *sailpoint.APIClient
+}
+
+type workflowExternalClientResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	WorkflowId       types.String `tfsdk:"workflow_id"`
+	RotationTriggers types.Map    `tfsdk:"rotation_triggers"`
+	ClientId         types.String `tfsdk:"client_id"`
+	ClientSecret     types.String `tfsdk:"client_secret"`
+	Url              types.String `tfsdk:"url"`
+}
+
+func (r *workflowExternalClientResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_external_client_v1"
+}
+
+func (r *workflowExternalClientResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceschema.Schema{
+		Description: "Generates the OAuth client an external system uses to start a Workflow with an EXTERNAL trigger.",
+		MarkdownDescription: "Generates the OAuth client an external system uses to start a " +
+			"[Workflow](https://developer.sailpoint.com/docs/extensibility/workflows/) with an `EXTERNAL` trigger, via " +
+			"`POST /workflows/v1/{id}/external/oauth-clients`. The external system exchanges `client_id`/`client_secret` " +
+			"for an access token and then calls `url`. Changing `workflow_id` or `rotation_triggers` generates a new " +
+			"client; destroying the resource makes no API call.\n\n" +
+			"~> `client_secret` is only returned when the client is generated, so it is kept in state (marked sensitive) " +
+			"and the resource can't be imported. Store state accordingly, or pass the credentials straight to a secret " +
+			"store.",
+		Attributes: map[string]resourceschema.Attribute{
+			"id": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "Same as workflow_id; client_id is sensitive, so it isn't reused as the ID.",
+				MarkdownDescription: "Same as `workflow_id`; `client_id` is sensitive, so it isn't reused as the ID.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workflow_id": resourceschema.StringAttribute{
+				Required:            true,
+				Description:         "ID of the workflow to generate the client for. Its trigger must be of type EXTERNAL.",
+				MarkdownDescription: "ID of the workflow to generate the client for. Its `trigger.type` must be `EXTERNAL`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotation_triggers": resourceschema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Description:         "Arbitrary key/value pairs that force replacement when changed, rotating the client.",
+				MarkdownDescription: "Arbitrary key/value pairs that force replacement when changed, rotating the client (e.g. a `time_rotating` timestamp).",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"client_id": resourceschema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				Description:         "OAuth client ID.",
+				MarkdownDescription: "OAuth client ID.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_secret": resourceschema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				Description:         "OAuth client secret.",
+				MarkdownDescription: "OAuth client secret.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "URL the external system calls to start the workflow.",
+				MarkdownDescription: "URL the external system calls to start the workflow (`.../workflows/v1/execute/external/{id}`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *workflowExternalClientResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = cp.GetClient()
+}
+
+func (r *workflowExternalClientResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan workflowExternalClientResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workflowId := plan.WorkflowId.ValueString()
+	tflog.Debug(ctx, "Generating Workflow external trigger client", map[string]interface{}{"workflow_id": workflowId})
+
+	apiResp, httpResp, err := r.client.WorkflowsAPI.CreateWorkflowExternalTriggerV1(ctx, workflowId).Execute()
+	if err != nil {
+		tflog.Error(ctx, "Error generating Workflow external trigger client", map[string]interface{}{"error": err.Error()})
+		resp.Diagnostics.AddError(
+			"Error generating Workflow external trigger client",
+			errDetail(err, httpResp)+" Note: the workflow's trigger.type must be EXTERNAL.",
+		)
+		return
+	}
+	state, err := workflowExternalClientState(plan, apiResp.GetId(), apiResp.GetSecret(), apiResp.GetUrl())
+	if err != nil {
+		resp.Diagnostics.AddError("Error generating Workflow external trigger client", err.Error())
+		return
+	}
+
+	tflog.Info(ctx, "Generated Workflow external trigger client", map[string]interface{}{"workflow_id": workflowId})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *workflowExternalClientResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// No-op by design: the API has no endpoint to read a client back.
+	var state workflowExternalClientResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *workflowExternalClientResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every configurable attribute forces replacement, so there is
+	// nothing to update; keep the existing client.
+	var state workflowExternalClientResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *workflowExternalClientResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// No API call: clients can't be deleted. The client lives as long as
+	// the workflow does, unless generating a newer one retires it.
+	resp.State.RemoveResource(ctx)
+}
+
+// workflowExternalClientState is the state Create keeps for a client the
+// API generated for plan's workflow. Without an id and secret there is
+// nothing the external system could use, so that is an error.
+func workflowExternalClientState(plan workflowExternalClientResourceModel, clientId, clientSecret, url string) (workflowExternalClientResourceModel, error) {
+	workflowId := plan.WorkflowId.ValueString()
+	if clientId == "" || clientSecret == "" {
+		return plan, fmt.Errorf("the API returned no client id and secret for workflow %q", workflowId)
+	}
+
+	state := plan
+	state.Id = types.StringValue(workflowId)
+	state.ClientId = types.StringValue(clientId)
+	state.ClientSecret = types.StringValue(clientSecret)
+	state.Url = types.StringValue(url)
+	return state, nil
+}
@@ -0,0 +1,132 @@
+package workflow_v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func workflowExternalClientSchema(t *testing.T) resourceschema.Schema {
+	t.Helper()
+	var resp resource.SchemaResponse
+	NewWorkflowExternalClientResource().Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Schema: %v", resp.Diagnostics)
+	}
+	return resp.Schema
+}
+
+func workflowExternalClientTestState(t *testing.T, m workflowExternalClientResourceModel) tfsdk.State {
+	t.Helper()
+	s := workflowExternalClientSchema(t)
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}
+	if diags := state.Set(context.Background(), &m); diags.HasError() {
+		t.Fatalf("State.Set: %v", diags)
+	}
+	return state
+}
+
+func testWorkflowExternalClientModel() workflowExternalClientResourceModel {
+	return workflowExternalClientResourceModel{
+		Id:               types.StringValue("wf-1"),
+		WorkflowId:       types.StringValue("wf-1"),
+		RotationTriggers: types.MapValueMust(types.StringType, map[string]attr.Value{"rotated": types.StringValue("2026-01-01")}),
+		ClientId:         types.StringValue("client-1"),
+		ClientSecret:     types.StringValue("secret-1"),
+		Url:              types.StringValue("https://tenant.api.identitynow.com/beta/workflows/execute/external/wf-1"),
+	}
+}
+
+func TestWorkflowExternalClientSchema(t *testing.T) {
+	s := workflowExternalClientSchema(t)
+
+	for _, name := range []string{"client_id", "client_secret"} {
+		if !s.Attributes[name].IsSensitive() {
+			t.Errorf("%s is not sensitive", name)
+		}
+	}
+	for _, name := range []string{"id", "url", "workflow_id", "rotation_triggers"} {
+		if s.Attributes[name].IsSensitive() {
+			t.Errorf("%s is sensitive", name)
+		}
+	}
+	if len(s.Attributes["workflow_id"].(resourceschema.StringAttribute).PlanModifiers) == 0 {
+		t.Error("workflow_id has no RequiresReplace plan modifier")
+	}
+	if len(s.Attributes["rotation_triggers"].(resourceschema.MapAttribute).PlanModifiers) == 0 {
+		t.Error("rotation_triggers has no RequiresReplace plan modifier")
+	}
+
+	// An existing client's secret can't be recovered, so there is no import.
+	if _, ok := NewWorkflowExternalClientResource().(resource.ResourceWithImportState); ok {
+		t.Error("workflowExternalClientResource implements ImportState")
+	}
+}
+
+func TestWorkflowExternalClientState(t *testing.T) {
+	plan := testWorkflowExternalClientModel()
+	plan.Id = types.StringUnknown()
+	plan.ClientId = types.StringUnknown()
+	plan.ClientSecret = types.StringUnknown()
+	plan.Url = types.StringUnknown()
+
+	got, err := workflowExternalClientState(plan, "client-2", "secret-2", "https://example.com/execute/external/wf-1")
+	if err != nil {
+		t.Fatalf("workflowExternalClientState: %v", err)
+	}
+	if got.Id.ValueString() != "wf-1" || got.ClientId.ValueString() != "client-2" ||
+		got.ClientSecret.ValueString() != "secret-2" || got.Url.ValueString() != "https://example.com/execute/external/wf-1" {
+		t.Errorf("state = %+v, want wf-1/client-2/secret-2/url", got)
+	}
+	if !got.RotationTriggers.Equal(plan.RotationTriggers) {
+		t.Errorf("rotation_triggers = %s, want %s", got.RotationTriggers, plan.RotationTriggers)
+	}
+
+	for _, tt := range []struct{ id, secret string }{{"", "secret"}, {"client", ""}} {
+		if _, err := workflowExternalClientState(plan, tt.id, tt.secret, ""); err == nil {
+			t.Errorf("workflowExternalClientState(%q, %q) returned no error", tt.id, tt.secret)
+		}
+	}
+}
+
+func TestWorkflowExternalClientReadKeepsState(t *testing.T) {
+	ctx := context.Background()
+	want := testWorkflowExternalClientModel()
+	state := workflowExternalClientTestState(t, want)
+
+	r := &workflowExternalClientResource{}
+	resp := resource.ReadResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw.Copy()}}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+
+	var got workflowExternalClientResourceModel
+	if diags := resp.State.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("State.Get: %v", diags)
+	}
+	if !got.Id.Equal(want.Id) || !got.WorkflowId.Equal(want.WorkflowId) || !got.RotationTriggers.Equal(want.RotationTriggers) ||
+		!got.ClientId.Equal(want.ClientId) || !got.ClientSecret.Equal(want.ClientSecret) || !got.Url.Equal(want.Url) {
+		t.Errorf("Read changed state to %+v, want %+v", got, want)
+	}
+}
+
+func TestWorkflowExternalClientDeleteForgetsClient(t *testing.T) {
+	state := workflowExternalClientTestState(t, testWorkflowExternalClientModel())
+
+	r := &workflowExternalClientResource{}
+	resp := resource.DeleteResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw.Copy()}}
+	r.Delete(context.Background(), resource.DeleteRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("Delete left the client in state")
+	}
+}
@@ -11,6 +11,16 @@ const workflowTriggerDescription = "The trigger that starts the workflow. \"attr
 	"shape depends on \"type\" - see the resource/data source's top-level description for the shape each trigger " +
 	"\"type\" expects."
 
+const workflowDefinitionDescription = "The map of steps that the workflow will execute, as a raw JSON object " +
+	"(`{\"start\": \"...\", \"steps\": {...}}`). Each step's own shape varies by its \"type\" - see " +
+	"https://developer.sailpoint.com/docs/extensibility/workflows/ for the JSON schema each step type expects."
+
+// workflowDefinitionMarkdownDescription adds what the resource accepts on
+// input (see workflow_definition.go).
+const workflowDefinitionMarkdownDescription = workflowDefinitionDescription + " A full workflow document exported " +
+	"from the UI (or by `identitynow_workflow_export_v1`) is also accepted; only its `definition` is used. Values that " +
+	"differ only in key order, null-valued keys or server-managed fields are treated as equal."
+
 // basetypesObjectAsOptions returns the (currently zero-value) options used
 // whenever a hand-written "trigger" types.Object is decoded via .As(...) -
 // factored into a helper purely so every call site stays consistent if a
@@ -98,13 +108,10 @@ func applyWorkflowDataSourceDefinitionField(attrs *map[string]datasourceschema.A
 	if *attrs == nil {
 		*attrs = map[string]datasourceschema.Attribute{}
 	}
-	desc := "The map of steps that the workflow will execute, as a raw JSON object (`{\"start\": \"...\", \"steps\": " +
-		"{...}}`). Each step's own shape varies by its \"type\" - see " +
-		"https://developer.sailpoint.com/docs/extensibility/workflows/ for the JSON schema each step type expects."
 	(*attrs)["definition"] = datasourceschema.StringAttribute{
-		CustomType:          jsontypes.NormalizedType{},
+		CustomType:          workflowDefinitionType{},
 		Computed:            true,
-		Description:         desc,
-		MarkdownDescription: desc,
+		Description:         workflowDefinitionDescription,
+		MarkdownDescription: workflowDefinitionDescription,
 	}
 }
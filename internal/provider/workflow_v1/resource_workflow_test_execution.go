@@ -0,0 +1,408 @@
+// This file implements identitynow_workflow_test_v1, a trigger-style
+// resource (see source_load_entitlement_wait_v1 for the pattern) around
+// POST /workflows/v1/{id}/test. Create starts a test run with the given
+// input, waits for the execution to finish and fails the apply, with the
+// execution's step history, unless it completed with no failed step. Read is a no-op, Update
+// only persists create_timeout, and Delete just forgets the run.
+//
+// The run is followed through GET /workflow-executions/v1/{id}/history
+// rather than GET /workflow-executions/v1/{id}: the spec describes the
+// latter's 200 response as a bare "items:" with no "type", so its
+// generated return type doesn't match the single object the endpoint
+// returns, while the history is a well-typed list whose closing
+// WorkflowExecution* event carries the same status - and is needed anyway
+// to report a failure.
+package workflow_v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+	"github.com/sailpoint-oss/golang-sdk/v3/workflows"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+// defaultWorkflowTestTimeout is create_timeout's default; a test run is
+// polled at util.PollInterval's cadence.
+const defaultWorkflowTestTimeout = "10m"
+
+var (
+	_ resource.Resource              = (*workflowTestResource)(nil)
+	_ resource.ResourceWithConfigure = (*workflowTestResource)(nil)
+)
+
+func NewWorkflowTestResource() resource.Resource {
+	return &workflowTestResource{}
+}
+
+type workflowTestResource struct {
+	client *sailpoint.APIClient
+}
+
+type workflowTestResourceModel struct {
+	Id            types.String         `tfsdk:"id"`
+	WorkflowId    types.String         `tfsdk:"workflow_id"`
+	Input         jsontypes.Normalized `tfsdk:"input"`
+	Triggers      types.Map            `tfsdk:"triggers"`
+	CreateTimeout types.String         `tfsdk:"create_timeout"`
+	Status        types.String         `tfsdk:"status"`
+	StartTime     types.String         `tfsdk:"start_time"`
+	CloseTime     types.String         `tfsdk:"close_time"`
+}
+
+// workflowTestResult is what a finished execution's history says about it.
+type workflowTestResult struct {
+	Status    string
+	StartTime types.String
+	CloseTime types.String
+}
+
+func (r *workflowTestResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_test_v1"
+}
+
+func (r *workflowTestResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceschema.Schema{
+		Description: "Runs a test execution of a Workflow with a JSON input and waits for it to finish, failing if any step fails.",
+		MarkdownDescription: "Runs a test execution of a [Workflow](https://developer.sailpoint.com/docs/extensibility/workflows/) " +
+			"via `POST /workflows/v1/{id}/test` with a JSON `input`, then waits for the execution to finish. The apply fails, " +
+			"showing the execution's step history, unless the execution completes. This is a trigger-style resource with " +
+			"`null_resource`-style replacement behavior: changing `workflow_id`, `input` or `triggers` runs a new test, and " +
+			"destroying it makes no API call.\n\n" +
+			"~> A test is a **live run** of the workflow - its actions really happen in the tenant. The workflow must be " +
+			"disabled (`enabled = false`) to be tested.",
+		Attributes: map[string]resourceschema.Attribute{
+			"id": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "ID of the workflow execution the test started.",
+				MarkdownDescription: "ID of the workflow execution the test started.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workflow_id": resourceschema.StringAttribute{
+				Required:            true,
+				Description:         "ID of the workflow to test. The workflow must be disabled.",
+				MarkdownDescription: "ID of the workflow to test. The workflow must be disabled.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"input": resourceschema.StringAttribute{
+				CustomType:  jsontypes.NormalizedType{},
+				Required:    true,
+				Description: "Test input as a raw JSON object, resembling the input the workflow's trigger would send.",
+				MarkdownDescription: "Test input as a raw JSON object, resembling the input the workflow's trigger would send - " +
+					"see the [event trigger documentation](https://developer.sailpoint.com/docs/extensibility/event-triggers/available) " +
+					"for each trigger's input. Changing it runs a new test.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": resourceschema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Description:         "Arbitrary key/value pairs that force replacement when changed, running a new test.",
+				MarkdownDescription: "Arbitrary key/value pairs that force replacement when changed, running a new test.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"create_timeout": resourceschema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultWorkflowTestTimeout),
+				Description:         "Maximum time to wait for the test execution to finish, as a Go duration string.",
+				MarkdownDescription: "Maximum time to wait for the test execution to finish, as a Go duration string such as `30m`. Defaults to `10m`.",
+			},
+			"status": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "Final status of the test execution. Always Completed in state, since any other outcome fails the apply.",
+				MarkdownDescription: "Final status of the test execution. Always `Completed` in state, since any other outcome fails the apply.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"start_time": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "Date/time when the test execution started, in RFC3339 format.",
+				MarkdownDescription: "Date/time when the test execution started, in RFC3339 format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"close_time": resourceschema.StringAttribute{
+				Computed:            true,
+				Description:         "Date/time when the test execution finished, in RFC3339 format.",
+				MarkdownDescription: "Date/time when the test execution finished, in RFC3339 format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *workflowTestResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cp, ok := req.ProviderData.(clientProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected a provider client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = cp.GetClient()
+}
+
+func (r *workflowTestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan workflowTestResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, err := util.ParseWaitTimeout("create_timeout", plan.CreateTimeout, defaultWorkflowTestTimeout)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid create_timeout", err.Error())
+		return
+	}
+
+	var input map[string]interface{}
+	if err := json.Unmarshal([]byte(plan.Input.ValueString()), &input); err != nil || input == nil {
+		resp.Diagnostics.AddError("Invalid input", "input must be a JSON object.")
+		return
+	}
+
+	createCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	workflowId := plan.WorkflowId.ValueString()
+	tflog.Debug(ctx, "Testing Workflow", map[string]interface{}{"workflow_id": workflowId})
+
+	apiResp, httpResp, err := r.client.WorkflowsAPI.
+		TestWorkflowV1(createCtx, workflowId).
+		TestWorkflowV1Request(*workflows.NewTestWorkflowV1Request(input)).
+		Execute()
+	if err != nil {
+		tflog.Error(ctx, "Error testing Workflow", map[string]interface{}{"error": err.Error()})
+		resp.Diagnostics.AddError(
+			"Error testing Workflow",
+			errDetail(err, httpResp)+" Note: only disabled workflows can be tested - set enabled = false on the workflow first.",
+		)
+		return
+	}
+	executionId := apiResp.GetWorkflowExecutionId()
+	if executionId == "" {
+		resp.Diagnostics.AddError("Error testing Workflow", fmt.Sprintf("Testing workflow %q did not return an execution id to wait for.", workflowId))
+		return
+	}
+
+	tflog.Info(ctx, "Started Workflow test execution", map[string]interface{}{"workflow_id": workflowId, "execution_id": executionId})
+
+	result, history, err := r.waitForWorkflowExecution(createCtx, executionId, timeout)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for Workflow test execution",
+			fmt.Sprintf("Test execution %q of workflow %q: %s", executionId, workflowId, err.Error()),
+		)
+		return
+	}
+	if failure := workflowTestFailure(result, history); failure != "" {
+		resp.Diagnostics.AddError(
+			"Workflow test execution failed",
+			fmt.Sprintf("Test execution %q of workflow %q %s. Step history:\n\n%s",
+				executionId, workflowId, failure, workflowExecutionHistorySummary(history)),
+		)
+		return
+	}
+
+	state := plan
+	state.Id = types.StringValue(executionId)
+	state.Status = types.StringValue(result.Status)
+	state.StartTime = result.StartTime
+	state.CloseTime = result.CloseTime
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *workflowTestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// No-op by design: a test run is over once Create returns, and its
+	// execution is archived after 90 days, so there is nothing to refresh.
+	var state workflowTestResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *workflowTestResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan workflowTestResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state workflowTestResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := util.ParseWaitTimeout("create_timeout", plan.CreateTimeout, defaultWorkflowTestTimeout); err != nil {
+		resp.Diagnostics.AddError("Invalid create_timeout", err.Error())
+		return
+	}
+
+	// workflow_id, input and triggers force replacement, so only
+	// create_timeout can change here - and it must not start a new test.
+	state.CreateTimeout = plan.CreateTimeout
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *workflowTestResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// No API call: a finished test execution can't be undone.
+	resp.State.RemoveResource(ctx)
+}
+
+// waitForWorkflowExecution polls executionId's history until it holds a
+// closing WorkflowExecution* event, and returns the outcome together with
+// the full history. Right after a test starts the execution may not be
+// visible yet, so a 404 is polled through like an unfinished history.
+func (r *workflowTestResource) waitForWorkflowExecution(ctx context.Context, executionId string, timeout time.Duration) (workflowTestResult, []workflows.GetWorkflowExecutionHistoryV1200ResponseInner, error) {
+	started := time.Now()
+	for attempt := 0; ; attempt++ {
+		history, httpResp, err := r.client.WorkflowsAPI.GetWorkflowExecutionHistoryV1(ctx, executionId).Execute()
+		if err != nil {
+			if ctx.Err() != nil {
+				return workflowTestResult{}, nil, fmt.Errorf("timed out after %s waiting for the execution to finish", timeout)
+			}
+			if httpResp == nil || httpResp.StatusCode != http.StatusNotFound {
+				return workflowTestResult{}, nil, fmt.Errorf("retrieving execution history: %s", errDetail(err, httpResp))
+			}
+		}
+
+		if result, done := workflowExecutionResult(history); done {
+			tflog.Info(ctx, "Workflow test execution finished", map[string]interface{}{
+				"execution_id": executionId,
+				"status":       result.Status,
+				"elapsed":      time.Since(started).Round(time.Second).String(),
+			})
+			return result, history, nil
+		}
+
+		tflog.Info(ctx, "Waiting for Workflow test execution", map[string]interface{}{
+			"execution_id": executionId,
+			"events":       len(history),
+			"elapsed":      time.Since(started).Round(time.Second).String(),
+		})
+
+		if err := util.SleepWithContext(ctx, util.PollInterval(attempt)); err != nil {
+			return workflowTestResult{}, nil, fmt.Errorf("timed out after %s waiting for the execution to finish", timeout)
+		}
+	}
+}
+
+// workflowExecutionResult reports whether history holds the event that
+// closed the execution. The status is that event's type without its
+// "WorkflowExecution" prefix - WorkflowExecutionCompleted gives the
+// executions list's "Completed", WorkflowExecutionFailed gives "Failed" -
+// so a closing event the spec doesn't list yet (a cancellation, say) still
+// ends the wait instead of running into the timeout.
+func workflowExecutionResult(history []workflows.GetWorkflowExecutionHistoryV1200ResponseInner) (workflowTestResult, bool) {
+	result := workflowTestResult{StartTime: types.StringNull(), CloseTime: types.StringNull()}
+	done := false
+	for i := range history {
+		event := &history[i]
+		status, ok := strings.CutPrefix(event.GetType(), "WorkflowExecution")
+		if !ok {
+			continue
+		}
+		switch status {
+		case "Scheduled":
+		case "Started":
+			result.StartTime = timeToStringValue(event.Timestamp)
+		default:
+			result.Status = status
+			result.CloseTime = timeToStringValue(event.Timestamp)
+			done = true
+		}
+	}
+	return result, done
+}
+
+// workflowTestFailure says why a finished test run failed, or returns "" if
+// it passed: the execution must have completed, and no event in its history
+// may be a *Failed one - a step can fail (ActivityTaskFailed,
+// WorkflowTaskFailed) in a run that still completes.
+func workflowTestFailure(result workflowTestResult, history []workflows.GetWorkflowExecutionHistoryV1200ResponseInner) string {
+	var failed []string
+	for i := range history {
+		event := &history[i]
+		if !strings.HasSuffix(event.GetType(), "Failed") || strings.HasPrefix(event.GetType(), "WorkflowExecution") {
+			continue
+		}
+		step := event.GetType()
+		if name, ok := event.Attributes["displayName"].(string); ok && name != "" {
+			step += fmt.Sprintf(" %q", name)
+		}
+		failed = append(failed, step)
+	}
+
+	switch {
+	case result.Status != "Completed":
+		return fmt.Sprintf("finished with status %q", result.Status)
+	case len(failed) > 0:
+		return fmt.Sprintf("completed, but with failed steps: %s", strings.Join(failed, ", "))
+	}
+	return ""
+}
+
+// workflowExecutionHistorySummary renders history one event per line, for
+// the error a failed test reports. Attributes are only printed for *Failed
+// events - they carry the error - to keep the summary readable.
+func workflowExecutionHistorySummary(history []workflows.GetWorkflowExecutionHistoryV1200ResponseInner) string {
+	if len(history) == 0 {
+		return "(no history recorded)"
+	}
+	lines := make([]string, 0, len(history))
+	for i := range history {
+		event := &history[i]
+		line := event.GetType()
+		if event.Timestamp != nil {
+			line = event.Timestamp.Format(time.RFC3339) + " " + line
+		}
+		if name, ok := event.Attributes["displayName"].(string); ok && name != "" {
+			line += fmt.Sprintf(" %q", name)
+		}
+		if strings.HasSuffix(event.GetType(), "Failed") && len(event.Attributes) > 0 {
+			if b, err := json.Marshal(event.Attributes); err == nil {
+				line += ": " + string(b)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,132 @@
+package workflow_v1
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sailpoint-oss/golang-sdk/v3/workflows"
+)
+
+func workflowTestEvent(typ string, minute int, attrs map[string]interface{}) workflows.GetWorkflowExecutionHistoryV1200ResponseInner {
+	ts := workflows.SailPointTime{Time: time.Date(2026, time.March, 4, 5, minute, 0, 0, time.UTC)}
+	return workflows.GetWorkflowExecutionHistoryV1200ResponseInner{Type: &typ, Timestamp: &ts, Attributes: attrs}
+}
+
+func TestWorkflowExecutionResult(t *testing.T) {
+	t.Run("still running", func(t *testing.T) {
+		history := []workflows.GetWorkflowExecutionHistoryV1200ResponseInner{
+			workflowTestEvent("WorkflowExecutionScheduled", 0, nil),
+			workflowTestEvent("WorkflowExecutionStarted", 1, nil),
+			workflowTestEvent("ActivityTaskScheduled", 2, nil),
+		}
+		if _, done := workflowExecutionResult(history); done {
+			t.Fatal("workflowExecutionResult reported done before a closing event")
+		}
+	})
+
+	t.Run("no history yet", func(t *testing.T) {
+		if _, done := workflowExecutionResult(nil); done {
+			t.Fatal("workflowExecutionResult reported done for an empty history")
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		history := []workflows.GetWorkflowExecutionHistoryV1200ResponseInner{
+			workflowTestEvent("WorkflowExecutionStarted", 1, nil),
+			workflowTestEvent("ActivityTaskFailed", 2, nil),
+			workflowTestEvent("WorkflowExecutionFailed", 3, nil),
+		}
+		result, done := workflowExecutionResult(history)
+		if !done {
+			t.Fatal("workflowExecutionResult did not report done")
+		}
+		if result.Status != "Failed" {
+			t.Errorf("Status = %q, want %q", result.Status, "Failed")
+		}
+		if got := result.StartTime.ValueString(); got != "2026-03-04T05:01:00Z" {
+			t.Errorf("StartTime = %q, want 2026-03-04T05:01:00Z", got)
+		}
+		if got := result.CloseTime.ValueString(); got != "2026-03-04T05:03:00Z" {
+			t.Errorf("CloseTime = %q, want 2026-03-04T05:03:00Z", got)
+		}
+	})
+
+	t.Run("unlisted closing event", func(t *testing.T) {
+		history := []workflows.GetWorkflowExecutionHistoryV1200ResponseInner{
+			workflowTestEvent("WorkflowExecutionCanceled", 4, nil),
+		}
+		result, done := workflowExecutionResult(history)
+		if !done || result.Status != "Canceled" {
+			t.Errorf("workflowExecutionResult = %q, %t; want %q, true", result.Status, done, "Canceled")
+		}
+		if !result.StartTime.IsNull() {
+			t.Errorf("StartTime = %q, want null", result.StartTime.ValueString())
+		}
+	})
+}
+
+func TestWorkflowTestFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		history []workflows.GetWorkflowExecutionHistoryV1200ResponseInner
+		want    string
+	}{
+		{
+			name: "completed",
+			history: []workflows.GetWorkflowExecutionHistoryV1200ResponseInner{
+				workflowTestEvent("WorkflowExecutionStarted", 1, nil),
+				workflowTestEvent("ActivityTaskCompleted", 2, map[string]interface{}{"displayName": "Send Email"}),
+				workflowTestEvent("WorkflowExecutionCompleted", 3, nil),
+			},
+			want: "",
+		},
+		{
+			name: "completed with a failed activity",
+			history: []workflows.GetWorkflowExecutionHistoryV1200ResponseInner{
+				workflowTestEvent("WorkflowExecutionStarted", 1, nil),
+				workflowTestEvent("ActivityTaskFailed", 2, map[string]interface{}{"displayName": "Send Email", "error": "recipient not found"}),
+				workflowTestEvent("WorkflowTaskFailed", 3, nil),
+				workflowTestEvent("WorkflowExecutionCompleted", 4, nil),
+			},
+			want: `completed, but with failed steps: ActivityTaskFailed "Send Email", WorkflowTaskFailed`,
+		},
+		{
+			name: "failed",
+			history: []workflows.GetWorkflowExecutionHistoryV1200ResponseInner{
+				workflowTestEvent("ActivityTaskFailed", 2, nil),
+				workflowTestEvent("WorkflowExecutionFailed", 3, nil),
+			},
+			want: `finished with status "Failed"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, done := workflowExecutionResult(tt.history)
+			if !done {
+				t.Fatal("workflowExecutionResult did not report done")
+			}
+			if got := workflowTestFailure(result, tt.history); got != tt.want {
+				t.Errorf("workflowTestFailure = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkflowExecutionHistorySummary(t *testing.T) {
+	history := []workflows.GetWorkflowExecutionHistoryV1200ResponseInner{
+		workflowTestEvent("ActivityTaskScheduled", 1, map[string]interface{}{"displayName": "Send Email"}),
+		workflowTestEvent("ActivityTaskFailed", 2, map[string]interface{}{"displayName": "Send Email", "error": "recipient not found"}),
+	}
+	want := strings.Join([]string{
+		`2026-03-04T05:01:00Z ActivityTaskScheduled "Send Email"`,
+		`2026-03-04T05:02:00Z ActivityTaskFailed "Send Email": {"displayName":"Send Email","error":"recipient not found"}`,
+	}, "\n")
+	if got := workflowExecutionHistorySummary(history); got != want {
+		t.Errorf("workflowExecutionHistorySummary =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := workflowExecutionHistorySummary(nil); got != "(no history recorded)" {
+		t.Errorf("workflowExecutionHistorySummary(nil) = %q", got)
+	}
+}
@@ -0,0 +1,343 @@
+// This file implements plan-time checks of workflowResource's "definition"
+// and "trigger". Both are opaque JSON strings (see the package doc), so
+// without these a misspelled step name or an unknown actionId was only
+// reported by the API at apply - or, for a dangling nextStep, not until the
+// workflow ran.
+//
+// The checks come in two layers:
+//   - ValidateConfig checks the shape on its own: "start" names a step,
+//     every nextStep/defaultStep/choices[].nextStep names a step, every step
+//     is reachable from "start" and leads on unless it is a success or
+//     failure step, and an EVENT/SCHEDULED trigger has the
+//     attribute that identifies it. Loop steps (attributes.start +
+//     attributes.steps) are checked the same way, as their own graph.
+//   - ModifyPlan checks the definition against the workflow library: each
+//     step's actionId must exist, each required input of the action must be
+//     set (as "name" or "name.$"), and an EVENT trigger's id must be a
+//     library trigger. It runs there rather than in ValidateConfig because
+//     the provider - and so the API client - isn't configured yet when
+//     `terraform validate` runs; the library is read once per provider
+//     instance (see workflow_library.go). If it can't be read, the plan
+//     goes ahead with a warning. ModifyPlan also carries
+//     warn_when_disabled's warning (resource_warn_when_disabled.go).
+//
+// Each problem is reported as a separate diagnostic prefixed with its
+// location, e.g. `steps["Send Email"].nextStep`.
+package workflow_v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+var (
+	_ resource.ResourceWithValidateConfig = (*workflowResource)(nil)
+	_ resource.ResourceWithModifyPlan     = (*workflowResource)(nil)
+)
+
+// workflowTerminalStepTypes end a workflow, so they have no next step.
+var workflowTerminalStepTypes = []string{"success", "failure"}
+
+func (r *workflowResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var definition workflowDefinitionValue
+	var trigger types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("definition"), &definition)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("trigger"), &trigger)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if def, ok := workflowDefinitionFromConfig(definition); ok {
+		for _, problem := range validateWorkflowDefinition(def, "") {
+			resp.Diagnostics.AddAttributeError(path.Root("definition"), "Invalid workflow definition", problem)
+		}
+	}
+
+	if typ, attrs, ok := workflowTriggerFromConfig(ctx, trigger); ok {
+		for _, problem := range validateWorkflowTrigger(typ, attrs) {
+			resp.Diagnostics.AddAttributeError(path.Root("trigger").AtName("attributes"), "Invalid workflow trigger", problem)
+		}
+	}
+}
+
+func (r *workflowResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	warnWorkflowDisabledOutsideTerraform(ctx, req, resp)
+	if resp.Diagnostics.HasError() || r.client == nil {
+		return
+	}
+
+	var definition workflowDefinitionValue
+	var trigger types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("definition"), &definition)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("trigger"), &trigger)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	def, defOk := workflowDefinitionFromConfig(definition)
+	typ, attrs, triggerOk := workflowTriggerFromConfig(ctx, trigger)
+	if !defOk && !triggerOk {
+		return
+	}
+
+	lib, err := loadWorkflowLibrary(ctx, r.client, r.cache)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Workflow library unavailable",
+			fmt.Sprintf("Could not read the workflow library, so actionIds, required inputs and trigger ids were not checked at plan time: %s", err.Error()),
+		)
+		return
+	}
+
+	if defOk {
+		for _, problem := range validateWorkflowDefinitionAgainstLibrary(def, lib, "") {
+			resp.Diagnostics.AddAttributeError(path.Root("definition"), "Invalid workflow definition", problem)
+		}
+	}
+	if triggerOk {
+		for _, problem := range validateWorkflowTriggerAgainstLibrary(typ, attrs, lib) {
+			resp.Diagnostics.AddAttributeError(path.Root("trigger").AtName("attributes"), "Invalid workflow trigger", problem)
+		}
+	}
+}
+
+// workflowDefinitionFromConfig decodes a known, non-empty "definition"
+// ("{}" means no definition yet, as in definitionToAPI).
+// A full export document is checked by its "definition". Undecodable JSON
+// is left to workflowDefinitionValue's own validation.
+func workflowDefinitionFromConfig(v workflowDefinitionValue) (map[string]interface{}, bool) {
+	if v.IsNull() || v.IsUnknown() || v.ValueString() == "" {
+		return nil, false
+	}
+	def, err := decodeWorkflowDefinition(v.ValueString())
+	if err != nil || len(def) == 0 {
+		return nil, false
+	}
+	return def, true
+}
+
+// workflowTriggerFromConfig returns a known trigger's type and decoded
+// attributes.
+func workflowTriggerFromConfig(ctx context.Context, v types.Object) (string, map[string]interface{}, bool) {
+	if v.IsNull() || v.IsUnknown() {
+		return "", nil, false
+	}
+	var m triggerModel
+	if diags := v.As(ctx, &m, basetypesObjectAsOptions()); diags.HasError() {
+		return "", nil, false
+	}
+	if m.Type.IsNull() || m.Type.IsUnknown() || m.Attributes.IsNull() || m.Attributes.IsUnknown() {
+		return "", nil, false
+	}
+	var attrs map[string]interface{}
+	if err := json.Unmarshal([]byte(m.Attributes.ValueString()), &attrs); err != nil || attrs == nil {
+		return "", nil, false
+	}
+	return m.Type.ValueString(), attrs, true
+}
+
+// validateWorkflowDefinition checks one step graph - the definition itself,
+// or a loop step's attributes - and recurses into loop steps. at locates
+// the graph inside "definition" ("" for the root).
+func validateWorkflowDefinition(def map[string]interface{}, at string) []string {
+	var problems []string
+	steps, ok := def["steps"].(map[string]interface{})
+	if !ok {
+		return []string{workflowPath(at, "steps") + ": must be an object of steps keyed by name"}
+	}
+	start, ok := def["start"].(string)
+	if !ok || start == "" {
+		problems = append(problems, workflowPath(at, "start")+": must name the first step")
+	} else if _, ok := steps[start]; !ok {
+		problems = append(problems, fmt.Sprintf("%s: %q is not a step in %s", workflowPath(at, "start"), start, workflowPath(at, "steps")))
+	}
+
+	edges := map[string][]string{}
+	for _, name := range util.SortedKeys(steps) {
+		stepAt := workflowStepPath(at, name)
+		step, ok := steps[name].(map[string]interface{})
+		if !ok {
+			problems = append(problems, stepAt+": must be an object")
+			continue
+		}
+		refs := workflowStepReferences(step)
+		// Loop bodies are left alone: how their last step hands back to
+		// the loop isn't documented.
+		if at == "" && len(refs) == 0 && !workflowStepTerminal(step) {
+			problems = append(problems, stepAt+": has no nextStep, but only success and failure steps can end the workflow")
+		}
+		for _, ref := range refs {
+			if _, ok := steps[ref.target]; !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s: %q is not a step in %s", stepAt, ref.field, ref.target, workflowPath(at, "steps")))
+				continue
+			}
+			edges[name] = append(edges[name], ref.target)
+		}
+		if loop, ok := workflowLoopDefinition(step); ok {
+			problems = append(problems, validateWorkflowDefinition(loop, stepAt+".attributes")...)
+		}
+	}
+
+	if _, ok := steps[start]; ok {
+		reached := map[string]bool{start: true}
+		queue := []string{start}
+		for len(queue) > 0 {
+			name := queue[0]
+			queue = queue[1:]
+			for _, next := range edges[name] {
+				if !reached[next] {
+					reached[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		for _, name := range util.SortedKeys(steps) {
+			if !reached[name] {
+				problems = append(problems, fmt.Sprintf("%s: is not reachable from start step %q", workflowStepPath(at, name), start))
+			}
+		}
+	}
+	return problems
+}
+
+// validateWorkflowDefinitionAgainstLibrary checks every step's actionId
+// and required inputs, including steps inside loops.
+func validateWorkflowDefinitionAgainstLibrary(def map[string]interface{}, lib *workflowLibrary, at string) []string {
+	var problems []string
+	steps, _ := def["steps"].(map[string]interface{})
+	for _, name := range util.SortedKeys(steps) {
+		stepAt := workflowStepPath(at, name)
+		step, ok := steps[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if actionId, ok := step["actionId"].(string); ok && actionId != "" {
+			item := findWorkflowLibraryItem(lib.Actions, actionId)
+			if item == nil {
+				item = findWorkflowLibraryItem(lib.Operators, actionId)
+			}
+			if item == nil {
+				problems = append(problems, fmt.Sprintf("%s.actionId: %q is not an action in the workflow library", stepAt, actionId))
+			} else {
+				attrs, _ := step["attributes"].(map[string]interface{})
+				for _, f := range item.FormFields {
+					if f.Required && !workflowStepHasInput(attrs, f.inputName()) {
+						problems = append(problems, fmt.Sprintf("%s.attributes: missing required input %q (%s) of %s",
+							stepAt, f.inputName(), f.Label, actionId))
+					}
+				}
+			}
+		}
+		if loop, ok := workflowLoopDefinition(step); ok {
+			problems = append(problems, validateWorkflowDefinitionAgainstLibrary(loop, lib, stepAt+".attributes")...)
+		}
+	}
+	return problems
+}
+
+// validateWorkflowTrigger checks that a trigger has the attribute that
+// identifies it. EXTERNAL triggers are filled in by the API.
+func validateWorkflowTrigger(typ string, attrs map[string]interface{}) []string {
+	switch typ {
+	case "EVENT":
+		if id, _ := attrs["id"].(string); id == "" {
+			return []string{"id: is required for an EVENT trigger (the event trigger id, e.g. idn:identity-attributes-changed)"}
+		}
+	case "SCHEDULED":
+		if f, _ := attrs["frequency"].(string); f == "" {
+			return []string{"frequency: is required for a SCHEDULED trigger"}
+		}
+	}
+	return nil
+}
+
+func validateWorkflowTriggerAgainstLibrary(typ string, attrs map[string]interface{}, lib *workflowLibrary) []string {
+	if typ != "EVENT" {
+		return nil
+	}
+	id, _ := attrs["id"].(string)
+	if id == "" || findWorkflowLibraryItem(lib.Triggers, id) != nil {
+		return nil
+	}
+	return []string{fmt.Sprintf("id: %q is not a trigger in the workflow library", id)}
+}
+
+type workflowStepReference struct {
+	field  string
+	target string
+}
+
+// workflowStepReferences returns the steps a step can continue with: its
+// nextStep, a choice step's defaultStep, and each choice's nextStep. A
+// null or empty reference is no reference.
+func workflowStepReferences(step map[string]interface{}) []workflowStepReference {
+	var refs []workflowStepReference
+	for _, field := range []string{"nextStep", "defaultStep"} {
+		if target, ok := step[field].(string); ok && target != "" {
+			refs = append(refs, workflowStepReference{field: field, target: target})
+		}
+	}
+	choices, _ := step["choices"].([]interface{})
+	for i, c := range choices {
+		choice, _ := c.(map[string]interface{})
+		if target, ok := choice["nextStep"].(string); ok && target != "" {
+			refs = append(refs, workflowStepReference{field: fmt.Sprintf("choices[%d].nextStep", i), target: target})
+		}
+	}
+	return refs
+}
+
+// workflowLoopDefinition returns a loop step's inner step graph, which
+// lives in its attributes as its own start and steps.
+func workflowLoopDefinition(step map[string]interface{}) (map[string]interface{}, bool) {
+	attrs, ok := step["attributes"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	if _, ok := attrs["steps"].(map[string]interface{}); !ok {
+		return nil, false
+	}
+	if _, ok := attrs["start"].(string); !ok {
+		return nil, false
+	}
+	return attrs, true
+}
+
+func workflowStepHasInput(attrs map[string]interface{}, name string) bool {
+	for _, key := range []string{name, name + ".$"} {
+		if v, ok := attrs[key]; ok && v != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func workflowPath(at, key string) string {
+	if at == "" {
+		return key
+	}
+	return at + "." + key
+}
+
+func workflowStepPath(at, name string) string {
+	return fmt.Sprintf("%s[%q]", workflowPath(at, "steps"), name)
+}
+
+// workflowStepTerminal reports whether a step ends the workflow.
+func workflowStepTerminal(step map[string]interface{}) bool {
+	typ, _ := step["type"].(string)
+	return util.ContainsString(workflowTerminalStepTypes, strings.ToLower(typ))
+}
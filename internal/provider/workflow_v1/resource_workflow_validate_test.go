@@ -0,0 +1,142 @@
+package workflow_v1
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func workflowTestDefinition(t *testing.T, s string) map[string]interface{} {
+	t.Helper()
+	var def map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &def); err != nil {
+		t.Fatalf("bad test definition: %v", err)
+	}
+	return def
+}
+
+func TestValidateWorkflowDefinition(t *testing.T) {
+	tests := []struct {
+		name string
+		def  string
+		want []string
+	}{
+		{
+			name: "valid",
+			def: `{"start": "Get Identity", "steps": {
+				"Get Identity": {"actionId": "sp:get-identity", "nextStep": "Is Manager"},
+				"Is Manager": {"type": "choice", "choices": [{"nextStep": "Send Email"}], "defaultStep": "End"},
+				"Send Email": {"actionId": "sp:send-email", "nextStep": "End"},
+				"End": {"type": "success"}
+			}}`,
+		},
+		{
+			name: "missing steps",
+			def:  `{"start": "A"}`,
+			want: []string{"steps: must be an object of steps keyed by name"},
+		},
+		{
+			name: "unknown start",
+			def:  `{"start": "Nope", "steps": {"End": {"type": "success"}}}`,
+			want: []string{`start: "Nope" is not a step in steps`},
+		},
+		{
+			name: "dangling references",
+			def: `{"start": "A", "steps": {
+				"A": {"type": "choice", "choices": [{"nextStep": "End"}, {"nextStep": "Missing"}], "defaultStep": "Gone"},
+				"End": {"type": "failure"}
+			}}`,
+			want: []string{
+				`steps["A"].defaultStep: "Gone" is not a step in steps`,
+				`steps["A"].choices[1].nextStep: "Missing" is not a step in steps`,
+			},
+		},
+		{
+			name: "dead end and unreachable step",
+			def: `{"start": "A", "steps": {
+				"A": {"actionId": "sp:send-email"},
+				"Orphan": {"type": "success"}
+			}}`,
+			want: []string{
+				`steps["A"]: has no nextStep, but only success and failure steps can end the workflow`,
+				`steps["Orphan"]: is not reachable from start step "A"`,
+			},
+		},
+		{
+			name: "loop body",
+			def: `{"start": "Loop", "steps": {
+				"Loop": {"actionId": "sp:loop:iterator", "nextStep": "End", "attributes": {
+					"start": "Inner",
+					"steps": {"Inner": {"actionId": "sp:send-email", "nextStep": "Nowhere"}}
+				}},
+				"End": {"type": "success"}
+			}}`,
+			want: []string{
+				`steps["Loop"].attributes.steps["Inner"].nextStep: "Nowhere" is not a step in steps["Loop"].attributes.steps`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateWorkflowDefinition(workflowTestDefinition(t, tt.def), "")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateWorkflowDefinition =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWorkflowDefinitionAgainstLibrary(t *testing.T) {
+	lib := &workflowLibrary{
+		Actions: []workflowLibraryItem{
+			{Id: "sp:send-email", FormFields: []workflowLibraryFormField{
+				{Name: "recipientId.$", Label: "Recipient", Required: true},
+				{Name: "subject", Label: "Subject", Required: true},
+				{Name: "body", Label: "Body"},
+			}},
+			{Id: "sp:loop:iterator"},
+		},
+		Operators: []workflowLibraryItem{{Id: "sp:operator-choice"}},
+		Triggers:  []workflowLibraryItem{{Id: "idn:identity-attributes-changed"}},
+	}
+
+	def := workflowTestDefinition(t, `{"start": "Choose", "steps": {
+		"Choose": {"actionId": "sp:operator-choice"},
+		"Send Email": {"actionId": "sp:send-email", "attributes": {"recipientId.$": "$.trigger.identity.id"}},
+		"Custom": {"actionId": "sp:made-up"},
+		"Loop": {"actionId": "sp:loop:iterator", "attributes": {
+			"start": "Inner",
+			"steps": {"Inner": {"actionId": "sp:send-email", "attributes": {"recipientId": "2c9180...", "subject": "Hi"}}}
+		}},
+		"End": {"type": "success"}
+	}}`)
+	want := []string{
+		`steps["Custom"].actionId: "sp:made-up" is not an action in the workflow library`,
+		`steps["Send Email"].attributes: missing required input "subject" (Subject) of sp:send-email`,
+	}
+	if got := validateWorkflowDefinitionAgainstLibrary(def, lib, ""); !reflect.DeepEqual(got, want) {
+		t.Errorf("validateWorkflowDefinitionAgainstLibrary =\n%q\nwant\n%q", got, want)
+	}
+
+	if got := validateWorkflowTriggerAgainstLibrary("EVENT", map[string]interface{}{"id": "idn:identity-attributes-changed"}, lib); got != nil {
+		t.Errorf("known trigger: got %q", got)
+	}
+	if got := validateWorkflowTriggerAgainstLibrary("EVENT", map[string]interface{}{"id": "idn:nope"}, lib); len(got) != 1 {
+		t.Errorf("unknown trigger: got %q, want one problem", got)
+	}
+	if got := validateWorkflowTriggerAgainstLibrary("SCHEDULED", map[string]interface{}{"frequency": "daily"}, lib); got != nil {
+		t.Errorf("scheduled trigger: got %q", got)
+	}
+}
+
+func TestValidateWorkflowTrigger(t *testing.T) {
+	if got := validateWorkflowTrigger("EVENT", map[string]interface{}{}); len(got) != 1 {
+		t.Errorf("EVENT without id: got %q, want one problem", got)
+	}
+	if got := validateWorkflowTrigger("SCHEDULED", map[string]interface{}{"frequency": "daily"}); got != nil {
+		t.Errorf("SCHEDULED with frequency: got %q", got)
+	}
+	if got := validateWorkflowTrigger("EXTERNAL", map[string]interface{}{}); got != nil {
+		t.Errorf("EXTERNAL: got %q", got)
+	}
+}
@@ -0,0 +1,88 @@
+// This file implements workflowResource's "warn_when_disabled" option, a
+// plan-time warning toggle. ISC disables a workflow on its own after
+// repeated execution failures; by default that only shows up as an
+// "enabled: false -> true" diff in the next plan, easily missed in a large
+// one. With warn_when_disabled on, ModifyPlan also reports it as a warning,
+// including the failure count that most likely caused it.
+//
+// The option changes nothing but that warning: Read stays read-only, so
+// state records the workflow as disabled and the plan shows the drift, and
+// the apply re-enables the workflow through the normal Update (PUT) like
+// any other change to "enabled". It only warns when the configuration says
+// enabled = true, so a workflow configured with enabled = false is never
+// flagged.
+package workflow_v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// applyWorkflowWarnWhenDisabledField hand-adds "warn_when_disabled", a
+// provider-side setting with no API counterpart.
+func applyWorkflowWarnWhenDisabledField(attrs *map[string]resourceschema.Attribute) {
+	if *attrs == nil {
+		*attrs = map[string]resourceschema.Attribute{}
+	}
+	(*attrs)["warn_when_disabled"] = resourceschema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+		Description: "Whether to warn at plan time when the workflow was disabled outside Terraform " +
+			"(e.g. by the platform after repeated failures). Only adds a warning: the apply re-enables the " +
+			"workflow through the ordinary enabled diff either way. Defaults to false.",
+		MarkdownDescription: "Whether to warn at plan time when the workflow was disabled outside Terraform " +
+			"(e.g. by the platform after repeated failures), including its failure count. Only adds a warning: " +
+			"the apply re-enables the workflow through the ordinary `enabled` diff whether or not this is set. " +
+			"Only applies while `enabled = true` is configured. Defaults to `false`.",
+	}
+}
+
+// workflowReEnablePlanned reports whether a plan re-enables a workflow
+// that refresh found disabled while the configuration has it enabled.
+func workflowReEnablePlanned(warn, stateEnabled, configEnabled types.Bool) bool {
+	return warn.ValueBool() &&
+		!stateEnabled.IsNull() && !stateEnabled.IsUnknown() && !stateEnabled.ValueBool() &&
+		configEnabled.ValueBool()
+}
+
+// warnWorkflowDisabledOutsideTerraform adds warn_when_disabled's
+// warning to an update plan (see the file comment).
+func warnWorkflowDisabledOutsideTerraform(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var warn, configEnabled types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("warn_when_disabled"), &warn)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("enabled"), &configEnabled)...)
+	var state workflowResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !workflowReEnablePlanned(warn, state.Enabled, configEnabled) {
+		return
+	}
+	resp.Diagnostics.AddAttributeWarning(path.Root("enabled"), "Workflow disabled outside Terraform",
+		fmt.Sprintf("Workflow %q (%s) is configured as enabled but is now disabled. ISC disables a workflow after "+
+			"repeated execution failures; it has failed %d time(s) in total. This apply re-enables it; check its "+
+			"recent executions (identitynow_workflow_executions_v1) for the cause.",
+			state.Name.ValueString(), state.Id.ValueString(), state.FailureCount.ValueInt64()))
+}
+
+// workflowWarnWhenDisabledValue is the warn_when_disabled setting to keep in
+// state; null (e.g. right after import) means the default, false.
+func workflowWarnWhenDisabledValue(v types.Bool) types.Bool {
+	if v.IsNull() || v.IsUnknown() {
+		return types.BoolValue(false)
+	}
+	return v
+}
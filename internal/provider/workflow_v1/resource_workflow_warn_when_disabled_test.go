@@ -0,0 +1,31 @@
+package workflow_v1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestWorkflowReEnablePlanned(t *testing.T) {
+	tests := []struct {
+		name          string
+		warn          types.Bool
+		stateEnabled  types.Bool
+		configEnabled types.Bool
+		want          bool
+	}{
+		{"disabled outside Terraform", types.BoolValue(true), types.BoolValue(false), types.BoolValue(true), true},
+		{"warning off", types.BoolValue(false), types.BoolValue(false), types.BoolValue(true), false},
+		{"still enabled", types.BoolValue(true), types.BoolValue(true), types.BoolValue(true), false},
+		{"configured disabled", types.BoolValue(true), types.BoolValue(false), types.BoolValue(false), false},
+		{"enabled not configured", types.BoolValue(true), types.BoolValue(false), types.BoolNull(), false},
+		{"unknown state", types.BoolValue(true), types.BoolUnknown(), types.BoolValue(true), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := workflowReEnablePlanned(tt.warn, tt.stateEnabled, tt.configEnabled); got != tt.want {
+				t.Errorf("workflowReEnablePlanned = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
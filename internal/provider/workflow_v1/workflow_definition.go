@@ -0,0 +1,242 @@
+// This file implements the custom string type behind the workflow
+// "definition" attribute, and the export document emitted by
+// identitynow_workflow_export_v1 (datasource_workflow_export.go).
+//
+// A workflow exported from the ISC UI is the whole Workflow object - name,
+// owner, trigger and definition plus server-managed fields (id, created,
+// modified, executionCount, creator, ...) - and both the UI export and the
+// API fill in null-valued keys that the original JSON didn't have. With
+// plain jsontypes.Normalized, pasting an export into "definition" was sent
+// to the API as-is and never matched what definitionFromAPI read back,
+// giving a diff on every plan.
+//
+// workflowDefinitionValue compares values after normalizeWorkflowDefinition:
+// a full export document is reduced to its "definition", null-valued object
+// keys are dropped at every level (extending stripNullMapValues, which only
+// handles the top level of trigger.attributes), and object keys are
+// re-encoded in sorted order. Values that normalize the same are
+// semantically equal, so Read and apply keep whichever form the
+// configuration used. definitionToAPI sends the normalized form.
+package workflow_v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/sailpoint-oss/golang-sdk/v3/workflows"
+)
+
+var (
+	_ basetypes.StringTypable                    = (*workflowDefinitionType)(nil)
+	_ basetypes.StringValuable                   = (*workflowDefinitionValue)(nil)
+	_ basetypes.StringValuableWithSemanticEquals = (*workflowDefinitionValue)(nil)
+	_ xattr.ValidateableAttribute                = (*workflowDefinitionValue)(nil)
+)
+
+// workflowServerFields are the top-level Workflow fields the server
+// manages; they are left out of export documents.
+var workflowServerFields = []string{
+	"id", "created", "modified", "modifiedBy", "executionCount", "failureCount", "creator",
+}
+
+type workflowDefinitionType struct {
+	basetypes.StringType
+}
+
+func (t workflowDefinitionType) String() string {
+	return "workflow_v1.workflowDefinitionType"
+}
+
+func (t workflowDefinitionType) ValueType(ctx context.Context) attr.Value {
+	return workflowDefinitionValue{}
+}
+
+func (t workflowDefinitionType) Equal(o attr.Type) bool {
+	other, ok := o.(workflowDefinitionType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t workflowDefinitionType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return workflowDefinitionValue{StringValue: in}, nil
+}
+
+func (t workflowDefinitionType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return workflowDefinitionValue{StringValue: stringValue}, nil
+}
+
+// workflowDefinitionValue is a workflow definition JSON string: either the
+// definition itself ({"start": ..., "steps": {...}}) or a full workflow
+// export document containing it.
+type workflowDefinitionValue struct {
+	basetypes.StringValue
+}
+
+func newWorkflowDefinitionNull() workflowDefinitionValue {
+	return workflowDefinitionValue{StringValue: basetypes.NewStringNull()}
+}
+
+func newWorkflowDefinitionValue(s string) workflowDefinitionValue {
+	return workflowDefinitionValue{StringValue: basetypes.NewStringValue(s)}
+}
+
+func (v workflowDefinitionValue) Type(ctx context.Context) attr.Type {
+	return workflowDefinitionType{}
+}
+
+func (v workflowDefinitionValue) Equal(o attr.Value) bool {
+	other, ok := o.(workflowDefinitionValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v workflowDefinitionValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(workflowDefinitionValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+		return false, diags
+	}
+
+	// Undecodable JSON is never equal to anything but itself; ValidateAttribute
+	// reports it.
+	a, err := normalizeWorkflowDefinitionJSON(v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	b, err := normalizeWorkflowDefinitionJSON(newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	return a == b, diags
+}
+
+func (v workflowDefinitionValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+	if _, err := normalizeWorkflowDefinitionJSON(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid workflow definition JSON",
+			fmt.Sprintf("Could not decode the value as a JSON object: %s", err.Error()),
+		)
+	}
+}
+
+// normalizeWorkflowDefinitionJSON returns the canonical encoding of a
+// definition or export document's definition (see the file comment). Numbers
+// are kept as written.
+func normalizeWorkflowDefinitionJSON(s string) (string, error) {
+	def, err := decodeWorkflowDefinition(s)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(def)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// decodeWorkflowDefinition decodes s, unwraps it if it is a full export
+// document and drops null-valued keys.
+func decodeWorkflowDefinition(s string) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader([]byte(s)))
+	dec.UseNumber()
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON object")
+	}
+	if isWorkflowExportDocument(doc) {
+		def, ok := doc["definition"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("the workflow document's \"definition\" is not a JSON object")
+		}
+		doc = def
+	}
+	return stripNullJSONValues(doc).(map[string]interface{}), nil
+}
+
+// isWorkflowExportDocument reports whether doc is a whole Workflow object
+// rather than a definition: it has a "definition" key, and no "steps" of its
+// own.
+func isWorkflowExportDocument(doc map[string]interface{}) bool {
+	_, hasDefinition := doc["definition"]
+	_, hasSteps := doc["steps"]
+	return hasDefinition && !hasSteps
+}
+
+// stripNullJSONValues applies stripNullMapValues to every object in a
+// decoded JSON value. Nulls inside arrays are kept, since removing them
+// would shift the remaining elements.
+func stripNullJSONValues(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := stripNullMapValues(v)
+		for k, e := range out {
+			out[k] = stripNullJSONValues(e)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			out[i] = stripNullJSONValues(e)
+		}
+		return out
+	}
+	return v
+}
+
+// workflowExportDocument encodes dto in the shape the ISC UI imports: the
+// Workflow object without workflowServerFields or null values, indented
+// with two spaces.
+func workflowExportDocument(dto *workflows.Workflow) (string, error) {
+	b, err := json.Marshal(dto)
+	if err != nil {
+		return "", err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return "", err
+	}
+	for _, f := range workflowServerFields {
+		delete(doc, f)
+	}
+	out, err := json.MarshalIndent(stripNullJSONValues(doc), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
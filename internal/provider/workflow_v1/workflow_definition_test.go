@@ -0,0 +1,110 @@
+package workflow_v1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sailpoint-oss/golang-sdk/v3/workflows"
+)
+
+func TestNormalizeWorkflowDefinitionJSON(t *testing.T) {
+	const want = `{"start":"Send Email","steps":{"End":{"type":"success"},"Send Email":{"actionId":"sp:send-email","attributes":{"recipients":[null,"a"],"subject":"Hi"},"nextStep":"End"}}}`
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{
+			name: "definition",
+			in: `{"steps": {"Send Email": {"nextStep": "End", "actionId": "sp:send-email",
+				"attributes": {"subject": "Hi", "recipients": [null, "a"]}}, "End": {"type": "success"}}, "start": "Send Email"}`,
+		},
+		{
+			name: "definition with nulls",
+			in: `{"start": "Send Email", "steps": {"End": {"type": "success", "description": null},
+				"Send Email": {"actionId": "sp:send-email", "nextStep": "End", "displayName": null,
+				"attributes": {"recipients": [null, "a"], "subject": "Hi", "body": null}}}}`,
+		},
+		{
+			name: "UI export document",
+			in: `{"id": "d201c5d9", "name": "Send Email", "created": "2022-01-10T16:06:16.636381447Z",
+				"modified": "2023-12-05T15:18:27.699132301Z", "executionCount": 2, "failureCount": 0,
+				"creator": {"type": "IDENTITY", "id": "2c9180", "name": "Admin"}, "enabled": false,
+				"owner": {"type": "IDENTITY", "id": "2c9180", "name": "Admin"},
+				"trigger": {"type": "EVENT", "attributes": {"id": "idn:identity-attributes-changed", "filter.$": null}},
+				"definition": {"start": "Send Email", "steps": {
+					"Send Email": {"actionId": "sp:send-email", "nextStep": "End",
+						"attributes": {"recipients": [null, "a"], "subject": "Hi", "body": null}},
+					"End": {"type": "success"}}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeWorkflowDefinitionJSON(tt.in)
+			if err != nil {
+				t.Fatalf("normalizeWorkflowDefinitionJSON: %v", err)
+			}
+			if got != want {
+				t.Errorf("normalizeWorkflowDefinitionJSON =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+
+	for _, in := range []string{`{"start": `, `["a"]`, `{"definition": "x"}`, `{} {}`} {
+		if _, err := normalizeWorkflowDefinitionJSON(in); err == nil {
+			t.Errorf("normalizeWorkflowDefinitionJSON(%s): want error", in)
+		}
+	}
+}
+
+func TestWorkflowDefinitionValueSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+	apiValue := newWorkflowDefinitionValue(`{"start":"End","steps":{"End":{"type":"success"}}}`)
+
+	for _, config := range []string{
+		`{"steps": {"End": {"type": "success", "description": null}}, "start": "End"}`,
+		`{"id": "d201c5d9", "name": "Done", "executionCount": 7, "definition": {"start": "End", "steps": {"End": {"type": "success"}}}}`,
+	} {
+		equal, diags := apiValue.StringSemanticEquals(ctx, newWorkflowDefinitionValue(config))
+		if diags.HasError() || !equal {
+			t.Errorf("StringSemanticEquals(%s) = %v, %v; want true", config, equal, diags)
+		}
+	}
+
+	for _, config := range []string{
+		`{"start": "End", "steps": {"End": {"type": "failure"}}}`,
+		`{"start": `,
+	} {
+		equal, diags := apiValue.StringSemanticEquals(ctx, newWorkflowDefinitionValue(config))
+		if diags.HasError() || equal {
+			t.Errorf("StringSemanticEquals(%s) = %v, %v; want false", config, equal, diags)
+		}
+	}
+}
+
+func TestWorkflowExportDocument(t *testing.T) {
+	var dto workflows.Workflow
+	if err := json.Unmarshal([]byte(`{"id": "d201c5d9", "name": "Send Email", "executionCount": 2, "description": null,
+		"definition": {"start": "End", "steps": {"End": {"type": "success", "description": null}}}}`), &dto); err != nil {
+		t.Fatalf("bad test workflow: %v", err)
+	}
+
+	got, err := workflowExportDocument(&dto)
+	if err != nil {
+		t.Fatalf("workflowExportDocument: %v", err)
+	}
+	want := `{
+  "definition": {
+    "start": "End",
+    "steps": {
+      "End": {
+        "type": "success"
+      }
+    }
+  },
+  "name": "Send Email"
+}`
+	if got != want {
+		t.Errorf("workflowExportDocument =\n%s\nwant\n%s", got, want)
+	}
+}
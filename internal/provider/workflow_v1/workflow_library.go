@@ -0,0 +1,160 @@
+// This file reads the workflow library - the catalogs of actions, triggers
+// and operators a workflow definition can use, from
+// GET /workflow-library/v1/actions, /triggers and /operators - for the
+// library data sources (datasource_workflow_library.go) and the workflow
+// resource's plan-time definition checks (resource_workflow_validate.go).
+//
+// The library only changes when SailPoint ships new actions, so it is read
+// once per provider instance and kept in the provider's util.ProviderCache:
+// a configuration with many workflows costs three list calls, not three
+// per workflow.
+//
+// The SDK's library models are converted into the package-local
+// workflowLibraryItem through JSON, as roleFilterBody does in role_v1, so
+// nothing here depends on how the generator shaped their nullable
+// formFields.
+package workflow_v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v3"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+const (
+	workflowLibraryCacheKey = "workflow_v1.library"
+
+	// workflowLibraryListMaxLimit matches the documented maximum "limit"
+	// of the library's actions and triggers lists, used as the page size.
+	workflowLibraryListMaxLimit = 250
+)
+
+// workflowLibrary is the whole library, split by kind.
+type workflowLibrary struct {
+	Actions   []workflowLibraryItem
+	Triggers  []workflowLibraryItem
+	Operators []workflowLibraryItem
+}
+
+// workflowLibraryItem is one action, trigger or operator. InputExample is
+// only returned for triggers.
+type workflowLibraryItem struct {
+	Id           string                     `json:"id"`
+	Name         string                     `json:"name"`
+	Type         string                     `json:"type"`
+	Description  string                     `json:"description"`
+	Deprecated   bool                       `json:"deprecated"`
+	FormFields   []workflowLibraryFormField `json:"formFields"`
+	InputExample map[string]interface{}     `json:"inputExample"`
+}
+
+// workflowLibraryFormField is one input an action or operator accepts (or,
+// for a trigger, one attribute it is configured with).
+type workflowLibraryFormField struct {
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	HelpText    string `json:"helpText"`
+	Required    bool   `json:"required"`
+}
+
+// findWorkflowLibraryItem returns the item with id among items, or nil.
+func findWorkflowLibraryItem(items []workflowLibraryItem, id string) *workflowLibraryItem {
+	for i := range items {
+		if items[i].Id == id {
+			return &items[i]
+		}
+	}
+	return nil
+}
+
+// inputName is the attribute key a step uses for f, without the ".$"
+// suffix that marks a JSONPath-valued input: a required "recipientId" is
+// provided by either "recipientId" or "recipientId.$".
+func (f workflowLibraryFormField) inputName() string {
+	return strings.TrimSuffix(f.Name, ".$")
+}
+
+// loadWorkflowLibrary returns the provider's cached library, reading it on
+// first use.
+func loadWorkflowLibrary(ctx context.Context, client *sailpoint.APIClient, cache *util.ProviderCache) (*workflowLibrary, error) {
+	v, err := cache.Get(ctx, workflowLibraryCacheKey, func(ctx context.Context) (interface{}, error) {
+		return readWorkflowLibrary(ctx, client)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*workflowLibrary), nil
+}
+
+func readWorkflowLibrary(ctx context.Context, client *sailpoint.APIClient) (*workflowLibrary, error) {
+	if client == nil {
+		return nil, fmt.Errorf("no API client configured")
+	}
+
+	actions, err := readWorkflowLibraryPages("actions", func(offset int32) (interface{}, *http.Response, error) {
+		return client.WorkflowsAPI.ListWorkflowLibraryActionsV1(ctx).Offset(offset).Limit(workflowLibraryListMaxLimit).Execute()
+	})
+	if err != nil {
+		return nil, err
+	}
+	triggers, err := readWorkflowLibraryPages("triggers", func(offset int32) (interface{}, *http.Response, error) {
+		return client.WorkflowsAPI.ListWorkflowLibraryTriggersV1(ctx).Offset(offset).Limit(workflowLibraryListMaxLimit).Execute()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The operators list isn't paginated.
+	page, httpResp, err := client.WorkflowsAPI.ListWorkflowLibraryOperatorsV1(ctx).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("listing workflow library operators: %s", errDetail(err, httpResp))
+	}
+	operators, err := workflowLibraryItemsFromAPI(page)
+	if err != nil {
+		return nil, fmt.Errorf("decoding workflow library operators: %w", err)
+	}
+
+	return &workflowLibrary{Actions: actions, Triggers: triggers, Operators: operators}, nil
+}
+
+// readWorkflowLibraryPages reads every page of one of the library's
+// paginated lists; page returns the SDK's items at offset.
+func readWorkflowLibraryPages(kind string, page func(offset int32) (interface{}, *http.Response, error)) ([]workflowLibraryItem, error) {
+	var all []workflowLibraryItem
+	var offset int32
+	for {
+		apiItems, httpResp, err := page(offset)
+		if err != nil {
+			return nil, fmt.Errorf("listing workflow library %s: %s", kind, errDetail(err, httpResp))
+		}
+		items, err := workflowLibraryItemsFromAPI(apiItems)
+		if err != nil {
+			return nil, fmt.Errorf("decoding workflow library %s: %w", kind, err)
+		}
+		all = append(all, items...)
+		if len(items) < workflowLibraryListMaxLimit {
+			return all, nil
+		}
+		offset += workflowLibraryListMaxLimit
+	}
+}
+
+func workflowLibraryItemsFromAPI(apiItems interface{}) ([]workflowLibraryItem, error) {
+	b, err := json.Marshal(apiItems)
+	if err != nil {
+		return nil, err
+	}
+	var items []workflowLibraryItem
+	if err := json.Unmarshal(b, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
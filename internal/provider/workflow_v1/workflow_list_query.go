@@ -0,0 +1,253 @@
+// This file holds the client-side query behind identitynow_workflows_v1
+// (datasource_workflows.go). golang-sdk v3's ListWorkflowsV1 builder has no
+// filters/sorters/offset/limit methods - GET /workflows/v1 returns every
+// workflow in the tenant in one response - so the data source fetches that
+// full list and then filters, sorts and pages it here.
+//
+// The raw "filters" expression is evaluated against the same
+// workflowListCriteria as the typed "filter" block, so both accept the same
+// properties; only "and"-joined "eq" terms are understood, which covers
+// everything the endpoint itself ever accepted.
+package workflow_v1
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sailpoint-oss/golang-sdk/v3/workflows"
+
+	"terraform-provider-identitynow/internal/provider/util"
+)
+
+// workflowListCriteria are the conditions a workflow must meet to be listed;
+// nil/empty fields match everything.
+type workflowListCriteria struct {
+	Name         *string
+	NameContains *string
+	Enabled      *bool
+	TriggerType  *string
+	TriggerId    *string
+	OwnerId      *string
+	CreatorId    *string
+}
+
+// workflowListFilterProperties maps each "filters" property to the
+// criteria field it sets. "enabled" is a bool; the rest are strings.
+var workflowListFilterProperties = map[string]func(c *workflowListCriteria) **string{
+	"name":        func(c *workflowListCriteria) **string { return &c.Name },
+	"triggerType": func(c *workflowListCriteria) **string { return &c.TriggerType },
+	"triggerId":   func(c *workflowListCriteria) **string { return &c.TriggerId },
+	"owner.id":    func(c *workflowListCriteria) **string { return &c.OwnerId },
+	"creator.id":  func(c *workflowListCriteria) **string { return &c.CreatorId },
+}
+
+// workflowListSorters are the properties "sorters" accepts.
+var workflowListSorters = []string{"name", "created", "modified"}
+
+// parseWorkflowListFilters parses a "filters" expression such as
+// `enabled eq true and triggerType eq "EVENT"`.
+func parseWorkflowListFilters(expr string) (workflowListCriteria, error) {
+	var c workflowListCriteria
+	terms, err := splitWorkflowListFilterTerms(expr)
+	if err != nil {
+		return c, err
+	}
+	for _, term := range terms {
+		fields := strings.Fields(term)
+		if len(fields) < 3 || fields[1] != "eq" {
+			return c, fmt.Errorf("unsupported term %q: only `<property> eq <value>` terms joined by `and` are supported", term)
+		}
+		prop := fields[0]
+		value := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(term, prop)), "eq"))
+		if prop == "enabled" {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return c, fmt.Errorf("term %q: enabled must be true or false", term)
+			}
+			c.Enabled = &b
+			continue
+		}
+		field, ok := workflowListFilterProperties[prop]
+		if !ok {
+			return c, fmt.Errorf("unsupported property %q: supported properties are enabled, %s", prop,
+				strings.Join(workflowSortedFilterProperties(), ", "))
+		}
+		s, err := strconv.Unquote(value)
+		if err != nil || !strings.HasPrefix(value, `"`) {
+			return c, fmt.Errorf("term %q: %s must be a double-quoted string", term, prop)
+		}
+		*field(&c) = &s
+	}
+	return c, nil
+}
+
+// splitWorkflowListFilterTerms splits expr on the word "and" outside of
+// quoted strings, and trims each term.
+func splitWorkflowListFilterTerms(expr string) ([]string, error) {
+	var terms []string
+	start := 0
+	inQuotes := false
+	for i := 0; i < len(expr); i++ {
+		switch {
+		case inQuotes && expr[i] == '\\':
+			i++
+		case expr[i] == '"':
+			inQuotes = !inQuotes
+		case !inQuotes && workflowFilterAndAt(expr, i):
+			terms = append(terms, strings.TrimSpace(expr[start:i]))
+			start = i + len("and")
+			i = start - 1
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated string in %q", expr)
+	}
+	last := strings.TrimSpace(expr[start:])
+	if last == "" && len(terms) == 0 {
+		return nil, nil
+	}
+	terms = append(terms, last)
+	for _, t := range terms {
+		if t == "" {
+			return nil, fmt.Errorf("misplaced `and` in %q", expr)
+		}
+	}
+	return terms, nil
+}
+
+// workflowFilterAndAt reports whether the word "and" starts at expr[i].
+func workflowFilterAndAt(expr string, i int) bool {
+	end := i + len("and")
+	if end > len(expr) || !strings.EqualFold(expr[i:end], "and") {
+		return false
+	}
+	return (i == 0 || expr[i-1] == ' ') && (end == len(expr) || expr[end] == ' ')
+}
+
+func workflowSortedFilterProperties() []string {
+	props := make([]string, 0, len(workflowListFilterProperties))
+	for p := range workflowListFilterProperties {
+		props = append(props, p)
+	}
+	sort.Strings(props)
+	return props
+}
+
+// matches reports whether dto meets every set criterion.
+func (c workflowListCriteria) matches(dto *workflows.Workflow) bool {
+	if c.Name != nil && dto.GetName() != *c.Name {
+		return false
+	}
+	if c.NameContains != nil && !util.FilterNameContains(dto.GetName(), *c.NameContains) {
+		return false
+	}
+	if c.Enabled != nil && dto.GetEnabled() != *c.Enabled {
+		return false
+	}
+	if c.TriggerType != nil || c.TriggerId != nil {
+		if dto.Trigger == nil {
+			return false
+		}
+		if c.TriggerType != nil && !strings.EqualFold(dto.Trigger.Type, *c.TriggerType) {
+			return false
+		}
+		if c.TriggerId != nil {
+			if id, _ := dto.Trigger.Attributes["id"].(string); id != *c.TriggerId {
+				return false
+			}
+		}
+	}
+	if c.OwnerId != nil && (dto.Owner == nil || dto.Owner.GetId() != *c.OwnerId) {
+		return false
+	}
+	if c.CreatorId != nil && (dto.Creator == nil || dto.Creator.GetId() != *c.CreatorId) {
+		return false
+	}
+	return true
+}
+
+type workflowListSorter struct {
+	property   string
+	descending bool
+}
+
+// parseWorkflowListSorters parses a "sorters" expression: comma-separated
+// properties, each optionally prefixed with "-" for descending order.
+func parseWorkflowListSorters(expr string) ([]workflowListSorter, error) {
+	var sorters []workflowListSorter
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		s := workflowListSorter{property: strings.TrimPrefix(part, "-"), descending: strings.HasPrefix(part, "-")}
+		if !util.ContainsString(workflowListSorters, s.property) {
+			return nil, fmt.Errorf("unsupported sort property %q: supported properties are %s", s.property,
+				strings.Join(workflowListSorters, ", "))
+		}
+		sorters = append(sorters, s)
+	}
+	return sorters, nil
+}
+
+// sortWorkflows sorts dtos in place by sorters, keeping the API's order for
+// ties. Workflows missing a timestamp sort first.
+func sortWorkflows(dtos []workflows.Workflow, sorters []workflowListSorter) {
+	if len(sorters) == 0 {
+		return
+	}
+	sort.SliceStable(dtos, func(i, j int) bool {
+		for _, s := range sorters {
+			cmp := compareWorkflows(&dtos[i], &dtos[j], s.property)
+			if cmp == 0 {
+				continue
+			}
+			if s.descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+}
+
+func compareWorkflows(a, b *workflows.Workflow, property string) int {
+	switch property {
+	case "name":
+		return strings.Compare(strings.ToLower(a.GetName()), strings.ToLower(b.GetName()))
+	case "created":
+		return compareWorkflowTimes(a.Created, b.Created)
+	case "modified":
+		return compareWorkflowTimes(a.Modified, b.Modified)
+	}
+	return 0
+}
+
+func compareWorkflowTimes(a, b *workflows.SailPointTime) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	return a.Time.Compare(b.Time)
+}
+
+// pageWorkflows applies offset and then limit (a negative limit means no
+// limit).
+func pageWorkflows(dtos []workflows.Workflow, offset, limit int64) []workflows.Workflow {
+	if offset >= int64(len(dtos)) {
+		return dtos[:0]
+	}
+	if offset > 0 {
+		dtos = dtos[offset:]
+	}
+	if limit >= 0 && int64(len(dtos)) > limit {
+		dtos = dtos[:limit]
+	}
+	return dtos
+}
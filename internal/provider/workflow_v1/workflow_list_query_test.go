@@ -0,0 +1,125 @@
+package workflow_v1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sailpoint-oss/golang-sdk/v3/workflows"
+)
+
+func workflowListTestWorkflow(name string, enabled bool, triggerType, triggerId, ownerId string, modifiedDay int) workflows.Workflow {
+	modified := workflows.SailPointTime{Time: time.Date(2026, time.May, modifiedDay, 0, 0, 0, 0, time.UTC)}
+	w := workflows.Workflow{Name: &name, Enabled: &enabled, Modified: &modified}
+	if triggerType != "" {
+		w.Trigger = &workflows.WorkflowTrigger{Type: triggerType, Attributes: map[string]interface{}{"id": triggerId}}
+	}
+	if ownerId != "" {
+		w.Owner = &workflows.WorkflowBodyOwner{Id: &ownerId}
+	}
+	return w
+}
+
+func workflowListTestNames(dtos []workflows.Workflow) []string {
+	names := make([]string, 0, len(dtos))
+	for i := range dtos {
+		names = append(names, dtos[i].GetName())
+	}
+	return names
+}
+
+func TestParseWorkflowListFilters(t *testing.T) {
+	c, err := parseWorkflowListFilters(`enabled eq true AND triggerType eq "EVENT" and name eq "Send and \"notify\""`)
+	if err != nil {
+		t.Fatalf("parseWorkflowListFilters: %v", err)
+	}
+	if c.Enabled == nil || !*c.Enabled {
+		t.Errorf("Enabled = %v, want true", c.Enabled)
+	}
+	if c.TriggerType == nil || *c.TriggerType != "EVENT" {
+		t.Errorf("TriggerType = %v, want EVENT", c.TriggerType)
+	}
+	if c.Name == nil || *c.Name != `Send and "notify"` {
+		t.Errorf("Name = %v, want %q", c.Name, `Send and "notify"`)
+	}
+
+	if c, err := parseWorkflowListFilters(""); err != nil || !reflect.DeepEqual(c, workflowListCriteria{}) {
+		t.Errorf("empty filters = %+v, %v; want no criteria", c, err)
+	}
+
+	for _, expr := range []string{
+		`connectorInstanceId eq "abc"`,
+		`name co "Send"`,
+		`enabled eq maybe`,
+		`name eq Send`,
+		`enabled eq true and`,
+		`name eq "unterminated`,
+	} {
+		if _, err := parseWorkflowListFilters(expr); err == nil {
+			t.Errorf("parseWorkflowListFilters(%q) succeeded, want an error", expr)
+		}
+	}
+}
+
+func TestWorkflowListQuery(t *testing.T) {
+	all := []workflows.Workflow{
+		workflowListTestWorkflow("Onboarding", true, "EVENT", "idn:identity-created", "owner-1", 3),
+		workflowListTestWorkflow("offboarding", false, "EVENT", "idn:identity-deleted", "owner-2", 1),
+		workflowListTestWorkflow("Nightly report", true, "SCHEDULED", "", "owner-1", 2),
+		workflowListTestWorkflow("Untriggered", true, "", "", "", 4),
+	}
+
+	enabled := true
+	event := "event"
+	owner := "owner-1"
+	boarding := "BOARDING"
+	tests := []struct {
+		name     string
+		criteria workflowListCriteria
+		want     []string
+	}{
+		{"everything", workflowListCriteria{}, []string{"Onboarding", "offboarding", "Nightly report", "Untriggered"}},
+		{"enabled", workflowListCriteria{Enabled: &enabled}, []string{"Onboarding", "Nightly report", "Untriggered"}},
+		{"trigger type ignores case", workflowListCriteria{TriggerType: &event}, []string{"Onboarding", "offboarding"}},
+		{"owner and enabled", workflowListCriteria{OwnerId: &owner, Enabled: &enabled}, []string{"Onboarding", "Nightly report"}},
+		{"name contains", workflowListCriteria{NameContains: &boarding}, []string{"Onboarding", "offboarding"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []workflows.Workflow
+			for i := range all {
+				if tt.criteria.matches(&all[i]) {
+					got = append(got, all[i])
+				}
+			}
+			if names := workflowListTestNames(got); !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("matches = %q, want %q", names, tt.want)
+			}
+		})
+	}
+
+	t.Run("sort and page", func(t *testing.T) {
+		dtos := append([]workflows.Workflow(nil), all...)
+		sorters, err := parseWorkflowListSorters("-modified")
+		if err != nil {
+			t.Fatalf("parseWorkflowListSorters: %v", err)
+		}
+		sortWorkflows(dtos, sorters)
+		if names := workflowListTestNames(pageWorkflows(dtos, 1, 2)); !reflect.DeepEqual(names, []string{"Onboarding", "Nightly report"}) {
+			t.Errorf("page = %q", names)
+		}
+
+		sorters, _ = parseWorkflowListSorters("name")
+		sortWorkflows(dtos, sorters)
+		if names := workflowListTestNames(dtos); !reflect.DeepEqual(names, []string{"Nightly report", "offboarding", "Onboarding", "Untriggered"}) {
+			t.Errorf("sorted by name = %q", names)
+		}
+		if got := pageWorkflows(dtos, 10, -1); len(got) != 0 {
+			t.Errorf("offset past the end = %d workflows, want 0", len(got))
+		}
+	})
+
+	if _, err := parseWorkflowListSorters("name,-executionCount"); err == nil {
+		t.Error("parseWorkflowListSorters accepted an unsupported property")
+	}
+}